@@ -0,0 +1,13 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/sunholo/ailang/internal/termcolor"
+)
+
+// formatErrorsFlag adds --format-errors to a command's flags. The mode takes
+// effect as soon as the flag is parsed, overriding the environment.
+func formatErrorsFlag(fs *flag.FlagSet) {
+	fs.Func("format-errors", "Color of errors and messages: plain (no ANSI codes) or rich (always colored); by default NO_COLOR, CLICOLOR and CLICOLOR_FORCE decide, then whether stdout is a terminal", termcolor.SetMode)
+}
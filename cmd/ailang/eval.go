@@ -19,12 +19,13 @@ func runEval() {
 	langs := fs.String("langs", "python,ailang", "Comma-separated list of languages")
 	model := fs.String("model", "claude-sonnet-4-5", "LLM model to use (gpt5, claude-sonnet-4-5, gemini-2-5-pro)")
 	seed := fs.Int64("seed", 42, "Random seed for deterministic runs")
-	outputDir := fs.String("output", "eval_results", "Output directory for results")
+	outputDir := fs.String("output", "eval_results", "Output directory for results, or a .json file for a single per-run results file")
 	timeout := fs.Duration("timeout", 30*time.Second, "Timeout for code execution")
-	mock := fs.Bool("mock", false, "Use mock AI agent (for testing)")
+	mock := fs.Bool("mock", false, "Replay stored solutions (benchmarks/<id>.solution.<ext>) instead of calling a model")
 	listModels := fs.Bool("list-models", false, "List available models and exit")
 	selfRepair := fs.Bool("self-repair", false, "Enable single-shot self-repair on errors")
 	promptVersion := fs.String("prompt-version", "", "Prompt version ID (e.g., v0.3.0-baseline, v0.3.0-hints)")
+	validateSpecs := fs.Bool("validate-specs", false, "Check every spec in benchmarks/ and its stored solutions, then exit")
 
 	if err := fs.Parse(os.Args[2:]); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
@@ -37,14 +38,28 @@ func runEval() {
 		return
 	}
 
+	// Handle --validate-specs
+	if *validateSpecs {
+		runValidateSpecs("benchmarks")
+		return
+	}
+
 	if *benchmarkID == "" {
 		fmt.Fprintf(os.Stderr, "%s: --benchmark flag is required\n", red("Error"))
 		fmt.Println("Usage: ailang eval --benchmark <id> [--langs python,ailang] [--model gpt-4]")
 		os.Exit(1)
 	}
 
+	if *mock {
+		*model = eval_harness.MockModel
+	}
+
 	// Load benchmark spec
-	specPath := filepath.Join("benchmarks", *benchmarkID+".yml")
+	specPath, err := eval_harness.SpecPath("benchmarks", *benchmarkID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+		os.Exit(1)
+	}
 	spec, err := eval_harness.LoadSpec(specPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s: failed to load benchmark: %v\n", red("Error"), err)
@@ -62,27 +77,31 @@ func runEval() {
 		targetLangs[i] = strings.TrimSpace(targetLangs[i])
 	}
 
-	// Create metrics logger
+	// Create metrics logger, unless all runs go to one results file
+	resultsFile := ""
+	if strings.HasSuffix(*outputDir, ".json") {
+		resultsFile = *outputDir
+	}
 	logger := eval_harness.NewMetricsLogger(*outputDir)
+	var runs []eval_harness.RunSummary
 
-	// Create AI agent (or mock)
+	// Create AI agent. Mock agents replay benchmarks/<id>.solution.<ext>
+	// and are created per language below.
 	var agent *eval_harness.AIAgent
-
 	if *mock {
-		fmt.Fprintf(os.Stderr, "%s: --self-repair not supported with --mock\n", red("Error"))
 		if *selfRepair {
+			fmt.Fprintf(os.Stderr, "%s: --self-repair not supported with --mock\n", red("Error"))
 			os.Exit(1)
 		}
-		// Mock agent doesn't support self-repair currently
-	}
-
-	// Create real AI agent (required for self-repair)
-	aiAgent, err := eval_harness.NewAIAgent(*model, *seed)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s: failed to create AI agent: %v\n", red("Error"), err)
-		os.Exit(1)
+		fmt.Printf("  %s Mock mode: using stored solutions from benchmarks/\n", cyan("ℹ"))
+	} else {
+		aiAgent, err := eval_harness.NewAIAgent(*model, *seed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: failed to create AI agent: %v\n", red("Error"), err)
+			os.Exit(1)
+		}
+		agent = aiAgent
 	}
-	agent = aiAgent
 
 	if *selfRepair {
 		fmt.Printf("  %s Self-repair enabled (will retry on errors)\n", cyan("ℹ"))
@@ -131,6 +150,15 @@ func runEval() {
 		}
 		fmt.Printf("  Prompt: %s...\n", truncatePrompt(prompt, 60))
 
+		if *mock {
+			mockAgent, err := eval_harness.NewMockAgent("benchmarks", spec.ID, lang, *seed)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", red("✗"), err)
+				continue
+			}
+			agent = mockAgent
+		}
+
 		// Get runner
 		runner, err := eval_harness.GetRunner(lang, spec)
 		if err != nil {
@@ -198,14 +226,43 @@ func runEval() {
 		}
 
 		// Log metrics
-		if err := logger.Log(metrics); err != nil {
+		if resultsFile != "" {
+			runs = append(runs, eval_harness.NewRunSummary(metrics))
+		} else if err := logger.Log(metrics); err != nil {
 			fmt.Fprintf(os.Stderr, "%s: failed to log metrics: %v\n", yellow("⚠"), err)
 		}
 	}
 
+	if resultsFile != "" {
+		if err := eval_harness.WriteResultsFile(resultsFile, runs); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+			os.Exit(1)
+		}
+		fmt.Printf("\n%s Benchmark complete. Results saved to %s\n", green("✓"), resultsFile)
+		return
+	}
+
 	fmt.Printf("\n%s Benchmark complete. Results saved to %s/\n", green("✓"), *outputDir)
 }
 
+// runValidateSpecs checks the benchmark corpus without invoking a model,
+// exiting with status 1 if any spec or stored solution is malformed
+func runValidateSpecs(dir string) {
+	problems, count, err := eval_harness.ValidateSpecs(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+		os.Exit(1)
+	}
+	for _, p := range problems {
+		fmt.Printf("%s %s\n", red("✗"), p)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("\n%s %d problem(s) in %d benchmark spec(s)\n", red("✗"), len(problems), count)
+		os.Exit(1)
+	}
+	fmt.Printf("%s %d benchmark spec(s) valid\n", green("✓"), count)
+}
+
 // truncatePrompt truncates a prompt for display
 func truncatePrompt(prompt string, maxLen int) string {
 	// Take first line or maxLen chars
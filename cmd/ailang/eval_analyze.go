@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sunholo/ailang/internal/eval_analysis"
 	"github.com/sunholo/ailang/internal/eval_analyzer"
 )
 
@@ -27,12 +28,19 @@ func runEvalAnalyze() {
 	forceNew := fs.Bool("force-new", false, "Always create new docs (disable deduplication)")
 	mergeThreshold := fs.Float64("merge-threshold", 0.75, "Similarity threshold for merging (0.0-1.0)")
 	skipWellDocumented := fs.Bool("skip-documented", false, "Skip generation if issue is already well-documented")
+	since := fs.String("since", "", "Report regressions against a baseline version (e.g. v0.3.0) instead of generating docs")
+	dashboardPath := fs.String("dashboard", "docs/static/benchmarks/latest.json", "Dashboard JSON with the baseline history (used with --since)")
 
 	if err := fs.Parse(os.Args[2:]); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
 		os.Exit(1)
 	}
 
+	if *since != "" {
+		runEvalRegressionReport(*resultsDir, *since, *dashboardPath)
+		return
+	}
+
 	fmt.Printf("%s Analyzing eval results from %s...\n", cyan("→"), *resultsDir)
 
 	// Parse categories
@@ -62,6 +70,18 @@ func runEvalAnalyze() {
 	fmt.Printf("  Issues Found: %d\n", len(analysis.Issues))
 	fmt.Println()
 
+	// Flaky benchmarks often point at nondeterminism in the language itself
+	if results, err := eval_analysis.LoadResults(*resultsDir); err == nil {
+		if flaky := eval_analysis.FindFlaky(results); len(flaky) > 0 {
+			fmt.Printf("%s Flaky Benchmarks (result varies across runs):\n", yellow("⚠"))
+			for _, s := range flaky {
+				fmt.Printf("  • %s (%s): %d/%d passed, models: %s\n",
+					s.ID, s.Lang, s.Passes, s.Runs, strings.Join(s.Models, ", "))
+			}
+			fmt.Println()
+		}
+	}
+
 	if len(analysis.Issues) == 0 {
 		fmt.Printf("%s No issues found meeting frequency threshold (%d)\n", green("✓"), *minFrequency)
 		return
@@ -251,6 +271,44 @@ func runEvalAnalyze() {
 	}
 }
 
+// runEvalRegressionReport compares the results in resultsDir against the
+// stored baseline for version and exits non-zero if any benchmark regressed
+func runEvalRegressionReport(resultsDir, version, dashboardPath string) {
+	fmt.Printf("%s Comparing %s against baseline %s...\n", cyan("→"), resultsDir, version)
+
+	baseline, err := eval_analysis.LoadBaselineByVersion(version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: failed to load baseline %s: %v\n", red("Error"), version, err)
+		os.Exit(1)
+	}
+
+	current, err := eval_analysis.LoadResults(resultsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: failed to load results: %v\n", red("Error"), err)
+		os.Exit(1)
+	}
+
+	report, err := eval_analysis.Compare(baseline.Results, current, version, filepath.Base(resultsDir))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: failed to compare: %v\n", red("Error"), err)
+		os.Exit(1)
+	}
+
+	// The dashboard history records the success rate published for each version
+	if dashboard, err := eval_analysis.LoadDashboard(dashboardPath); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: failed to read dashboard history: %v\n", yellow("⚠"), err)
+	} else if entry, ok := dashboard.HistoryFor(version); ok {
+		fmt.Printf("  Published %s: %.1f%% (%d/%d runs)\n", version, entry.SuccessRate*100, entry.SuccessCount, entry.TotalRuns)
+	}
+	fmt.Println()
+
+	fmt.Print(eval_analysis.FormatRegressions(report, true))
+
+	if report.HasRegressions() {
+		os.Exit(1)
+	}
+}
+
 // generateFilename creates a safe filename from issue title and category
 func generateFilename(title, category string) string {
 	// Convert to lowercase, replace spaces with underscores
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/pipeline"
+	"github.com/sunholo/ailang/internal/runtime"
+)
+
+// evalExprResult is the --json output of eval-expr
+type evalExprResult struct {
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// runEvalExpr evaluates a single expression and prints its value and type
+// Usage: ailang eval-expr [--caps IO] [--json] '<expr>'
+func runEvalExpr() {
+	fs := flag.NewFlagSet("eval-expr", flag.ExitOnError)
+	capsFlag := fs.String("caps", "", "Enable capabilities (comma-separated: IO,FS,Net)")
+	jsonFlag := fs.Bool("json", false, "Print {value, type} or the error as JSON")
+	compactFlag := fs.Bool("compact", false, "Use compact JSON output")
+	formatErrorsFlag(fs)
+
+	// Parse from os.Args[2:] (everything after "eval-expr")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(1)
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "%s: expected exactly one expression\n", red("Error"))
+		fmt.Println("Usage: ailang eval-expr [--caps IO] [--json] '<expr>'")
+		os.Exit(1)
+	}
+
+	// Builtins read capabilities from the evaluator they are registered with
+	effCtx := effects.NewEffContext()
+	for _, capName := range strings.Split(*capsFlag, ",") {
+		if capName = strings.TrimSpace(capName); capName != "" {
+			effCtx.Grant(effects.NewCapability(capName))
+		}
+	}
+	evaluator := eval.NewCoreEvaluator()
+	evaluator.SetEffContext(effCtx)
+	builtinResolver := runtime.NewBuiltinOnlyResolver(runtime.NewBuiltinRegistry(evaluator))
+
+	cfg := pipeline.Config{
+		Mode:           pipeline.ModeEval,
+		GlobalResolver: builtinResolver,
+	}
+	src := pipeline.Source{
+		Code:     fs.Arg(0),
+		Filename: "<repl>",
+		IsREPL:   true,
+	}
+
+	result, err := pipeline.Run(cfg, src)
+	if err != nil {
+		if *jsonFlag {
+			handleStructuredError(err, *compactFlag)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+		}
+		os.Exit(1)
+	}
+
+	value := "()"
+	if result.Value != nil {
+		value = result.Value.String()
+	}
+	typ := ""
+	if result.Type != nil {
+		typ = result.Type.String()
+	}
+
+	if *jsonFlag {
+		outputJSON(evalExprResult{Value: value, Type: typ}, *compactFlag)
+		return
+	}
+	fmt.Printf("%s :: %s\n", value, typ)
+}
@@ -302,7 +302,10 @@ func runBenchmarksParallel(jobs []Job, seed int64, outputDir string, timeout tim
 // runSingleBenchmark executes a single benchmark configuration
 func runSingleBenchmark(model, benchmarkID, lang string, seed int64, outputDir string, timeout time.Duration, selfRepair bool, promptVersion string) (bool, error) {
 	// Load benchmark spec
-	specPath := filepath.Join("benchmarks", benchmarkID+".yml")
+	specPath, err := eval_harness.SpecPath("benchmarks", benchmarkID)
+	if err != nil {
+		return false, err
+	}
 	spec, err := eval_harness.LoadSpec(specPath)
 	if err != nil {
 		return false, fmt.Errorf("failed to load benchmark: %w", err)
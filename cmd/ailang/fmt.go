@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunholo/ailang/internal/formatter"
+)
+
+// runFmt formats AILANG source files
+// Usage: ailang fmt [-w] [--check] <file.ail>...
+func runFmt() {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	writeFlag := fs.Bool("w", false, "Write result back to the source file instead of stdout")
+	checkFlag := fs.Bool("check", false, "Exit non-zero if any file is not already formatted")
+
+	// Parse from os.Args[2:] (everything after "fmt")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(1)
+	}
+
+	if fs.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "%s: missing file argument\n", red("Error"))
+		fmt.Println("Usage: ailang fmt [-w] [--check] <file.ail>...")
+		os.Exit(1)
+	}
+	if *writeFlag && *checkFlag {
+		fmt.Fprintf(os.Stderr, "%s: -w and --check cannot be used together\n", red("Error"))
+		os.Exit(1)
+	}
+
+	failed := false
+	unformatted := 0
+	for _, filename := range fs.Args() {
+		content, err := readSource(filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+			failed = true
+			continue
+		}
+
+		formatted, err := formatter.Source(content, filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+			failed = true
+			continue
+		}
+
+		changed := !bytes.Equal(content, formatted)
+		switch {
+		case *checkFlag:
+			if changed {
+				fmt.Println(filename)
+				unformatted++
+			}
+		case *writeFlag:
+			if changed {
+				if err := os.WriteFile(filename, formatted, 0644); err != nil {
+					fmt.Fprintf(os.Stderr, "%s: cannot write file '%s': %v\n", red("Error"), filename, err)
+					failed = true
+				}
+			}
+		default:
+			os.Stdout.Write(formatted)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+	if unformatted > 0 {
+		fmt.Fprintf(os.Stderr, "%s: %d file(s) not formatted\n", red("Error"), unformatted)
+		os.Exit(1)
+	}
+}
@@ -1,23 +1,32 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/builtins"
 	"github.com/sunholo/ailang/internal/effects"
 	ailangErrors "github.com/sunholo/ailang/internal/errors"
 	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/eval_harness"
+	"github.com/sunholo/ailang/internal/iface"
+	"github.com/sunholo/ailang/internal/loader"
 	"github.com/sunholo/ailang/internal/pipeline"
 	"github.com/sunholo/ailang/internal/repl"
 	"github.com/sunholo/ailang/internal/runtime"
 	"github.com/sunholo/ailang/internal/runtime/argdecode"
 	"github.com/sunholo/ailang/internal/schema"
+	"github.com/sunholo/ailang/internal/termcolor"
 	"github.com/sunholo/ailang/internal/types"
 )
 
@@ -28,11 +37,11 @@ var (
 	BuildTime = "unknown"
 
 	// Color output
-	green  = color.New(color.FgGreen).SprintFunc()
-	red    = color.New(color.FgRed).SprintFunc()
-	yellow = color.New(color.FgYellow).SprintFunc()
-	cyan   = color.New(color.FgCyan).SprintFunc()
-	bold   = color.New(color.Bold).SprintFunc()
+	green  = termcolor.Func(color.FgGreen)
+	red    = termcolor.Func(color.FgRed)
+	yellow = termcolor.Func(color.FgYellow)
+	cyan   = termcolor.Func(color.FgCyan)
+	bold   = termcolor.Func(color.Bold)
 
 	// Global flags
 	_ = false // quietMode placeholder for future use
@@ -41,6 +50,7 @@ var (
 func main() {
 	var (
 		versionFlag             = flag.Bool("version", false, "Print version information")
+		jsonFlag                = flag.Bool("json", false, "Print version information as JSON (with --version)")
 		helpFlag                = flag.Bool("help", false, "Show help")
 		learnFlag               = flag.Bool("learn", false, "Enable learning mode (collect training data)")
 		traceFlag               = flag.Bool("trace", false, "Enable execution tracing")
@@ -53,6 +63,7 @@ func main() {
 		maxRecursionDepthFlag   = flag.Int("max-recursion-depth", 10000, "Maximum recursion depth (default: 10000)")
 	)
 
+	_ = termcolor.SetMode(termcolor.Auto) // A command's --format-errors overrides it
 	flag.Parse()
 
 	// Set compact mode globally if flag is provided
@@ -64,7 +75,7 @@ func main() {
 	_ = *quietFlag
 
 	if *versionFlag {
-		printVersion()
+		printVersion(*jsonFlag, *compactFlag)
 		return
 	}
 
@@ -82,12 +93,11 @@ func main() {
 	case "repl":
 		runREPL(*learnFlag, *traceFlag)
 
+	case "version":
+		runVersion(*jsonFlag, *compactFlag)
+
 	case "test":
-		path := "."
-		if flag.NArg() >= 2 {
-			path = flag.Arg(1)
-		}
-		runTests(path)
+		runTests()
 
 	case "watch":
 		if flag.NArg() < 2 {
@@ -98,20 +108,16 @@ func main() {
 		watchFile(flag.Arg(1), *traceFlag, *binopShimFlag, *failOnShimFlag, *requireLoweringFlag, *trackInstantiationsFlag, *maxRecursionDepthFlag)
 
 	case "check":
-		if flag.NArg() < 2 {
-			fmt.Fprintf(os.Stderr, "%s: missing file argument\n", red("Error"))
-			fmt.Println("Usage: ailang check <file.ail>")
-			os.Exit(1)
-		}
-		checkFile(flag.Arg(1))
+		runCheck()
+
+	case "fmt":
+		runFmt()
+
+	case "eval-expr":
+		runEvalExpr()
 
 	case "iface":
-		if flag.NArg() < 2 {
-			fmt.Fprintf(os.Stderr, "%s: missing module argument\n", red("Error"))
-			fmt.Println("Usage: ailang iface <module>")
-			os.Exit(1)
-		}
-		outputInterface(flag.Arg(1))
+		runIface()
 
 	case "export-training":
 		exportTraining()
@@ -153,7 +159,29 @@ func main() {
 	}
 }
 
-func printVersion() {
+// versionInfo is the machine-readable form of printVersion's output
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+}
+
+// runVersion implements `ailang version [--json] [--compact]`
+func runVersion(asJSON, compact bool) {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	jsonFlag := fs.Bool("json", asJSON, "Print version information as JSON")
+	compactFlag := fs.Bool("compact", compact, "Use compact JSON output")
+	if err := fs.Parse(flag.Args()[1:]); err != nil {
+		os.Exit(1)
+	}
+	printVersion(*jsonFlag, *compactFlag)
+}
+
+func printVersion(asJSON, compact bool) {
+	if asJSON {
+		outputJSON(versionInfo{Version: Version, Commit: Commit, BuildTime: BuildTime}, compact)
+		return
+	}
 	fmt.Printf("AILANG %s\n", bold(Version))
 	if Commit != "unknown" {
 		fmt.Printf("Commit: %s\n", Commit)
@@ -174,11 +202,16 @@ func printHelp() {
 	fmt.Println("Commands:")
 	fmt.Printf("  %s             Run an AILANG program\n", cyan("run [flags] <file>"))
 	fmt.Printf("  %s                       Start the interactive REPL\n", cyan("repl"))
-	fmt.Printf("  %s                   Run tests\n", cyan("test [path]"))
+	fmt.Printf("  %s                   Run inline tests and properties\n", cyan("test [path]"))
 	fmt.Printf("  %s           Watch file for changes and auto-reload\n", cyan("watch <file>"))
-	fmt.Printf("  %s           Type-check a file without running\n", cyan("check <file>"))
+	fmt.Printf("  %s  Evaluate one expression and print its value and type\n", cyan("eval-expr '<expr>'"))
+	fmt.Printf("  %s           Type-check a file (or every .ail file in a directory)\n", cyan("check <file>"))
+	fmt.Printf("  %s    Same, with diagnostics as JSON\n", cyan("check --json <file>"))
+	fmt.Printf("  %s Format source (stdout, -w in place, --check)\n", cyan("fmt [-w|--check] <file>"))
 	fmt.Printf("  %s        Output normalized JSON interface for a module\n", cyan("iface <module>"))
+	fmt.Printf("  %s Output the module import graph as Graphviz DOT\n", cyan("iface --emit-dot <module>"))
 	fmt.Printf("  %s           Export training data\n", cyan("export-training"))
+	fmt.Printf("  %s     Print version information (as JSON with --json)\n", cyan("version [--json]"))
 	fmt.Println()
 	fmt.Println("Evaluation & Benchmarking:")
 	fmt.Printf("  %s         Run AI benchmarks (AILANG vs Python)\n", cyan("eval [flags]"))
@@ -196,18 +229,40 @@ func printHelp() {
 	fmt.Println()
 	fmt.Println("Run Command Flags (must come BEFORE filename):")
 	fmt.Println("  --caps <list>        Enable capabilities (comma-separated: IO,FS,Net)")
-	fmt.Println("  --entry <name>       Entrypoint function name (default: main)")
+	fmt.Println("  --entry <name>       Entrypoint function name (default: the @entry function, else main)")
 	fmt.Println("  --args-json <json>   JSON arguments to pass to entrypoint")
+	fmt.Println("  --args-stdin         Read JSON arguments from stdin (instead of --args-json)")
 	fmt.Println("  --trace              Enable execution tracing")
+	fmt.Println("  --trace-defaulting   Report numeric defaulting decisions (JSON with --json)")
+	fmt.Println("  --dump-instances     Print the instance resolved for each operator and literal (JSON with --json)")
+	fmt.Println("  --trace-eval         Print each evaluated Core node with position and value (stderr)")
+	fmt.Println("  --coverage           Report which if-branches and match arms ran (stderr)")
+	fmt.Println("  --int-overflow <m>   Int overflow behaviour: wrap (default) or checked")
 	fmt.Println("  --print              Print return value (default: true)")
 	fmt.Println("  --no-print           Suppress output (exit code only)")
+	fmt.Println("  --capture-output     Capture IO output instead of writing it directly")
+	fmt.Println("  --expected-output <file>  Fail unless captured output matches file")
+	fmt.Println("  --optimize           Inline temporaries, fold constants and drop unreachable code")
+	fmt.Println("  --require-pure       Fail if any effectful builtin is referenced (also for check)")
+	fmt.Println("  --max-errors <n>     Report at most n errors, 0 for all (default: 20, also for check)")
+	fmt.Println("  --strict             Safe mode for CI: --fail-on-shim, --require-lowering, --int-overflow checked")
+	fmt.Println("  --stdin              Read the program from stdin (or give - as the filename)")
+	fmt.Println("  --allow-net-http     Let the Net effect use http:// (default: https only)")
+	fmt.Println("  --net-allow-domain <d>  Restrict Net to domain d (*.example.com for subdomains); repeatable")
+	fmt.Println("  --net-timeout <dur>  Timeout of each Net request (default: 30s)")
+	fmt.Println("  --net-max-bytes <n>  Largest Net response body in bytes (default: 5 MB)")
+	fmt.Println("  --audit-effects      Log every effect operation performed (stderr, JSON with --json)")
+	fmt.Println("  --format-errors <m>  plain (no color) or rich (always color); default follows NO_COLOR,")
+	fmt.Println("                       CLICOLOR and the terminal (also for check, repl, test and eval-expr)")
 	fmt.Println()
 	fmt.Println("Global Flags:")
 	fmt.Println("  --version            Print version information")
+	fmt.Println("  --json               With --version, print {version, commit, buildTime} as JSON")
 	fmt.Println("  --help               Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Printf("  %s                        # Start REPL\n", cyan("ailang repl"))
+	fmt.Printf("  %s      # Run a file of REPL inputs and exit\n", cyan("ailang repl --script demo.repl"))
 	fmt.Printf("  %s              # Run program with IO capability\n", cyan("ailang run --caps IO hello.ail"))
 	fmt.Printf("  %s  # Run with custom entrypoint\n", cyan("ailang run --caps IO --entry test main.ail"))
 	fmt.Printf("  %s                  # Type-check without running\n", cyan("ailang check src/"))
@@ -230,12 +285,35 @@ func runCommand() {
 	failOnShimFlag := fs.Bool("fail-on-shim", false, "Fail if operator shim would be used (CI mode)")
 	requireLoweringFlag := fs.Bool("require-lowering", false, "Require operator lowering pass")
 	trackInstantiationsFlag := fs.Bool("track-instantiations", false, "Track and dump polymorphic type instantiations")
-	entryFlag := fs.String("entry", "main", "Entrypoint function name to execute")
+	entryFlag := fs.String("entry", "", "Entrypoint function name to execute (default: the @entry function, else main)")
 	argsJSONFlag := fs.String("args-json", "null", "JSON arguments to pass to entrypoint")
+	argsStdinFlag := fs.Bool("args-stdin", false, "Read JSON arguments for the entrypoint from stdin")
 	printFlag := fs.Bool("print", true, "Print return value (even for unit type)")
 	noPrintFlag := fs.Bool("no-print", false, "Suppress output (exit code only)")
+	printTypeFlag := fs.Bool("print-type", false, "Print the result's type and effects after its value (42 : int); with --json emit {value, type, effects}")
 	capsFlag := fs.String("caps", "", "Enable capabilities (comma-separated: IO,FS,Net)")
 	maxRecursionDepthFlag := fs.Int("max-recursion-depth", 10000, "Maximum recursion depth (default: 10000)")
+	captureOutputFlag := fs.Bool("capture-output", false, "Capture IO output and print it after the program finishes")
+	expectedOutputFlag := fs.String("expected-output", "", "Compare captured IO output against this file (implies --capture-output)")
+	traceDefaultingFlag := fs.Bool("trace-defaulting", false, "Report numeric defaulting decisions (JSON with --json)")
+	dumpInstancesFlag := fs.Bool("dump-instances", false, "Print every resolved constraint (node, class, type, method) after type checking (JSON with --json)")
+	traceEvalFlag := fs.Bool("trace-eval", false, "Print each evaluated Core node with its position and value to stderr")
+	coverageFlag := fs.Bool("coverage", false, "Report covered vs. total if-branches and match arms, and the ones that never ran, to stderr")
+	intOverflowFlag := fs.String("int-overflow", "wrap", "Int arithmetic on overflow: wrap (two's complement) or checked (RT_INT_OVERFLOW error)")
+	optimizeFlag := fs.Bool("optimize", false, "Inline single-use pure lets, fold constant operations and drop top-level bindings unreachable from the entrypoint")
+	requirePureFlag := fs.Bool("require-pure", false, "Fail if the program references any effectful builtin, whatever capabilities are granted")
+	noPreludeFlag := fs.Bool("no-prelude", false, "Do not auto-import the prelude's type class instances (Num, Eq, Ord, Show)")
+	maxErrorsFlag := fs.Int("max-errors", defaultMaxErrors, "Report at most this many errors (0 for no limit)")
+	strictFlag := fs.Bool("strict", false, "Enable every safety flag for CI: --fail-on-shim, --require-lowering and --int-overflow checked")
+	stdinFlag := fs.Bool("stdin", false, "Read the program from stdin (same as giving - as the file)")
+	formatErrorsFlag(fs)
+	netDefaults := effects.NewNetContext()
+	allowNetHTTPFlag := fs.Bool("allow-net-http", false, "Let the Net effect use plain http:// URLs (default: https only)")
+	var netDomains stringList
+	fs.Var(&netDomains, "net-allow-domain", "Only let the Net effect reach this domain (*.example.com for subdomains); repeatable")
+	netTimeoutFlag := fs.Duration("net-timeout", netDefaults.Timeout, "Timeout of each Net request")
+	netMaxBytesFlag := fs.Int64("net-max-bytes", netDefaults.MaxBytes, "Largest Net response body accepted, in bytes")
+	auditEffectsFlag := fs.Bool("audit-effects", false, "After running, log every effect operation performed with its time and key argument (stderr, JSON with --json)")
 
 	// Parse from os.Args[2:] (everything after "run")
 	if err := fs.Parse(os.Args[2:]); err != nil {
@@ -243,28 +321,117 @@ func runCommand() {
 		os.Exit(1)
 	}
 
-	// Check for filename argument
-	if fs.NArg() < 1 {
+	// --strict turns the safety flags on; an explicit --int-overflow still wins
+	if *strictFlag {
+		*failOnShimFlag = true
+		*requireLoweringFlag = true
+		intOverflowSet := false
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "int-overflow" {
+				intOverflowSet = true
+			}
+		})
+		if !intOverflowSet {
+			*intOverflowFlag = "checked"
+		}
+	}
+
+	// Check for filename argument; --stdin stands for "-"
+	filename := fs.Arg(0)
+	if *stdinFlag {
+		if filename != "" && filename != "-" {
+			fmt.Fprintf(os.Stderr, "%s: --stdin reads the program from stdin; do not also give a file\n", red("Error"))
+			os.Exit(1)
+		}
+		filename = "-"
+	}
+	if filename == "" {
 		fmt.Fprintf(os.Stderr, "%s: missing file argument\n", red("Error"))
-		fmt.Println("Usage: ailang run [--caps IO] [--entry main] [--args-json '<json>'] <file.ail>")
+		fmt.Println("Usage: ailang run [--caps IO] [--entry main] [--args-json '<json>'] <file.ail | ->")
 		fmt.Println("Note: Flags must come BEFORE the filename")
 		os.Exit(1)
 	}
 
-	filename := fs.Arg(0)
-	runFile(filename, *traceFlag, *seedFlag, *virtualTime, *jsonFlag, *compactFlag, *quietFlag, *binopShimFlag, *failOnShimFlag, *requireLoweringFlag, *trackInstantiationsFlag, *entryFlag, *argsJSONFlag, *printFlag, *noPrintFlag, *capsFlag, *maxRecursionDepthFlag)
-}
+	// --args-stdin replaces --args-json; both at once is ambiguous
+	if *argsStdinFlag && filename == "-" {
+		fmt.Fprintf(os.Stderr, "%s: --args-stdin cannot be used when the program is read from stdin\n", red("Error"))
+		os.Exit(1)
+	}
+	if *argsStdinFlag {
+		argsJSONSet := false
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "args-json" {
+				argsJSONSet = true
+			}
+		})
+		if argsJSONSet {
+			fmt.Fprintf(os.Stderr, "%s: --args-json and --args-stdin cannot be used together\n", red("Error"))
+			os.Exit(1)
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: failed to read arguments from stdin: %v\n", red("Error"), err)
+			os.Exit(1)
+		}
+		*argsJSONFlag = strings.TrimSpace(string(data))
+		if *argsJSONFlag == "" {
+			fmt.Fprintf(os.Stderr, "%s: --args-stdin: no JSON arguments on stdin\n", red("Error"))
+			os.Exit(1)
+		}
+	}
 
-func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput bool, compact bool, quiet bool, binopShim bool, failOnShim bool, requireLowering bool, trackInstantiations bool, entry string, argsJSON string, print bool, noprint bool, caps string, maxRecursionDepth int) {
-	// Read the file
-	content, err := os.ReadFile(filename)
+	intOverflow, err := eval.ParseIntOverflow(*intOverflowFlag)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s: cannot read file '%s': %v\n", red("Error"), filename, err)
+		fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
 		os.Exit(1)
 	}
 
+	// Net effect security settings
+	if *netTimeoutFlag <= 0 || *netMaxBytesFlag <= 0 {
+		fmt.Fprintf(os.Stderr, "%s: --net-timeout and --net-max-bytes must be positive\n", red("Error"))
+		os.Exit(1)
+	}
+	netCtx := netDefaults
+	netCtx.AllowHTTP = *allowNetHTTPFlag
+	netCtx.AllowedDomains = netDomains
+	netCtx.Timeout = *netTimeoutFlag
+	netCtx.MaxBytes = *netMaxBytesFlag
+
+	runFile(filename, *traceFlag, *seedFlag, *virtualTime, *jsonFlag, *compactFlag, *quietFlag, *binopShimFlag, *failOnShimFlag, *requireLoweringFlag, *trackInstantiationsFlag, *entryFlag, *argsJSONFlag, *printFlag, *noPrintFlag, *capsFlag, *maxRecursionDepthFlag, *captureOutputFlag, *expectedOutputFlag, *traceDefaultingFlag, *optimizeFlag, *traceEvalFlag, intOverflow, *requirePureFlag, *noPreludeFlag, *maxErrorsFlag, *coverageFlag, *dumpInstancesFlag, *printTypeFlag, netCtx, *auditEffectsFlag)
+}
+
+// stringList is a flag that may be given several times, collecting its values
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput bool, compact bool, quiet bool, binopShim bool, failOnShim bool, requireLowering bool, trackInstantiations bool, entry string, argsJSON string, print bool, noprint bool, caps string, maxRecursionDepth int, captureOutput bool, expectedOutput string, traceDefaulting bool, optimize bool, traceEval bool, intOverflow eval.IntOverflow, requirePure bool, noPrelude bool, maxErrors int, coverage bool, dumpInstances bool, printType bool, netCtx *effects.NetContext, auditEffects bool) {
+	// Read the file, or the program on stdin for "-" (run --stdin)
+	fromStdin := filename == "-"
+	var content []byte
+	var err error
+	if fromStdin {
+		filename = pipeline.StdinFilename
+		content, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: cannot read program from stdin: %v\n", red("Error"), err)
+			os.Exit(1)
+		}
+	} else {
+		content, err = readSource(filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+			os.Exit(1)
+		}
+	}
+
 	// Check file extension
-	if !strings.HasSuffix(filename, ".ail") {
+	if !fromStdin && !strings.HasSuffix(filename, ".ail") {
 		fmt.Fprintf(os.Stderr, "%s: file must have .ail extension\n", yellow("Warning"))
 	}
 
@@ -298,6 +465,7 @@ func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput
 	if maxRecursionDepth > 0 {
 		evaluator.SetMaxRecursionDepth(maxRecursionDepth)
 	}
+	evaluator.SetIntOverflow(intOverflow)
 	builtins := runtime.NewBuiltinRegistry(evaluator)
 	builtinResolver := runtime.NewBuiltinOnlyResolver(builtins)
 
@@ -305,10 +473,14 @@ func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput
 	// Non-module files (v0.1.0 style) need ModeEval for proper execution
 	contentStr := string(content)
 	hasModuleKeyword := false
+	modulePath := ""
 	for _, line := range strings.Split(contentStr, "\n") {
 		trimmed := strings.TrimLeft(line, " \t")
 		if strings.HasPrefix(trimmed, "module ") {
 			hasModuleKeyword = true
+			if fields := strings.Fields(trimmed); len(fields) > 1 {
+				modulePath = fields[1]
+			}
 			break
 		}
 	}
@@ -329,80 +501,79 @@ func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput
 		FailOnShim:            failOnShim,
 		RequireLowering:       requireLowering,
 		TrackInstantiations:   trackInstantiations,
+		Optimize:              optimize,
+		RequirePure:           requirePure,
+		NoPrelude:             noPrelude,
 		GlobalResolver:        builtinResolver, // Provide builtin access for type checking
 	}
+	if traceEval {
+		cfg.TraceEval = os.Stderr
+	}
+	if coverage {
+		cfg.Coverage = eval.NewCoverage()
+	}
 	src := pipeline.Source{
 		Code:     string(content),
 		Filename: filename,
 		IsREPL:   false,
 	}
+	if fromStdin {
+		// With no file to go by, a module is loaded as its declared path
+		// and its relative imports resolve from the current directory
+		src.Stdin = true
+		if modulePath != "" {
+			src.Filename = modulePath + ".ail"
+		}
+	}
 
 	result, err := pipeline.Run(cfg, src)
 	if err != nil {
-		if jsonOutput {
+		errs := splitErrors([]error{err})
+		shown, omitted := capErrors(errs, maxErrors)
+		if jsonOutput && len(errs) > 1 {
+			outputJSON(multiErrorReport(err, shown, omitted), compact)
+		} else if jsonOutput {
 			// Structured JSON output
 			handleStructuredError(err, compact)
 		} else {
 			// Human-readable error output
-			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+			for _, e := range shown {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), e)
+			}
+			printOmittedErrors("", omitted)
 		}
 		os.Exit(1)
 	}
 
-	// Display exhaustiveness warnings
+	// Display warnings
 	for _, warning := range result.Warnings {
 		fmt.Fprintf(os.Stderr, "%s\n", yellow(warning.String()))
 	}
+	if traceDefaulting {
+		if jsonOutput {
+			outputJSON(defaultingReport(result.Defaulting), compact)
+		} else if len(result.Defaulting) > 0 {
+			fmt.Fprintln(os.Stderr, types.FormatDefaultingTraces(result.Defaulting))
+		}
+	}
+	if dumpInstances {
+		if jsonOutput {
+			outputJSON(instancesReport(result.Resolved), compact)
+		} else {
+			fmt.Fprintln(os.Stderr, types.FormatResolvedConstraints(result.Resolved))
+		}
+	}
 
 	// Entrypoint resolution and execution
 	// Only attempt entrypoint resolution if the module has exports
 	if result.Interface != nil && len(result.Interface.Exports) > 0 {
 		// Module mode - look up and call entrypoint
-		fnExport, exists := result.Interface.Exports[entry]
-		if !exists {
-			// Auto-select entrypoint if possible
-			if entry == "main" {
-				// Try to auto-select an unambiguous entrypoint
-				var zeroArgFuncs []string
-				for name, export := range result.Interface.Exports {
-					if export.Type != nil {
-						if fnType, isFn := export.Type.Type.(*types.TFunc2); isFn {
-							if len(fnType.Params) == 0 {
-								zeroArgFuncs = append(zeroArgFuncs, name)
-							}
-						}
-					}
-				}
-
-				// Case 1: Exactly one zero-arg function
-				if len(zeroArgFuncs) == 1 {
-					entry = zeroArgFuncs[0]
-					fnExport = result.Interface.Exports[entry]
-					exists = true
-				} else if len(zeroArgFuncs) > 1 {
-					// Case 2: Multiple zero-arg functions, try "test"
-					for _, name := range zeroArgFuncs {
-						if name == "test" {
-							entry = name
-							fnExport = result.Interface.Exports[entry]
-							exists = true
-							break
-						}
-					}
-				}
-			}
-
-			if !exists {
-				fmt.Fprintf(os.Stderr, "%s: entrypoint '%s' not found in module\n", red("Error"), entry)
-				fmt.Fprintf(os.Stderr, "Available exports: ")
-				exportNames := []string{}
-				for name := range result.Interface.Exports {
-					exportNames = append(exportNames, name)
-				}
-				fmt.Fprintf(os.Stderr, "%v\n", exportNames)
-				os.Exit(1)
-			}
+		entry, err = selectEntrypoint(result.Interface.Exports, entry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+			os.Exit(1)
 		}
+		fnExport := result.Interface.Exports[entry]
 
 		// Check function type and decode arguments
 		scheme := fnExport.Type
@@ -419,10 +590,16 @@ func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput
 		}
 
 		// Module execution with runtime (v0.2.0+)
-		rt := runtime.NewModuleRuntime(filepath.Dir(filename))
+		rt := runtime.NewModuleRuntime(filepath.Dir(src.Filename))
 
 		// Set up effect context with capability grants
 		effCtx := effects.NewEffContext()
+		if netCtx != nil {
+			effCtx.Net = netCtx
+		}
+		if auditEffects {
+			effCtx.EnableAudit()
+		}
 		if caps != "" {
 			for _, capName := range strings.Split(caps, ",") {
 				capName = strings.TrimSpace(capName)
@@ -433,22 +610,55 @@ func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput
 		}
 		rt.GetEvaluator().SetEffContext(effCtx)
 
+		// Redirect IO output into a buffer when it is to be checked afterwards
+		var captured *bytes.Buffer
+		if captureOutput || expectedOutput != "" {
+			captured = effCtx.CaptureOutput()
+		}
+
 		// Set recursion depth limit
 		if maxRecursionDepth > 0 {
 			rt.GetEvaluator().SetMaxRecursionDepth(maxRecursionDepth)
 		}
+		rt.GetEvaluator().SetEvalTrace(cfg.TraceEval)
+		rt.GetEvaluator().SetIntOverflow(intOverflow)
+
+		// Dead-code elimination: keep only what the entrypoint can reach
+		modules := result.Modules
+		if optimize && modules != nil {
+			var stats pipeline.DeadCodeStats
+			modules, stats = pipeline.EliminateDeadCode(modules, result.Interface.Module, entry)
+			if !quiet {
+				fmt.Printf("  %s Optimized: inlined %d calls and %d lets, folded %d constant operations, removed %d of %d top-level bindings\n",
+					yellow("⚡"), result.InlinedCalls, result.InlinedLets, result.FoldedOps, stats.Removed, stats.Kept+stats.Removed)
+			}
+		}
 
 		// Pre-load modules from pipeline result
-		if result.Modules != nil {
-			for path, loaded := range result.Modules {
+		if modules != nil {
+			for path, loaded := range modules {
 				rt.PreloadModule(path, loaded)
 			}
 		}
 
+		// Coverage counts the program's own modules, not the stdlib
+		if cfg.Coverage != nil {
+			for path, loaded := range modules {
+				if !strings.HasPrefix(path, "std/") && loaded.Core != nil {
+					pipeline.WalkCore(loaded.Core, cfg.Coverage.AddNode)
+				}
+			}
+			rt.GetEvaluator().SetCoverage(cfg.Coverage)
+		}
+
 		// Load and evaluate module
 		inst, err := rt.LoadAndEvaluate(result.Interface.Module)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s: module evaluation failed: %v\n", red("Error"), err)
+			if jsonOutput {
+				handleStructuredError(err, compact)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s: module evaluation failed: %v\n", red("Error"), err)
+			}
 			os.Exit(1)
 		}
 
@@ -483,7 +693,7 @@ func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput
 		if len(fnType.Params) == 0 {
 			// Zero-arg function - argsJSON must be null
 			if argsJSON != "null" {
-				fmt.Fprintf(os.Stderr, "%s: entrypoint '%s' takes no arguments, but --args-json was provided\n", red("Error"), entry)
+				fmt.Fprintf(os.Stderr, "%s: entrypoint '%s' takes no arguments, but arguments were provided via --args-json/--args-stdin\n", red("Error"), entry)
 				os.Exit(1)
 			}
 			args = []eval.Value{} // Empty args
@@ -504,25 +714,41 @@ func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput
 		// Call the entrypoint function
 		execResult, err := runtime.CallEntrypoint(rt, inst, entry, args)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s: execution failed: %v\n", red("Error"), err)
+			if captured != nil {
+				os.Stdout.Write(captured.Bytes())
+			}
+			if jsonOutput {
+				handleStructuredError(err, compact)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s: execution failed: %v\n", red("Error"), err)
+			}
+			printAuditLog(effCtx, jsonOutput, compact)
 			os.Exit(1)
 		}
+		if captured != nil {
+			checkCapturedOutput(captured.String(), expectedOutput)
+		}
+		printAuditLog(effCtx, jsonOutput, compact)
 
 		// Print result if not Unit and not suppressed
 		if execResult.Type() != "unit" && !noprint {
 			if print {
-				fmt.Println(execResult.String())
+				printResult(execResult, fnType.Return, fnType.EffectRow, printType, jsonOutput, compact)
 			}
 		}
 	} else {
 		// Non-module mode - print result if evaluated by pipeline (ModeEval)
 		if result.Value != nil && result.Value.Type() != "unit" && !noprint {
 			if print {
-				fmt.Println(result.Value.String())
+				printResult(result.Value, result.Type, result.Effects, printType, jsonOutput, compact)
 			}
 		}
 	}
 
+	if cfg.Coverage != nil {
+		fmt.Fprint(os.Stderr, cfg.Coverage.Summary())
+	}
+
 	// Dump instantiations if tracking
 	if trackInstantiations && result.Instantiations != nil {
 		fmt.Printf("\n%s Polymorphic Instantiations:\n", cyan("📊"))
@@ -538,39 +764,240 @@ func runFile(filename string, trace bool, seed int, virtualTime bool, jsonOutput
 	}
 }
 
-func runREPL(learn bool, trace bool) {
-	// Use the new REPL implementation with version info
-	r := repl.NewWithVersion(Version, BuildTime)
-	if trace {
-		r.EnableTrace()
+// printResult prints the value run produced. With --print-type it is
+// followed by its type and any effects ("42 : int", "() : () ! {IO}"), or
+// with --json as well reported as {"value", "type", "effects"}.
+func printResult(value eval.Value, typ types.Type, effects *types.Row, printType, jsonOutput, compact bool) {
+	if !printType || typ == nil {
+		fmt.Println(value.String())
+		return
 	}
-	r.Start(os.Stdin, os.Stdout)
+
+	labels := []string{}
+	if effects != nil {
+		for label := range effects.Labels {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+	}
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"value":   value.String(),
+			"type":    typ.String(),
+			"effects": labels,
+		}, compact)
+		return
+	}
+	if len(labels) > 0 {
+		fmt.Printf("%s : %s ! {%s}\n", value, typ, strings.Join(labels, ", "))
+		return
+	}
+	fmt.Printf("%s : %s\n", value, typ)
 }
 
-func runTests(path string) {
-	fmt.Printf("%s Running tests in %s\n", cyan("→"), path)
+// selectEntrypoint picks the function run executes. Precedence:
+//  1. the --entry name, if given (it must be exported)
+//  2. otherwise the function declared with @entry (at most one may be)
+//  3. otherwise main, or the only exported function (of any arity; its
+//     argument comes from --args-json)
+//  4. otherwise the only zero-argument exported function, or test when
+//     several take no arguments
+//
+// Anything else is an error listing the candidates.
+func selectEntrypoint(exports map[string]*iface.IfaceItem, entry string) (string, error) {
+	if _, ok := exports[entry]; ok {
+		return entry, nil
+	}
 
-	// Find all .ail files with tests
-	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	var funcs, zeroArg, marked []string
+	for name, export := range exports {
+		if export.Entry {
+			marked = append(marked, name)
 		}
+		if export.Type != nil {
+			if fnType, isFn := export.Type.Type.(*types.TFunc2); isFn {
+				funcs = append(funcs, name)
+				if len(fnType.Params) == 0 {
+					zeroArg = append(zeroArg, name)
+				}
+			}
+		}
+	}
+	sort.Strings(funcs)
+	sort.Strings(zeroArg)
+	sort.Strings(marked)
+
+	if entry == "" {
+		switch {
+		case len(marked) == 1:
+			return marked[0], nil
+		case len(marked) > 1:
+			return "", fmt.Errorf("%d functions are marked @entry: %s; keep one or choose with --entry",
+				len(marked), strings.Join(marked, ", "))
+		}
+		entry = "main"
+		if _, ok := exports[entry]; ok {
+			return entry, nil
+		}
+		if len(funcs) == 1 {
+			return funcs[0], nil
+		}
+		if len(zeroArg) == 1 {
+			return zeroArg[0], nil
+		}
+		for _, name := range zeroArg {
+			if name == "test" {
+				return name, nil
+			}
+		}
+	}
 
-		if strings.HasSuffix(p, ".ail") {
-			// TODO: Check if file has tests and run them
-			fmt.Printf("  %s %s\n", green("✓"), p)
+	const precedence = "entrypoint precedence: --entry <name>, then @entry, then main, then the only exported function, then the only zero-argument function or test"
+	switch {
+	case len(funcs) == 0:
+		return "", fmt.Errorf("entrypoint '%s' not found: module exports no functions (%s)", entry, precedence)
+	case entry == "main":
+		return "", fmt.Errorf("no 'main' and %d exported functions, choose one with --entry or mark it @entry: %s (%s)",
+			len(funcs), strings.Join(funcs, ", "), precedence)
+	default:
+		return "", fmt.Errorf("entrypoint '%s' not found in module; exported functions: %s (%s)",
+			entry, strings.Join(funcs, ", "), precedence)
+	}
+}
+
+// defaultingJSON is one numeric defaulting decision in --trace-defaulting --json output
+type defaultingJSON struct {
+	Class    string    `json:"class"`
+	TypeVar  string    `json:"type_var"`
+	Default  string    `json:"default"`
+	Location string    `json:"location"`
+	NodeID   uint64    `json:"node_id,omitempty"`
+	Span     *ast.Span `json:"span,omitempty"`
+}
+
+// defaultingReport builds the --trace-defaulting --json document
+func defaultingReport(traces []types.DefaultingTrace) map[string]interface{} {
+	entries := make([]defaultingJSON, 0, len(traces))
+	for _, trace := range traces {
+		entry := defaultingJSON{
+			Class:    trace.ClassName,
+			TypeVar:  trace.TypeVar,
+			Default:  trace.Default.String(),
+			Location: trace.Location,
+			NodeID:   trace.NodeID,
 		}
+		if trace.Span.Line > 0 {
+			entry.Span = &ast.Span{Start: trace.Span, End: trace.Span}
+		}
+		entries = append(entries, entry)
+	}
+	return map[string]interface{}{
+		"schema":     schema.DefaultingV1,
+		"defaulting": entries,
+	}
+}
 
-		return nil
-	})
+// instanceJSON is one resolved constraint in --dump-instances --json output
+type instanceJSON struct {
+	NodeID uint64    `json:"node_id"`
+	Class  string    `json:"class"`
+	Type   string    `json:"type"`
+	Method string    `json:"method,omitempty"`
+	Span   *ast.Span `json:"span,omitempty"`
+}
+
+// instancesReport builds the --dump-instances --json document
+func instancesReport(resolved []*types.ResolvedConstraint) map[string]interface{} {
+	entries := make([]instanceJSON, 0, len(resolved))
+	for _, rc := range resolved {
+		entry := instanceJSON{
+			NodeID: rc.NodeID,
+			Class:  rc.ClassName,
+			Type:   rc.Type.String(),
+			Method: rc.Method,
+		}
+		if rc.Span.Line > 0 {
+			entry.Span = &ast.Span{Start: rc.Span, End: rc.Span}
+		}
+		entries = append(entries, entry)
+	}
+	return map[string]interface{}{
+		"schema":    schema.InstancesV1,
+		"instances": entries,
+	}
+}
+
+// printAuditLog prints the effect operations recorded with --audit-effects,
+// one per line to stderr, or as {"schema", "operations"} with --json
+func printAuditLog(ctx *effects.EffContext, jsonOutput, compact bool) {
+	entries := ctx.AuditLog()
+	if entries == nil {
+		return
+	}
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"schema":     schema.AuditV1,
+			"operations": entries,
+		}, compact)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Effect audit: %d operation(s)\n", len(entries))
+	for _, entry := range entries {
+		fmt.Fprintf(os.Stderr, "  %s\n", entry)
+	}
+}
 
+// checkCapturedOutput prints captured program output, or compares it against
+// the expected output file using the same normalization as the eval harness
+// and exits non-zero on mismatch.
+func checkCapturedOutput(actual string, expectedFile string) {
+	if expectedFile == "" {
+		fmt.Print(actual)
+		return
+	}
+
+	expected, err := os.ReadFile(expectedFile)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+		fmt.Fprintf(os.Stderr, "%s: cannot read expected output '%s': %v\n", red("Error"), expectedFile, err)
+		os.Exit(1)
+	}
+	if !eval_harness.CompareOutput(string(expected), actual) {
+		fmt.Fprintf(os.Stderr, "%s: output does not match %s\n", red("Error"), expectedFile)
+		fmt.Fprintf(os.Stderr, "--- expected\n%s\n--- actual\n%s\n", strings.TrimSpace(string(expected)), strings.TrimSpace(actual))
+		os.Exit(1)
+	}
+	fmt.Printf("%s Output matches %s\n", green("✓"), expectedFile)
+}
+
+func runREPL(learn bool, trace bool) {
+	fs := flag.NewFlagSet("repl", flag.ExitOnError)
+	noPreludeFlag := fs.Bool("no-prelude", false, "Do not auto-import std/prelude")
+	scriptFlag := fs.String("script", "", "Run the REPL inputs in this file (expressions and :commands), echoing each, then exit")
+	formatErrorsFlag(fs)
+	if err := fs.Parse(flag.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
 		os.Exit(1)
 	}
 
-	// TODO: Implement test runner
-	fmt.Printf("\n%s All tests passed!\n", green("✓"))
+	// Use the new REPL implementation with version info
+	r := repl.NewWithVersion(Version, BuildTime)
+	if trace {
+		r.EnableTrace()
+	}
+	if *noPreludeFlag {
+		r.DisablePrelude()
+	}
+	if *scriptFlag != "" {
+		script, err := os.Open(*scriptFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: cannot read script: %v\n", red("Error"), err)
+			os.Exit(1)
+		}
+		defer script.Close()
+		r.RunScript(script, os.Stdout)
+		return
+	}
+	r.Start(os.Stdin, os.Stdout)
 }
 
 func watchFile(filename string, trace bool, binopShim bool, failOnShim bool, requireLowering bool, trackInstantiations bool, maxRecursionDepth int) {
@@ -579,17 +1006,80 @@ func watchFile(filename string, trace bool, binopShim bool, failOnShim bool, req
 
 	// TODO: Implement file watching
 	// For now, just run the file once (no json/compact/quiet for watch mode)
-	// Default to main entrypoint with null args for watch mode, no caps
-	runFile(filename, trace, 0, false, false, false, false, binopShim, failOnShim, requireLowering, trackInstantiations, "main", "null", true, false, "", maxRecursionDepth)
+	// Default entrypoint with null args for watch mode, no caps
+	runFile(filename, trace, 0, false, false, false, false, binopShim, failOnShim, requireLowering, trackInstantiations, "", "null", true, false, "", maxRecursionDepth, false, "", false, false, false, eval.IntOverflowWrap, false, false, defaultMaxErrors, false, false, false, nil, false)
 }
 
-func checkFile(filename string) {
-	// Read the file
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s: cannot read file '%s': %v\n", red("Error"), filename, err)
+// defaultMaxErrors is how many errors check and run report per file unless
+// --max-errors says otherwise
+const defaultMaxErrors = 20
+
+// runCheck type-checks a file or directory without running it
+// Usage: ailang check [--json] [--compact] [--check-only-changed] [--max-errors N] [--format-errors plain|rich] <file.ail|dir>
+func runCheck() {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	jsonFlag := fs.Bool("json", false, "Output diagnostics in structured JSON format")
+	compactFlag := fs.Bool("compact", false, "Use compact JSON output")
+	requirePureFlag := fs.Bool("require-pure", false, "Fail if the program references any effectful builtin")
+	maxErrorsFlag := fs.Int("max-errors", defaultMaxErrors, "Report at most this many errors per file (0 for no limit)")
+	onlyChangedFlag := fs.Bool("check-only-changed", false, "Skip files that checked cleanly last time and whose source and dependencies are unchanged")
+	formatErrorsFlag(fs)
+
+	// Parse from os.Args[2:] (everything after "check")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "%s: missing file argument\n", red("Error"))
+		fmt.Println("Usage: ailang check [--json] [--compact] [--require-pure] [--check-only-changed] [--max-errors N] <file.ail|dir>")
 		os.Exit(1)
 	}
+	if *compactFlag {
+		schema.SetCompactMode(true)
+	}
+
+	var state *pipeline.CheckState
+	if *onlyChangedFlag {
+		state = pipeline.LoadCheckState(checkStatePath())
+	}
+
+	if *jsonFlag {
+		checkJSON(fs.Arg(0), state, *requirePureFlag, *maxErrorsFlag)
+		return
+	}
+	checkFile(fs.Arg(0), state, *requirePureFlag, *maxErrorsFlag)
+}
+
+// checkStatePath is where check --check-only-changed keeps its state:
+// $AILANG_CACHE_DIR/check-state.json, by default in the user cache directory
+func checkStatePath() string {
+	dir := os.Getenv("AILANG_CACHE_DIR")
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			base = os.TempDir()
+		}
+		dir = filepath.Join(base, "ailang")
+	}
+	return filepath.Join(dir, "check-state.json")
+}
+
+// saveCheckState writes the state of a --check-only-changed run (if any)
+func saveCheckState(state *pipeline.CheckState) {
+	if err := state.Save(checkStatePath()); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: cannot save check state: %v\n", yellow("Warning"), err)
+	}
+}
+
+func checkFile(filename string, state *pipeline.CheckState, requirePure bool, maxErrors int) {
+	if info, err := os.Stat(filename); err == nil && info.IsDir() {
+		checkDir(filename, state, requirePure, maxErrors)
+		return
+	}
+	if state.Unchanged(filename, requirePure) {
+		fmt.Printf("%s %s is unchanged since its last clean check\n", green("✓"), filename)
+		return
+	}
 
 	// Type check
 	fmt.Printf("%s Type checking %s...\n", cyan("→"), filename)
@@ -597,9 +1087,242 @@ func checkFile(filename string) {
 	// Effect check
 	fmt.Printf("%s Effect checking...\n", cyan("→"))
 
+	result, errs := checkSource(filename, nil, requirePure)
+	state.Record(filename, requirePure, result, errs)
+	saveCheckState(state)
+	if len(errs) > 0 {
+		shown, omitted := capErrors(errs, maxErrors)
+		for _, e := range shown {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), e)
+		}
+		printOmittedErrors("", omitted)
+		os.Exit(1)
+	}
+
+	for _, warning := range deprecationWarnings(result) {
+		fmt.Fprintf(os.Stderr, "%s\n", yellow(warning.String()))
+	}
+
+	fmt.Printf("\n%s No errors found!\n", green("✓"))
+}
+
+// deprecationWarnings returns the warnings check prints: uses of deprecated
+// symbols. Other warnings are shown by run and by check --json.
+func deprecationWarnings(result pipeline.Result) []ailangErrors.Warning {
+	var warnings []ailangErrors.Warning
+	for _, w := range result.Warnings {
+		if _, ok := w.(*pipeline.DeprecationWarning); ok {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
+// checkDir type-checks every .ail file under dir, sharing one module cache so
+// that common imports are compiled once, and prints a per-file summary.
+// Hidden directories are skipped, and so are unchanged files if state is
+// set (--check-only-changed). Exits non-zero if any file has errors.
+func checkDir(dir string, state *pipeline.CheckState, requirePure bool, maxErrors int) {
+	files := ailFilesIn(dir)
+
+	fmt.Printf("%s Type checking %d files in %s...\n", cyan("→"), len(files), dir)
+
+	cache := pipeline.NewModuleCache()
+	failed, totalErrors, skipped := 0, 0, 0
+	for _, file := range files {
+		if state.Unchanged(file, requirePure) {
+			skipped++
+			fmt.Printf("  %s %s (unchanged)\n", green("✓"), file)
+			continue
+		}
+		result, errs := checkSource(file, cache, requirePure)
+		state.Record(file, requirePure, result, errs)
+		if len(errs) > 0 {
+			failed++
+			totalErrors += len(errs)
+			fmt.Printf("  %s %s (%d %s)\n", red("✗"), file, len(errs), plural(len(errs), "error", "errors"))
+			shown, omitted := capErrors(errs, maxErrors)
+			for _, e := range shown {
+				fmt.Fprintf(os.Stderr, "    %s: %v\n", red("Error"), e)
+			}
+			printOmittedErrors("    ", omitted)
+			continue
+		}
+		fmt.Printf("  %s %s\n", green("✓"), file)
+		for _, warning := range deprecationWarnings(result) {
+			fmt.Fprintf(os.Stderr, "    %s\n", yellow(warning.String()))
+		}
+	}
+
+	saveCheckState(state)
+
+	if failed > 0 {
+		fmt.Printf("\n%s %d of %d files have errors (%d %s)\n", red("✗"), failed, len(files), totalErrors, plural(totalErrors, "error", "errors"))
+		os.Exit(1)
+	}
+	if skipped > 0 {
+		fmt.Printf("\n%s No errors found in %d files (%d unchanged)!\n", green("✓"), len(files), skipped)
+		return
+	}
+	fmt.Printf("\n%s No errors found in %d files!\n", green("✓"), len(files))
+}
+
+// ailFilesIn lists the .ail files under dir, skipping hidden directories.
+// Exits if the walk fails or finds nothing.
+func ailFilesIn(dir string) []string {
+	var files []string
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && p != dir && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && strings.HasSuffix(p, ".ail") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(os.Stderr, "%s: no .ail files found in %s\n", red("Error"), dir)
+		os.Exit(1)
+	}
+	return files
+}
+
+// checkReport is the --json output of ailang check for one file
+type checkReport struct {
+	Schema        string                    `json:"schema,omitempty"`
+	File          string                    `json:"file"`
+	Diagnostics   []ailangErrors.Diagnostic `json:"diagnostics"`
+	OmittedErrors int                       `json:"omitted_errors,omitempty"` // Errors cut by --max-errors
+	Unchanged     bool                      `json:"unchanged,omitempty"`      // Skipped by --check-only-changed
+}
+
+// checkJSON type-checks a file (or every file in a directory) and prints the
+// diagnostics as JSON instead of colored text. A directory yields
+// {schema, files: [{file, diagnostics}]}. Exits non-zero if any file has errors.
+func checkJSON(path string, state *pipeline.CheckState, requirePure bool, maxErrors int) {
+	files := []string{path}
+	info, err := os.Stat(path)
+	isDir := err == nil && info.IsDir()
+	if isDir {
+		files = ailFilesIn(path)
+	}
+
+	cache := pipeline.NewModuleCache()
+	reports := make([]checkReport, 0, len(files))
+	failed := false
+	for _, file := range files {
+		if state.Unchanged(file, requirePure) {
+			reports = append(reports, checkReport{File: file, Diagnostics: []ailangErrors.Diagnostic{}, Unchanged: true})
+			continue
+		}
+		result, errs := checkSource(file, cache, requirePure)
+		state.Record(file, requirePure, result, errs)
+		if len(errs) > 0 {
+			failed = true
+		}
+		shown, omitted := capErrors(errs, maxErrors)
+		reports = append(reports, checkReport{File: file, Diagnostics: checkDiagnostics(result, shown), OmittedErrors: omitted})
+	}
+
+	saveCheckState(state)
+
+	var out interface{}
+	if isDir {
+		out = map[string]interface{}{"schema": schema.CheckV1, "files": reports}
+	} else {
+		reports[0].Schema = schema.CheckV1
+		out = reports[0]
+	}
+	data, err := schema.MarshalDeterministic(out)
+	if err == nil {
+		data, err = schema.FormatJSON(data)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// checkDiagnostics lists the errors of a check run followed by its warnings
+func checkDiagnostics(result pipeline.Result, errs []error) []ailangErrors.Diagnostic {
+	diags := make([]ailangErrors.Diagnostic, 0, len(errs)+len(result.Warnings))
+	for _, err := range errs {
+		var instErr *types.MissingInstanceError
+		if _, ok := ailangErrors.AsReport(err); !ok && errors.As(err, &instErr) {
+			diags = append(diags, errorReport(err).Diagnostic())
+			continue
+		}
+		diags = append(diags, ailangErrors.DiagnosticFromError(err))
+	}
+	for _, w := range result.Warnings {
+		diags = append(diags, ailangErrors.DiagnosticFromWarning(w))
+	}
+	return diags
+}
+
+// splitErrors expands an error that bundles several failures, such as all
+// parse errors of a module, into its individual errors
+func splitErrors(errs []error) []error {
+	var out []error
+	for _, err := range errs {
+		var parseErrs *loader.ParseErrors
+		if errors.As(err, &parseErrs) && len(parseErrs.Errs) > 0 {
+			out = append(out, parseErrs.Errs...)
+			continue
+		}
+		out = append(out, err)
+	}
+	return out
+}
+
+// capErrors keeps the first max errors (all of them if max is not positive)
+// and returns how many were left out
+func capErrors(errs []error, max int) ([]error, int) {
+	if max <= 0 || len(errs) <= max {
+		return errs, 0
+	}
+	return errs[:max], len(errs) - max
+}
+
+// printOmittedErrors notes on stderr how many errors --max-errors cut
+func printOmittedErrors(indent string, omitted int) {
+	if omitted > 0 {
+		fmt.Fprintf(os.Stderr, "%s... and %d more %s.\n", indent, omitted, plural(omitted, "error", "errors"))
+	}
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// checkSource type-checks one file without evaluating it. All failures are
+// returned as errors; cache may be nil.
+func checkSource(filename string, cache *pipeline.ModuleCache, requirePure bool) (pipeline.Result, []error) {
+	content, err := readSource(filename)
+	if err != nil {
+		return pipeline.Result{}, []error{err}
+	}
+
 	// Use unified pipeline in dry-run mode (no evaluation)
 	cfg := pipeline.Config{
-		DryLink: true, // Don't evaluate, just check
+		DryLink:     true, // Don't evaluate, just check
+		Cache:       cache,
+		RequirePure: requirePure,
 	}
 	src := pipeline.Source{
 		Code:     string(content),
@@ -609,32 +1332,80 @@ func checkFile(filename string) {
 
 	result, err := pipeline.Run(cfg, src)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+		return result, splitErrors([]error{err})
+	}
+	return result, result.Errors
+}
+
+func runIface() {
+	fs := flag.NewFlagSet("iface", flag.ExitOnError)
+	dotFlag := fs.Bool("emit-dot", false, "Output the module import graph in Graphviz DOT format instead")
+
+	// Parse from os.Args[2:] (everything after "iface")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "%s: missing module argument\n", red("Error"))
+		fmt.Println("Usage: ailang iface [--emit-dot] <module>")
 		os.Exit(1)
 	}
+	if *dotFlag {
+		outputModuleGraph(fs.Arg(0))
+		return
+	}
+	outputInterface(fs.Arg(0))
+}
 
-	// Check for any errors
-	if len(result.Errors) > 0 {
-		for _, e := range result.Errors {
-			fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), e)
+// moduleFilename resolves a module path (or file name) to its source file
+// readSource reads a source file named on the command line. An empty path
+// is rejected up front, and a missing file is reported with the path both
+// as given and as resolved.
+func readSource(filename string) ([]byte, error) {
+	if strings.TrimSpace(filename) == "" {
+		return nil, fmt.Errorf("cannot read file: empty path")
+	}
+	content, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		abs, absErr := filepath.Abs(filename)
+		if absErr != nil {
+			abs = filename
 		}
-		os.Exit(1)
+		return nil, fmt.Errorf("cannot read file '%s': file not found (looked for %s)", filename, abs)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read file '%s': %w", filename, err)
+	}
+	return content, nil
+}
 
-	fmt.Printf("\n%s No errors found!\n", green("✓"))
+func moduleFilename(modulePath string) string {
+	if strings.HasSuffix(modulePath, ".ail") {
+		return modulePath
+	}
+	return strings.ReplaceAll(modulePath, "/", string(filepath.Separator)) + ".ail"
+}
+
+// outputModuleGraph prints the import graph of a module and everything it
+// imports in Graphviz DOT format, e.g. ailang iface --emit-dot app | dot -Tsvg
+func outputModuleGraph(modulePath string) {
+	filename := moduleFilename(modulePath)
+	modules, err := loader.NewModuleLoader(".").LoadAll([]string{filename})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
+		os.Exit(1)
+	}
+	graph := pipeline.BuildModuleGraph(loader.CanonicalModuleID(filename), modules)
+	fmt.Print(graph.DOT())
 }
 
 func outputInterface(modulePath string) {
 	// Read the file
-	filename := modulePath
-	if !strings.HasSuffix(filename, ".ail") {
-		// Try to resolve as module path
-		filename = strings.ReplaceAll(modulePath, "/", string(filepath.Separator)) + ".ail"
-	}
+	filename := moduleFilename(modulePath)
 
-	content, err := os.ReadFile(filename)
+	content, err := readSource(filename)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s: cannot read file '%s': %v\n", red("Error"), filename, err)
+		fmt.Fprintf(os.Stderr, "%s: %v\n", red("Error"), err)
 		os.Exit(1)
 	}
 
@@ -692,15 +1463,79 @@ func exportTraining() {
 
 // handleStructuredError outputs structured JSON error reports
 func handleStructuredError(err error, compact bool) {
+	outputJSON(errorReport(err), compact)
+}
+
+// multiErrorReport reports a failure made of several errors. The report
+// describes the first one and lists every shown error under data.errors.
+func multiErrorReport(err error, shown []error, omitted int) *ailangErrors.Report {
+	rep := errorReport(err)
+	first := ailangErrors.DiagnosticFromError(shown[0])
+	rep.Code, rep.Message, rep.Span = first.Code, first.Message, first.Span
+	diags := make([]ailangErrors.Diagnostic, 0, len(shown))
+	for _, e := range shown {
+		diags = append(diags, ailangErrors.DiagnosticFromError(e))
+	}
+	rep.Data["errors"] = diags
+	if omitted > 0 {
+		rep.Data["omitted_errors"] = omitted
+	}
+	return rep
+}
+
+// errorReport converts err into a structured report, keeping the details of
+// the error kinds that carry more than a message
+func errorReport(err error) *ailangErrors.Report {
 	// Try to extract a structured Report using errors.AsReport
 	if rep, ok := ailangErrors.AsReport(err); ok {
-		outputJSON(rep, compact)
-		return
+		return rep
+	}
+
+	// Missing instances carry the instances that do exist and a suggested fix
+	var instErr *types.MissingInstanceError
+	if errors.As(err, &instErr) {
+		rep := ailangErrors.NewGeneric("typecheck", err)
+		rep.Code = ailangErrors.TC010
+		rep.Data["class"] = instErr.Class
+		rep.Data["type"] = instErr.Type.String()
+		rep.Data["available"] = instErr.Available
+		if instErr.Hint != "" {
+			rep.Fix = &ailangErrors.Fix{Suggestion: instErr.Hint, Confidence: 0.7}
+		}
+		return rep
+	}
+
+	// Evaluation failures carry the failing span and call stack
+	var rtErr *eval.RuntimeError
+	if errors.As(err, &rtErr) {
+		return runtimeErrorReport(rtErr)
 	}
 
 	// Fallback: wrap in generic error
-	generic := ailangErrors.NewGeneric("runtime", err)
-	outputJSON(generic, compact)
+	return ailangErrors.NewGeneric("runtime", err)
+}
+
+// runtimeErrorReport converts an evaluation failure into a structured report.
+// Errors raised with error(msg) get code RT_USER_ERROR and the bare message.
+func runtimeErrorReport(rtErr *eval.RuntimeError) *ailangErrors.Report {
+	rep := ailangErrors.NewGeneric("runtime", rtErr.Err)
+
+	var userErr *eval.UserError
+	if errors.As(rtErr.Err, &userErr) {
+		rep.Code = ailangErrors.RTUserError
+		rep.Message = userErr.Message
+	}
+	if rtErr.Pos.Line > 0 {
+		rep.Span = &ast.Span{Start: rtErr.Pos, End: rtErr.Pos}
+	}
+	if len(rtErr.Stack) > 0 {
+		stack := make([]string, 0, len(rtErr.Stack))
+		for i := len(rtErr.Stack) - 1; i >= 0; i-- {
+			stack = append(stack, rtErr.Stack[i].Function)
+		}
+		rep.Data["stack"] = stack
+	}
+	return rep
 }
 
 // outputJSON marshals and prints JSON
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/elaborate"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/lexer"
+	"github.com/sunholo/ailang/internal/parser"
+	"github.com/sunholo/ailang/internal/pipeline"
+	"github.com/sunholo/ailang/internal/runtime"
+	ailangTest "github.com/sunholo/ailang/internal/test"
+)
+
+// runTests evaluates the inline tests [...] assertions and checks the
+// properties [...] of a file, or of every file under a directory, and reports
+// each one that does not hold.
+// Usage: ailang test [--seed N] [--trials N] [file.ail|dir]
+func runTests() {
+	fs := flag.NewFlagSet("test", flag.ExitOnError)
+	seedFlag := fs.Int64("seed", 0, "Seed for property inputs (default: AILANG_SEED, else random)")
+	trialsFlag := fs.Int("trials", 100, "Random inputs to try per property")
+	formatErrorsFlag(fs)
+
+	// Parse from os.Args[2:] (everything after "test")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(1)
+	}
+	path := "."
+	if fs.NArg() > 0 {
+		path = fs.Arg(0)
+	}
+	if *trialsFlag < 1 {
+		fmt.Fprintf(os.Stderr, "%s: --trials must be at least 1\n", red("Error"))
+		os.Exit(1)
+	}
+	seed := *seedFlag
+	if seed == 0 {
+		seed = effects.NewEffContext().Env.Seed
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	files := []string{path}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		files = ailFilesIn(path)
+	}
+
+	fmt.Printf("%s Running tests in %s\n", cyan("→"), path)
+
+	cache := pipeline.NewModuleCache()
+	passed, failed := 0, 0
+	for _, file := range files {
+		p, f := testFile(file, cache, seed, *trialsFlag)
+		passed += p
+		failed += f
+	}
+
+	total := passed + failed
+	if failed > 0 {
+		fmt.Printf("\n%s %d of %d %s failed\n", red("✗"), failed, total, plural(total, "test", "tests"))
+		os.Exit(1)
+	}
+	if total == 0 {
+		fmt.Printf("\n%s No tests found\n", yellow("!"))
+		return
+	}
+	fmt.Printf("\n%s All %d %s passed!\n", green("✓"), total, plural(total, "test", "tests"))
+}
+
+// testFile runs the inline tests and properties of one file and returns how
+// many passed and failed. A file that does not compile counts as one failure
+// per test.
+func testFile(filename string, cache *pipeline.ModuleCache, seed int64, trials int) (passed, failed int) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Printf("  %s %s: %v\n", red("✗"), filename, err)
+		return 0, 1
+	}
+
+	p := parser.New(lexer.New(string(content), filename))
+	file := p.ParseFile()
+	if len(p.Errors()) > 0 {
+		// Only files with tests matter, and their tests cannot be found
+		return 0, 0
+	}
+	var funcs []*ast.FuncDecl
+	count := 0
+	for _, fn := range file.Funcs {
+		if len(fn.Tests)+len(fn.Properties) > 0 {
+			funcs = append(funcs, fn)
+			count += len(fn.Tests) + len(fn.Properties)
+		}
+	}
+	if count == 0 {
+		return 0, 0
+	}
+
+	rt, inst, err := loadTestModule(filename, string(content), file, cache)
+	if err != nil {
+		fmt.Printf("  %s %s (%d %s)\n", red("✗"), filename, count, plural(count, "test", "tests"))
+		fmt.Fprintf(os.Stderr, "    %s: %v\n", red("Error"), err)
+		return 0, count
+	}
+
+	var failures []string
+	for _, fn := range funcs {
+		for i, tc := range fn.Tests {
+			val, err := inst.GetBinding(elaborate.TestBindingName(fn.Name, i))
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s test %d at %s: %v", fn.Name, i+1, tc.Pos, err))
+				continue
+			}
+			if b, ok := val.(*eval.BoolValue); !ok || !b.Value {
+				failures = append(failures, fmt.Sprintf("%s test %d at %s: %s", fn.Name, i+1, tc.Pos, sourceBetween(string(content), tc.Pos, tc.End)))
+				continue
+			}
+			passed++
+		}
+		for i, prop := range fn.Properties {
+			label := fmt.Sprintf("%s property %d at %s: %s", fn.Name, i+1, prop.Pos, sourceBetween(string(content), prop.Pos, prop.End))
+			msg, err := checkProperty(rt, inst, elaborate.PropertyBindingName(fn.Name, i), prop, seed, trials)
+			switch {
+			case err != nil:
+				failures = append(failures, fmt.Sprintf("%s\n      %v", label, err))
+			case msg != "":
+				failures = append(failures, fmt.Sprintf("%s\n      %s", label, msg))
+			default:
+				passed++
+			}
+		}
+	}
+
+	if len(failures) == 0 {
+		fmt.Printf("  %s %s (%d %s)\n", green("✓"), filename, passed, plural(passed, "test", "tests"))
+		return passed, 0
+	}
+	fmt.Printf("  %s %s (%d of %d failed)\n", red("✗"), filename, len(failures), count)
+	for _, f := range failures {
+		fmt.Printf("    %s %s\n", red("✗"), f)
+	}
+	return passed, len(failures)
+}
+
+// checkProperty tries a property on random inputs. It returns a description
+// of the shrunk counterexample if the property fails, or an error if it
+// cannot be run at all.
+func checkProperty(rt *runtime.ModuleRuntime, inst *runtime.ModuleInstance, binding string, prop *ast.Property, seed int64, trials int) (string, error) {
+	gens := make([]*ailangTest.Gen, len(prop.Binders))
+	for i, b := range prop.Binders {
+		gen, ok := ailangTest.GenFor(b.Type.String())
+		if !ok {
+			return "", fmt.Errorf("cannot generate values of type %s for %s (supported: int, bool, string)", b.Type, b.Name)
+		}
+		gens[i] = gen
+	}
+	val, err := inst.GetBinding(binding)
+	if err != nil {
+		return "", err
+	}
+	fn, ok := val.(*eval.FunctionValue)
+	if !ok {
+		return "", fmt.Errorf("property %s is not a function", binding)
+	}
+
+	holds := func(args []eval.Value) (bool, error) {
+		result, err := rt.GetEvaluator().CallFunction(fn, args)
+		if err != nil {
+			return false, err
+		}
+		b, ok := result.(*eval.BoolValue)
+		return ok && b.Value, nil
+	}
+	cex := ailangTest.CheckProperty(gens, trials, rand.New(rand.NewSource(seed)), holds)
+	if cex == nil {
+		return "", nil
+	}
+
+	args := make([]string, len(cex.Args))
+	for i, arg := range cex.Args {
+		args[i] = fmt.Sprintf("%s = %s", prop.Binders[i].Name, formatArg(arg))
+	}
+	msg := fmt.Sprintf("counterexample: %s (seed %d, trial %d, %d %s)",
+		strings.Join(args, ", "), seed, cex.Trial, cex.Shrinks, plural(cex.Shrinks, "shrink", "shrinks"))
+	if cex.Err != nil {
+		msg += fmt.Sprintf("\n      error: %v", cex.Err)
+	}
+	return msg, nil
+}
+
+// formatArg shows a generated value as it would be written in source
+func formatArg(v eval.Value) string {
+	if s, ok := v.(*eval.StringValue); ok {
+		return strconv.Quote(s.Value)
+	}
+	return v.String()
+}
+
+// loadTestModule type-checks a module file and evaluates it, so that the
+// module values holding its tests can be forced
+func loadTestModule(filename, content string, file *ast.File, cache *pipeline.ModuleCache) (*runtime.ModuleRuntime, *runtime.ModuleInstance, error) {
+	if file.Module == nil {
+		return nil, nil, fmt.Errorf("inline tests need a module declaration")
+	}
+
+	builtins := runtime.NewBuiltinRegistry(eval.NewCoreEvaluator())
+	cfg := pipeline.Config{
+		Mode:           pipeline.ModeCheck,
+		Cache:          cache,
+		GlobalResolver: runtime.NewBuiltinOnlyResolver(builtins),
+	}
+	result, err := pipeline.Run(cfg, pipeline.Source{Code: content, Filename: filename})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(result.Errors) > 0 {
+		return nil, nil, result.Errors[0]
+	}
+	if result.Interface == nil {
+		return nil, nil, fmt.Errorf("no interface generated for module")
+	}
+
+	rt := runtime.NewModuleRuntime(filepath.Dir(filename))
+	for path, loaded := range result.Modules {
+		rt.PreloadModule(path, loaded)
+	}
+	inst, err := rt.LoadAndEvaluate(result.Interface.Module)
+	return rt, inst, err
+}
+
+// sourceBetween returns the source text from one position up to another,
+// trimmed, so that a failing assertion is shown as it was written
+func sourceBetween(content string, from, to ast.Pos) string {
+	lines := strings.Split(content, "\n")
+	if from.Line < 1 || to.Line < from.Line || to.Line > len(lines) {
+		return ""
+	}
+	var b strings.Builder
+	for line := from.Line; line <= to.Line; line++ {
+		text := []rune(lines[line-1])
+		start, end := 0, len(text)
+		if line == from.Line {
+			start = min(max(from.Column-1, 0), len(text))
+		}
+		if line == to.Line {
+			end = min(max(to.Column-1, start), len(text))
+		}
+		b.WriteString(string(text[start:end]))
+		if line < to.Line {
+			b.WriteString(" ")
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,65 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"io"
+	"strings"
+	"syscall/js"
+)
+
+// jsInputReader feeds readLine/readAll from a JavaScript callback
+//
+// The callback is invoked whenever the program needs more input. It returns
+// the next line of input as a string (a trailing newline is added if
+// missing), or null/undefined to signal end of input.
+type jsInputReader struct {
+	callback js.Value
+	pending  string
+	eof      bool
+}
+
+// Read implements io.Reader by asking the callback for a line at a time
+func (r *jsInputReader) Read(p []byte) (int, error) {
+	for r.pending == "" {
+		if r.eof {
+			return 0, io.EOF
+		}
+		result := r.callback.Invoke()
+		if result.Type() != js.TypeString {
+			r.eof = true
+			continue
+		}
+		line := result.String()
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		r.pending = line
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// inputReader returns the reader for the registered callback, or an empty
+// reader (immediate EOF) when no callback is registered
+func inputReader(callback js.Value) io.Reader {
+	if callback.Type() != js.TypeFunction {
+		return strings.NewReader("")
+	}
+	return &jsInputReader{callback: callback}
+}
+
+// setInput registers the JavaScript input callback: ailangSetInput(fn)
+//
+// Passing null or no argument removes the callback, so reads return EOF.
+func setInput(this js.Value, args []js.Value) interface{} {
+	callback := js.Null()
+	if len(args) > 0 {
+		callback = args[0]
+	}
+	replInstance.SetInput(callback)
+	return nil
+}
@@ -18,23 +18,36 @@ var (
 
 // WasmREPL wraps the REPL for browser use
 type WasmREPL struct {
-	repl   *repl.REPL
-	output *bytes.Buffer
+	repl      *repl.REPL
+	output    *bytes.Buffer
+	input     js.Value // Callback registered with ailangSetInput (null = none)
+	noPrelude bool     // Skip the std/prelude auto-import (ailangReset({noPrelude: true}))
 }
 
 // NewWasmREPL creates a new browser-ready REPL
 func NewWasmREPL() *WasmREPL {
 	w := &WasmREPL{
-		repl:   repl.NewWithVersion(Version, BuildTime),
 		output: &bytes.Buffer{},
+		input:  js.Null(),
 	}
+	w.repl = w.newREPL()
+	return w
+}
 
-	// Auto-import prelude for numeric defaults (just like CLI REPL)
-	// This is discarded since we don't want to show import message on init
-	discardBuf := &bytes.Buffer{}
-	w.repl.HandleCommand(":import std/prelude", discardBuf)
+// newREPL creates the underlying REPL with the page's input source
+func (w *WasmREPL) newREPL() *repl.REPL {
+	r := repl.NewWithVersion(Version, BuildTime)
 
-	return w
+	// There is no stdin in the browser: reads return EOF until the page
+	// registers an input callback
+	r.SetInput(inputReader(w.input))
+
+	// Auto-import prelude for numeric defaults and std/io (just like CLI REPL)
+	if w.noPrelude {
+		r.DisablePrelude()
+	}
+	r.PrepareSession()
+	return r
 }
 
 // Eval evaluates a single expression and returns the result
@@ -53,9 +66,16 @@ func (w *WasmREPL) HandleCommand(cmd string) string {
 	return w.output.String()
 }
 
-// Reset clears the REPL environment
-func (w *WasmREPL) Reset() string {
-	w.repl = repl.New()
+// SetInput routes readLine/readAll to a JavaScript callback
+func (w *WasmREPL) SetInput(callback js.Value) {
+	w.input = callback
+	w.repl.SetInput(inputReader(callback))
+}
+
+// Reset clears the REPL environment, optionally without the prelude
+func (w *WasmREPL) Reset(noPrelude bool) string {
+	w.noPrelude = noPrelude
+	w.repl = w.newREPL()
 	return "Environment reset"
 }
 
@@ -80,8 +100,15 @@ func evalExpression(this js.Value, args []js.Value) interface{} {
 }
 
 // resetREPL resets the REPL environment
+// An optional options object {noPrelude: true} starts it without std/prelude
 func resetREPL(this js.Value, args []js.Value) interface{} {
-	return replInstance.Reset()
+	noPrelude := false
+	if len(args) > 0 && args[0].Type() == js.TypeObject {
+		if opt := args[0].Get("noPrelude"); opt.Type() == js.TypeBoolean {
+			noPrelude = opt.Bool()
+		}
+	}
+	return replInstance.Reset(noPrelude)
 }
 
 // getVersion returns version info
@@ -101,6 +128,7 @@ func main() {
 	js.Global().Set("ailangEval", js.FuncOf(evalExpression))
 	js.Global().Set("ailangReset", js.FuncOf(resetREPL))
 	js.Global().Set("ailangVersion", js.FuncOf(getVersion))
+	js.Global().Set("ailangSetInput", js.FuncOf(setInput))
 
 	// Signal ready (safely check if console exists)
 	if console := js.Global().Get("console"); !console.IsUndefined() {
@@ -79,19 +79,34 @@ class AilangREPL {
 
   /**
    * Reset the REPL environment
+   * @param {{noPrelude: boolean}=} options - Pass {noPrelude: true} to start
+   *   without the std/prelude auto-import
    */
-  reset() {
+  reset(options) {
     if (!this.ready) {
       return 'Error: REPL not initialized';
     }
 
     try {
-      return window.ailangReset();
+      return window.ailangReset(options || {});
     } catch (err) {
       return `Error: ${err.message}`;
     }
   }
 
+  /**
+   * Register the source of program input for readLine/readAll
+   * @param {?function(): ?string} callback - Returns the next line of input,
+   *   or null for end of input. Pass null to remove it (reads return EOF).
+   */
+  setInput(callback) {
+    if (!this.ready) {
+      return;
+    }
+
+    window.ailangSetInput(callback || null);
+  }
+
   /**
    * Get version information
    */
@@ -33,8 +33,10 @@ func (p Pos) String() string {
 type File struct {
 	Module     *ModuleDecl   // Optional module declaration
 	Imports    []*ImportDecl // Import declarations
+	Exports    []string      // Standalone export list: export { a, b }
 	Decls      []Node        // Top-level declarations (deprecated, use Funcs/Statements)
 	Funcs      []*FuncDecl   // Function declarations
+	Consts     []*ConstDecl  // Top-level value declarations
 	Statements []Node        // Top-level statements/expressions
 	Path       string        // File path for validation
 	Pos        Pos
@@ -183,6 +185,11 @@ type Param struct {
 	Pos  Pos
 }
 
+// SectionParam is the parameter name of a lambda desugared from an operator
+// section: (+ 1) parses as \$section. $section + 1. It cannot clash with a
+// source identifier
+const SectionParam = "$section"
+
 func (l *Lambda) String() string {
 	params := []string{}
 	for _, p := range l.Params {
@@ -330,6 +337,34 @@ func (m *Match) String() string {
 func (m *Match) Position() Pos { return m.Pos }
 func (m *Match) exprNode()     {}
 
+// Handle runs Body with effect operations intercepted by the handlers:
+// handle { body } with { IO.print(s) => ..., IO.println(s) => ... }
+type Handle struct {
+	Body        Expr
+	Handlers    []*Handler
+	HandlersPos Pos // Position of the brace opening the handlers
+	Pos         Pos
+}
+
+// Handler is one arm of a handle expression: Effect.Op(Param) => Body
+type Handler struct {
+	Effect string
+	Op     string
+	Param  string
+	Body   Expr
+	Pos    Pos
+}
+
+func (h *Handle) String() string {
+	arms := []string{}
+	for _, arm := range h.Handlers {
+		arms = append(arms, fmt.Sprintf("%s.%s(%s) => %s", arm.Effect, arm.Op, arm.Param, arm.Body))
+	}
+	return fmt.Sprintf("(handle { %s } with { %s })", h.Body, strings.Join(arms, ", "))
+}
+func (h *Handle) Position() Pos { return h.Pos }
+func (h *Handle) exprNode()     {}
+
 // List represents a list literal
 type List struct {
 	Elements []Expr
@@ -414,6 +449,20 @@ func (r *RecordUpdate) String() string {
 func (r *RecordUpdate) Position() Pos { return r.Pos }
 func (r *RecordUpdate) exprNode()     {}
 
+// AllValues is the compiler-provided function listing every constructor of an
+// enumeration (an ADT whose constructors have no fields) in declaration order.
+// Syntax: allValues[Direction]  (called as allValues[Direction]())
+type AllValues struct {
+	Type Type
+	Pos  Pos
+}
+
+func (a *AllValues) String() string {
+	return fmt.Sprintf("allValues[%s]", a.Type)
+}
+func (a *AllValues) Position() Pos { return a.Pos }
+func (a *AllValues) exprNode()     {}
+
 // Error represents a parse error node (placeholder for error recovery)
 type Error struct {
 	Pos Pos
@@ -479,33 +528,58 @@ func (r *Recv) exprNode()     {}
 
 // FuncDecl represents a function declaration
 type FuncDecl struct {
-	Name       string
-	TypeParams []string // Generic type parameters
-	Params     []*Param
-	ReturnType Type
-	Effects    []string
-	Tests      []*TestCase
-	Properties []*Property
-	Body       Expr
-	IsPure     bool
-	IsExport   bool // Export flag
-	Pos        Pos
-	Span       Span   // For SID calculation
-	SID        string // Stable ID (calculated post-parse)
-	Origin     string // "func_decl" for metadata
+	Name        string
+	TypeParams  []string // Generic type parameters
+	Params      []*Param
+	ReturnType  Type
+	Effects     []string
+	Tests       []*TestCase
+	Properties  []*Property
+	Body        Expr
+	Where       []*FuncDecl // Local helpers from a trailing where block
+	IsPure      bool
+	IsExport    bool          // Export flag
+	Annotations []*Annotation // Leading annotations: @deprecated("...")
+	Doc         string        // Doc comment (--- lines or a block comment) directly above
+	Pos         Pos
+	Span        Span   // For SID calculation
+	SID         string // Stable ID (calculated post-parse)
+	Origin      string // "func_decl" for metadata
+}
+
+// Annotation represents a declaration annotation: @name or @name("arg", ...)
+type Annotation struct {
+	Name string
+	Args []string // String literal arguments
+	Pos  Pos
+}
+
+// FindAnnotation returns the first annotation with the given name, or nil
+func (f *FuncDecl) FindAnnotation(name string) *Annotation {
+	for _, a := range f.Annotations {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
 }
 
+// TestCase is one assertion of an inline tests [...] block. The expression
+// must be a bool that holds: tests [double(2) == 4]
 type TestCase struct {
-	Inputs   []Expr // Multiple inputs for multi-arg functions
-	Expected Expr   // Expected output
-	Pos      Pos
+	Expr Expr
+	Pos  Pos
+	End  Pos // Position of the ',' or ']' that ends the assertion
 }
 
+// Property is one law of an inline properties [...] block. It must hold for
+// every value of its binders: forall(x: int) => double(x) == x + x
 type Property struct {
 	Name    string
 	Binders []*Binder // forall bindings
 	Expr    Expr
 	Pos     Pos
+	End     Pos // Position of the ',' or ']' that ends the property
 }
 
 type Binder struct {
@@ -528,12 +602,39 @@ func (f *FuncDecl) String() string {
 func (f *FuncDecl) Position() Pos { return f.Pos }
 func (f *FuncDecl) stmtNode()     {}
 
+// ConstDecl represents a top-level value declaration:
+// [export] let name [: type] = expr  or  [export] const name [: type] = expr
+type ConstDecl struct {
+	Name     string
+	Type     Type // Optional annotation
+	Value    Expr
+	IsExport bool
+	IsConst  bool // Declared with 'const' rather than 'let'
+	Pos      Pos
+}
+
+func (c *ConstDecl) String() string {
+	exportStr := ""
+	if c.IsExport {
+		exportStr = "export "
+	}
+	keyword := "let"
+	if c.IsConst {
+		keyword = "const"
+	}
+	return fmt.Sprintf("%s%s %s = %s", exportStr, keyword, c.Name, c.Value)
+}
+func (c *ConstDecl) Position() Pos { return c.Pos }
+func (c *ConstDecl) stmtNode()     {}
+
 // TypeDecl represents a type declaration
 type TypeDecl struct {
 	Name       string
 	TypeParams []string
 	Definition TypeDef
-	Exported   bool // True if type was declared with 'export'
+	Exported   bool     // True if type was declared with 'export'
+	Derive     []string // Classes to derive instances for: derive(Show, Eq, Ord)
+	Doc        string   // Doc comment (--- lines or a block comment) directly above
 	Pos        Pos
 }
 
@@ -548,9 +649,64 @@ type AlgebraicType struct {
 }
 
 type Constructor struct {
-	Name   string
-	Fields []Type
-	Pos    Pos
+	Name       string
+	Fields     []Type
+	FieldNames []string // Parallel to Fields for record-style constructors: Point { x: int, y: int }
+	Pos        Pos
+}
+
+// IsNewtype reports whether the declaration is a newtype: a non-generic
+// algebraic type with a single constructor of one positional field, such as
+// `type UserId = UserId(int)`. Newtypes are distinct from their field type
+// when type checking but are represented by the unboxed field value.
+func (t *TypeDecl) IsNewtype() bool {
+	alg, ok := t.Definition.(*AlgebraicType)
+	if !ok || len(t.TypeParams) > 0 || len(alg.Constructors) != 1 {
+		return false
+	}
+	ctor := alg.Constructors[0]
+	return len(ctor.Fields) == 1 && !ctor.HasNamedFields()
+}
+
+// HasNamedFields reports whether the constructor was declared record-style
+func (c *Constructor) HasNamedFields() bool {
+	return len(c.FieldNames) > 0 && len(c.FieldNames) == len(c.Fields)
+}
+
+// FieldAccessors synthesizes an accessor function for every named constructor
+// field of an algebraic type: `type Point = Point { x: int }` yields `x(p) = p.x`.
+// A field shared by several constructors yields a single accessor.
+// Accessors follow the export flag of the type declaration.
+func FieldAccessors(decl *TypeDecl) []*FuncDecl {
+	alg, ok := decl.Definition.(*AlgebraicType)
+	if !ok {
+		return nil
+	}
+
+	var accessors []*FuncDecl
+	seen := make(map[string]bool)
+	for _, ctor := range alg.Constructors {
+		if !ctor.HasNamedFields() {
+			continue
+		}
+		for _, field := range ctor.FieldNames {
+			if seen[field] {
+				continue
+			}
+			seen[field] = true
+			param := "$" + decl.Name
+			accessors = append(accessors, &FuncDecl{
+				Name:     field,
+				Params:   []*Param{{Name: param, Pos: ctor.Pos}},
+				Body:     &RecordAccess{Record: &Identifier{Name: param, Pos: ctor.Pos}, Field: field, Pos: ctor.Pos},
+				IsPure:   true,
+				IsExport: decl.Exported,
+				Pos:      ctor.Pos,
+				Origin:   "field_accessor",
+			})
+		}
+	}
+	return accessors
 }
 
 func (a *AlgebraicType) typeDefNode() {}
@@ -663,6 +819,23 @@ func (s *SimpleType) String() string { return s.Name }
 func (s *SimpleType) Position() Pos  { return s.Pos }
 func (s *SimpleType) typeNode()      {}
 
+// TypeApp represents a type constructor applied to arguments: Option[int], Result[a, e]
+type TypeApp struct {
+	Name string
+	Args []Type
+	Pos  Pos
+}
+
+func (t *TypeApp) String() string {
+	args := []string{}
+	for _, a := range t.Args {
+		args = append(args, a.String())
+	}
+	return fmt.Sprintf("%s[%s]", t.Name, strings.Join(args, ", "))
+}
+func (t *TypeApp) Position() Pos { return t.Pos }
+func (t *TypeApp) typeNode()     {}
+
 // TypeVar represents type variables
 type TypeVar struct {
 	Name string
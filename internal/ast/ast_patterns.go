@@ -14,6 +14,33 @@ func (w *WildcardPattern) String() string { return "_" }
 func (w *WildcardPattern) Position() Pos  { return w.Pos }
 func (w *WildcardPattern) patternNode()   {}
 
+// AsPattern matches Pattern and also binds the whole value to Name: x @ Some(y)
+type AsPattern struct {
+	Name    string
+	Pattern Pattern
+	Pos     Pos
+}
+
+func (a *AsPattern) String() string { return fmt.Sprintf("%s @ %s", a.Name, a.Pattern) }
+func (a *AsPattern) Position() Pos  { return a.Pos }
+func (a *AsPattern) patternNode()   {}
+
+// OrPattern matches if any of its alternatives matches: Some(1) | Some(2)
+type OrPattern struct {
+	Alternatives []Pattern
+	Pos          Pos
+}
+
+func (o *OrPattern) String() string {
+	alts := []string{}
+	for _, a := range o.Alternatives {
+		alts = append(alts, a.String())
+	}
+	return strings.Join(alts, " | ")
+}
+func (o *OrPattern) Position() Pos { return o.Pos }
+func (o *OrPattern) patternNode()  {}
+
 // ConsPattern matches list cons
 type ConsPattern struct {
 	Head Pattern
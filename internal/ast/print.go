@@ -199,6 +199,25 @@ func simplify(node interface{}) interface{} {
 		}
 		return m
 
+	case *Handle:
+		m := map[string]interface{}{
+			"type": "Handle",
+			"body": simplify(n.Body),
+		}
+		if len(n.Handlers) > 0 {
+			m["handlers"] = simplifySlice(n.Handlers)
+		}
+		return m
+
+	case *Handler:
+		return map[string]interface{}{
+			"type":   "Handler",
+			"effect": n.Effect,
+			"op":     n.Op,
+			"param":  n.Param,
+			"body":   simplify(n.Body),
+		}
+
 	case *Case:
 		m := map[string]interface{}{
 			"type":    "Case",
@@ -252,6 +271,19 @@ func simplify(node interface{}) interface{} {
 			"type": "WildcardPattern",
 		}
 
+	case *AsPattern:
+		return map[string]interface{}{
+			"type":    "AsPattern",
+			"name":    n.Name,
+			"pattern": simplify(n.Pattern),
+		}
+
+	case *OrPattern:
+		return map[string]interface{}{
+			"type":         "OrPattern",
+			"alternatives": simplifyPatternSlice(n.Alternatives),
+		}
+
 	case *ConsPattern:
 		return map[string]interface{}{
 			"type": "ConsPattern",
@@ -310,6 +342,13 @@ func simplify(node interface{}) interface{} {
 			"name": n.Name,
 		}
 
+	case *TypeApp:
+		return map[string]interface{}{
+			"type": "TypeApp",
+			"name": n.Name,
+			"args": simplifyTypeSlice(n.Args),
+		}
+
 	case *TypeVar:
 		return map[string]interface{}{
 			"type": "TypeVar",
@@ -359,6 +398,23 @@ func simplify(node interface{}) interface{} {
 		if n.Exported {
 			m["exported"] = true
 		}
+		if len(n.Derive) > 0 {
+			m["derive"] = n.Derive
+		}
+		return m
+
+	case *ConstDecl:
+		m := map[string]interface{}{
+			"type":  "ConstDecl",
+			"name":  n.Name,
+			"value": simplify(n.Value),
+		}
+		if n.Type != nil {
+			m["typeAnnotation"] = simplify(n.Type)
+		}
+		if n.IsExport {
+			m["exported"] = true
+		}
 		return m
 
 	case *AlgebraicType:
@@ -382,6 +438,9 @@ func simplify(node interface{}) interface{} {
 		if len(n.Fields) > 0 {
 			m["fields"] = simplifyTypeSlice(n.Fields)
 		}
+		if len(n.FieldNames) > 0 {
+			m["fieldNames"] = n.FieldNames
+		}
 		return m
 
 	case *RecordType:
@@ -492,6 +551,12 @@ func simplifySlice(items interface{}) []interface{} {
 			result[i] = simplify(item)
 		}
 		return result
+	case []*Handler:
+		result := make([]interface{}, len(items))
+		for i, item := range items {
+			result[i] = simplify(item)
+		}
+		return result
 	default:
 		return []interface{}{fmt.Sprintf("unhandled slice type: %T", items)}
 	}
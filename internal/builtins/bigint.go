@@ -0,0 +1,161 @@
+package builtins
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// Arbitrary-precision integers: the std/bigint builtins plus the operator
+// builtins that Num[BigInt], Eq[BigInt] and Ord[BigInt] lower to
+
+func init() {
+	registerBigIntBuiltins()
+}
+
+func registerBigIntBuiltins() {
+	specs := []BuiltinSpec{
+		{Module: "std/bigint", Name: "_bigint_fromInt", NumArgs: 1, Type: intToBigIntType, Impl: bigIntFromIntImpl},
+		{Module: "std/bigint", Name: "_bigint_add", NumArgs: 2, Type: bigIntBinaryType, Impl: bigIntArith("_bigint_add", (*big.Int).Add)},
+		{Module: "std/bigint", Name: "_bigint_mul", NumArgs: 2, Type: bigIntBinaryType, Impl: bigIntArith("_bigint_mul", (*big.Int).Mul)},
+		{Module: "std/bigint", Name: "_bigint_toString", NumArgs: 1, Type: bigIntToStringType, Impl: bigIntToStringImpl},
+
+		// fromInt_BigInt is Num[BigInt]'s fromInt. The operator lowering pass
+		// applies it to integer literals whose type resolved to BigInt.
+		{Module: "std/prelude", Name: "fromInt_BigInt", NumArgs: 1, Type: intToBigIntType, Impl: bigIntFromIntImpl},
+		{Module: "std/math", Name: "add_BigInt", NumArgs: 2, Type: bigIntBinaryType, Impl: bigIntArith("add_BigInt", (*big.Int).Add)},
+		{Module: "std/math", Name: "sub_BigInt", NumArgs: 2, Type: bigIntBinaryType, Impl: bigIntArith("sub_BigInt", (*big.Int).Sub)},
+		{Module: "std/math", Name: "mul_BigInt", NumArgs: 2, Type: bigIntBinaryType, Impl: bigIntArith("mul_BigInt", (*big.Int).Mul)},
+		{Module: "std/math", Name: "div_BigInt", NumArgs: 2, Type: bigIntBinaryType, Impl: bigIntDivision("div_BigInt", "Division by zero", (*big.Int).Quo)},
+		{Module: "std/math", Name: "mod_BigInt", NumArgs: 2, Type: bigIntBinaryType, Impl: bigIntDivision("mod_BigInt", "Modulo by zero", (*big.Int).Rem)},
+		{Module: "std/math", Name: "neg_BigInt", NumArgs: 1, Type: bigIntUnaryType, Impl: bigIntNegImpl},
+		{Module: "std/prelude", Name: "eq_BigInt", NumArgs: 2, Type: bigIntCmpType, Impl: bigIntCmp("eq_BigInt", func(c int) bool { return c == 0 })},
+		{Module: "std/prelude", Name: "ne_BigInt", NumArgs: 2, Type: bigIntCmpType, Impl: bigIntCmp("ne_BigInt", func(c int) bool { return c != 0 })},
+		{Module: "std/prelude", Name: "lt_BigInt", NumArgs: 2, Type: bigIntCmpType, Impl: bigIntCmp("lt_BigInt", func(c int) bool { return c < 0 })},
+		{Module: "std/prelude", Name: "le_BigInt", NumArgs: 2, Type: bigIntCmpType, Impl: bigIntCmp("le_BigInt", func(c int) bool { return c <= 0 })},
+		{Module: "std/prelude", Name: "gt_BigInt", NumArgs: 2, Type: bigIntCmpType, Impl: bigIntCmp("gt_BigInt", func(c int) bool { return c > 0 })},
+		{Module: "std/prelude", Name: "ge_BigInt", NumArgs: 2, Type: bigIntCmpType, Impl: bigIntCmp("ge_BigInt", func(c int) bool { return c >= 0 })},
+	}
+	for _, spec := range specs {
+		spec.IsPure = true
+		if err := RegisterEffectBuiltin(spec); err != nil {
+			panic(fmt.Sprintf("failed to register %s: %v", spec.Name, err))
+		}
+	}
+}
+
+// Type signatures
+
+func intToBigIntType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.Int()).Returns(T.BigInt()).Build()
+}
+
+func bigIntToStringType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.BigInt()).Returns(T.String()).Build()
+}
+
+func bigIntUnaryType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.BigInt()).Returns(T.BigInt()).Build()
+}
+
+func bigIntBinaryType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.BigInt(), T.BigInt()).Returns(T.BigInt()).Build()
+}
+
+func bigIntCmpType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.BigInt(), T.BigInt()).Returns(T.Bool()).Build()
+}
+
+// Implementations
+
+func bigIntFromIntImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	n, ok := args[0].(*eval.IntValue)
+	if !ok {
+		return nil, fmt.Errorf("_bigint_fromInt: expected int, got %T", args[0])
+	}
+	return &eval.BigIntValue{Value: big.NewInt(int64(n.Value))}, nil
+}
+
+func bigIntToStringImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	n, err := bigIntArg("_bigint_toString", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &eval.StringValue{Value: n.String()}, nil
+}
+
+func bigIntNegImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	n, err := bigIntArg("neg_BigInt", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &eval.BigIntValue{Value: new(big.Int).Neg(n)}, nil
+}
+
+// bigIntArith wraps a math/big operation (z.Op(x, y)) as a builtin. Results
+// are always fresh values, so BigInt values stay immutable.
+func bigIntArith(name string, op func(z, x, y *big.Int) *big.Int) EffectImpl {
+	return func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		a, b, err := bigIntArgs(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return &eval.BigIntValue{Value: op(new(big.Int), a, b)}, nil
+	}
+}
+
+// bigIntDivision is bigIntArith for Quo/Rem, which truncate toward zero like
+// div_Int and mod_Int but panic on a zero divisor
+func bigIntDivision(name, zeroMsg string, op func(z, x, y *big.Int) *big.Int) EffectImpl {
+	return func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		a, b, err := bigIntArgs(name, args)
+		if err != nil {
+			return nil, err
+		}
+		if b.Sign() == 0 {
+			return nil, eval.NewRuntimeError("RT_DIV0", zeroMsg, nil)
+		}
+		return &eval.BigIntValue{Value: op(new(big.Int), a, b)}, nil
+	}
+}
+
+// bigIntCmp builds a comparison builtin from a test on a.Cmp(b)
+func bigIntCmp(name string, test func(c int) bool) EffectImpl {
+	return func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		a, b, err := bigIntArgs(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return &eval.BoolValue{Value: test(a.Cmp(b))}, nil
+	}
+}
+
+// Helpers
+
+func bigIntArg(name string, v eval.Value) (*big.Int, error) {
+	n, ok := v.(*eval.BigIntValue)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected BigInt, got %T", name, v)
+	}
+	return n.Value, nil
+}
+
+func bigIntArgs(name string, args []eval.Value) (*big.Int, *big.Int, error) {
+	a, err := bigIntArg(name, args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := bigIntArg(name, args[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
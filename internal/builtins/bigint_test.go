@@ -0,0 +1,85 @@
+package builtins
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func bigIntValue(s string) *eval.BigIntValue {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("bad BigInt literal " + s)
+	}
+	return &eval.BigIntValue{Value: n}
+}
+
+// TestBigIntStdlibBuiltins tests the std/bigint builtins beyond the Int range
+func TestBigIntStdlibBuiltins(t *testing.T) {
+	fromInt, ok := GetSpec("_bigint_fromInt")
+	require.True(t, ok)
+	assert.Equal(t, "std/bigint", fromInt.Module)
+	assert.True(t, fromInt.IsPure)
+
+	maxInt, err := fromInt.Impl(nil, []eval.Value{&eval.IntValue{Value: math.MaxInt64}})
+	require.NoError(t, err)
+
+	mul, _ := GetSpec("_bigint_mul")
+	product, err := mul.Impl(nil, []eval.Value{maxInt, maxInt})
+	require.NoError(t, err)
+
+	add, _ := GetSpec("_bigint_add")
+	sum, err := add.Impl(nil, []eval.Value{product, maxInt})
+	require.NoError(t, err)
+
+	toString, _ := GetSpec("_bigint_toString")
+	str, err := toString.Impl(nil, []eval.Value{sum})
+	require.NoError(t, err)
+	assert.Equal(t, "85070591730234615856620279821087277056", str.(*eval.StringValue).Value)
+
+	// Operands are never mutated
+	assert.Equal(t, "9223372036854775807", maxInt.String())
+}
+
+// TestBigIntOperatorBuiltins tests the builtins that BigInt operators lower to
+func TestBigIntOperatorBuiltins(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []eval.Value
+		expected string
+	}{
+		{"sub_BigInt", []eval.Value{bigIntValue("5"), bigIntValue("12")}, "-7"},
+		{"div_BigInt", []eval.Value{bigIntValue("-7"), bigIntValue("2")}, "-3"},
+		{"mod_BigInt", []eval.Value{bigIntValue("-7"), bigIntValue("2")}, "-1"},
+		{"neg_BigInt", []eval.Value{bigIntValue("100000000000000000000")}, "-100000000000000000000"},
+		{"fromInt_BigInt", []eval.Value{&eval.IntValue{Value: 42}}, "42"},
+		{"lt_BigInt", []eval.Value{bigIntValue("99999999999999999999"), bigIntValue("100000000000000000000")}, "true"},
+		{"eq_BigInt", []eval.Value{bigIntValue("10"), bigIntValue("10")}, "true"},
+		{"ge_BigInt", []eval.Value{bigIntValue("9"), bigIntValue("10")}, "false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, ok := GetSpec(tt.name)
+			require.True(t, ok, "%s should be registered", tt.name)
+			result, err := spec.Impl(nil, tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.String())
+		})
+	}
+}
+
+// TestBigIntDivisionByZero tests that div and mod report RT_DIV0
+func TestBigIntDivisionByZero(t *testing.T) {
+	for _, name := range []string{"div_BigInt", "mod_BigInt"} {
+		spec, ok := GetSpec(name)
+		require.True(t, ok)
+		_, err := spec.Impl(nil, []eval.Value{bigIntValue("1"), bigIntValue("0")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "RT_DIV0")
+	}
+}
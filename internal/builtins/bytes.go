@@ -0,0 +1,150 @@
+package builtins
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// Byte sequence builtins: conversion to/from UTF-8 strings and base64/hex codecs
+
+func init() {
+	registerBytesBuiltins()
+}
+
+func registerBytesBuiltins() {
+	specs := []BuiltinSpec{
+		{Name: "_bytes_fromString", Type: stringToBytesType, Impl: bytesFromStringImpl},
+		{Name: "_bytes_toString", Type: bytesToStringType, Impl: bytesToStringImpl},
+		{Name: "_base64_encode", Type: bytesToStringType, Impl: base64EncodeImpl},
+		{Name: "_base64_decode", Type: stringToBytesResultType, Impl: base64DecodeImpl},
+		{Name: "_hex_encode", Type: bytesToStringType, Impl: hexEncodeImpl},
+		{Name: "_hex_decode", Type: stringToBytesResultType, Impl: hexDecodeImpl},
+	}
+	for _, spec := range specs {
+		spec.Module = "std/bytes"
+		spec.NumArgs = 1
+		spec.IsPure = true
+		if err := RegisterEffectBuiltin(spec); err != nil {
+			panic(fmt.Sprintf("failed to register %s: %v", spec.Name, err))
+		}
+	}
+}
+
+// Type signatures
+
+func stringToBytesType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.String()).Returns(T.Bytes()).Build()
+}
+
+func bytesToStringType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.Bytes()).Returns(T.String()).Build()
+}
+
+func stringToBytesResultType() types.Type {
+	T := types.NewBuilder()
+	// Type signature: string -> Result[bytes, string]
+	return T.Func(T.String()).Returns(T.App("Result", T.Bytes(), T.String())).Build()
+}
+
+// Implementations
+
+func bytesFromStringImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	s, err := stringArg("_bytes_fromString", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &eval.BytesValue{Value: []byte(s)}, nil
+}
+
+// bytesToStringImpl decodes bytes as UTF-8; invalid sequences become U+FFFD
+func bytesToStringImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	b, err := bytesArg("_bytes_toString", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &eval.StringValue{Value: strings.ToValidUTF8(string(b), "�")}, nil
+}
+
+func base64EncodeImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	b, err := bytesArg("_base64_encode", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &eval.StringValue{Value: base64.StdEncoding.EncodeToString(b)}, nil
+}
+
+func base64DecodeImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	s, err := stringArg("_base64_decode", args[0])
+	if err != nil {
+		return nil, err
+	}
+	b, decErr := base64.StdEncoding.DecodeString(s)
+	if decErr != nil {
+		return bytesErr(fmt.Sprintf("invalid base64: %v", decErr)), nil
+	}
+	return bytesOk(b), nil
+}
+
+func hexEncodeImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	b, err := bytesArg("_hex_encode", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &eval.StringValue{Value: hex.EncodeToString(b)}, nil
+}
+
+func hexDecodeImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	s, err := stringArg("_hex_decode", args[0])
+	if err != nil {
+		return nil, err
+	}
+	b, decErr := hex.DecodeString(s)
+	if decErr != nil {
+		return bytesErr(fmt.Sprintf("invalid hex: %v", decErr)), nil
+	}
+	return bytesOk(b), nil
+}
+
+// Helpers
+
+func stringArg(name string, v eval.Value) (string, error) {
+	s, ok := v.(*eval.StringValue)
+	if !ok {
+		return "", fmt.Errorf("%s: expected string, got %T", name, v)
+	}
+	return s.Value, nil
+}
+
+func bytesArg(name string, v eval.Value) ([]byte, error) {
+	b, ok := v.(*eval.BytesValue)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected bytes, got %T", name, v)
+	}
+	return b.Value, nil
+}
+
+func bytesOk(b []byte) eval.Value {
+	return &eval.TaggedValue{
+		ModulePath: "std/result",
+		TypeName:   "Result",
+		CtorName:   "Ok",
+		Fields:     []eval.Value{&eval.BytesValue{Value: b}},
+	}
+}
+
+func bytesErr(msg string) eval.Value {
+	return &eval.TaggedValue{
+		ModulePath: "std/result",
+		TypeName:   "Result",
+		CtorName:   "Err",
+		Fields:     []eval.Value{&eval.StringValue{Value: msg}},
+	}
+}
@@ -0,0 +1,64 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func callBytes(t *testing.T, impl EffectImpl, arg eval.Value) eval.Value {
+	t.Helper()
+	result, err := impl(nil, []eval.Value{arg})
+	require.NoError(t, err)
+	return result
+}
+
+func TestBytes_StringRoundTrip(t *testing.T) {
+	b := callBytes(t, bytesFromStringImpl, &eval.StringValue{Value: "héllo"})
+	bv, ok := b.(*eval.BytesValue)
+	require.True(t, ok)
+	assert.Equal(t, []byte("héllo"), bv.Value)
+
+	s := callBytes(t, bytesToStringImpl, b)
+	assert.Equal(t, "héllo", s.(*eval.StringValue).Value)
+}
+
+func TestBytes_ToStringInvalidUTF8(t *testing.T) {
+	s := callBytes(t, bytesToStringImpl, &eval.BytesValue{Value: []byte{'a', 0xff, 'b'}})
+	assert.Equal(t, "a�b", s.(*eval.StringValue).Value)
+}
+
+func TestBytes_Base64(t *testing.T) {
+	raw := &eval.BytesValue{Value: []byte{0x00, 0xff, 0x10, 'h', 'i'}}
+	enc := callBytes(t, base64EncodeImpl, raw)
+	assert.Equal(t, "AP8QaGk=", enc.(*eval.StringValue).Value)
+
+	dec := callBytes(t, base64DecodeImpl, enc)
+	require.True(t, isOk(dec))
+	assert.Equal(t, raw.Value, extractOk(dec).(*eval.BytesValue).Value)
+
+	bad := callBytes(t, base64DecodeImpl, &eval.StringValue{Value: "not base64!"})
+	assert.Contains(t, extractErr(bad), "invalid base64")
+}
+
+func TestBytes_Hex(t *testing.T) {
+	raw := &eval.BytesValue{Value: []byte{0xde, 0xad, 0xbe, 0xef}}
+	enc := callBytes(t, hexEncodeImpl, raw)
+	assert.Equal(t, "deadbeef", enc.(*eval.StringValue).Value)
+
+	dec := callBytes(t, hexDecodeImpl, &eval.StringValue{Value: "DEADbeef"})
+	require.True(t, isOk(dec))
+	assert.Equal(t, raw.Value, extractOk(dec).(*eval.BytesValue).Value)
+
+	bad := callBytes(t, hexDecodeImpl, &eval.StringValue{Value: "abc"})
+	assert.Contains(t, extractErr(bad), "invalid hex")
+}
+
+func TestBytes_WrongArgumentType(t *testing.T) {
+	_, err := base64EncodeImpl(nil, []eval.Value{&eval.StringValue{Value: "x"}})
+	assert.Error(t, err)
+	_, err = hexDecodeImpl(nil, []eval.Value{&eval.IntValue{Value: 1}})
+	assert.Error(t, err)
+}
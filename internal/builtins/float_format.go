@@ -0,0 +1,52 @@
+package builtins
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// _float_format renders a float with an explicit number of decimal places.
+// show and toText use eval.FormatFloat, the shortest round-trip form.
+
+func init() {
+	registerFloatFormatBuiltin()
+}
+
+func registerFloatFormatBuiltin() {
+	spec := BuiltinSpec{
+		Module:  "std/string",
+		Name:    "_float_format",
+		NumArgs: 2,
+		IsPure:  true,
+		Type:    floatFormatType,
+		Impl:    floatFormatImpl,
+	}
+	if err := RegisterEffectBuiltin(spec); err != nil {
+		panic(fmt.Sprintf("failed to register _float_format: %v", err))
+	}
+}
+
+func floatFormatType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.Float(), T.Int()).Returns(T.String()).Build()
+}
+
+// floatFormatImpl prints f with precision digits after the decimal point
+// (rounded half to even). A negative precision falls back to the shortest
+// round-trip form used by show; NaN and infinities print as in show.
+func floatFormatImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	f, fok := args[0].(*eval.FloatValue)
+	p, pok := args[1].(*eval.IntValue)
+	if !fok || !pok {
+		return nil, fmt.Errorf("_float_format: expected (float, int), got %T and %T", args[0], args[1])
+	}
+	if p.Value < 0 || math.IsNaN(f.Value) || math.IsInf(f.Value, 0) {
+		return &eval.StringValue{Value: eval.FormatFloat(f.Value)}, nil
+	}
+	return &eval.StringValue{Value: strconv.FormatFloat(f.Value, 'f', p.Value, 64)}, nil
+}
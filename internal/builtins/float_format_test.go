@@ -0,0 +1,30 @@
+package builtins
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func TestFloatFormat(t *testing.T) {
+	tests := []struct {
+		f         float64
+		precision int
+		expected  string
+	}{
+		{3.14159, 2, "3.14"},
+		{2.5, 0, "2"},
+		{1.0, 3, "1.000"},
+		{-0.125, 2, "-0.12"},
+		{0.30000000000000004, -1, "0.30000000000000004"},
+		{math.Inf(1), 2, "Inf"},
+	}
+	for _, tt := range tests {
+		result, err := floatFormatImpl(nil, []eval.Value{&eval.FloatValue{Value: tt.f}, &eval.IntValue{Value: tt.precision}})
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, result.(*eval.StringValue).Value)
+	}
+}
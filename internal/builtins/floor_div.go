@@ -0,0 +1,73 @@
+package builtins
+
+import (
+	"fmt"
+
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// Floored division: div_Int and mod_Int truncate toward zero, so the
+// remainder has the sign of the dividend ((-7) % 3 == -1). These round
+// toward negative infinity instead, so the remainder has the sign of the
+// divisor ((-7) mod 3 == 2), as in Python. a == div(a, b) * b + mod(a, b)
+// holds for both conventions.
+
+func init() {
+	registerFloorDivBuiltins()
+}
+
+func registerFloorDivBuiltins() {
+	specs := []BuiltinSpec{
+		{Module: "std/math", Name: "_int_div_floor", NumArgs: 2, Type: intFloorDivType, Impl: intIntToIntErr(intDivFloor)},
+		{Module: "std/math", Name: "_int_mod_floor", NumArgs: 2, Type: intFloorDivType, Impl: intIntToIntErr(intModFloor)},
+	}
+	for _, spec := range specs {
+		spec.IsPure = true
+		if err := RegisterEffectBuiltin(spec); err != nil {
+			panic(fmt.Sprintf("failed to register %s: %v", spec.Name, err))
+		}
+	}
+}
+
+func intFloorDivType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.Int(), T.Int()).Returns(T.Int()).Build()
+}
+
+// intDivFloor is a / b rounded toward negative infinity
+func intDivFloor(a, b int) (int, error) {
+	if b == 0 {
+		return 0, eval.NewRuntimeError("RT_DIV0", "Division by zero", nil)
+	}
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q, nil
+}
+
+// intModFloor is the remainder of intDivFloor, which is zero or has the
+// sign of b
+func intModFloor(a, b int) (int, error) {
+	if b == 0 {
+		return 0, eval.NewRuntimeError("RT_DIV0", "Modulo by zero", nil)
+	}
+	r := a % b
+	if r != 0 && (r < 0) != (b < 0) {
+		r += b
+	}
+	return r, nil
+}
+
+// checkedIntDivFloor is intDivFloor failing with RT_INT_OVERFLOW on
+// minInt / -1, used under --int-overflow=checked
+func checkedIntDivFloor(a, b int) (int, error) {
+	if b == 0 {
+		return intDivFloor(a, b)
+	}
+	if _, ok := eval.CheckedDiv(a, b); !ok {
+		return 0, eval.IntOverflowError("_int_div_floor", a, b)
+	}
+	return intDivFloor(a, b)
+}
@@ -0,0 +1,61 @@
+package builtins
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func TestDivMod_SignConventions(t *testing.T) {
+	tests := []struct {
+		a, b               int
+		truncDiv, truncMod int
+		floorDiv, floorMod int
+	}{
+		{7, 3, 2, 1, 2, 1},
+		{-7, 3, -2, -1, -3, 2},
+		{7, -3, -2, 1, -3, -2},
+		{-7, -3, 2, -1, 2, -1},
+		{-6, 3, -2, 0, -2, 0},
+		{0, -5, 0, 0, 0, 0},
+	}
+	call := func(name string, a, b int) int {
+		builtin, ok := GetSpec(name)
+		require.True(t, ok, name)
+		v, err := builtin.Impl(nil, []eval.Value{&eval.IntValue{Value: a}, &eval.IntValue{Value: b}})
+		require.NoError(t, err, "%s(%d, %d)", name, a, b)
+		return v.(*eval.IntValue).Value
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.truncDiv, call("div_Int", tt.a, tt.b), "div_Int(%d, %d)", tt.a, tt.b)
+		assert.Equal(t, tt.truncMod, call("mod_Int", tt.a, tt.b), "mod_Int(%d, %d)", tt.a, tt.b)
+		assert.Equal(t, tt.floorDiv, call("_int_div_floor", tt.a, tt.b), "_int_div_floor(%d, %d)", tt.a, tt.b)
+		assert.Equal(t, tt.floorMod, call("_int_mod_floor", tt.a, tt.b), "_int_mod_floor(%d, %d)", tt.a, tt.b)
+		assert.Equal(t, tt.a, tt.floorDiv*tt.b+tt.floorMod, "floored div and mod must recombine to %d", tt.a)
+	}
+}
+
+func TestFloorDiv_ZeroDivisor(t *testing.T) {
+	_, err := intDivFloor(1, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RT_DIV0")
+
+	_, err = intModFloor(-1, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RT_DIV0")
+}
+
+func TestFloorDiv_CheckedOverflow(t *testing.T) {
+	impl, ok := CheckedIntImpl("_int_div_floor")
+	require.True(t, ok)
+	_, err := impl(nil, []eval.Value{&eval.IntValue{Value: math.MinInt}, &eval.IntValue{Value: -1}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RT_INT_OVERFLOW")
+
+	_, err = impl(nil, []eval.Value{&eval.IntValue{Value: 1}, &eval.IntValue{Value: 0}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RT_DIV0")
+}
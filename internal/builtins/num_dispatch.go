@@ -0,0 +1,103 @@
+package builtins
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// Arithmetic at a type variable: inside a constrained polymorphic function
+// such as `let f = \y. y + 1`, the operator has no ground Num instance when
+// it is lowered. It lowers to the *_Num builtins instead, which pick the
+// instance from the runtime operands. An integer literal in such a function
+// is still an int at runtime, so an int operand beside a float or bigint goes
+// through that instance's fromInt, as the literal would have if its type had
+// been known (f(2.5) adds 2.5 and 1.0).
+
+func init() {
+	registerNumDispatch()
+}
+
+func registerNumDispatch() {
+	for _, op := range []string{"add", "sub", "mul", "div", "mod"} {
+		registerNumBuiltin(op+"_Num", 2, numDispatch(op, false))
+	}
+	registerNumBuiltin("neg_Num", 1, numDispatch("neg", false))
+
+	for _, op := range []string{"add", "sub", "mul", "div", "neg"} {
+		checkedIntArithmetic[op+"_Num"] = numDispatch(op, true)
+	}
+}
+
+func registerNumBuiltin(name string, numArgs int, impl EffectImpl) {
+	typeFunc := func() types.Type {
+		T := types.NewBuilder()
+		params := make([]types.Type, numArgs)
+		for i := range params {
+			params[i] = T.Var("α")
+		}
+		return T.Func(params...).Returns(T.Var("α")).Build()
+	}
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module: "std/math", Name: name, NumArgs: numArgs, IsPure: true, Type: typeFunc, Impl: impl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register %s: %v", name, err))
+	}
+}
+
+// numDispatch returns the body of op_Num: it converts int operands to the
+// widest operand's instance and calls op at that instance. checked selects
+// the overflow-checked Int builtins.
+func numDispatch(op string, checked bool) EffectImpl {
+	return func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		suffix := numInstance(args)
+		name := op + "_" + suffix
+		if suffix == "Int" && checked {
+			if impl, ok := checkedIntArithmetic[name]; ok {
+				return impl(ctx, args)
+			}
+		}
+		spec, ok := GetSpec(name)
+		if !ok {
+			return nil, fmt.Errorf("%s_Num: no builtin %s", op, name)
+		}
+		converted := make([]eval.Value, len(args))
+		for i, arg := range args {
+			converted[i] = fromIntAt(suffix, arg)
+		}
+		return spec.Impl(ctx, converted)
+	}
+}
+
+// numInstance names the Num instance of a set of runtime operands
+func numInstance(args []eval.Value) string {
+	suffix := "Int"
+	for _, arg := range args {
+		switch arg.(type) {
+		case *eval.FloatValue:
+			return "Float"
+		case *eval.BigIntValue:
+			suffix = "BigInt"
+		}
+	}
+	return suffix
+}
+
+// fromIntAt applies the fromInt of the named instance to an int operand
+func fromIntAt(suffix string, v eval.Value) eval.Value {
+	i, ok := v.(*eval.IntValue)
+	if !ok {
+		return v
+	}
+	switch suffix {
+	case "Float":
+		return &eval.FloatValue{Value: float64(i.Value)}
+	case "BigInt":
+		return &eval.BigIntValue{Value: big.NewInt(int64(i.Value))}
+	}
+	return v
+}
@@ -0,0 +1,40 @@
+package builtins
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func TestNumDispatch_PicksInstanceFromOperands(t *testing.T) {
+	tests := []struct {
+		name string
+		args []eval.Value
+		want string
+	}{
+		{"add_Num", []eval.Value{&eval.IntValue{Value: 2}, &eval.IntValue{Value: 3}}, "5"},
+		{"add_Num", []eval.Value{&eval.FloatValue{Value: 2.5}, &eval.IntValue{Value: 1}}, "3.5"},
+		{"mul_Num", []eval.Value{&eval.IntValue{Value: 2}, &eval.FloatValue{Value: 1.5}}, "3.0"},
+		{"div_Num", []eval.Value{&eval.IntValue{Value: 7}, &eval.IntValue{Value: 2}}, "3"},
+		{"div_Num", []eval.Value{&eval.FloatValue{Value: 7}, &eval.IntValue{Value: 2}}, "3.5"},
+		{"sub_Num", []eval.Value{&eval.BigIntValue{Value: big.NewInt(10)}, &eval.IntValue{Value: 4}}, "6"},
+		{"neg_Num", []eval.Value{&eval.FloatValue{Value: 2.5}}, "-2.5"},
+	}
+	for _, tt := range tests {
+		spec, ok := GetSpec(tt.name)
+		require.True(t, ok, tt.name)
+		v, err := spec.Impl(nil, tt.args)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, v.String(), "%s%v", tt.name, tt.args)
+	}
+}
+
+func TestNumDispatch_DivisionByZero(t *testing.T) {
+	spec, ok := GetSpec("div_Num")
+	require.True(t, ok)
+	_, err := spec.Impl(nil, []eval.Value{&eval.IntValue{Value: 1}, &eval.IntValue{Value: 0}})
+	assert.Error(t, err)
+}
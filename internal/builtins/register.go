@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"strings"
@@ -24,6 +25,8 @@ func init() {
 	registerStringTrim()
 	registerStringUpper()
 	registerStringLower()
+	registerStringLines()
+	registerStringWords()
 
 	// Register arithmetic builtins
 	registerArithmetic()
@@ -37,8 +40,9 @@ func init() {
 	// Register conversion builtins
 	registerConversions()
 
-	// Register string operations
+	// Register string and list concatenation
 	registerStringConcat()
+	registerListConcat()
 
 	// Register IO effect builtins
 	registerIO()
@@ -99,13 +103,22 @@ func registerNetHTTPRequest() {
 		IsPure:  false,
 		Effect:  "Net",
 		Type:    makeHTTPRequestType,
-		Impl:    effects.NetHTTPRequest,
+		Impl:    netHTTPRequestImpl,
 	})
 	if err != nil {
 		panic(fmt.Sprintf("failed to register _net_httpRequest: %v", err))
 	}
 }
 
+// netHTTPRequestImpl runs effects.NetHTTPRequest, recording it in the
+// effect audit log once the Net capability is known to be granted
+func netHTTPRequestImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	if ctx.HasCap("Net") {
+		ctx.RecordOp("Net", "httpRequest", args)
+	}
+	return effects.NetHTTPRequest(ctx, args)
+}
+
 // makeHTTPRequestType builds the type signature for _net_httpRequest
 // Type: (String, String, List<{name: String, value: String}>, String)
 //
@@ -359,6 +372,68 @@ func strLowerImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error
 	return &eval.StringValue{Value: strings.ToLower(s.Value)}, nil
 }
 
+// registerStringLines registers the _str_lines builtin
+func registerStringLines() {
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module:  "std/string",
+		Name:    "_str_lines",
+		NumArgs: 1,
+		IsPure:  true,
+		Type:    makeStrSplitType,
+		Impl:    strLinesImpl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register _str_lines: %v", err))
+	}
+}
+
+// makeStrSplitType builds string -> List[string], shared by _str_lines and _str_words
+func makeStrSplitType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.String()).Returns(T.List(T.String())).Build()
+}
+
+// strLinesImpl splits on "\n", dropping a trailing "\r" from each line (CRLF).
+// A trailing newline does not produce a final empty line.
+func strLinesImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	s := args[0].(*eval.StringValue)
+	if s.Value == "" {
+		return &eval.ListValue{Elements: []eval.Value{}}, nil
+	}
+	parts := strings.Split(strings.TrimSuffix(s.Value, "\n"), "\n")
+	elems := make([]eval.Value, len(parts))
+	for i, line := range parts {
+		elems[i] = &eval.StringValue{Value: strings.TrimSuffix(line, "\r")}
+	}
+	return &eval.ListValue{Elements: elems}, nil
+}
+
+// registerStringWords registers the _str_words builtin
+func registerStringWords() {
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module:  "std/string",
+		Name:    "_str_words",
+		NumArgs: 1,
+		IsPure:  true,
+		Type:    makeStrSplitType,
+		Impl:    strWordsImpl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register _str_words: %v", err))
+	}
+}
+
+// strWordsImpl splits on runs of Unicode whitespace, ignoring leading/trailing space
+func strWordsImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	s := args[0].(*eval.StringValue)
+	fields := strings.Fields(s.Value)
+	elems := make([]eval.Value, len(fields))
+	for i, w := range fields {
+		elems[i] = &eval.StringValue{Value: w}
+	}
+	return &eval.ListValue{Elements: elems}, nil
+}
+
 // ============================================================================
 // Arithmetic Builtins (Int and Float operations)
 // ============================================================================
@@ -368,6 +443,8 @@ func registerArithmetic() {
 	registerBuiltin("add_Int", 2, true, intIntToInt(func(a, b int) int { return a + b }))
 	registerBuiltin("sub_Int", 2, true, intIntToInt(func(a, b int) int { return a - b }))
 	registerBuiltin("mul_Int", 2, true, intIntToInt(func(a, b int) int { return a * b }))
+	// div_Int and mod_Int truncate like Go: (-7) % 3 == -1. See floor_div.go
+	// for the floored variants.
 	registerBuiltin("div_Int", 2, true, intIntToIntErr(func(a, b int) (int, error) {
 		if b == 0 {
 			return 0, eval.NewRuntimeError("RT_DIV0", "Division by zero", nil)
@@ -391,17 +468,53 @@ func registerArithmetic() {
 	registerBuiltin("neg_Float", 1, true, floatNegFloat)
 }
 
-// floatDivFloat: division with IEEE 754 behavior (returns Inf for div-by-zero)
+// checkedIntArithmetic holds overflow-checked variants of the Int arithmetic
+// builtins, used in their place under --int-overflow=checked
+var checkedIntArithmetic = map[string]EffectImpl{
+	"add_Int": intIntToIntErr(checkedIntOp("add_Int", eval.CheckedAdd[int])),
+	"sub_Int": intIntToIntErr(checkedIntOp("sub_Int", eval.CheckedSub[int])),
+	"mul_Int": intIntToIntErr(checkedIntOp("mul_Int", eval.CheckedMul[int])),
+	"div_Int": intIntToIntErr(func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, eval.NewRuntimeError("RT_DIV0", "Division by zero", nil)
+		}
+		return checkedIntOp("div_Int", eval.CheckedDiv[int])(a, b)
+	}),
+	"_int_div_floor": intIntToIntErr(checkedIntDivFloor),
+	"neg_Int": func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		a := args[0].(*eval.IntValue).Value
+		c, ok := eval.CheckedNeg(a)
+		if !ok {
+			return nil, eval.IntOverflowError("neg_Int", a)
+		}
+		return &eval.IntValue{Value: c}, nil
+	},
+}
+
+// CheckedIntImpl returns the overflow-checked variant of an Int arithmetic
+// builtin, or false if the builtin cannot overflow
+func CheckedIntImpl(name string) (EffectImpl, bool) {
+	impl, ok := checkedIntArithmetic[name]
+	return impl, ok
+}
+
+// checkedIntOp turns a Checked* helper into a builtin body that fails with
+// RT_INT_OVERFLOW instead of wrapping
+func checkedIntOp(name string, op func(a, b int) (int, bool)) func(a, b int) (int, error) {
+	return func(a, b int) (int, error) {
+		c, ok := op(a, b)
+		if !ok {
+			return 0, eval.IntOverflowError(name, a, b)
+		}
+		return c, nil
+	}
+}
+
+// floatDivFloat: division with IEEE 754 behavior. Dividing by zero gives
+// Inf signed by both operands (1.0 / -0.0 is -Inf), and 0.0 / 0.0 is NaN.
 func floatDivFloat(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
 	a := args[0].(*eval.FloatValue)
 	b := args[1].(*eval.FloatValue)
-	if b.Value == 0.0 {
-		// IEEE 754 behavior: return +/-Inf
-		if a.Value >= 0 {
-			return &eval.FloatValue{Value: math.Inf(1)}, nil
-		}
-		return &eval.FloatValue{Value: math.Inf(-1)}, nil
-	}
 	return &eval.FloatValue{Value: a.Value / b.Value}, nil
 }
 
@@ -508,7 +621,8 @@ func registerBuiltin(name string, numArgs int, isPure bool, impl func(*effects.E
 }
 
 // ============================================================================
-// Comparison Builtins (eq, ne, lt, le, gt, ge for Int, Float, String, Bool)
+// Comparison Builtins (eq, ne, lt, le, gt, ge for Int, Float, String, Bool,
+// Unit and tuples)
 // ============================================================================
 
 func registerComparisons() {
@@ -520,7 +634,9 @@ func registerComparisons() {
 	registerCmp("gt_Int", func(a, b int) bool { return a > b })
 	registerCmp("ge_Int", func(a, b int) bool { return a >= b })
 
-	// Float comparisons
+	// Float comparisons follow IEEE 754 deliberately: NaN is unequal to
+	// everything including itself and every ordering test on it is false
+	// (so NaN /= NaN, but not NaN < x or NaN >= x), and -0.0 == 0.0.
 	registerCmpFloat("eq_Float", func(a, b float64) bool { return a == b })
 	registerCmpFloat("ne_Float", func(a, b float64) bool { return a != b })
 	registerCmpFloat("lt_Float", func(a, b float64) bool { return a < b })
@@ -539,6 +655,32 @@ func registerComparisons() {
 	// Bool comparisons
 	registerCmpBool("eq_Bool", func(a, b bool) bool { return a == b })
 	registerCmpBool("ne_Bool", func(a, b bool) bool { return a != b })
+
+	// Unit comparisons: () is the only value
+	registerCmpUnit("eq_Unit", true)
+	registerCmpUnit("ne_Unit", false)
+
+	// Tuple comparisons: element-wise, Ord is lexicographic
+	registerCmpTuple("eq_Tuple", func(a, b eval.Value) (bool, error) { return structuralEqual(a, b) })
+	registerCmpTuple("ne_Tuple", func(a, b eval.Value) (bool, error) {
+		eq, err := structuralEqual(a, b)
+		return !eq, err
+	})
+	registerCmpTuple("lt_Tuple", structuralOrder(func(c int) bool { return c < 0 }))
+	registerCmpTuple("le_Tuple", structuralOrder(func(c int) bool { return c <= 0 }))
+	registerCmpTuple("gt_Tuple", structuralOrder(func(c int) bool { return c > 0 }))
+	registerCmpTuple("ge_Tuple", structuralOrder(func(c int) bool { return c >= 0 }))
+
+	// Derived ADT comparisons: constructors order by declaration, then fields
+	registerCmpTuple("eq_ADT", func(a, b eval.Value) (bool, error) { return structuralEqual(a, b) })
+	registerCmpTuple("ne_ADT", func(a, b eval.Value) (bool, error) {
+		eq, err := structuralEqual(a, b)
+		return !eq, err
+	})
+	registerCmpTuple("lt_ADT", structuralOrder(func(c int) bool { return c < 0 }))
+	registerCmpTuple("le_ADT", structuralOrder(func(c int) bool { return c <= 0 }))
+	registerCmpTuple("gt_ADT", structuralOrder(func(c int) bool { return c > 0 }))
+	registerCmpTuple("ge_ADT", structuralOrder(func(c int) bool { return c >= 0 }))
 }
 
 func registerCmp(name string, fn func(int, int) bool) {
@@ -613,6 +755,167 @@ func registerCmpBool(name string, fn func(bool, bool) bool) {
 	}
 }
 
+func registerCmpUnit(name string, result bool) {
+	impl := func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		return &eval.BoolValue{Value: result}, nil
+	}
+	typeFunc := func() types.Type {
+		T := types.NewBuilder()
+		return T.Func(T.Unit(), T.Unit()).Returns(T.Bool()).Build()
+	}
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module: "std/prelude", Name: name, NumArgs: 2, IsPure: true, Type: typeFunc, Impl: impl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register %s: %v", name, err))
+	}
+}
+
+// registerCmpTuple registers a tuple or derived ADT comparison. The type
+// checker only lowers to these when every element or field has the instance,
+// so the values are always ones structuralEqual and structuralCompare understand.
+func registerCmpTuple(name string, fn func(eval.Value, eval.Value) (bool, error)) {
+	impl := func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		result, err := fn(args[0], args[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return &eval.BoolValue{Value: result}, nil
+	}
+	typeFunc := func() types.Type {
+		T := types.NewBuilder()
+		return T.Func(T.Var("a"), T.Var("a")).Returns(T.Bool()).Build()
+	}
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module: "std/prelude", Name: name, NumArgs: 2, IsPure: true, Type: typeFunc, Impl: impl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register %s: %v", name, err))
+	}
+}
+
+// unordered is what structuralCompare returns when the first differing
+// elements include a NaN, which no ordering test holds for
+const unordered = 2
+
+// structuralOrder builds a tuple ordering from a test on structuralCompare
+func structuralOrder(test func(c int) bool) func(eval.Value, eval.Value) (bool, error) {
+	return func(a, b eval.Value) (bool, error) {
+		c, err := structuralCompare(a, b)
+		if c == unordered {
+			return false, err
+		}
+		return test(c), err
+	}
+}
+
+// structuralEqual compares values with the semantics of each Eq instance,
+// recursing into tuples and constructor fields
+func structuralEqual(a, b eval.Value) (bool, error) {
+	switch x := a.(type) {
+	case *eval.IntValue:
+		if y, ok := b.(*eval.IntValue); ok {
+			return x.Value == y.Value, nil
+		}
+	case *eval.FloatValue:
+		if y, ok := b.(*eval.FloatValue); ok {
+			return x.Value == y.Value, nil
+		}
+	case *eval.StringValue:
+		if y, ok := b.(*eval.StringValue); ok {
+			return x.Value == y.Value, nil
+		}
+	case *eval.BoolValue:
+		if y, ok := b.(*eval.BoolValue); ok {
+			return x.Value == y.Value, nil
+		}
+	case *eval.BigIntValue:
+		if y, ok := b.(*eval.BigIntValue); ok {
+			return x.Value.Cmp(y.Value) == 0, nil
+		}
+	case *eval.UnitValue:
+		if _, ok := b.(*eval.UnitValue); ok {
+			return true, nil
+		}
+	case *eval.TupleValue:
+		if y, ok := b.(*eval.TupleValue); ok && len(x.Elements) == len(y.Elements) {
+			for i := range x.Elements {
+				eq, err := structuralEqual(x.Elements[i], y.Elements[i])
+				if err != nil || !eq {
+					return false, err
+				}
+			}
+			return true, nil
+		}
+	case *eval.TaggedValue:
+		if y, ok := b.(*eval.TaggedValue); ok {
+			if x.CtorName != y.CtorName || len(x.Fields) != len(y.Fields) {
+				return false, nil
+			}
+			for i := range x.Fields {
+				eq, err := structuralEqual(x.Fields[i], y.Fields[i])
+				if err != nil || !eq {
+					return false, err
+				}
+			}
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("cannot compare %s with %s", a.Type(), b.Type())
+}
+
+// structuralCompare orders values with the semantics of each Ord instance,
+// comparing tuples lexicographically and constructors by declaration order,
+// then by their fields. It returns -1, 0 or +1, or unordered once it reaches
+// a NaN, as for the float comparisons.
+func structuralCompare(a, b eval.Value) (int, error) {
+	switch x := a.(type) {
+	case *eval.IntValue:
+		if y, ok := b.(*eval.IntValue); ok {
+			return cmp.Compare(x.Value, y.Value), nil
+		}
+	case *eval.FloatValue:
+		if y, ok := b.(*eval.FloatValue); ok {
+			if math.IsNaN(x.Value) || math.IsNaN(y.Value) {
+				return unordered, nil
+			}
+			return cmp.Compare(x.Value, y.Value), nil // -0.0 and 0.0 are equal
+		}
+	case *eval.StringValue:
+		if y, ok := b.(*eval.StringValue); ok {
+			return strings.Compare(x.Value, y.Value), nil
+		}
+	case *eval.BigIntValue:
+		if y, ok := b.(*eval.BigIntValue); ok {
+			return x.Value.Cmp(y.Value), nil
+		}
+	case *eval.TupleValue:
+		if y, ok := b.(*eval.TupleValue); ok && len(x.Elements) == len(y.Elements) {
+			for i := range x.Elements {
+				c, err := structuralCompare(x.Elements[i], y.Elements[i])
+				if err != nil || c != 0 {
+					return c, err
+				}
+			}
+			return 0, nil
+		}
+	case *eval.TaggedValue:
+		if y, ok := b.(*eval.TaggedValue); ok {
+			if c := cmp.Compare(x.CtorIndex, y.CtorIndex); c != 0 || len(x.Fields) != len(y.Fields) {
+				return c, nil
+			}
+			for i := range x.Fields {
+				c, err := structuralCompare(x.Fields[i], y.Fields[i])
+				if err != nil || c != 0 {
+					return c, err
+				}
+			}
+			return 0, nil
+		}
+	}
+	return 0, fmt.Errorf("cannot order %s with %s", a.Type(), b.Type())
+}
+
 // ============================================================================
 // Logic Builtins (and, or, not)
 // ============================================================================
@@ -679,6 +982,15 @@ func registerConversions() {
 		panic(fmt.Sprintf("failed to register intToFloat: %v", err))
 	}
 
+	// fromInt_Float is Num[Float]'s fromInt. The operator lowering pass
+	// applies it to integer literals whose type resolved to Float.
+	err = RegisterEffectBuiltin(BuiltinSpec{
+		Module: "std/prelude", Name: "fromInt_Float", NumArgs: 1, IsPure: true, Type: type1, Impl: impl1,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register fromInt_Float: %v", err))
+	}
+
 	// floatToInt
 	impl2 := func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
 		a := args[0].(*eval.FloatValue)
@@ -697,7 +1009,7 @@ func registerConversions() {
 }
 
 // ============================================================================
-// String Operations (concat)
+// String and List Operations (concat)
 // ============================================================================
 
 func registerStringConcat() {
@@ -718,15 +1030,44 @@ func registerStringConcat() {
 	}
 }
 
+// registerListConcat registers concat_List, the builtin `++` lowers to when
+// the type checker typed it as list concatenation
+func registerListConcat() {
+	impl := func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		a := args[0].(*eval.ListValue)
+		b := args[1].(*eval.ListValue)
+		elems := make([]eval.Value, 0, len(a.Elements)+len(b.Elements))
+		elems = append(append(elems, a.Elements...), b.Elements...)
+		return &eval.ListValue{Elements: elems}, nil
+	}
+	typeFunc := func() types.Type {
+		T := types.NewBuilder()
+		return T.Func(T.List(T.Var("a")), T.List(T.Var("a"))).Returns(T.List(T.Var("a"))).Build()
+	}
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module: "std/list", Name: "concat_List", NumArgs: 2, IsPure: true, Type: typeFunc, Impl: impl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register concat_List: %v", err))
+	}
+}
+
 // ============================================================================
-// IO Effect Builtins (_io_print, _io_println, _io_readLine)
+// IO Effect Builtins (_io_print, _io_println, _io_readLine, _io_readAll, _io_handle)
 // ============================================================================
 
 func registerIO() {
 	// _io_print
 	impl1 := func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		if result, handled, err := ctx.Intercept("IO", "print", args); handled {
+			return result, err
+		}
+		if !ctx.HasCap("IO") {
+			return nil, effects.NewCapabilityError("IO")
+		}
+		ctx.RecordOp("IO", "print", args)
 		s := args[0].(*eval.StringValue)
-		fmt.Print(s.Value)
+		fmt.Fprint(ctx.Stdout(), s.Value)
 		return &eval.UnitValue{}, nil
 	}
 	type1 := func() types.Type {
@@ -742,8 +1083,15 @@ func registerIO() {
 
 	// _io_println
 	impl2 := func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		if result, handled, err := ctx.Intercept("IO", "println", args); handled {
+			return result, err
+		}
+		if !ctx.HasCap("IO") {
+			return nil, effects.NewCapabilityError("IO")
+		}
+		ctx.RecordOp("IO", "println", args)
 		s := args[0].(*eval.StringValue)
-		fmt.Println(s.Value)
+		fmt.Fprintln(ctx.Stdout(), s.Value)
 		return &eval.UnitValue{}, nil
 	}
 	type2 := func() types.Type {
@@ -757,10 +1105,13 @@ func registerIO() {
 		panic(fmt.Sprintf("failed to register _io_println: %v", err))
 	}
 
-	// _io_readLine (stub for v0.3.10)
+	// _io_readLine
 	impl3 := func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
-		// Stub: return empty string
-		return &eval.StringValue{Value: ""}, nil
+		if !ctx.HasCap("IO") {
+			return nil, effects.NewCapabilityError("IO")
+		}
+		ctx.RecordOp("IO", "readLine", args)
+		return effects.ReadLine(ctx)
 	}
 	type3 := func() types.Type {
 		T := types.NewBuilder()
@@ -772,6 +1123,49 @@ func registerIO() {
 	if err != nil {
 		panic(fmt.Sprintf("failed to register _io_readLine: %v", err))
 	}
+
+	// _io_readAll: read stdin until EOF
+	impl4 := func(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+		if !ctx.HasCap("IO") {
+			return nil, effects.NewCapabilityError("IO")
+		}
+		ctx.RecordOp("IO", "readAll", args)
+		return effects.ReadAll(ctx)
+	}
+	err = RegisterEffectBuiltin(BuiltinSpec{
+		Module: "std/io", Name: "_io_readAll", NumArgs: 0, IsPure: false, Effect: "IO", Type: type3, Impl: impl4,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register _io_readAll: %v", err))
+	}
+
+	// _io_handle: the target of handle { ... } with { IO.print(s) => ... }
+	err = RegisterEffectBuiltin(BuiltinSpec{
+		Module: "std/io", Name: "_io_handle", NumArgs: 3, IsPure: true, Type: makeIOHandleType, Impl: ioHandleImpl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register _io_handle: %v", err))
+	}
+}
+
+func makeIOHandleType() types.Type {
+	// _io_handle : ∀α ε ρ σ. ((string) -> () ! ρ, (string) -> () ! σ, () -> α ! ε) -> α ! ε
+	// The handlers of print and println, then the body. IO stays in ε, as
+	// the body may use operations that are not handled. Each handler gets its
+	// own row: rows are closed, so a pure handler could not share one with an
+	// IO body or handler. Their effects are not added to the result; the
+	// runtime capability check still applies to them.
+	T := types.NewBuilder()
+	printHandler := T.Func(T.String()).Returns(T.Unit()).RowTail("ρ").Build()
+	printlnHandler := T.Func(T.String()).Returns(T.Unit()).RowTail("σ").Build()
+	body := T.Func().Returns(T.Var("α")).RowTail("ε").Build()
+	return T.Func(printHandler, printlnHandler, body).Returns(T.Var("α")).RowTail("ε").Build()
+}
+
+// ioHandleImpl is never reached at runtime: running the handlers and the
+// body needs the evaluator, so the runtime binds _io_handle itself
+func ioHandleImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	return nil, fmt.Errorf("_io_handle: must be applied by the evaluator (see runtime.BuiltinRegistry)")
 }
 
 // ============================================================================
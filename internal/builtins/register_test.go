@@ -1,10 +1,13 @@
 package builtins
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/effects"
 	"github.com/sunholo/ailang/internal/eval"
 )
 
@@ -62,3 +65,239 @@ func TestStrLenImpl(t *testing.T) {
 		})
 	}
 }
+
+func listStrings(t *testing.T, v eval.Value) []string {
+	t.Helper()
+	list, ok := v.(*eval.ListValue)
+	require.True(t, ok, "result should be ListValue")
+	out := make([]string, len(list.Elements))
+	for i, e := range list.Elements {
+		out[i] = e.(*eval.StringValue).Value
+	}
+	return out
+}
+
+// TestStrLinesImpl tests line splitting, including CRLF input
+func TestStrLinesImpl(t *testing.T) {
+	spec, ok := GetSpec("_str_lines")
+	require.True(t, ok)
+	assert.Equal(t, "std/string", spec.Module)
+	assert.True(t, spec.IsPure)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", []string{}},
+		{"single", "abc", []string{"abc"}},
+		{"trailing newline", "a\nb\n", []string{"a", "b"}},
+		{"blank lines kept", "a\n\nb", []string{"a", "", "b"}},
+		{"only newline", "\n", []string{""}},
+		{"crlf", "a\r\nb\r\n", []string{"a", "b"}},
+		{"mixed endings", "a\r\nb\nc", []string{"a", "b", "c"}},
+		{"unicode", "世界\n👋", []string{"世界", "👋"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := spec.Impl(nil, []eval.Value{&eval.StringValue{Value: tt.input}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, listStrings(t, result))
+		})
+	}
+}
+
+// TestStrWordsImpl tests whitespace splitting
+func TestStrWordsImpl(t *testing.T) {
+	spec, ok := GetSpec("_str_words")
+	require.True(t, ok)
+	assert.Equal(t, "std/string", spec.Module)
+	assert.True(t, spec.IsPure)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", []string{}},
+		{"only whitespace", " \t\n ", []string{}},
+		{"simple", "hello world", []string{"hello", "world"}},
+		{"leading and trailing", "  hello world  ", []string{"hello", "world"}},
+		{"whitespace runs", "a \t\r\n b", []string{"a", "b"}},
+		{"unicode space", "世界　👋", []string{"世界", "👋"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := spec.Impl(nil, []eval.Value{&eval.StringValue{Value: tt.input}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, listStrings(t, result))
+		})
+	}
+}
+
+// TestIOBuiltinsRequireCapability checks that the IO builtins refuse to run
+// unless the effect context grants IO, however they are reached
+func TestIOBuiltinsRequireCapability(t *testing.T) {
+	args := map[string][]eval.Value{
+		"_io_print":    {&eval.StringValue{Value: "x"}},
+		"_io_println":  {&eval.StringValue{Value: "x"}},
+		"_io_readLine": {},
+		"_io_readAll":  {},
+	}
+	for name, a := range args {
+		t.Run(name, func(t *testing.T) {
+			spec, ok := GetSpec(name)
+			require.True(t, ok)
+
+			ctx := effects.NewEffContext()
+			out := ctx.CaptureOutput()
+			ctx.ProvideInput(strings.NewReader(""))
+			_, err := spec.Impl(ctx, a)
+			var capErr *effects.CapabilityError
+			require.ErrorAs(t, err, &capErr)
+			assert.Empty(t, out.String())
+
+			ctx.Grant(effects.NewCapability("IO"))
+			_, err = spec.Impl(ctx, a)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestCheckedIntImpl tests the overflow-checked Int arithmetic variants
+func TestCheckedIntImpl(t *testing.T) {
+	ints := func(ns ...int) []eval.Value {
+		vals := make([]eval.Value, len(ns))
+		for i, n := range ns {
+			vals[i] = &eval.IntValue{Value: n}
+		}
+		return vals
+	}
+
+	add, ok := CheckedIntImpl("add_Int")
+	require.True(t, ok)
+	result, err := add(nil, ints(2, 3))
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.(*eval.IntValue).Value)
+
+	_, err = add(nil, ints(math.MaxInt, 1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RT_INT_OVERFLOW")
+
+	neg, ok := CheckedIntImpl("neg_Int")
+	require.True(t, ok)
+	_, err = neg(nil, ints(math.MinInt))
+	assert.Contains(t, err.Error(), "RT_INT_OVERFLOW")
+
+	div, ok := CheckedIntImpl("div_Int")
+	require.True(t, ok)
+	_, err = div(nil, ints(1, 0))
+	assert.Contains(t, err.Error(), "RT_DIV0")
+	_, err = div(nil, ints(math.MinInt, -1))
+	assert.Contains(t, err.Error(), "RT_INT_OVERFLOW")
+
+	_, ok = CheckedIntImpl("mod_Int")
+	assert.False(t, ok, "mod_Int cannot overflow")
+}
+
+// TestTupleComparisons tests the structural Unit and tuple comparison builtins
+func TestTupleComparisons(t *testing.T) {
+	tuple := func(elems ...eval.Value) eval.Value { return &eval.TupleValue{Elements: elems} }
+	one := &eval.IntValue{Value: 1}
+	two := &eval.IntValue{Value: 2}
+	a := &eval.StringValue{Value: "a"}
+	b := &eval.StringValue{Value: "b"}
+
+	tests := []struct {
+		name     string
+		left     eval.Value
+		right    eval.Value
+		expected bool
+	}{
+		{"eq_Tuple", tuple(one, a), tuple(one, a), true},
+		{"eq_Tuple", tuple(one, tuple(a, &eval.UnitValue{})), tuple(one, tuple(b, &eval.UnitValue{})), false},
+		{"ne_Tuple", tuple(one, a), tuple(one, b), true},
+		{"lt_Tuple", tuple(one, b), tuple(two, a), true}, // first element decides
+		{"lt_Tuple", tuple(one, b), tuple(one, a), false},
+		{"le_Tuple", tuple(one, a), tuple(one, a), true},
+		{"gt_Tuple", tuple(tuple(two, a), one), tuple(tuple(one, b), two), true},
+		{"ge_Tuple", tuple(one, a), tuple(one, b), false},
+		{"eq_Unit", &eval.UnitValue{}, &eval.UnitValue{}, true},
+		{"ne_Unit", &eval.UnitValue{}, &eval.UnitValue{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, ok := GetSpec(tt.name)
+			require.True(t, ok, "%s should be registered", tt.name)
+			result, err := spec.Impl(nil, []eval.Value{tt.left, tt.right})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(*eval.BoolValue).Value)
+		})
+	}
+}
+
+// TestFloatComparisonSemantics pins the IEEE 754 semantics of float Eq and
+// Ord: NaN is unequal to itself and unordered, and -0.0 equals 0.0. Tuples
+// and derived ADT instances agree with the float builtins.
+func TestFloatComparisonSemantics(t *testing.T) {
+	f := func(x float64) eval.Value { return &eval.FloatValue{Value: x} }
+	tuple := func(elems ...eval.Value) eval.Value { return &eval.TupleValue{Elements: elems} }
+	nan := f(math.NaN())
+	negZero := f(math.Copysign(0, -1))
+	one := &eval.IntValue{Value: 1}
+
+	tests := []struct {
+		name     string
+		left     eval.Value
+		right    eval.Value
+		expected bool
+	}{
+		{"eq_Float", nan, nan, false},
+		{"ne_Float", nan, nan, true},
+		{"lt_Float", nan, f(1), false},
+		{"le_Float", nan, f(1), false},
+		{"gt_Float", nan, f(1), false},
+		{"ge_Float", f(1), nan, false},
+		{"eq_Float", negZero, f(0), true},
+		{"lt_Float", negZero, f(0), false},
+		{"le_Float", negZero, f(0), true},
+		{"eq_Tuple", tuple(nan, one), tuple(nan, one), false},
+		{"ne_Tuple", tuple(nan, one), tuple(nan, one), true},
+		{"lt_Tuple", tuple(nan, one), tuple(f(1), one), false},
+		{"ge_Tuple", tuple(nan, one), tuple(f(1), one), false},
+		{"gt_Tuple", tuple(nan, one), tuple(f(1), one), false},
+		{"lt_Tuple", tuple(f(1), nan), tuple(f(2), nan), true}, // first element decides
+		{"eq_Tuple", tuple(negZero, one), tuple(f(0), one), true},
+		{"le_Tuple", tuple(negZero, one), tuple(f(0), one), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, ok := GetSpec(tt.name)
+			require.True(t, ok, "%s should be registered", tt.name)
+			result, err := spec.Impl(nil, []eval.Value{tt.left, tt.right})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(*eval.BoolValue).Value, "%s(%s, %s)", tt.name, tt.left, tt.right)
+		})
+	}
+}
+
+// TestFloatDivision checks IEEE 754 division by zero
+func TestFloatDivision(t *testing.T) {
+	spec, ok := GetSpec("div_Float")
+	require.True(t, ok)
+	div := func(a, b float64) float64 {
+		result, err := spec.Impl(nil, []eval.Value{&eval.FloatValue{Value: a}, &eval.FloatValue{Value: b}})
+		require.NoError(t, err)
+		return result.(*eval.FloatValue).Value
+	}
+
+	assert.True(t, math.IsInf(div(1, 0), 1))
+	assert.True(t, math.IsInf(div(-1, 0), -1))
+	assert.True(t, math.IsInf(div(1, math.Copysign(0, -1)), -1))
+	assert.True(t, math.IsNaN(div(0, 0)))
+	assert.Equal(t, 2.5, div(5, 2))
+}
@@ -21,6 +21,11 @@ func init() {
 	registerStringPrimitiveMeta()
 	registerIOMeta()
 	registerJSONMeta()
+	registerBytesMeta()
+	registerBigIntMeta()
+	registerSafeDivMeta()
+	registerFloorDivMeta()
+	registerStructuralCompareMeta()
 	registerNetMeta()
 }
 
@@ -56,6 +61,14 @@ func registerArithmeticMeta() {
 	Registry["div_Float"] = &BuiltinMeta{Name: "div_Float", NumArgs: 2, IsPure: true}
 	Registry["mod_Float"] = &BuiltinMeta{Name: "mod_Float", NumArgs: 2, IsPure: true}
 	Registry["neg_Float"] = &BuiltinMeta{Name: "neg_Float", NumArgs: 1, IsPure: true}
+
+	// Operations at a type variable, dispatched on the operands
+	Registry["add_Num"] = &BuiltinMeta{Name: "add_Num", NumArgs: 2, IsPure: true}
+	Registry["sub_Num"] = &BuiltinMeta{Name: "sub_Num", NumArgs: 2, IsPure: true}
+	Registry["mul_Num"] = &BuiltinMeta{Name: "mul_Num", NumArgs: 2, IsPure: true}
+	Registry["div_Num"] = &BuiltinMeta{Name: "div_Num", NumArgs: 2, IsPure: true}
+	Registry["mod_Num"] = &BuiltinMeta{Name: "mod_Num", NumArgs: 2, IsPure: true}
+	Registry["neg_Num"] = &BuiltinMeta{Name: "neg_Num", NumArgs: 1, IsPure: true}
 }
 
 // registerComparisonMeta registers metadata for comparison builtins
@@ -80,6 +93,7 @@ func registerComparisonMeta() {
 // registerConversionMeta registers metadata for numeric conversion builtins
 func registerConversionMeta() {
 	Registry["intToFloat"] = &BuiltinMeta{Name: "intToFloat", NumArgs: 1, IsPure: true}
+	Registry["fromInt_Float"] = &BuiltinMeta{Name: "fromInt_Float", NumArgs: 1, IsPure: true}
 	Registry["floatToInt"] = &BuiltinMeta{Name: "floatToInt", NumArgs: 1, IsPure: true}
 }
 
@@ -92,6 +106,7 @@ func registerStringMeta() {
 	Registry["le_String"] = &BuiltinMeta{Name: "le_String", NumArgs: 2, IsPure: true}
 	Registry["gt_String"] = &BuiltinMeta{Name: "gt_String", NumArgs: 2, IsPure: true}
 	Registry["ge_String"] = &BuiltinMeta{Name: "ge_String", NumArgs: 2, IsPure: true}
+	Registry["concat_List"] = &BuiltinMeta{Name: "concat_List", NumArgs: 2, IsPure: true}
 }
 
 // registerBooleanMeta registers metadata for boolean operation builtins
@@ -103,6 +118,19 @@ func registerBooleanMeta() {
 	Registry["ne_Bool"] = &BuiltinMeta{Name: "ne_Bool", NumArgs: 2, IsPure: true}
 }
 
+// registerStructuralCompareMeta registers metadata for Unit, tuple and
+// derived ADT comparison builtins
+func registerStructuralCompareMeta() {
+	Registry["eq_Unit"] = &BuiltinMeta{Name: "eq_Unit", NumArgs: 2, IsPure: true}
+	Registry["ne_Unit"] = &BuiltinMeta{Name: "ne_Unit", NumArgs: 2, IsPure: true}
+	for _, op := range []string{"eq", "ne", "lt", "le", "gt", "ge"} {
+		for _, suffix := range []string{"_Tuple", "_ADT"} {
+			name := op + suffix
+			Registry[name] = &BuiltinMeta{Name: name, NumArgs: 2, IsPure: true}
+		}
+	}
+}
+
 // registerStringPrimitiveMeta registers metadata for low-level string operation builtins
 func registerStringPrimitiveMeta() {
 	Registry["_str_len"] = &BuiltinMeta{Name: "_str_len", NumArgs: 1, IsPure: true}
@@ -113,6 +141,9 @@ func registerStringPrimitiveMeta() {
 	Registry["_str_upper"] = &BuiltinMeta{Name: "_str_upper", NumArgs: 1, IsPure: true}
 	Registry["_str_lower"] = &BuiltinMeta{Name: "_str_lower", NumArgs: 1, IsPure: true}
 	Registry["_str_trim"] = &BuiltinMeta{Name: "_str_trim", NumArgs: 1, IsPure: true}
+	Registry["_str_lines"] = &BuiltinMeta{Name: "_str_lines", NumArgs: 1, IsPure: true}
+	Registry["_str_words"] = &BuiltinMeta{Name: "_str_words", NumArgs: 1, IsPure: true}
+	Registry["_float_format"] = &BuiltinMeta{Name: "_float_format", NumArgs: 2, IsPure: true}
 }
 
 // registerIOMeta registers metadata for I/O operation builtins
@@ -120,6 +151,8 @@ func registerIOMeta() {
 	Registry["_io_print"] = &BuiltinMeta{Name: "_io_print", NumArgs: 1, IsPure: false}
 	Registry["_io_println"] = &BuiltinMeta{Name: "_io_println", NumArgs: 1, IsPure: false}
 	Registry["_io_readLine"] = &BuiltinMeta{Name: "_io_readLine", NumArgs: 0, IsPure: false}
+	Registry["_io_readAll"] = &BuiltinMeta{Name: "_io_readAll", NumArgs: 0, IsPure: false}
+	Registry["_io_handle"] = &BuiltinMeta{Name: "_io_handle", NumArgs: 3, IsPure: true}
 }
 
 // registerJSONMeta registers metadata for JSON encoding builtins
@@ -128,6 +161,44 @@ func registerJSONMeta() {
 	Registry["_json_decode"] = &BuiltinMeta{Name: "_json_decode", NumArgs: 1, IsPure: true}
 }
 
+// registerBytesMeta registers metadata for byte sequence builtins
+func registerBytesMeta() {
+	Registry["_bytes_fromString"] = &BuiltinMeta{Name: "_bytes_fromString", NumArgs: 1, IsPure: true}
+	Registry["_bytes_toString"] = &BuiltinMeta{Name: "_bytes_toString", NumArgs: 1, IsPure: true}
+	Registry["_base64_encode"] = &BuiltinMeta{Name: "_base64_encode", NumArgs: 1, IsPure: true}
+	Registry["_base64_decode"] = &BuiltinMeta{Name: "_base64_decode", NumArgs: 1, IsPure: true}
+	Registry["_hex_encode"] = &BuiltinMeta{Name: "_hex_encode", NumArgs: 1, IsPure: true}
+	Registry["_hex_decode"] = &BuiltinMeta{Name: "_hex_decode", NumArgs: 1, IsPure: true}
+}
+
+// registerBigIntMeta registers metadata for arbitrary-precision integer builtins
+func registerBigIntMeta() {
+	Registry["_bigint_fromInt"] = &BuiltinMeta{Name: "_bigint_fromInt", NumArgs: 1, IsPure: true}
+	Registry["_bigint_add"] = &BuiltinMeta{Name: "_bigint_add", NumArgs: 2, IsPure: true}
+	Registry["_bigint_mul"] = &BuiltinMeta{Name: "_bigint_mul", NumArgs: 2, IsPure: true}
+	Registry["_bigint_toString"] = &BuiltinMeta{Name: "_bigint_toString", NumArgs: 1, IsPure: true}
+
+	// Operator builtins for the Num, Eq and Ord instances
+	Registry["fromInt_BigInt"] = &BuiltinMeta{Name: "fromInt_BigInt", NumArgs: 1, IsPure: true}
+	for _, op := range []string{"add", "sub", "mul", "div", "mod", "eq", "ne", "lt", "le", "gt", "ge"} {
+		name := op + "_BigInt"
+		Registry[name] = &BuiltinMeta{Name: name, NumArgs: 2, IsPure: true}
+	}
+	Registry["neg_BigInt"] = &BuiltinMeta{Name: "neg_BigInt", NumArgs: 1, IsPure: true}
+}
+
+// registerSafeDivMeta registers metadata for the Result-returning division builtins
+func registerSafeDivMeta() {
+	Registry["_int_safeDiv"] = &BuiltinMeta{Name: "_int_safeDiv", NumArgs: 2, IsPure: true}
+	Registry["_float_safeDiv"] = &BuiltinMeta{Name: "_float_safeDiv", NumArgs: 2, IsPure: true}
+}
+
+// registerFloorDivMeta registers metadata for the floored division builtins
+func registerFloorDivMeta() {
+	Registry["_int_div_floor"] = &BuiltinMeta{Name: "_int_div_floor", NumArgs: 2, IsPure: true}
+	Registry["_int_mod_floor"] = &BuiltinMeta{Name: "_int_mod_floor", NumArgs: 2, IsPure: true}
+}
+
 // registerNetMeta registers metadata for Net effect builtins
 func registerNetMeta() {
 	Registry["_net_httpGet"] = &BuiltinMeta{Name: "_net_httpGet", NumArgs: 1, IsPure: false}
@@ -0,0 +1,81 @@
+package builtins
+
+import (
+	"fmt"
+
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// Safe division: like div_Int and div_Float, but a zero divisor is an Err
+// value instead of a runtime error (Int) or an infinity (Float)
+
+func init() {
+	registerSafeDivBuiltins()
+}
+
+func registerSafeDivBuiltins() {
+	specs := []BuiltinSpec{
+		{Module: "std/math", Name: "_int_safeDiv", NumArgs: 2, Type: intSafeDivType, Impl: intSafeDivImpl},
+		{Module: "std/math", Name: "_float_safeDiv", NumArgs: 2, Type: floatSafeDivType, Impl: floatSafeDivImpl},
+	}
+	for _, spec := range specs {
+		spec.IsPure = true
+		if err := RegisterEffectBuiltin(spec); err != nil {
+			panic(fmt.Sprintf("failed to register %s: %v", spec.Name, err))
+		}
+	}
+}
+
+func intSafeDivType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.Int(), T.Int()).Returns(T.App("Result", T.Int(), T.String())).Build()
+}
+
+func floatSafeDivType() types.Type {
+	T := types.NewBuilder()
+	return T.Func(T.Float(), T.Float()).Returns(T.App("Result", T.Float(), T.String())).Build()
+}
+
+func intSafeDivImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	a, aok := args[0].(*eval.IntValue)
+	b, bok := args[1].(*eval.IntValue)
+	if !aok || !bok {
+		return nil, fmt.Errorf("_int_safeDiv: expected int arguments, got %T and %T", args[0], args[1])
+	}
+	if b.Value == 0 {
+		return safeDivErr(), nil
+	}
+	return safeDivOk(&eval.IntValue{Value: a.Value / b.Value}), nil
+}
+
+func floatSafeDivImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	a, aok := args[0].(*eval.FloatValue)
+	b, bok := args[1].(*eval.FloatValue)
+	if !aok || !bok {
+		return nil, fmt.Errorf("_float_safeDiv: expected float arguments, got %T and %T", args[0], args[1])
+	}
+	if b.Value == 0 {
+		return safeDivErr(), nil
+	}
+	return safeDivOk(&eval.FloatValue{Value: a.Value / b.Value}), nil
+}
+
+func safeDivOk(v eval.Value) eval.Value {
+	return &eval.TaggedValue{
+		ModulePath: "std/result",
+		TypeName:   "Result",
+		CtorName:   "Ok",
+		Fields:     []eval.Value{v},
+	}
+}
+
+func safeDivErr() eval.Value {
+	return &eval.TaggedValue{
+		ModulePath: "std/result",
+		TypeName:   "Result",
+		CtorName:   "Err",
+		Fields:     []eval.Value{&eval.StringValue{Value: "Division by zero"}},
+	}
+}
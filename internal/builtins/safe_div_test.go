@@ -0,0 +1,36 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func TestSafeDiv_Int(t *testing.T) {
+	ok, err := intSafeDivImpl(nil, []eval.Value{&eval.IntValue{Value: 7}, &eval.IntValue{Value: 2}})
+	require.NoError(t, err)
+	require.True(t, isOk(ok))
+	assert.Equal(t, 3, extractOk(ok).(*eval.IntValue).Value)
+
+	zero, err := intSafeDivImpl(nil, []eval.Value{&eval.IntValue{Value: 7}, &eval.IntValue{Value: 0}})
+	require.NoError(t, err)
+	assert.Equal(t, "Division by zero", extractErr(zero))
+}
+
+func TestSafeDiv_Float(t *testing.T) {
+	ok, err := floatSafeDivImpl(nil, []eval.Value{&eval.FloatValue{Value: 1}, &eval.FloatValue{Value: 4}})
+	require.NoError(t, err)
+	require.True(t, isOk(ok))
+	assert.Equal(t, 0.25, extractOk(ok).(*eval.FloatValue).Value)
+
+	zero, err := floatSafeDivImpl(nil, []eval.Value{&eval.FloatValue{Value: 1}, &eval.FloatValue{Value: 0}})
+	require.NoError(t, err)
+	assert.Equal(t, "Division by zero", extractErr(zero))
+}
+
+func TestSafeDiv_WrongArgumentType(t *testing.T) {
+	_, err := intSafeDivImpl(nil, []eval.Value{&eval.FloatValue{Value: 1}, &eval.IntValue{Value: 1}})
+	assert.Error(t, err)
+}
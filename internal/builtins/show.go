@@ -2,8 +2,6 @@ package builtins
 
 import (
 	"fmt"
-	"math"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -14,6 +12,9 @@ import (
 
 func init() {
 	registerShow()
+	registerShowNewtype()
+	registerError()
+	registerTry()
 }
 
 func registerShow() {
@@ -47,6 +48,102 @@ func showImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
 	return &eval.StringValue{Value: showValue(val, 0)}, nil
 }
 
+// registerShowNewtype registers show_newtype, which show(x) is rewritten to
+// when x is a newtype: newtype values are unboxed, so the constructor name
+// comes from the type checker instead of the value
+func registerShowNewtype() {
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module:  "$builtin",
+		Name:    "show_newtype",
+		NumArgs: 2,
+		IsPure:  true,
+		Type:    makeShowNewtypeType,
+		Impl:    showNewtypeImpl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register show_newtype: %v", err))
+	}
+}
+
+func makeShowNewtypeType() types.Type {
+	// show_newtype : ∀α. (string, α) -> string
+	T := types.NewBuilder()
+	return T.Func(T.String(), T.Var("α")).Returns(T.String()).Build()
+}
+
+// showNewtypeImpl shows the unboxed field inside its constructor, as show
+// prints a single-field ADT value: UserId(42)
+func showNewtypeImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	ctor, ok := args[0].(*eval.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("show_newtype: expected constructor name, got %T", args[0])
+	}
+	return &eval.StringValue{Value: ctor.Value + "(" + showValue(args[1], 1) + ")"}, nil
+}
+
+func registerError() {
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module:  "$builtin",
+		Name:    "error",
+		NumArgs: 1,
+		IsPure:  true,
+		Type:    makeErrorType,
+		Impl:    errorImpl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register error: %v", err))
+	}
+}
+
+func makeErrorType() types.Type {
+	// error : ∀α. string -> α
+	// Never returns, so the result unifies with whatever the context expects
+	alpha := &types.TVar2{Name: "α", Kind: types.Star}
+	return &types.TFunc2{
+		Params:    []types.Type{types.TString},
+		Return:    alpha,
+		EffectRow: types.EmptyEffectRow(),
+	}
+}
+
+// errorImpl aborts evaluation with a user error (RT_USER_ERROR)
+func errorImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	msg, ok := args[0].(*eval.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("error: expected string, got %T", args[0])
+	}
+	return nil, &eval.UserError{Message: msg.Value}
+}
+
+func registerTry() {
+	err := RegisterEffectBuiltin(BuiltinSpec{
+		Module:  "$builtin",
+		Name:    "try",
+		NumArgs: 1,
+		IsPure:  true,
+		Type:    makeTryType,
+		Impl:    tryImpl,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register try: %v", err))
+	}
+}
+
+func makeTryType() types.Type {
+	// try : ∀α ε. (() -> α ! ε) -> Result[α, string] ! ε
+	// The thunk may perform effects; try performs them too
+	T := types.NewBuilder()
+	thunk := T.Func().Returns(T.Var("α")).RowTail("ε").Build()
+	return T.Func(thunk).Returns(T.App("Result", T.Var("α"), T.String())).RowTail("ε").Build()
+}
+
+// tryImpl is never reached at runtime: applying the thunk and catching its
+// failure needs the evaluator running the program, which resolves $builtin.try
+// itself (see CoreEvaluator.TryBuiltin)
+func tryImpl(ctx *effects.EffContext, args []eval.Value) (eval.Value, error) {
+	return nil, fmt.Errorf("try: must be applied by the evaluator (see CoreEvaluator.Try)")
+}
+
 // Constants for show function
 const (
 	maxDepth      = 3
@@ -68,24 +165,7 @@ func showValue(v eval.Value, depth int) string {
 		return strconv.Itoa(val.Value)
 
 	case *eval.FloatValue:
-		// Handle special cases
-		if math.IsNaN(val.Value) {
-			return "NaN"
-		}
-		if math.IsInf(val.Value, 1) {
-			return "Inf"
-		}
-		if math.IsInf(val.Value, -1) {
-			return "-Inf"
-		}
-		// Use 'f' format to ensure decimal point is always shown
-		// e.g., "5.0" not "5", "3.14" not "3.14"
-		s := strconv.FormatFloat(val.Value, 'f', -1, 64)
-		// Ensure at least one decimal place (e.g., "5" -> "5.0")
-		if !strings.Contains(s, ".") {
-			s += ".0"
-		}
-		return s
+		return eval.FormatFloat(val.Value)
 
 	case *eval.BoolValue:
 		if val.Value {
@@ -97,6 +177,12 @@ func showValue(v eval.Value, depth int) string {
 		// Return string without quotes (identity for strings)
 		return val.Value
 
+	case *eval.BytesValue:
+		return truncateIfNeeded(val.String())
+
+	case *eval.BigIntValue:
+		return val.Value.String()
+
 	case *eval.ListValue:
 		if len(val.Elements) == 0 {
 			return "[]"
@@ -112,20 +198,21 @@ func showValue(v eval.Value, depth int) string {
 		if len(val.Fields) == 0 {
 			return "{}"
 		}
-		// Sort keys for deterministic output
-		keys := make([]string, 0, len(val.Fields))
-		for k := range val.Fields {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
 
 		var parts []string
-		for _, k := range keys {
+		for _, k := range val.FieldNames() {
 			parts = append(parts, fmt.Sprintf("%s: %s", k, showValue(val.Fields[k], depth+1)))
 		}
 		result := "{" + strings.Join(parts, ", ") + "}"
 		return truncateIfNeeded(result)
 
+	case *eval.TupleValue:
+		var parts []string
+		for _, elem := range val.Elements {
+			parts = append(parts, showValue(elem, depth+1))
+		}
+		return truncateIfNeeded("(" + strings.Join(parts, ", ") + ")")
+
 	case *eval.TaggedValue:
 		// ADT constructors: Some(42) → "Some(42)"
 		if len(val.Fields) == 0 {
@@ -138,7 +225,10 @@ func showValue(v eval.Value, depth int) string {
 		return val.CtorName + "(" + strings.Join(argStrs, ", ") + ")"
 
 	case *eval.FunctionValue:
-		return "<function>"
+		return val.String()
+
+	case *eval.BuiltinFunction:
+		return val.String()
 
 	case *eval.ErrorValue:
 		return fmt.Sprintf("Error: %s", val.Message)
@@ -31,6 +31,10 @@ func TestShow_Primitives(t *testing.T) {
 		{"float integer-like", testctx.MakeFloat(5.0), "5.0"},
 		{"float small", testctx.MakeFloat(0.001), "0.001"},
 		{"float large", testctx.MakeFloat(123456.789), "123456.789"},
+		{"float shortest round-trip", testctx.MakeFloat(0.30000000000000004), "0.30000000000000004"},
+		{"float million", testctx.MakeFloat(1234567.0), "1234567.0"},
+		{"float tiny", testctx.MakeFloat(1e-7), "1e-07"},
+		{"float huge", testctx.MakeFloat(1e21), "1e+21"},
 
 		// Booleans
 		{"bool true", testctx.MakeBool(true), "true"},
@@ -246,6 +250,19 @@ func TestShow_Constructors(t *testing.T) {
 	}
 }
 
+func TestShow_Tuples(t *testing.T) {
+	ctx := testctx.NewMockEffContext()
+
+	tuple := &eval.TupleValue{Elements: []eval.Value{
+		testctx.MakeInt(1),
+		testctx.MakeList([]eval.Value{testctx.MakeInt(2)}),
+		&eval.TaggedValue{CtorName: "None"},
+	}}
+	result, err := showImpl(ctx.EffContext, []eval.Value{tuple})
+	require.NoError(t, err)
+	assert.Equal(t, "(1, [2], None)", testctx.GetString(result))
+}
+
 func TestShow_DepthLimit(t *testing.T) {
 	ctx := testctx.NewMockEffContext()
 
@@ -293,7 +310,18 @@ func TestShow_FunctionValue(t *testing.T) {
 
 	result, err := showImpl(ctx.EffContext, []eval.Value{funcVal})
 	require.NoError(t, err)
-	assert.Equal(t, "<function>", testctx.GetString(result))
+	assert.Equal(t, "<function/1>", testctx.GetString(result))
+
+	builtin := &eval.BuiltinFunction{Name: "_str_len"}
+	result, err = showImpl(ctx.EffContext, []eval.Value{builtin})
+	require.NoError(t, err)
+	assert.Equal(t, "<builtin:_str_len>", testctx.GetString(result))
+
+	// Functions inside data render the same way
+	list := &eval.ListValue{Elements: []eval.Value{funcVal, builtin}}
+	result, err = showImpl(ctx.EffContext, []eval.Value{list})
+	require.NoError(t, err)
+	assert.Equal(t, "[<function/1>, <builtin:_str_len>]", testctx.GetString(result))
 }
 
 func TestShow_ErrorValue(t *testing.T) {
@@ -319,3 +347,17 @@ func TestShow_TypeRegistration(t *testing.T) {
 	assert.True(t, spec.IsPure)
 	assert.Equal(t, "", spec.Effect)
 }
+
+func TestErrorBuiltin(t *testing.T) {
+	spec, ok := GetSpec("error")
+	require.True(t, ok, "error builtin should be registered")
+	assert.True(t, spec.IsPure)
+	assert.Equal(t, 1, spec.NumArgs)
+
+	result, err := spec.Impl(nil, []eval.Value{&eval.StringValue{Value: "boom"}})
+	assert.Nil(t, result)
+
+	var userErr *eval.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Equal(t, "boom", userErr.Message)
+}
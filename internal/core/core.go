@@ -338,8 +338,9 @@ func (l *LitPattern) patternNode()   {}
 func (l *LitPattern) String() string { return fmt.Sprintf("%v", l.Value) }
 
 type ConstructorPattern struct {
-	Name string
-	Args []CorePattern
+	Name    string
+	Args    []CorePattern
+	Newtype bool // Newtype constructor: the value is the unboxed field, matched by Args[0]
 }
 
 func (c *ConstructorPattern) patternNode() {}
@@ -366,6 +367,32 @@ func (r *RecordPattern) String() string {
 	return fmt.Sprintf("{%v}", r.Fields)
 }
 
+// AsPattern matches Pattern and binds the whole value to Name
+type AsPattern struct {
+	Name    string
+	Pattern CorePattern
+}
+
+func (a *AsPattern) patternNode() {}
+func (a *AsPattern) String() string {
+	return fmt.Sprintf("%s @ %s", a.Name, a.Pattern)
+}
+
+// OrPattern matches if any alternative matches; all alternatives bind the
+// same variables
+type OrPattern struct {
+	Alternatives []CorePattern
+}
+
+func (o *OrPattern) patternNode() {}
+func (o *OrPattern) String() string {
+	parts := make([]string, len(o.Alternatives))
+	for i, alt := range o.Alternatives {
+		parts[i] = alt.String()
+	}
+	return strings.Join(parts, " | ")
+}
+
 type WildcardPattern struct{}
 
 func (w *WildcardPattern) patternNode()   {}
@@ -388,6 +415,7 @@ func (t *TuplePattern) String() string {
 type ProgramFlags struct {
 	Lowered bool // Set after OpLowering pass
 	Linked  bool // Set after linking
+	Pruned  bool // Set after dead-code elimination (unreachable bindings removed)
 }
 
 // Program represents a Core program
@@ -399,10 +427,15 @@ type Program struct {
 
 // DeclMeta contains metadata for top-level declarations
 type DeclMeta struct {
-	Name     string
-	IsExport bool
-	IsPure   bool
-	SID      string // Source ID for tracing
+	Name           string
+	IsExport       bool
+	IsPure         bool
+	IsDeprecated   bool   // Declared with @deprecated
+	DeprecationMsg string // Optional @deprecated("...") message
+	IsInline       bool   // Declared with @inline
+	IsEntry        bool   // Declared with @entry
+	Doc            string // Doc comment above the declaration
+	SID            string // Source ID for tracing
 }
 
 // Dictionary-passing nodes for type class resolution
@@ -0,0 +1,88 @@
+package effects
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+// AuditEntry is one effect operation recorded in the audit log
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Effect string    `json:"effect"`
+	Op     string    `json:"op"`
+	Detail string    `json:"detail,omitempty"` // Key argument, e.g. the file path or URL
+}
+
+// String renders the entry as one log line
+func (e AuditEntry) String() string {
+	line := fmt.Sprintf("%s %s.%s", e.Time.Format(time.RFC3339Nano), e.Effect, e.Op)
+	if e.Detail != "" {
+		line += " " + e.Detail
+	}
+	return line
+}
+
+// EnableAudit starts recording every effect operation performed
+//
+// Operations are recorded once their capability check passed, so a denied
+// operation or one intercepted by a handler does not appear in the log.
+// `ailang run --audit-effects` enables the log and prints it after the run.
+func (ctx *EffContext) EnableAudit() {
+	if ctx.audit == nil {
+		ctx.audit = []AuditEntry{}
+	}
+}
+
+// AuditLog returns the operations recorded so far, oldest first
+//
+// Returns nil if auditing was never enabled.
+func (ctx *EffContext) AuditLog() []AuditEntry {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.audit
+}
+
+// RecordOp appends effect.op to the audit log, if auditing is enabled
+//
+// Effect operations dispatched through Call are recorded there; builtins
+// that perform an operation directly must call RecordOp themselves after
+// their capability check.
+func (ctx *EffContext) RecordOp(effect, op string, args []eval.Value) {
+	if ctx == nil || ctx.audit == nil {
+		return
+	}
+	ctx.audit = append(ctx.audit, AuditEntry{
+		Time:   time.Now(),
+		Effect: effect,
+		Op:     op,
+		Detail: auditDetail(effect, op, args),
+	})
+}
+
+// auditDetail picks the arguments worth logging: the path of an FS
+// operation, the URL (and method) of a Net request, the duration of a sleep.
+// Text written or read by IO is left out.
+func auditDetail(effect, op string, args []eval.Value) string {
+	str := func(i int) string {
+		if i < len(args) {
+			if s, ok := args[i].(*eval.StringValue); ok {
+				return s.Value
+			}
+		}
+		return ""
+	}
+	switch {
+	case effect == "FS", effect == "Net" && op != "httpRequest":
+		return str(0)
+	case effect == "Net":
+		return str(0) + " " + str(1)
+	case effect == "Clock" && op == "sleep" && len(args) > 0:
+		if n, ok := args[0].(*eval.IntValue); ok {
+			return fmt.Sprintf("%dms", n.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package effects
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func TestAudit_RecordsPerformedOperations(t *testing.T) {
+	ctx := NewEffContext()
+	ctx.Grant(NewCapability("IO"))
+	ctx.Grant(NewCapability("FS"))
+	ctx.CaptureOutput()
+	ctx.EnableAudit()
+
+	path := filepath.Join(t.TempDir(), "missing.txt")
+	if _, err := Call(ctx, "IO", "println", []eval.Value{&eval.StringValue{Value: "hi"}}); err != nil {
+		t.Fatalf("println failed: %v", err)
+	}
+	if _, err := Call(ctx, "FS", "exists", []eval.Value{&eval.StringValue{Value: path}}); err != nil {
+		t.Fatalf("exists failed: %v", err)
+	}
+	// Denied: no Clock capability
+	if _, err := Call(ctx, "Clock", "now", nil); err == nil {
+		t.Fatal("expected capability error for Clock.now")
+	}
+
+	log := ctx.AuditLog()
+	if len(log) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d: %v", len(log), log)
+	}
+	if log[0].Effect != "IO" || log[0].Op != "println" || log[0].Detail != "" {
+		t.Errorf("unexpected first entry: %+v", log[0])
+	}
+	if log[1].Effect != "FS" || log[1].Op != "exists" || log[1].Detail != path {
+		t.Errorf("unexpected second entry: %+v", log[1])
+	}
+	if log[0].Time.IsZero() || log[1].Time.Before(log[0].Time) {
+		t.Errorf("expected increasing timestamps, got %v and %v", log[0].Time, log[1].Time)
+	}
+}
+
+func TestAudit_DisabledByDefault(t *testing.T) {
+	ctx := NewEffContext()
+	ctx.Grant(NewCapability("IO"))
+	ctx.CaptureOutput()
+
+	if _, err := Call(ctx, "IO", "print", []eval.Value{&eval.StringValue{Value: "x"}}); err != nil {
+		t.Fatalf("print failed: %v", err)
+	}
+	if log := ctx.AuditLog(); log != nil {
+		t.Errorf("expected no audit log, got %v", log)
+	}
+}
+
+func TestAuditDetail(t *testing.T) {
+	str := func(s string) eval.Value { return &eval.StringValue{Value: s} }
+	tests := []struct {
+		effect, op string
+		args       []eval.Value
+		want       string
+	}{
+		{"Net", "httpGet", []eval.Value{str("https://example.com")}, "https://example.com"},
+		{"Net", "httpRequest", []eval.Value{str("POST"), str("https://example.com/api"), str("[]"), str("{}")}, "POST https://example.com/api"},
+		{"Clock", "sleep", []eval.Value{&eval.IntValue{Value: 250}}, "250ms"},
+		{"IO", "println", []eval.Value{str("secret")}, ""},
+	}
+	for _, tt := range tests {
+		if got := auditDetail(tt.effect, tt.op, tt.args); got != tt.want {
+			t.Errorf("auditDetail(%s.%s) = %q, want %q", tt.effect, tt.op, got, tt.want)
+		}
+	}
+}
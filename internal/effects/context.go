@@ -1,6 +1,9 @@
 package effects
 
 import (
+	"bufio"
+	"bytes"
+	"io"
 	"os"
 	"strconv"
 	"time"
@@ -19,6 +22,10 @@ type EffContext struct {
 	Env   EffEnv                // Environment configuration
 	Clock *ClockContext         // Clock effect state (monotonic time)
 	Net   *NetContext           // Net effect configuration (security settings)
+	IO    *IOContext            // IO effect configuration (output destination)
+
+	handlers []handlerFrame // Installed by handle expressions, innermost last
+	audit    []AuditEntry   // Operations performed, nil unless EnableAudit was called
 }
 
 // EffEnv provides deterministic effect execution configuration
@@ -115,6 +122,18 @@ func NewNetContext() *NetContext {
 	}
 }
 
+// IOContext configures where the IO effect writes and reads
+//
+// By default output goes to os.Stdout. Tests and `ailang run --capture-output`
+// install a buffer instead so program output can be compared against
+// expected text. Input comes from os.Stdin unless tests provide a reader.
+type IOContext struct {
+	Out io.Writer // Destination for print/println (nil = os.Stdout)
+	In  io.Reader // Source for readLine/readAll (nil = os.Stdin)
+
+	reader *bufio.Reader // Buffered reader over In, shared by all reads
+}
+
 // NewEffContext creates a new effect context
 //
 // The context is initialized with no capabilities granted (deny-by-default)
@@ -136,7 +155,72 @@ func NewEffContext() *EffContext {
 		Env:   loadEffEnv(),
 		Clock: NewClockContext(), // Initialize monotonic time anchor
 		Net:   NewNetContext(),   // Initialize secure network defaults
+		IO:    &IOContext{},      // Output goes to stdout unless captured
+	}
+}
+
+// CaptureOutput redirects IO output into a fresh buffer and returns it
+//
+// Everything printed by print/println after this call is appended to the
+// returned buffer instead of being written to stdout.
+//
+// Example:
+//
+//	out := ctx.CaptureOutput()
+//	// ... run program ...
+//	fmt.Println(out.String())
+func (ctx *EffContext) CaptureOutput() *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	if ctx.IO == nil {
+		ctx.IO = &IOContext{}
+	}
+	ctx.IO.Out = buf
+	return buf
+}
+
+// ProvideInput makes readLine/readAll read from r instead of stdin
+//
+// Example:
+//
+//	ctx.ProvideInput(strings.NewReader("line 1\nline 2\n"))
+func (ctx *EffContext) ProvideInput(r io.Reader) {
+	if ctx.IO == nil {
+		ctx.IO = &IOContext{}
+	}
+	ctx.IO.In = r
+	ctx.IO.reader = nil
+}
+
+// Stdout returns the writer IO output should go to
+//
+// Falls back to os.Stdout when no context or IO configuration is present.
+func (ctx *EffContext) Stdout() io.Writer {
+	if ctx == nil || ctx.IO == nil || ctx.IO.Out == nil {
+		return os.Stdout
+	}
+	return ctx.IO.Out
+}
+
+// Stdin returns the buffered reader IO input is read from
+//
+// The reader is created on first use and reused afterwards, so input
+// buffered by one readLine is still seen by the next readLine or readAll.
+// Falls back to a fresh reader over os.Stdin when no context is present.
+func (ctx *EffContext) Stdin() *bufio.Reader {
+	if ctx == nil {
+		return bufio.NewReader(os.Stdin)
+	}
+	if ctx.IO == nil {
+		ctx.IO = &IOContext{}
+	}
+	if ctx.IO.reader == nil {
+		in := ctx.IO.In
+		if in == nil {
+			in = os.Stdin
+		}
+		ctx.IO.reader = bufio.NewReader(in)
 	}
+	return ctx.IO.reader
 }
 
 // Grant adds a capability to the context
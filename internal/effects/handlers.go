@@ -0,0 +1,65 @@
+package effects
+
+import (
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+// HandlerFunc intercepts an effect operation in place of its builtin
+//
+// Handlers are installed by `handle { ... } with { IO.print(s) => ... }`.
+// The handler receives the operation's arguments and its result becomes
+// the operation's result.
+type HandlerFunc func(args []eval.Value) (eval.Value, error)
+
+// handlerFrame holds the handlers installed by one handle expression
+type handlerFrame struct {
+	effect string
+	ops    map[string]HandlerFunc
+}
+
+// PushHandlers installs handlers for operations of an effect
+//
+// Until the matching PopHandlers, the operations are routed to these
+// handlers instead of their builtins, even without the capability.
+//
+// Example:
+//
+//	ctx.PushHandlers("IO", map[string]HandlerFunc{"println": capture})
+//	defer ctx.PopHandlers()
+func (ctx *EffContext) PushHandlers(effect string, ops map[string]HandlerFunc) {
+	ctx.handlers = append(ctx.handlers, handlerFrame{effect: effect, ops: ops})
+}
+
+// PopHandlers removes the handlers installed last
+func (ctx *EffContext) PopHandlers() {
+	if len(ctx.handlers) > 0 {
+		ctx.handlers = ctx.handlers[:len(ctx.handlers)-1]
+	}
+}
+
+// Intercept runs the nearest handler installed for effect.op
+//
+// The handler runs with its own frame and every inner one removed, so an
+// operation it performs itself goes to an outer handler or the builtin.
+//
+// Returns:
+//   - The handler's result and error
+//   - Whether a handler was found (if not, the builtin should run)
+func (ctx *EffContext) Intercept(effect, op string, args []eval.Value) (eval.Value, bool, error) {
+	if ctx == nil {
+		return nil, false, nil
+	}
+	for i := len(ctx.handlers) - 1; i >= 0; i-- {
+		frame := ctx.handlers[i]
+		handler, ok := frame.ops[op]
+		if frame.effect != effect || !ok {
+			continue
+		}
+		saved := ctx.handlers
+		ctx.handlers = saved[:i:i] // A handle inside the handler must not overwrite saved frames
+		result, err := handler(args)
+		ctx.handlers = saved
+		return result, true, err
+	}
+	return nil, false, nil
+}
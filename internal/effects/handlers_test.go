@@ -0,0 +1,83 @@
+package effects
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func TestIntercept_RoutesToInstalledHandler(t *testing.T) {
+	ctx := NewEffContext() // No IO capability: a handled operation does not need it
+	out := ctx.CaptureOutput()
+
+	var got []string
+	ctx.PushHandlers("IO", map[string]HandlerFunc{
+		"println": func(args []eval.Value) (eval.Value, error) {
+			got = append(got, args[0].(*eval.StringValue).Value)
+			return &eval.UnitValue{}, nil
+		},
+	})
+
+	if _, err := Call(ctx, "IO", "println", []eval.Value{&eval.StringValue{Value: "a"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(got) != 1 || got[0] != "a" {
+		t.Errorf("expected handler to receive [a], got %v", got)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no output, got %q", out.String())
+	}
+
+	// print has no handler, so it still needs the capability
+	if _, err := Call(ctx, "IO", "print", []eval.Value{&eval.StringValue{Value: "b"}}); err == nil {
+		t.Error("expected capability error for unhandled print")
+	}
+
+	ctx.PopHandlers()
+	if _, handled, _ := ctx.Intercept("IO", "println", nil); handled {
+		t.Error("expected no handler after PopHandlers")
+	}
+}
+
+func TestIntercept_HandlerSeesOuterHandlers(t *testing.T) {
+	ctx := NewEffContext()
+
+	var trace []string
+	ctx.PushHandlers("IO", map[string]HandlerFunc{
+		"println": func(args []eval.Value) (eval.Value, error) {
+			trace = append(trace, "outer:"+args[0].(*eval.StringValue).Value)
+			return &eval.UnitValue{}, nil
+		},
+	})
+	ctx.PushHandlers("IO", map[string]HandlerFunc{
+		"println": func(args []eval.Value) (eval.Value, error) {
+			trace = append(trace, "inner:"+args[0].(*eval.StringValue).Value)
+			// Performing the operation again reaches the outer handler
+			return Call(ctx, "IO", "println", []eval.Value{&eval.StringValue{Value: "again"}})
+		},
+	})
+
+	if _, err := Call(ctx, "IO", "println", []eval.Value{&eval.StringValue{Value: "x"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	want := []string{"inner:x", "outer:again"}
+	if len(trace) != len(want) || trace[0] != want[0] || trace[1] != want[1] {
+		t.Errorf("expected %v, got %v", want, trace)
+	}
+
+	// The inner frame is still installed afterwards
+	trace = nil
+	if _, _, err := ctx.Intercept("IO", "println", []eval.Value{&eval.StringValue{Value: "y"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(trace) != 2 || trace[0] != "inner:y" {
+		t.Errorf("expected inner handler first, got %v", trace)
+	}
+}
+
+func TestIntercept_NilContext(t *testing.T) {
+	var ctx *EffContext
+	if _, handled, err := ctx.Intercept("IO", "print", nil); handled || err != nil {
+		t.Errorf("expected nil context to handle nothing, got handled=%v err=%v", handled, err)
+	}
+}
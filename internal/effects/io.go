@@ -1,10 +1,8 @@
 package effects
 
 import (
-	"bufio"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 
 	"github.com/sunholo/ailang/internal/eval"
@@ -15,11 +13,12 @@ func init() {
 	RegisterOp("IO", "print", ioPrint)
 	RegisterOp("IO", "println", ioPrintln)
 	RegisterOp("IO", "readLine", ioReadLine)
+	RegisterOp("IO", "readAll", ioReadAll)
 }
 
 // ioPrint implements IO.print(s: String) -> ()
 //
-// Prints a string to stdout without a trailing newline.
+// Prints a string to stdout (or the captured output) without a trailing newline.
 //
 // Parameters:
 //   - ctx: Effect context (capability check already done by Call())
@@ -42,13 +41,13 @@ func ioPrint(ctx *EffContext, args []eval.Value) (eval.Value, error) {
 		return nil, fmt.Errorf("print: expected String, got %T", args[0])
 	}
 
-	fmt.Print(str.Value)
+	fmt.Fprint(ctx.Stdout(), str.Value)
 	return &eval.UnitValue{}, nil
 }
 
 // ioPrintln implements IO.println(s: String) -> ()
 //
-// Prints a string to stdout with a trailing newline.
+// Prints a string to stdout (or the captured output) with a trailing newline.
 //
 // Parameters:
 //   - ctx: Effect context
@@ -71,7 +70,7 @@ func ioPrintln(ctx *EffContext, args []eval.Value) (eval.Value, error) {
 		return nil, fmt.Errorf("println: expected String, got %T", args[0])
 	}
 
-	fmt.Println(str.Value)
+	fmt.Fprintln(ctx.Stdout(), str.Value)
 	return &eval.UnitValue{}, nil
 }
 
@@ -97,13 +96,16 @@ func ioReadLine(ctx *EffContext, args []eval.Value) (eval.Value, error) {
 		return nil, fmt.Errorf("readLine: expected 0 arguments, got %d", len(args))
 	}
 
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err != nil {
-		if err == io.EOF {
-			// Return empty string on EOF
-			return &eval.StringValue{Value: ""}, nil
-		}
+	return ReadLine(ctx)
+}
+
+// ReadLine reads one line from the context's stdin reader
+//
+// Shared by the IO.readLine operation and the _io_readLine builtin. A final
+// line without a newline is returned as-is; at EOF the result is "".
+func ReadLine(ctx *EffContext) (eval.Value, error) {
+	line, err := ctx.Stdin().ReadString('\n')
+	if err != nil && err != io.EOF {
 		return nil, fmt.Errorf("readLine: %w", err)
 	}
 
@@ -114,3 +116,37 @@ func ioReadLine(ctx *EffContext, args []eval.Value) (eval.Value, error) {
 
 	return &eval.StringValue{Value: line}, nil
 }
+
+// ioReadAll implements IO.readAll() -> String
+//
+// Reads stdin until EOF and returns everything read as one string, including
+// newlines. Input already buffered by readLine is included.
+//
+// Parameters:
+//   - ctx: Effect context
+//   - args: [] - no arguments
+//
+// Returns:
+//   - StringValue with the remaining input (empty string at EOF)
+//   - Error if wrong number of arguments or read fails
+//
+// Example AILANG code:
+//
+//	let input = readAll()  -- whole benchmark input at once
+func ioReadAll(ctx *EffContext, args []eval.Value) (eval.Value, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("readAll: expected 0 arguments, got %d", len(args))
+	}
+	return ReadAll(ctx)
+}
+
+// ReadAll reads the context's stdin reader until EOF
+//
+// Shared by the IO.readAll operation and the _io_readAll builtin.
+func ReadAll(ctx *EffContext) (eval.Value, error) {
+	data, err := io.ReadAll(ctx.Stdin())
+	if err != nil {
+		return nil, fmt.Errorf("readAll: %w", err)
+	}
+	return &eval.StringValue{Value: string(data)}, nil
+}
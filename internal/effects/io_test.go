@@ -74,6 +74,34 @@ func TestIOPrintln_Success(t *testing.T) {
 	}
 }
 
+func TestIOPrint_CaptureOutput(t *testing.T) {
+	ctx := NewEffContext()
+	ctx.Grant(NewCapability("IO"))
+	out := ctx.CaptureOutput()
+
+	if _, err := Call(ctx, "IO", "print", []eval.Value{&eval.StringValue{Value: "Hello, "}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := Call(ctx, "IO", "println", []eval.Value{&eval.StringValue{Value: "world"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if got := out.String(); got != "Hello, world\n" {
+		t.Errorf("expected captured output 'Hello, world\\n', got %q", got)
+	}
+}
+
+func TestEffContextStdout_Default(t *testing.T) {
+	if got := NewEffContext().Stdout(); got != os.Stdout {
+		t.Errorf("expected os.Stdout by default, got %T", got)
+	}
+
+	var nilCtx *EffContext
+	if got := nilCtx.Stdout(); got != os.Stdout {
+		t.Errorf("expected os.Stdout for nil context, got %T", got)
+	}
+}
+
 func TestIOPrint_MissingCapability(t *testing.T) {
 	ctx := NewEffContext() // No IO capability granted
 
@@ -227,6 +255,67 @@ func TestIOReadLine_WrongArgCount(t *testing.T) {
 	}
 }
 
+func TestIOReadAll_MultiLine(t *testing.T) {
+	ctx := NewEffContext()
+	ctx.Grant(NewCapability("IO"))
+	ctx.ProvideInput(strings.NewReader("3\n1 2 3\n\nlast line without newline"))
+
+	result, err := Call(ctx, "IO", "readAll", []eval.Value{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	strVal, ok := result.(*eval.StringValue)
+	if !ok {
+		t.Fatalf("expected StringValue, got %T", result)
+	}
+	if strVal.Value != "3\n1 2 3\n\nlast line without newline" {
+		t.Errorf("expected whole input, got %q", strVal.Value)
+	}
+
+	// Everything has been consumed
+	result, err = Call(ctx, "IO", "readAll", []eval.Value{})
+	if err != nil {
+		t.Fatalf("expected no error at EOF, got: %v", err)
+	}
+	if result.(*eval.StringValue).Value != "" {
+		t.Errorf("expected empty string at EOF, got %q", result.(*eval.StringValue).Value)
+	}
+}
+
+func TestIOReadAll_AfterReadLine(t *testing.T) {
+	ctx := NewEffContext()
+	ctx.Grant(NewCapability("IO"))
+	ctx.ProvideInput(strings.NewReader("header\nbody 1\nbody 2\n"))
+
+	line, err := Call(ctx, "IO", "readLine", []eval.Value{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if line.(*eval.StringValue).Value != "header" {
+		t.Errorf("expected 'header', got %q", line.(*eval.StringValue).Value)
+	}
+
+	// Input buffered by readLine must not be lost
+	rest, err := Call(ctx, "IO", "readAll", []eval.Value{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if rest.(*eval.StringValue).Value != "body 1\nbody 2\n" {
+		t.Errorf("expected remaining input, got %q", rest.(*eval.StringValue).Value)
+	}
+}
+
+func TestIOReadAll_MissingCapability(t *testing.T) {
+	ctx := NewEffContext() // No IO capability
+	ctx.ProvideInput(strings.NewReader("secret"))
+
+	_, err := Call(ctx, "IO", "readAll", []eval.Value{})
+	if _, ok := err.(*CapabilityError); !ok {
+		t.Fatalf("expected *CapabilityError, got %v", err)
+	}
+}
+
 func TestCall_UnknownEffect(t *testing.T) {
 	ctx := NewEffContext()
 	ctx.Grant(NewCapability("Unknown"))
@@ -263,7 +352,7 @@ func TestRegisterOp(t *testing.T) {
 		t.Fatal("IO effect not registered")
 	}
 
-	ops := []string{"print", "println", "readLine"}
+	ops := []string{"print", "println", "readLine", "readAll"}
 	for _, op := range ops {
 		if Registry["IO"][op] == nil {
 			t.Errorf("IO.%s not registered", op)
@@ -273,7 +273,7 @@ func validateProtocol(scheme string, ctx *EffContext) error {
 		return nil // Always allowed
 	case "http":
 		if !ctx.Net.AllowHTTP {
-			return fmt.Errorf("E_NET_PROTOCOL_BLOCKED: http:// blocked (use --net-allow-http to enable)")
+			return fmt.Errorf("E_NET_INSECURE_PROTOCOL: http:// blocked (use --allow-net-http to enable)")
 		}
 		return nil
 	case "file", "ftp", "data", "gopher", "":
@@ -69,7 +69,7 @@ func TestNetProtocolValidation(t *testing.T) {
 			name:        "http blocked by default",
 			url:         "http://example.com",
 			allowHTTP:   false,
-			expectError: "E_NET_PROTOCOL_BLOCKED: http:// blocked",
+			expectError: "E_NET_INSECURE_PROTOCOL: http:// blocked",
 		},
 		{
 			name:        "http allowed with flag",
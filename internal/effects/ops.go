@@ -57,6 +57,7 @@ var Registry = map[string]map[string]EffOp{
 // Call invokes an effect operation
 //
 // This is the main entry point for effect execution. It performs:
+//  0. Handler dispatch (a handle expression may intercept the operation)
 //  1. Capability checking (deny if not granted)
 //  2. Operation lookup (find the effect implementation)
 //  3. Execution (call the EffOp function)
@@ -77,6 +78,11 @@ var Registry = map[string]map[string]EffOp{
 //	    &eval.StringValue{Value: "Hello!"},
 //	})
 func Call(ctx *EffContext, effectName, opName string, args []eval.Value) (eval.Value, error) {
+	// Step 0: An installed handler takes the place of the operation
+	if result, handled, err := ctx.Intercept(effectName, opName, args); handled {
+		return result, err
+	}
+
 	// Step 1: Check capability
 	if err := ctx.RequireCap(effectName); err != nil {
 		return nil, err
@@ -95,6 +101,7 @@ func Call(ctx *EffContext, effectName, opName string, args []eval.Value) (eval.V
 	}
 
 	// Step 4: Execute operation
+	ctx.RecordOp(effectName, opName, args)
 	return op(ctx, args)
 }
 
@@ -0,0 +1,118 @@
+package elaborate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
+)
+
+// GetTypeAliases returns the transparent type aliases declared by the module
+// (alias name → aliased type)
+func (e *Elaborator) GetTypeAliases() map[string]ast.Type {
+	aliases := make(map[string]ast.Type, len(e.typeAliases))
+	for name, decl := range e.typeAliases {
+		aliases[name] = decl.Definition.(*ast.TypeAlias).Target
+	}
+	return aliases
+}
+
+// checkAliasCycles rejects aliases that expand to themselves, such as
+// type A = B; type B = A, with a TYPE_ALIAS_CYCLE report naming the cycle
+func (e *Elaborator) checkAliasCycles() error {
+	names := make([]string, 0, len(e.typeAliases))
+	for name := range e.typeAliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					return append(append([]string(nil), path[i:]...), name)
+				}
+			}
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, ref := range aliasRefs(e.typeAliases[name].Definition.(*ast.TypeAlias).Target) {
+			if _, isAlias := e.typeAliases[ref]; !isAlias {
+				continue
+			}
+			if cycle := visit(ref); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, name := range names {
+		if cycle := visit(name); cycle != nil {
+			decl := e.typeAliases[cycle[0]]
+			return ailangErrors.WrapReport(&ailangErrors.Report{
+				Schema:  "ailang.error/v1",
+				Code:    ailangErrors.TypeAliasCycle,
+				Phase:   "elaborate",
+				Message: fmt.Sprintf("type alias cycle: %s", strings.Join(cycle, " → ")),
+				Span:    ailangErrors.SpanAt(decl.Pos),
+				Data:    map[string]any{"cycle": cycle},
+				Fix: &ailangErrors.Fix{
+					Suggestion: fmt.Sprintf("Make one of the aliases a type of its own, e.g. type %s = %s(...)", cycle[0], cycle[0]),
+					Confidence: 0.6,
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// aliasRefs returns the type names a type expression refers to
+func aliasRefs(t ast.Type) []string {
+	switch typ := t.(type) {
+	case *ast.SimpleType:
+		return []string{typ.Name}
+	case *ast.TypeApp:
+		refs := []string{typ.Name}
+		for _, arg := range typ.Args {
+			refs = append(refs, aliasRefs(arg)...)
+		}
+		return refs
+	case *ast.FuncType:
+		var refs []string
+		for _, p := range typ.Params {
+			refs = append(refs, aliasRefs(p)...)
+		}
+		return append(refs, aliasRefs(typ.Return)...)
+	case *ast.ListType:
+		return aliasRefs(typ.Element)
+	case *ast.TupleType:
+		var refs []string
+		for _, e := range typ.Elements {
+			refs = append(refs, aliasRefs(e)...)
+		}
+		return refs
+	case *ast.RecordType:
+		var refs []string
+		for _, f := range typ.Fields {
+			refs = append(refs, aliasRefs(f.Type)...)
+		}
+		return refs
+	}
+	return nil
+}
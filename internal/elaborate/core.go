@@ -3,6 +3,7 @@ package elaborate
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 
 	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/builtins"
@@ -13,23 +14,32 @@ import (
 // Elaborator transforms surface AST to Core ANF
 type Elaborator struct {
 	nextID       uint64
-	surfaceSpans map[uint64]ast.Pos  // Map Core IDs to surface positions
-	effectAnnots map[uint64][]string // Map Core IDs to effect annotations from AST
-	freshVarNum  int                 // For generating fresh variable names
+	surfaceSpans map[uint64]ast.Pos    // Map Core IDs to surface positions
+	effectAnnots map[uint64][]string   // Map Core IDs to effect annotations from AST
+	typeAnnots   map[uint64][]ast.Type // Map Core IDs to let and parameter type annotations from AST
+	returnAnnots map[uint64]ast.Type   // Map Core lambda IDs to declared return types from AST
+	freshVarNum  int                   // For generating fresh variable names
 	moduleLoader *loader.ModuleLoader
-	filePath     string                      // Current file path for relative imports
-	globalEnv    map[string]core.GlobalRef   // Global environment for imports (name -> GlobalRef)
-	constructors map[string]*ConstructorInfo // Available constructors (name -> info)
-	warnings     []*ExhaustivenessWarning    // Accumulated warnings
-	exChecker    *ExhaustivenessChecker      // Exhaustiveness checker
+	filePath     string                        // Current file path for relative imports
+	globalEnv    map[string]core.GlobalRef     // Global environment for imports (name -> GlobalRef)
+	constructors map[string]*ConstructorInfo   // Available constructors (name -> info)
+	importedADTs map[string][]*ConstructorInfo // Constructors of imported ADTs, in declaration order
+	typeAliases  map[string]*ast.TypeDecl      // Transparent aliases declared by the module (type Celsius = float)
+	warnings     []*ExhaustivenessWarning      // Accumulated warnings
+	exChecker    *ExhaustivenessChecker        // Exhaustiveness checker
 }
 
 // ConstructorInfo holds information about an available constructor
 type ConstructorInfo struct {
-	TypeName   string // The ADT type name (e.g., "Option")
-	CtorName   string // Constructor name (e.g., "Some")
-	Arity      int    // Number of fields
-	IsImported bool   // Whether this constructor is imported
+	TypeName   string     // The ADT type name (e.g., "Option")
+	CtorName   string     // Constructor name (e.g., "Some")
+	Arity      int        // Number of fields
+	FieldNames []string   // Field names for record-style constructors (nil if positional)
+	FieldTypes []ast.Type // Declared field types (nil if imported)
+	TypeParams []string   // Type parameters of the ADT (nil if imported)
+	Newtype    bool       // Whether values are represented by the unboxed field (see ast.TypeDecl.IsNewtype)
+	IsImported bool       // Whether this constructor is imported
+	Index      int        // Position of the constructor in its type declaration
 }
 
 // NewElaborator creates a new elaborator
@@ -38,9 +48,12 @@ func NewElaborator() *Elaborator {
 		nextID:       1,
 		surfaceSpans: make(map[uint64]ast.Pos),
 		effectAnnots: make(map[uint64][]string),
+		typeAnnots:   make(map[uint64][]ast.Type),
+		returnAnnots: make(map[uint64]ast.Type),
 		freshVarNum:  0,
 		globalEnv:    make(map[string]core.GlobalRef),
 		constructors: make(map[string]*ConstructorInfo),
+		typeAliases:  make(map[string]*ast.TypeDecl),
 		warnings:     []*ExhaustivenessWarning{},
 		exChecker:    NewExhaustivenessChecker(),
 	}
@@ -53,11 +66,14 @@ func NewElaboratorWithPath(filePath string) *Elaborator {
 		nextID:       1,
 		surfaceSpans: make(map[uint64]ast.Pos),
 		effectAnnots: make(map[uint64][]string),
+		typeAnnots:   make(map[uint64][]ast.Type),
+		returnAnnots: make(map[uint64]ast.Type),
 		freshVarNum:  0,
 		moduleLoader: loader.NewModuleLoader(dir),
 		filePath:     filePath,
 		globalEnv:    make(map[string]core.GlobalRef),
 		constructors: make(map[string]*ConstructorInfo),
+		typeAliases:  make(map[string]*ast.TypeDecl),
 		warnings:     []*ExhaustivenessWarning{},
 		exChecker:    NewExhaustivenessChecker(),
 	}
@@ -94,6 +110,57 @@ func (e *Elaborator) RegisterConstructor(typeName, ctorName string, arity int, i
 	}
 }
 
+// RegisterConstructorFields records the field names of a record-style constructor.
+// The constructor must already be registered.
+func (e *Elaborator) RegisterConstructorFields(ctorName string, fieldNames []string) {
+	if info, ok := e.constructors[ctorName]; ok {
+		info.FieldNames = fieldNames
+	}
+}
+
+// RegisterNewtype marks a constructor as a newtype constructor, registering it
+// as imported if it is not declared in this module
+func (e *Elaborator) RegisterNewtype(typeName, ctorName string) {
+	info, ok := e.constructors[ctorName]
+	if !ok {
+		info = &ConstructorInfo{
+			TypeName:   typeName,
+			CtorName:   ctorName,
+			Arity:      1,
+			IsImported: true,
+		}
+		e.constructors[ctorName] = info
+	}
+	info.Newtype = true
+}
+
+// RegisterImportedADT records every constructor of an ADT declared by an
+// imported module, in declaration order. Only allValues[T] uses these; the
+// constructors a module imports by name are resolved through the global
+// environment.
+func (e *Elaborator) RegisterImportedADT(typeName string, ctors []*ConstructorInfo) {
+	if e.importedADTs == nil {
+		e.importedADTs = make(map[string][]*ConstructorInfo)
+	}
+	e.importedADTs[typeName] = ctors
+}
+
+// adtConstructors returns the constructors of an ADT declared in this module or
+// an imported one, in declaration order. Local declarations shadow imports.
+func (e *Elaborator) adtConstructors(typeName string) []*ConstructorInfo {
+	var ctors []*ConstructorInfo
+	for _, info := range e.constructors {
+		if info.TypeName == typeName && !info.IsImported {
+			ctors = append(ctors, info)
+		}
+	}
+	if len(ctors) == 0 {
+		return e.importedADTs[typeName]
+	}
+	sort.Slice(ctors, func(i, j int) bool { return ctors[i].Index < ctors[j].Index })
+	return ctors
+}
+
 // GetConstructors returns all constructors defined in this module (not imported)
 func (e *Elaborator) GetConstructors() map[string]*ConstructorInfo {
 	localConstructors := make(map[string]*ConstructorInfo)
@@ -110,6 +177,19 @@ func (e *Elaborator) GetEffectAnnotation(nodeID uint64) []string {
 	return e.effectAnnots[nodeID]
 }
 
+// GetTypeAnnotations returns the type annotations of the program, keyed by
+// Core node ID: the declared type of a let's value (one entry), or one entry
+// per lambda parameter (nil where a parameter is unannotated)
+func (e *Elaborator) GetTypeAnnotations() map[uint64][]ast.Type {
+	return e.typeAnnots
+}
+
+// GetReturnAnnotations returns the declared return types of functions,
+// keyed by the Core ID of their lambda
+func (e *Elaborator) GetReturnAnnotations() map[uint64]ast.Type {
+	return e.returnAnnots
+}
+
 // GetWarnings returns accumulated exhaustiveness warnings
 func (e *Elaborator) GetWarnings() []*ExhaustivenessWarning {
 	return e.warnings
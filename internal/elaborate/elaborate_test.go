@@ -1,9 +1,12 @@
 package elaborate
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
 	"github.com/sunholo/ailang/internal/lexer"
 	"github.com/sunholo/ailang/internal/parser"
 )
@@ -129,6 +132,44 @@ func TestANFTransformation(t *testing.T) {
 	}
 }
 
+// TestTypeAnnotations checks that let and lambda parameter annotations are
+// kept for the type checker, keyed by the Core node they belong to
+func TestTypeAnnotations(t *testing.T) {
+	l := lexer.New(`let x: float = 3 in \(a: int) b. x`, "test.ail")
+	p := parser.New(l)
+	prog := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parse errors: %v", p.Errors())
+	}
+
+	elab := NewElaborator()
+	coreProg, err := elab.Elaborate(prog)
+	if err != nil {
+		t.Fatalf("elaboration error: %v", err)
+	}
+
+	let, ok := coreProg.Decls[0].(*core.Let)
+	if !ok {
+		t.Fatalf("expected a Let, got %T", coreProg.Decls[0])
+	}
+	annots := elab.GetTypeAnnotations()
+	if got := annots[let.ID()]; len(got) != 1 || got[0].String() != "float" {
+		t.Errorf("let annotation: got %v", got)
+	}
+	outer, ok := let.Body.(*core.Lambda)
+	if !ok {
+		t.Fatalf("expected a Lambda body, got %T", let.Body)
+	}
+	if got := annots[outer.ID()]; len(got) != 1 || got[0].String() != "int" {
+		t.Errorf("parameter a annotation: got %v", got)
+	}
+	if inner, ok := outer.Body.(*core.Lambda); !ok {
+		t.Errorf("expected a curried Lambda, got %T", outer.Body)
+	} else if got, found := annots[inner.ID()]; found {
+		t.Errorf("unannotated parameter b should have no entry, got %v", got)
+	}
+}
+
 func TestNodeIDAssignment(t *testing.T) {
 	// Test that every node gets a unique ID
 	// Create a let expression manually for testing
@@ -164,3 +205,139 @@ func TestNodeIDAssignment(t *testing.T) {
 		t.Errorf("expected node IDs to be assigned, but nextID is %d", elab.nextID)
 	}
 }
+
+func TestGuardedMatchWarning(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		warn  bool
+	}{
+		{"no fall-through", `\n. match n { x if x > 0 => 1 }`, true},
+		{"with fall-through", `\n. match n { x if x > 0 => 1, _ => 0 }`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.New(lexer.New(tt.input, "test.ail"))
+			prog := p.Parse()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("parse errors: %v", p.Errors())
+			}
+
+			elab := NewElaborator()
+			if _, err := elab.Elaborate(prog); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			warnings := elab.GetWarnings()
+			if !tt.warn {
+				if len(warnings) != 0 {
+					t.Errorf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !warnings[0].Guarded {
+				t.Fatalf("expected one guarded-arm warning, got %v", warnings)
+			}
+			if !strings.Contains(warnings[0].String(), "EXHAUST003: guarded arms may not cover all cases") {
+				t.Errorf("unexpected warning text: %s", warnings[0])
+			}
+			if warnings[0].Code() != ailangErrors.EXHAUST003 {
+				t.Errorf("code = %s, want %s", warnings[0].Code(), ailangErrors.EXHAUST003)
+			}
+		})
+	}
+}
+
+func TestElaboratePipeline(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"x |> f", "f([x])"},
+		{"x |> f |> g", "let $tmp1 = f([x]) in g([$tmp1])"},
+		{"x |> add(1)", "let $tmp1 = add([1]) in $tmp1([x])"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p := parser.New(lexer.New(tt.input, "test.ail"))
+			prog := p.Parse()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("parse errors: %v", p.Errors())
+			}
+
+			coreExpr, err := NewElaborator().ElaborateExpr(prog.File.Statements[0].(ast.Expr))
+			if err != nil {
+				t.Fatalf("elaboration error: %v", err)
+			}
+			if got := coreExpr.String(); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestElaborateForwardConstructors(t *testing.T) {
+	input := `module m
+
+func wrap(x: int) -> Option[int] { Some(x) }
+func orZero(o: Option[int]) -> int { match o { None => 0, Some(n) => n } }
+
+type Option[a] = Some(a) | None
+`
+	p := parser.New(lexer.New(input, "test.ail"))
+	file := p.ParseFile()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parse errors: %v", p.Errors())
+	}
+
+	prog, err := NewElaborator().ElaborateFile(file)
+	if err != nil {
+		t.Fatalf("elaboration error: %v", err)
+	}
+	if len(prog.Decls) != 2 {
+		t.Fatalf("expected 2 declarations, got %d", len(prog.Decls))
+	}
+	// Some is a constructor, not a free variable, though its type comes later
+	if wrap := prog.Decls[0].String(); !strings.Contains(wrap, "$adt.make_Option_Some") {
+		t.Errorf("expected Some to elaborate to its constructor, got %s", wrap)
+	}
+	// A leading None arm must match None only, not bind a variable
+	if orZero := prog.Decls[1].String(); !strings.Contains(orZero, "None([])") {
+		t.Errorf("expected a None constructor pattern, got %s", orZero)
+	}
+}
+
+// TestTypeAliasCycle checks that aliases expanding to themselves are
+// rejected with TYPE_ALIAS_CYCLE, while an alias chain is accepted
+func TestTypeAliasCycle(t *testing.T) {
+	elaborateModule := func(src string) (*Elaborator, error) {
+		l := lexer.New(src, "aliases.ail")
+		p := parser.New(l)
+		file := p.ParseFile()
+		if len(p.Errors()) > 0 {
+			t.Fatalf("parse errors: %v", p.Errors())
+		}
+		elab := NewElaborator()
+		_, err := elab.ElaborateFile(file)
+		return elab, err
+	}
+
+	elab, err := elaborateModule("module aliases\ntype Celsius = float\ntype Temp = Celsius\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := elab.GetTypeAliases(); len(got) != 2 || got["Temp"].String() != "Celsius" {
+		t.Errorf("unexpected aliases: %v", got)
+	}
+
+	_, err = elaborateModule("module aliases\ntype A = B\ntype B = [A]\n")
+	rep, ok := ailangErrors.AsReport(err)
+	if !ok {
+		t.Fatalf("expected a structured report, got %v", err)
+	}
+	if rep.Code != ailangErrors.TypeAliasCycle || !strings.Contains(rep.Message, "A → B → A") {
+		t.Errorf("unexpected report: %s: %s", rep.Code, rep.Message)
+	}
+}
@@ -2,8 +2,11 @@ package elaborate
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/core"
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
 	"github.com/sunholo/ailang/internal/types"
 )
 
@@ -51,6 +54,105 @@ func (ec *ExhaustivenessChecker) CheckExhaustiveness(match *core.Match, scrutine
 	return true, nil
 }
 
+// CheckGuards finds guarded arms that no unguarded arm backs up
+//
+// A guard may evaluate to false, so a guarded arm never fully covers its
+// pattern. This check is independent of the scrutinee type: it returns the
+// patterns of guarded arms that are not covered by some unguarded arm, or nil
+// if the match has an unguarded catch-all arm.
+func (ec *ExhaustivenessChecker) CheckGuards(match *core.Match) []string {
+	var unguarded []core.CorePattern
+	for _, arm := range match.Arms {
+		if arm.Guard == nil {
+			if ec.isWildcard(arm.Pattern) {
+				return nil
+			}
+			unguarded = append(unguarded, arm.Pattern)
+		}
+	}
+
+	var missing []string
+	seen := make(map[string]bool)
+	for _, arm := range match.Arms {
+		if arm.Guard == nil {
+			continue
+		}
+		covered := false
+		for _, p := range unguarded {
+			if ec.covers(p, arm.Pattern) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			s := arm.Pattern.String()
+			if !seen[s] {
+				seen[s] = true
+				missing = append(missing, s)
+			}
+		}
+	}
+	return missing
+}
+
+// covers checks if every value matched by specific is also matched by general
+func (ec *ExhaustivenessChecker) covers(general, specific core.CorePattern) bool {
+	if ec.isWildcard(general) {
+		return true
+	}
+	if as, ok := general.(*core.AsPattern); ok {
+		return ec.covers(as.Pattern, specific)
+	}
+	if as, ok := specific.(*core.AsPattern); ok {
+		return ec.covers(general, as.Pattern)
+	}
+	if or, ok := specific.(*core.OrPattern); ok {
+		for _, alt := range or.Alternatives {
+			if !ec.covers(general, alt) {
+				return false
+			}
+		}
+		return true
+	}
+	if or, ok := general.(*core.OrPattern); ok {
+		for _, alt := range or.Alternatives {
+			if ec.covers(alt, specific) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch g := general.(type) {
+	case *core.LitPattern:
+		if s, ok := specific.(*core.LitPattern); ok {
+			return g.Value == s.Value
+		}
+	case *core.ConstructorPattern:
+		if s, ok := specific.(*core.ConstructorPattern); ok {
+			return g.Name == s.Name && ec.coversAll(g.Args, s.Args)
+		}
+	case *core.TuplePattern:
+		if s, ok := specific.(*core.TuplePattern); ok {
+			return ec.coversAll(g.Elements, s.Elements)
+		}
+	}
+	return false
+}
+
+// coversAll checks covers pairwise over argument patterns
+func (ec *ExhaustivenessChecker) coversAll(general, specific []core.CorePattern) bool {
+	if len(general) != len(specific) {
+		return false
+	}
+	for i := range general {
+		if !ec.covers(general[i], specific[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // PatternSet represents a set of concrete patterns
 type PatternSet []core.CorePattern
 
@@ -104,6 +206,18 @@ func (ec *ExhaustivenessChecker) expandPattern(p core.CorePattern) PatternSet {
 		// Variable patterns match everything (like wildcard)
 		return PatternSet{&core.WildcardPattern{}}
 
+	case *core.AsPattern:
+		// As-pattern covers exactly what its inner pattern covers
+		return ec.expandPattern(pat.Pattern)
+
+	case *core.OrPattern:
+		// Or-pattern covers the union of its alternatives
+		var covered PatternSet
+		for _, alt := range pat.Alternatives {
+			covered = append(covered, ec.expandPattern(alt)...)
+		}
+		return covered
+
 	case *core.LitPattern:
 		// Literal matches only itself
 		return PatternSet{pat}
@@ -168,9 +282,18 @@ func (ec *ExhaustivenessChecker) subtract(universe, covered PatternSet) PatternS
 
 // isWildcard checks if a pattern is a wildcard or variable (matches everything)
 func (ec *ExhaustivenessChecker) isWildcard(p core.CorePattern) bool {
-	switch p.(type) {
+	switch pat := p.(type) {
 	case *core.WildcardPattern, *core.VarPattern:
 		return true
+	case *core.AsPattern:
+		return ec.isWildcard(pat.Pattern)
+	case *core.OrPattern:
+		for _, alt := range pat.Alternatives {
+			if ec.isWildcard(alt) {
+				return true
+			}
+		}
+		return false
 	default:
 		return false
 	}
@@ -183,6 +306,32 @@ func (ec *ExhaustivenessChecker) patternsMatch(p1, p2 core.CorePattern) bool {
 		return true
 	}
 
+	// As-patterns match like their inner pattern
+	if as, ok := p1.(*core.AsPattern); ok {
+		return ec.patternsMatch(as.Pattern, p2)
+	}
+	if as, ok := p2.(*core.AsPattern); ok {
+		return ec.patternsMatch(p1, as.Pattern)
+	}
+
+	// Or-patterns match if any alternative does
+	if or, ok := p1.(*core.OrPattern); ok {
+		for _, alt := range or.Alternatives {
+			if ec.patternsMatch(alt, p2) {
+				return true
+			}
+		}
+		return false
+	}
+	if or, ok := p2.(*core.OrPattern); ok {
+		for _, alt := range or.Alternatives {
+			if ec.patternsMatch(p1, alt) {
+				return true
+			}
+		}
+		return false
+	}
+
 	// Check specific pattern types
 	switch pat1 := p1.(type) {
 	case *core.LitPattern:
@@ -217,14 +366,32 @@ func (ec *ExhaustivenessChecker) patternsMatch(p1, p2 core.CorePattern) bool {
 // ExhaustivenessWarning represents a non-exhaustive match warning
 type ExhaustivenessWarning struct {
 	Location       string   // Source location
+	Pos            ast.Pos  // Position of the match expression
 	MissingPattern []string // Missing patterns
+	Guarded        bool     // Missing patterns are guarded arms without a fall-through arm (EXHAUST003)
+}
+
+// Code returns EXHAUST003 for uncovered guards and ELB004 otherwise
+func (w *ExhaustivenessWarning) Code() string {
+	if w.Guarded {
+		return ailangErrors.EXHAUST003
+	}
+	return ailangErrors.ELB004
 }
 
-func (w *ExhaustivenessWarning) String() string {
+func (w *ExhaustivenessWarning) Message() string {
+	if w.Guarded {
+		return fmt.Sprintf("EXHAUST003: guarded arms may not cover all cases at %s\n  add a fall-through arm for: %s",
+			w.Location, strings.Join(w.MissingPattern, ", "))
+	}
 	if len(w.MissingPattern) == 1 {
-		return fmt.Sprintf("warning: non-exhaustive match at %s\n  missing pattern: %s",
+		return fmt.Sprintf("non-exhaustive match at %s\n  missing pattern: %s",
 			w.Location, w.MissingPattern[0])
 	}
-	return fmt.Sprintf("warning: non-exhaustive match at %s\n  missing patterns: %v",
+	return fmt.Sprintf("non-exhaustive match at %s\n  missing patterns: %v",
 		w.Location, w.MissingPattern)
 }
+
+func (w *ExhaustivenessWarning) Span() *ast.Span  { return ailangErrors.SpanAt(w.Pos) }
+func (w *ExhaustivenessWarning) Severity() string { return ailangErrors.SeverityWarning }
+func (w *ExhaustivenessWarning) String() string   { return "warning: " + w.Message() }
@@ -197,3 +197,122 @@ func TestExhaustiveness_InfiniteTypeIncomplete(t *testing.T) {
 		t.Error("Expected missing patterns for incomplete Int match")
 	}
 }
+
+// TestExhaustiveness_AsPattern tests that as-patterns cover what their inner pattern covers
+func TestExhaustiveness_AsPattern(t *testing.T) {
+	// match x { b @ true => 1, other @ _ => 0 }
+	match := &core.Match{
+		Scrutinee: &core.Var{Name: "x"},
+		Arms: []core.MatchArm{
+			{
+				Pattern: &core.AsPattern{Name: "b", Pattern: &core.LitPattern{Value: true}},
+				Body:    &core.Lit{Kind: core.IntLit, Value: 1},
+			},
+			{
+				Pattern: &core.AsPattern{Name: "other", Pattern: &core.WildcardPattern{}},
+				Body:    &core.Lit{Kind: core.IntLit, Value: 0},
+			},
+		},
+	}
+
+	checker := NewExhaustivenessChecker()
+	exhaustive, missing := checker.CheckExhaustiveness(match, &types.TCon{Name: "Bool"})
+	if !exhaustive {
+		t.Errorf("Expected exhaustive match, but got missing patterns: %v", missing)
+	}
+
+	// Without the catch-all, false is still missing
+	match.Arms = match.Arms[:1]
+	exhaustive, missing = checker.CheckExhaustiveness(match, &types.TCon{Name: "Bool"})
+	if exhaustive || len(missing) != 1 {
+		t.Errorf("Expected false to be missing, got exhaustive=%v missing=%v", exhaustive, missing)
+	}
+}
+
+// TestExhaustiveness_OrPattern tests that or-patterns cover the union of their alternatives
+func TestExhaustiveness_OrPattern(t *testing.T) {
+	// match x { true | false => 1 }
+	match := &core.Match{
+		Scrutinee: &core.Var{Name: "x"},
+		Arms: []core.MatchArm{
+			{
+				Pattern: &core.OrPattern{Alternatives: []core.CorePattern{
+					&core.LitPattern{Value: true},
+					&core.LitPattern{Value: false},
+				}},
+				Body: &core.Lit{Kind: core.IntLit, Value: 1},
+			},
+		},
+	}
+
+	checker := NewExhaustivenessChecker()
+	exhaustive, missing := checker.CheckExhaustiveness(match, &types.TCon{Name: "Bool"})
+	if !exhaustive {
+		t.Errorf("Expected exhaustive match, but got missing patterns: %v", missing)
+	}
+}
+
+// TestExhaustiveness_GuardsNeedFallthrough tests that guarded arms are not treated as total
+func TestExhaustiveness_GuardsNeedFallthrough(t *testing.T) {
+	guard := &core.Lit{Kind: core.BoolLit, Value: true}
+
+	tests := []struct {
+		name    string
+		arms    []core.MatchArm
+		missing []string
+	}{
+		{
+			name: "single guarded variable",
+			arms: []core.MatchArm{
+				{Pattern: &core.VarPattern{Name: "x"}, Guard: guard},
+			},
+			missing: []string{"x"},
+		},
+		{
+			name: "guarded variable with fall-through",
+			arms: []core.MatchArm{
+				{Pattern: &core.VarPattern{Name: "x"}, Guard: guard},
+				{Pattern: &core.WildcardPattern{}},
+			},
+		},
+		{
+			name: "guarded constructor backed by unguarded arm",
+			arms: []core.MatchArm{
+				{Pattern: &core.ConstructorPattern{Name: "Some", Args: []core.CorePattern{&core.VarPattern{Name: "n"}}}, Guard: guard},
+				{Pattern: &core.ConstructorPattern{Name: "Some", Args: []core.CorePattern{&core.WildcardPattern{}}}},
+				{Pattern: &core.ConstructorPattern{Name: "None"}},
+			},
+		},
+		{
+			name: "all arms for a constructor guarded",
+			arms: []core.MatchArm{
+				{Pattern: &core.ConstructorPattern{Name: "Some", Args: []core.CorePattern{&core.VarPattern{Name: "n"}}}, Guard: guard},
+				{Pattern: &core.ConstructorPattern{Name: "None"}},
+			},
+			missing: []string{"Some([n])"},
+		},
+		{
+			name: "specific unguarded arm does not cover general guarded arm",
+			arms: []core.MatchArm{
+				{Pattern: &core.ConstructorPattern{Name: "Some", Args: []core.CorePattern{&core.VarPattern{Name: "n"}}}, Guard: guard},
+				{Pattern: &core.ConstructorPattern{Name: "Some", Args: []core.CorePattern{&core.LitPattern{Value: int64(0)}}}},
+			},
+			missing: []string{"Some([n])"},
+		},
+	}
+
+	checker := NewExhaustivenessChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing := checker.CheckGuards(&core.Match{Scrutinee: &core.Var{Name: "n"}, Arms: tt.arms})
+			if len(missing) != len(tt.missing) {
+				t.Fatalf("Expected missing %v, got %v", tt.missing, missing)
+			}
+			for i := range missing {
+				if missing[i] != tt.missing[i] {
+					t.Errorf("Expected missing %v, got %v", tt.missing, missing)
+				}
+			}
+		})
+	}
+}
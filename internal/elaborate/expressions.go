@@ -19,7 +19,14 @@ func (e *Elaborator) elaborateExpr(expr ast.Expr) (core.CoreExpr, error) {
 
 // desugar handles surface syntax sugar
 func (e *Elaborator) desugar(expr ast.Expr) ast.Expr {
-	// For now, pass through - will add ? operator desugaring etc
+	// Pipeline: x |> f is f(x)
+	if binop, ok := expr.(*ast.BinaryOp); ok && binop.Op == "|>" {
+		return &ast.FuncCall{
+			Func: binop.Right,
+			Args: []ast.Expr{binop.Left},
+			Pos:  binop.Pos,
+		}
+	}
 	return expr
 }
 
@@ -63,6 +70,10 @@ func (e *Elaborator) normalize(expr ast.Expr) (core.CoreExpr, error) {
 		return e.normalizeFuncLit(ex)
 
 	case *ast.BinaryOp:
+		if ex.Op == "|>" {
+			// Pipelines can appear at any depth, not just at the root
+			return e.normalize(e.desugar(ex))
+		}
 		return e.normalizeBinaryOp(ex)
 
 	case *ast.UnaryOp:
@@ -95,12 +106,18 @@ func (e *Elaborator) normalize(expr ast.Expr) (core.CoreExpr, error) {
 	case *ast.List:
 		return e.normalizeList(ex)
 
+	case *ast.AllValues:
+		return e.normalizeAllValues(ex)
+
 	case *ast.Tuple:
 		return e.normalizeTuple(ex)
 
 	case *ast.Match:
 		return e.normalizeMatch(ex)
 
+	case *ast.Handle:
+		return e.normalizeHandle(ex)
+
 	default:
 		if expr == nil {
 			return nil, fmt.Errorf("normalization received nil expression")
@@ -164,6 +181,7 @@ func (e *Elaborator) normalizeLambda(lam *ast.Lambda) (core.CoreExpr, error) {
 		}
 		e.effectAnnots[coreLam.ID()] = lam.Effects
 	}
+	e.recordParamTypes(coreLam.ID(), lam.Params)
 
 	return coreLam, nil
 }
@@ -171,7 +189,7 @@ func (e *Elaborator) normalizeLambda(lam *ast.Lambda) (core.CoreExpr, error) {
 // normalizeFuncLit handles function literal expressions (func(x) -> T { body })
 // Desugars to Lambda: func(x: int) -> int { x + 1 } ≡ \x. x + 1
 func (e *Elaborator) normalizeFuncLit(funcLit *ast.FuncLit) (core.CoreExpr, error) {
-	// Extract parameter names (type annotations are handled by type checker)
+	// Extract parameter names (type annotations are checked by the type checker)
 	params := make([]string, len(funcLit.Params))
 	for i, p := range funcLit.Params {
 		params[i] = p.Name
@@ -199,10 +217,30 @@ func (e *Elaborator) normalizeFuncLit(funcLit *ast.FuncLit) (core.CoreExpr, erro
 		}
 		e.effectAnnots[coreLam.ID()] = funcLit.Effects
 	}
+	e.recordParamTypes(coreLam.ID(), funcLit.Params)
+	if funcLit.ReturnType != nil {
+		e.returnAnnots[coreLam.ID()] = funcLit.ReturnType
+	}
 
 	return coreLam, nil
 }
 
+// recordParamTypes stores the parameter annotations of a lambda for the type
+// checker, if any parameter has one
+func (e *Elaborator) recordParamTypes(id uint64, params []*ast.Param) {
+	annots := make([]ast.Type, len(params))
+	annotated := false
+	for i, p := range params {
+		if p.Type != nil {
+			annots[i] = p.Type
+			annotated = true
+		}
+	}
+	if annotated {
+		e.typeAnnots[id] = annots
+	}
+}
+
 // normalizeBinaryOp handles binary operations with ANF transformation
 func (e *Elaborator) normalizeBinaryOp(binop *ast.BinaryOp) (core.CoreExpr, error) {
 	// Normalize operands to atomic values
@@ -348,7 +386,7 @@ func (e *Elaborator) normalizeLet(let *ast.Let) (core.CoreExpr, error) {
 		// Return a Let that binds the value but returns Unit
 		// This allows the value to be computed (for side effects) and the binding to be visible
 		// in subsequent expressions in the block
-		return &core.Let{
+		coreLet := &core.Let{
 			CoreNode: e.makeNode(let.Position()),
 			Name:     let.Name,
 			Value:    value,
@@ -357,7 +395,11 @@ func (e *Elaborator) normalizeLet(let *ast.Let) (core.CoreExpr, error) {
 				Kind:     core.UnitLit,
 				Value:    "()",
 			},
-		}, nil
+		}
+		if let.Type != nil {
+			e.typeAnnots[coreLet.ID()] = []ast.Type{let.Type}
+		}
+		return coreLet, nil
 	}
 
 	// Check if it's recursive (let rec)
@@ -394,12 +436,16 @@ func (e *Elaborator) normalizeLet(let *ast.Let) (core.CoreExpr, error) {
 			return nil, err
 		}
 
-		return &core.Let{
+		coreLet := &core.Let{
 			CoreNode: e.makeNode(let.Position()),
 			Name:     let.Name,
 			Value:    value,
 			Body:     body,
-		}, nil
+		}
+		if let.Type != nil {
+			e.typeAnnots[coreLet.ID()] = []ast.Type{let.Type}
+		}
+		return coreLet, nil
 	}
 }
 
@@ -482,6 +528,9 @@ func (e *Elaborator) normalizeBlock(block *ast.Block) (core.CoreExpr, error) {
 				Value:    value,
 				Body:     result, // Thread through to next expression
 			}
+			if letExpr.Type != nil {
+				e.typeAnnots[result.ID()] = []ast.Type{letExpr.Type}
+			}
 		} else {
 			// Regular expression: normalize and bind to a wildcard
 			value, err := e.normalize(expr)
@@ -680,6 +729,46 @@ func (e *Elaborator) normalizeList(list *ast.List) (core.CoreExpr, error) {
 	return e.wrapWithBindings(result, allBindings), nil
 }
 
+// normalizeAllValues handles allValues[T], which desugars to a function
+// returning the constructors of the enumeration T in declaration order:
+// allValues[Color] ≡ func() => [Red, Green, Blue]
+func (e *Elaborator) normalizeAllValues(av *ast.AllValues) (core.CoreExpr, error) {
+	var typeName string
+	switch t := av.Type.(type) {
+	case *ast.SimpleType:
+		typeName = t.Name
+	case *ast.TypeApp:
+		typeName = t.Name
+	}
+	ctors := e.adtConstructors(typeName)
+	if len(ctors) == 0 {
+		return nil, fmt.Errorf("%s: allValues[%s]: %s is not an algebraic data type in scope", av.Pos, av.Type, av.Type)
+	}
+
+	elements := make([]core.CoreExpr, len(ctors))
+	for i, ctor := range ctors {
+		if ctor.Arity > 0 {
+			return nil, fmt.Errorf("%s: allValues[%s]: constructor %s has fields; only enumerations of constructors without fields can be listed",
+				av.Pos, av.Type, ctor.CtorName)
+		}
+		elements[i] = &core.VarGlobal{
+			CoreNode: e.makeNode(av.Position()),
+			Ref: core.GlobalRef{
+				Module: "$adt",
+				Name:   fmt.Sprintf("make_%s_%s", ctor.TypeName, ctor.CtorName),
+			},
+		}
+	}
+
+	return &core.Lambda{
+		CoreNode: e.makeNode(av.Position()),
+		Body: &core.List{
+			CoreNode: e.makeNode(av.Position()),
+			Elements: elements,
+		},
+	}, nil
+}
+
 // normalizeTuple handles tuple construction
 func (e *Elaborator) normalizeTuple(tuple *ast.Tuple) (core.CoreExpr, error) {
 	var elements []core.CoreExpr
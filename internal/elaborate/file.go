@@ -12,13 +12,8 @@ func (e *Elaborator) Elaborate(prog *ast.Program) (*core.Program, error) {
 	// Check new File structure first (for REPL and bare expressions)
 	if prog.File != nil && prog.File.Module == nil && len(prog.File.Statements) > 0 {
 		// First, process type declarations to register constructors
-		for _, stmt := range prog.File.Statements {
-			if typeDecl, ok := stmt.(*ast.TypeDecl); ok {
-				_, err := e.elaborateTypeDecl(typeDecl)
-				if err != nil {
-					return nil, fmt.Errorf("failed to process type declaration %s: %w", typeDecl.Name, err)
-				}
-			}
+		if err := e.registerTypeDecls(prog.File.Statements); err != nil {
+			return nil, err
 		}
 
 		// Process bare expressions from REPL
@@ -44,6 +39,10 @@ func (e *Elaborator) Elaborate(prog *ast.Program) (*core.Program, error) {
 		return &core.Program{Meta: make(map[string]*core.DeclMeta)}, nil
 	}
 
+	if err := e.registerTypeDecls(prog.Module.Decls); err != nil {
+		return nil, err
+	}
+
 	var coreDecls []core.CoreExpr
 	for _, decl := range prog.Module.Decls {
 		coreExpr, err := e.elaborateNode(decl)
@@ -66,15 +65,10 @@ func (e *Elaborator) ElaborateExpr(expr ast.Expr) (core.CoreExpr, error) {
 // ElaborateFile transforms a complete file with module structure to Core ANF
 func (e *Elaborator) ElaborateFile(file *ast.File) (*core.Program, error) {
 	// For REPL/simple cases without module or funcs
-	if file.Module == nil || (len(file.Imports) == 0 && len(file.Funcs) == 0) {
+	if file.Module == nil || (len(file.Imports) == 0 && len(file.Funcs) == 0 && len(file.Consts) == 0) {
 		// First, process type declarations to register constructors
-		for _, stmt := range file.Statements {
-			if typeDecl, ok := stmt.(*ast.TypeDecl); ok {
-				_, err := e.elaborateTypeDecl(typeDecl)
-				if err != nil {
-					return nil, fmt.Errorf("failed to process type declaration %s: %w", typeDecl.Name, err)
-				}
-			}
+		if err := e.registerTypeDecls(file.Statements); err != nil {
+			return nil, err
 		}
 
 		// Then elaborate statements as expressions
@@ -93,13 +87,14 @@ func (e *Elaborator) ElaborateFile(file *ast.File) (*core.Program, error) {
 
 	// First, process type declarations to register constructors
 	// This must happen before function elaboration so constructors are available
-	for _, decl := range file.Decls {
-		if typeDecl, ok := decl.(*ast.TypeDecl); ok {
-			_, err := e.elaborateTypeDecl(typeDecl)
-			if err != nil {
-				return nil, fmt.Errorf("failed to process type declaration %s: %w", typeDecl.Name, err)
-			}
-		}
+	if err := e.registerTypeDecls(file.Decls); err != nil {
+		return nil, err
+	}
+
+	// Record-style constructors get synthesized field accessor functions
+	file, err := withFieldAccessors(file)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build symbol table and imports map
@@ -147,7 +142,23 @@ func (e *Elaborator) ElaborateFile(file *ast.File) (*core.Program, error) {
 	var coreDecls []core.CoreExpr
 	meta := make(map[string]*core.DeclMeta)
 	for _, scc := range sccs {
-		if len(scc) == 1 && !isSelfRecursive(scc[0], symbols) {
+		if f := symbols[scc[0]]; len(scc) == 1 && f.Const != nil && !isSelfRecursive(f.Name, symbols) {
+			// Module value → Let, evaluated once on first use
+			value, err := e.elaborateExpr(f.Body)
+			if err != nil {
+				return nil, err
+			}
+			coreDecls = append(coreDecls, &core.Let{
+				CoreNode: e.makeNodeFromFunc(f),
+				Name:     f.Name,
+				Value:    value,
+				Body: &core.Var{
+					CoreNode: e.makeNodeFromFunc(f),
+					Name:     f.Name,
+				},
+			})
+			meta[f.Name] = &core.DeclMeta{Name: f.Name, IsExport: f.IsExport}
+		} else if len(scc) == 1 && !isSelfRecursive(scc[0], symbols) {
 			// Single non-recursive function → Let
 			f := symbols[scc[0]]
 			lambda, err := e.funcToLambda(f)
@@ -166,18 +177,25 @@ func (e *Elaborator) ElaborateFile(file *ast.File) (*core.Program, error) {
 			}
 			// Track metadata from original AST function
 			if astFunc := findASTFunc(file, f.Name); astFunc != nil {
-				meta[f.Name] = &core.DeclMeta{
-					Name:     f.Name,
-					IsExport: astFunc.IsExport,
-					IsPure:   astFunc.IsPure,
-				}
+				meta[f.Name] = declMetaFor(astFunc)
 			}
 			coreDecls = append(coreDecls, let)
 		} else {
-			// Mutual or self-recursive → LetRec
+			// Mutual or self-recursive → LetRec. Module values may take part:
+			// the runtime binds them lazily and reports a cycle that really
+			// needs a value before it exists (RT009)
 			var bindings []core.RecBinding
 			for _, fname := range scc {
 				f := symbols[fname]
+				if f.Const != nil {
+					value, err := e.elaborateExpr(f.Body)
+					if err != nil {
+						return nil, err
+					}
+					bindings = append(bindings, core.RecBinding{Name: f.Name, Value: value})
+					meta[f.Name] = &core.DeclMeta{Name: f.Name, IsExport: f.IsExport}
+					continue
+				}
 				lambda, err := e.funcToLambda(f)
 				if err != nil {
 					return nil, err
@@ -188,11 +206,10 @@ func (e *Elaborator) ElaborateFile(file *ast.File) (*core.Program, error) {
 				})
 				// Track metadata for each binding
 				if astFunc := findASTFunc(file, f.Name); astFunc != nil {
-					meta[f.Name] = &core.DeclMeta{
-						Name:     f.Name,
-						IsExport: astFunc.IsExport,
-						IsPure:   astFunc.IsPure,
+					if inline := astFunc.FindAnnotation("inline"); inline != nil {
+						return nil, fmt.Errorf("%s: @inline function %s is recursive; only non-recursive functions can be inlined", inline.Pos, f.Name)
 					}
+					meta[f.Name] = declMetaFor(astFunc)
 				}
 			}
 
@@ -224,6 +241,57 @@ func (e *Elaborator) ElaborateFile(file *ast.File) (*core.Program, error) {
 	return &core.Program{Decls: coreDecls, Meta: meta}, nil
 }
 
+// withFieldAccessors returns a shallow copy of file whose Funcs include the
+// accessors for record-style constructor fields (see ast.FieldAccessors).
+// The original file is left untouched.
+func withFieldAccessors(file *ast.File) (*ast.File, error) {
+	defined := make(map[string]bool)
+	for _, fn := range file.Funcs {
+		defined[fn.Name] = true
+	}
+
+	var accessors []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		typeDecl, ok := decl.(*ast.TypeDecl)
+		if !ok {
+			continue
+		}
+		for _, acc := range ast.FieldAccessors(typeDecl) {
+			if defined[acc.Name] {
+				return nil, fmt.Errorf("field accessor '%s' of type %s conflicts with another definition of '%s'", acc.Name, typeDecl.Name, acc.Name)
+			}
+			defined[acc.Name] = true
+			accessors = append(accessors, acc)
+		}
+	}
+	if len(accessors) == 0 {
+		return file, nil
+	}
+
+	augmented := *file
+	augmented.Funcs = append(append([]*ast.FuncDecl{}, file.Funcs...), accessors...)
+	return &augmented, nil
+}
+
+// declMetaFor builds the declaration metadata for an AST function
+func declMetaFor(fn *ast.FuncDecl) *core.DeclMeta {
+	meta := &core.DeclMeta{
+		Name:     fn.Name,
+		IsExport: fn.IsExport,
+		IsPure:   fn.IsPure,
+		Doc:      fn.Doc,
+	}
+	meta.IsInline = fn.FindAnnotation("inline") != nil
+	meta.IsEntry = fn.FindAnnotation("entry") != nil
+	if dep := fn.FindAnnotation("deprecated"); dep != nil {
+		meta.IsDeprecated = true
+		if len(dep.Args) > 0 {
+			meta.DeprecationMsg = dep.Args[0]
+		}
+	}
+	return meta
+}
+
 // findASTFunc finds the AST function declaration by name
 func findASTFunc(file *ast.File, name string) *ast.FuncDecl {
 	for _, fn := range file.Funcs {
@@ -256,14 +324,87 @@ func astFuncToSig(f *ast.FuncDecl) *FuncSig {
 	}
 }
 
+// constToSig converts a top-level value declaration to a FuncSig so that it
+// takes part in dependency ordering alongside functions
+func constToSig(c *ast.ConstDecl) *FuncSig {
+	return &FuncSig{
+		Name:     c.Name,
+		Body:     c.Value,
+		IsExport: c.IsExport,
+		Const:    c,
+	}
+}
+
 func collectFuncSigs(file *ast.File) []*FuncSig {
 	var funcs []*FuncSig
 	for _, f := range file.Funcs {
 		funcs = append(funcs, astFuncToSig(f))
 	}
+	for _, c := range file.Consts {
+		funcs = append(funcs, constToSig(c))
+	}
+	for _, f := range file.Funcs {
+		for i, tc := range f.Tests {
+			funcs = append(funcs, constToSig(testValue(f.Name, i, tc)))
+		}
+		for i, prop := range f.Properties {
+			funcs = append(funcs, constToSig(propertyValue(f.Name, i, prop)))
+		}
+	}
 	return funcs
 }
 
+// TestBindingName names the module value holding the i-th (0-based) inline
+// test of function fn. The $ keeps it apart from user identifiers.
+func TestBindingName(fn string, i int) string {
+	return fmt.Sprintf("%s$test%d", fn, i+1)
+}
+
+// testValue turns an inline test into a private module value, computed only
+// when a test runner asks for it
+func testValue(fn string, i int, tc *ast.TestCase) *ast.ConstDecl {
+	return &ast.ConstDecl{
+		Name:  TestBindingName(fn, i),
+		Value: assertion(tc.Expr, tc.Pos),
+		Pos:   tc.Pos,
+	}
+}
+
+// assertion wraps a test expression in an if, which makes the type checker
+// require a bool
+func assertion(expr ast.Expr, pos ast.Pos) ast.Expr {
+	return &ast.If{
+		Condition: expr,
+		Then:      &ast.Literal{Kind: ast.BoolLit, Value: true, Pos: pos},
+		Else:      &ast.Literal{Kind: ast.BoolLit, Value: false, Pos: pos},
+		Pos:       pos,
+	}
+}
+
+// PropertyBindingName names the module value holding the i-th (0-based)
+// property of function fn
+func PropertyBindingName(fn string, i int) string {
+	return fmt.Sprintf("%s$prop%d", fn, i+1)
+}
+
+// propertyValue turns a property into a private module value: a function of
+// its binders that returns whether the law holds for them
+func propertyValue(fn string, i int, prop *ast.Property) *ast.ConstDecl {
+	params := make([]*ast.Param, len(prop.Binders))
+	for j, b := range prop.Binders {
+		params[j] = &ast.Param{Name: b.Name, Type: b.Type, Pos: b.Pos}
+	}
+	return &ast.ConstDecl{
+		Name: PropertyBindingName(fn, i),
+		Value: &ast.Lambda{
+			Params: params,
+			Body:   assertion(prop.Expr, prop.Pos),
+			Pos:    prop.Pos,
+		},
+		Pos: prop.Pos,
+	}
+}
+
 // collectImports builds import name map
 func collectImports(file *ast.File) map[string]string {
 	imports := make(map[string]string)
@@ -286,7 +427,7 @@ func isSelfRecursive(fname string, symbols map[string]*FuncSig) bool {
 		return false
 	}
 
-	refs := findReferences(f.Body)
+	refs := funcReferences(f)
 	for _, ref := range refs {
 		if ref == fname {
 			return true
@@ -301,20 +442,65 @@ func (e *Elaborator) funcToLambda(f *FuncSig) (core.CoreExpr, error) {
 	if err != nil {
 		return nil, err
 	}
+	body, err = e.wrapWhere(f.FuncDecl, body)
+	if err != nil {
+		return nil, err
+	}
 
 	lambda := &core.Lambda{
 		CoreNode: e.makeNodeFromFunc(f),
 		Params:   f.Params,
 		Body:     body,
 	}
+	if f.FuncDecl != nil {
+		e.recordParamTypes(lambda.ID(), f.FuncDecl.Params)
+		if f.FuncDecl.ReturnType != nil {
+			e.returnAnnots[lambda.ID()] = f.FuncDecl.ReturnType
+		}
+	}
 
 	// TODO: Preserve metadata (pure, export, tests, props) in CoreNode.Meta
 
 	return lambda, nil
 }
 
+// wrapWhere binds the where helpers of fn around its elaborated body in a
+// single letrec, so helpers can call each other and see fn's parameters
+// while staying out of scope everywhere else
+func (e *Elaborator) wrapWhere(fn *ast.FuncDecl, body core.CoreExpr) (core.CoreExpr, error) {
+	if fn == nil || len(fn.Where) == 0 {
+		return body, nil
+	}
+
+	bindings := make([]core.RecBinding, len(fn.Where))
+	for i, helper := range fn.Where {
+		value, err := e.normalizeLambda(&ast.Lambda{
+			Params:  helper.Params,
+			Body:    e.desugar(helper.Body),
+			Effects: helper.Effects,
+			Pos:     helper.Pos,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("where helper %s of %s: %w", helper.Name, fn.Name, err)
+		}
+		if helper.ReturnType != nil {
+			e.returnAnnots[value.ID()] = helper.ReturnType
+		}
+		bindings[i] = core.RecBinding{Name: helper.Name, Value: value}
+	}
+
+	return &core.LetRec{
+		CoreNode: e.makeNode(fn.Where[0].Position()),
+		Bindings: bindings,
+		Body:     body,
+	}, nil
+}
+
 // makeNodeFromFunc creates CoreNode from FuncSig
 func (e *Elaborator) makeNodeFromFunc(f *FuncSig) core.CoreNode {
+	if f.Const != nil {
+		return e.makeNode(f.Const.Position())
+	}
 	pos := f.FuncDecl.Position()
 	return e.makeNode(pos)
 }
@@ -326,6 +512,17 @@ func (e *Elaborator) elaborateNode(node ast.Node) (core.CoreExpr, error) {
 		return e.elaborateExpr(n)
 	case *ast.FuncDecl:
 		return e.elaborateFuncDecl(n)
+	case *ast.ConstDecl:
+		value, err := e.elaborateExpr(n.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &core.Let{
+			CoreNode: e.makeNode(n.Position()),
+			Name:     n.Name,
+			Value:    value,
+			Body:     &core.Var{CoreNode: e.makeNode(n.Position()), Name: n.Name},
+		}, nil
 	case *ast.TypeDecl:
 		// Type declarations don't produce Core expressions
 		// They register constructors for use in expressions
@@ -335,6 +532,20 @@ func (e *Elaborator) elaborateNode(node ast.Node) (core.CoreExpr, error) {
 	}
 }
 
+// registerTypeDecls registers the constructors of every type declaration
+// among nodes before any expression is elaborated, so that a constructor can
+// be used textually before the type that declares it
+func (e *Elaborator) registerTypeDecls(nodes []ast.Node) error {
+	for _, node := range nodes {
+		if typeDecl, ok := node.(*ast.TypeDecl); ok {
+			if _, err := e.elaborateTypeDecl(typeDecl); err != nil {
+				return fmt.Errorf("failed to process type declaration %s: %w", typeDecl.Name, err)
+			}
+		}
+	}
+	return e.checkAliasCycles()
+}
+
 // elaborateTypeDecl processes a type declaration and registers its constructors
 // Type declarations don't produce Core expressions - they have side effects:
 // 1. Register constructors in the elaborator's constructor map
@@ -347,9 +558,18 @@ func (e *Elaborator) elaborateTypeDecl(decl *ast.TypeDecl) (core.CoreExpr, error
 	switch def := decl.Definition.(type) {
 	case *ast.AlgebraicType:
 		// Process each constructor in the ADT
-		for _, ctor := range def.Constructors {
+		for i, ctor := range def.Constructors {
 			// Register constructor in elaborator's map
 			e.RegisterConstructor(typeName, ctor.Name, len(ctor.Fields), false)
+			e.constructors[ctor.Name].FieldTypes = ctor.Fields
+			e.constructors[ctor.Name].TypeParams = decl.TypeParams
+			e.constructors[ctor.Name].Index = i
+			if ctor.HasNamedFields() {
+				e.RegisterConstructorFields(ctor.Name, ctor.FieldNames)
+			}
+		}
+		if decl.IsNewtype() {
+			e.RegisterNewtype(typeName, def.Constructors[0].Name)
 		}
 		// Type declarations don't produce code, return nil
 		return nil, nil
@@ -359,6 +579,11 @@ func (e *Elaborator) elaborateTypeDecl(decl *ast.TypeDecl) (core.CoreExpr, error
 		// TODO: Handle record type declarations if needed
 		return nil, nil
 
+	case *ast.TypeAlias:
+		// Aliases are transparent: the type checker expands them
+		e.typeAliases[typeName] = decl
+		return nil, nil
+
 	default:
 		return nil, fmt.Errorf("unknown type definition: %T", def)
 	}
@@ -377,6 +602,11 @@ func (e *Elaborator) elaborateFuncDecl(fn *ast.FuncDecl) (core.CoreExpr, error)
 	if err != nil {
 		return nil, err
 	}
+	if lam, ok := value.(*core.Lambda); ok {
+		if lam.Body, err = e.wrapWhere(fn, lam.Body); err != nil {
+			return nil, err
+		}
+	}
 
 	// Wrap in let rec if recursive
 	return &core.LetRec{
@@ -0,0 +1,70 @@
+package elaborate
+
+import (
+	"fmt"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// handledOps are the effect operations a handle expression can intercept, in
+// the order _io_handle takes their handlers. Each builtin is also the handler
+// used for an operation the expression leaves alone.
+var handledOps = []struct{ name, builtin string }{
+	{"IO.print", "_io_print"},
+	{"IO.println", "_io_println"},
+}
+
+// normalizeHandle handles handle { body } with { IO.println(s) => h, ... },
+// which desugars to a call of the _io_handle builtin with one function per
+// handled operation and the body as a thunk:
+//
+//	_io_handle(\s. _io_print(s), \s. h, func() => body)
+//
+// A handler runs with its handle expression removed, so the builtin passes
+// an operation on to the enclosing handlers.
+func (e *Elaborator) normalizeHandle(h *ast.Handle) (core.CoreExpr, error) {
+	arms := make(map[string]*ast.Handler, len(h.Handlers))
+	for _, arm := range h.Handlers {
+		name := arm.Effect + "." + arm.Op
+		known := false
+		for _, op := range handledOps {
+			known = known || op.name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("%s: handle: cannot intercept %s; only IO.print and IO.println can be handled", arm.Pos, name)
+		}
+		if arms[name] != nil {
+			return nil, fmt.Errorf("%s: handle: %s is handled twice", arm.Pos, name)
+		}
+		arms[name] = arm
+	}
+
+	args := make([]ast.Expr, 0, len(handledOps)+1)
+	for _, op := range handledOps {
+		arm, ok := arms[op.name]
+		if !ok {
+			arm = &ast.Handler{
+				Param: "s",
+				Body: &ast.FuncCall{
+					Func: &ast.Identifier{Name: op.builtin, Pos: h.HandlersPos},
+					Args: []ast.Expr{&ast.Identifier{Name: "s", Pos: h.HandlersPos}},
+					Pos:  h.HandlersPos,
+				},
+				Pos: h.HandlersPos,
+			}
+		}
+		args = append(args, &ast.Lambda{
+			Params: []*ast.Param{{Name: arm.Param, Pos: arm.Pos}},
+			Body:   arm.Body,
+			Pos:    arm.Pos,
+		})
+	}
+	args = append(args, &ast.Lambda{Params: []*ast.Param{}, Body: h.Body, Pos: h.Pos})
+
+	return e.normalize(&ast.FuncCall{
+		Func: &ast.Identifier{Name: "_io_handle", Pos: h.Pos},
+		Args: args,
+		Pos:  h.Pos,
+	})
+}
@@ -54,6 +54,14 @@ func (e *Elaborator) normalizeMatch(match *ast.Match) (core.CoreExpr, error) {
 
 	// Check exhaustiveness (without type info, use simple heuristic)
 	// For now, assume Bool type if we see boolean literals
+	pos := match.Position()
+	// Module elaborators are keyed by module path, so prefer the span's file
+	file := pos.File
+	if file == "" {
+		file = e.filePath
+	}
+	location := fmt.Sprintf("%s:%d:%d", file, pos.Line, pos.Column)
+	warned := false
 	scrutineeType := e.inferScrutineeType(arms)
 	if scrutineeType != nil {
 		exhaustive, missing := e.exChecker.CheckExhaustiveness(result, scrutineeType)
@@ -61,11 +69,24 @@ func (e *Elaborator) normalizeMatch(match *ast.Match) (core.CoreExpr, error) {
 
 		if !exhaustive {
 			// Add warning with source location
-			pos := match.Position()
-			location := fmt.Sprintf("%s:%d:%d", e.filePath, pos.Line, pos.Column)
 			e.warnings = append(e.warnings, &ExhaustivenessWarning{
 				Location:       location,
+				Pos:            pos,
+				MissingPattern: missing,
+			})
+			warned = true
+		}
+	}
+
+	// Guarded arms need an unguarded arm to fall through to, whatever the type
+	if !warned {
+		if missing := e.exChecker.CheckGuards(result); len(missing) > 0 {
+			result.Exhaustive = false
+			e.warnings = append(e.warnings, &ExhaustivenessWarning{
+				Location:       location,
+				Pos:            pos,
 				MissingPattern: missing,
+				Guarded:        true,
 			})
 		}
 	}
@@ -77,11 +98,40 @@ func (e *Elaborator) normalizeMatch(match *ast.Match) (core.CoreExpr, error) {
 func (e *Elaborator) elaboratePattern(pat ast.Pattern) (core.CorePattern, error) {
 	switch p := pat.(type) {
 	case *ast.Identifier:
+		// `_` lexes as an identifier but binds nothing
+		if p.Name == "_" {
+			return &core.WildcardPattern{}, nil
+		}
+		// A bare nullary constructor (e.g., None) matches that constructor only
+		if e.isNullaryConstructor(p.Name) {
+			return &core.ConstructorPattern{Name: p.Name}, nil
+		}
 		return &core.VarPattern{Name: p.Name}, nil
 	case *ast.Literal:
 		return &core.LitPattern{Value: p.Value}, nil
 	case *ast.WildcardPattern:
 		return &core.WildcardPattern{}, nil
+	case *ast.AsPattern:
+		inner, err := e.elaboratePattern(p.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		return &core.AsPattern{
+			Name:    p.Name,
+			Pattern: inner,
+		}, nil
+	case *ast.OrPattern:
+		alternatives := make([]core.CorePattern, len(p.Alternatives))
+		for i, altPat := range p.Alternatives {
+			coreAlt, err := e.elaboratePattern(altPat)
+			if err != nil {
+				return nil, err
+			}
+			alternatives[i] = coreAlt
+		}
+		return &core.OrPattern{
+			Alternatives: alternatives,
+		}, nil
 	case *ast.ConstructorPattern:
 		// Elaborate nested patterns
 		var args []core.CorePattern
@@ -92,9 +142,11 @@ func (e *Elaborator) elaboratePattern(pat ast.Pattern) (core.CorePattern, error)
 			}
 			args = append(args, coreArg)
 		}
+		ctorInfo, known := e.constructors[p.Name]
 		return &core.ConstructorPattern{
-			Name: p.Name,
-			Args: args,
+			Name:    p.Name,
+			Args:    args,
+			Newtype: known && ctorInfo.Newtype,
 		}, nil
 	case *ast.TuplePattern:
 		// Elaborate tuple element patterns
@@ -139,12 +191,26 @@ func (e *Elaborator) elaboratePattern(pat ast.Pattern) (core.CorePattern, error)
 	}
 }
 
+// isNullaryConstructor reports whether name refers to a constructor without
+// fields, declared in this module or imported from another one
+func (e *Elaborator) isNullaryConstructor(name string) bool {
+	if info, ok := e.constructors[name]; ok {
+		return info.Arity == 0
+	}
+	ref, ok := e.globalEnv[name]
+	return ok && ref.Module == "$adt"
+}
+
 // inferScrutineeType attempts to infer the type of a scrutinee from its patterns
 // This is a simple heuristic - returns Bool if we see boolean literals
 func (e *Elaborator) inferScrutineeType(arms []core.MatchArm) types.Type {
 	// Look at patterns to infer type
 	for _, arm := range arms {
-		if litPat, ok := arm.Pattern.(*core.LitPattern); ok {
+		pattern := arm.Pattern
+		if orPat, ok := pattern.(*core.OrPattern); ok && len(orPat.Alternatives) > 0 {
+			pattern = orPat.Alternatives[0]
+		}
+		if litPat, ok := pattern.(*core.LitPattern); ok {
 			switch litPat.Value.(type) {
 			case bool:
 				return &types.TCon{Name: "Bool"}
@@ -15,7 +15,8 @@ type FuncSig struct {
 	IsExport bool
 	Tests    []*ast.TestCase
 	Props    []*ast.Property
-	FuncDecl *ast.FuncDecl // Original declaration
+	FuncDecl *ast.FuncDecl  // Original declaration
+	Const    *ast.ConstDecl // Original declaration of a module value (nil for functions)
 }
 
 // CallGraph represents a dependency graph between functions
@@ -115,7 +116,7 @@ func BuildCallGraph(funcs []*FuncSig, symbols map[string]*FuncSig, imports map[s
 
 	// Analyze each function body for calls
 	for _, f := range funcs {
-		refs := findReferences(f.Body)
+		refs := funcReferences(f)
 		for _, ref := range refs {
 			// Only add edge if reference is to a local function
 			if _, isLocal := symbols[ref]; isLocal {
@@ -130,6 +131,28 @@ func BuildCallGraph(funcs []*FuncSig, symbols map[string]*FuncSig, imports map[s
 	return graph
 }
 
+// funcReferences finds the references made by a function body and its where
+// helpers. Helper names are local to the function, so they are left out.
+func funcReferences(f *FuncSig) []string {
+	refs := findReferences(f.Body)
+	if f.FuncDecl == nil || len(f.FuncDecl.Where) == 0 {
+		return refs
+	}
+
+	local := make(map[string]bool, len(f.FuncDecl.Where))
+	for _, helper := range f.FuncDecl.Where {
+		local[helper.Name] = true
+		refs = append(refs, findReferences(helper.Body)...)
+	}
+	external := refs[:0]
+	for _, ref := range refs {
+		if !local[ref] {
+			external = append(external, ref)
+		}
+	}
+	return external
+}
+
 // findReferences finds all identifier references in an expression
 func findReferences(expr ast.Expr) []string {
 	var refs []string
@@ -195,6 +218,12 @@ func findReferences(expr ast.Expr) []string {
 			refs = append(refs, findReferences(c.Body)...)
 		}
 
+	case *ast.Handle:
+		refs = append(refs, findReferences(ex.Body)...)
+		for _, h := range ex.Handlers {
+			refs = append(refs, findReferences(h.Body)...)
+		}
+
 	case *ast.Tuple:
 		for _, elem := range ex.Elements {
 			refs = append(refs, findReferences(elem)...)
@@ -37,7 +37,7 @@ func (v *anfVerifier) verifyExpr(expr core.CoreExpr, topLevel bool) error {
 
 	switch e := expr.(type) {
 	// Atomic expressions - always valid
-	case *core.Var, *core.Lit, *core.Lambda, *core.DictRef:
+	case *core.Var, *core.VarGlobal, *core.Lit, *core.Lambda, *core.DictRef:
 		return nil
 
 	// Let bindings - value can be complex, body must be verified
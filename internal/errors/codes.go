@@ -84,6 +84,9 @@ const (
 	// MOD012 indicates implicit module (file without module declaration)
 	MOD012 = "MOD012"
 
+	// MOD013 indicates a reference to a @deprecated import (warning)
+	MOD013 = "MOD013"
+
 	// ============================================================================
 	// Loader Errors (LDR###)
 	// ============================================================================
@@ -165,6 +168,11 @@ const (
 	// TC010 indicates missing type class instance
 	TC010 = "TC010"
 
+	// TypeAliasCycle indicates type aliases that expand to themselves
+	// (type A = B; type B = A). Like RT_USER_ERROR it is a named code
+	// outside the TC### registry.
+	TypeAliasCycle = "TYPE_ALIAS_CYCLE"
+
 	// ============================================================================
 	// Elaboration Errors (ELB###) - Already defined in json_encoder.go
 	// ============================================================================
@@ -176,6 +184,13 @@ const (
 	// ELB006 indicates failed ANF normalization
 	ELB006 = "ELB006"
 
+	// ELB007 indicates an effectful builtin referenced under --require-pure
+	ELB007 = "ELB007"
+
+	// EXHAUST003 indicates guarded match arms without a fall-through arm.
+	// It is a warning-only diagnostic code and is not in ErrorRegistry.
+	EXHAUST003 = "EXHAUST003"
+
 	// ============================================================================
 	// Linking Errors (LNK###) - Already defined in json_encoder.go
 	// ============================================================================
@@ -216,6 +231,16 @@ const (
 
 	// RT009 indicates value initialization cycle detected
 	RT009 = "RT009"
+
+	// RTUserError indicates the program aborted itself via error(msg).
+	// Like RT_DIV0 it is a named runtime code outside the RT### registry.
+	RTUserError = "RT_USER_ERROR"
+
+	// RTIntOverflow indicates an Int builtin overflowed under --int-overflow=checked
+	RTIntOverflow = "RT_INT_OVERFLOW"
+
+	// Unclassified marks failures that do not carry a structured code yet
+	Unclassified = "UNCLASSIFIED"
 )
 
 // ErrorInfo provides structured information about an error code
@@ -252,6 +277,7 @@ var ErrorRegistry = map[string]ErrorInfo{
 	MOD010: {MOD010, "module", "validation", "Module/path mismatch"},
 	MOD011: {MOD011, "module", "structure", "Multiple module declarations"},
 	MOD012: {MOD012, "module", "structure", "Implicit module warning"},
+	MOD013: {MOD013, "module", "deprecation", "Deprecated symbol referenced (warning)"},
 	MOD006: {MOD006, "module", "validation", "Export of private (underscore) name"},
 
 	// Loader errors
@@ -296,6 +322,7 @@ var ErrorRegistry = map[string]ErrorInfo{
 	ELB004: {ELB004, "elaborate", "pattern", "Non-exhaustive pattern"},
 	ELB005: {ELB005, "elaborate", "validation", "Invalid Core AST"},
 	ELB006: {ELB006, "elaborate", "normalize", "ANF normalization failed"},
+	ELB007: {ELB007, "elaborate", "purity", "Effectful builtin in required-pure program"},
 
 	// Linking errors
 	LNK001: {LNK001, "link", "instance", "Missing dictionary instance"},
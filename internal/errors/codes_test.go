@@ -119,7 +119,7 @@ func TestAllErrorCodesInRegistry(t *testing.T) {
 		// Type checking
 		TC001, TC002, TC003, TC004, TC005, TC006, TC007, TC008, TC009, TC010,
 		// Elaboration
-		ELB001, ELB002, ELB003, ELB004, ELB005, ELB006,
+		ELB001, ELB002, ELB003, ELB004, ELB005, ELB006, ELB007,
 		// Linking
 		LNK001, LNK002, LNK003, LNK004, LNK005,
 		// Evaluation
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/sunholo/ailang/internal/ast"
+)
+
+// Diagnostic severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Diagnostic is one problem found while checking a file, in the shape editors
+// and CI tools consume: a code, a message, a severity and (when known) a span.
+type Diagnostic struct {
+	Code     string    `json:"code"`
+	Message  string    `json:"message"`
+	Span     *ast.Span `json:"span,omitempty"`
+	Severity string    `json:"severity"`
+}
+
+var (
+	// A leading structured code, as in "MOD010: module declaration ..."
+	codePrefix = regexp.MustCompile(`^([A-Z][A-Z0-9_]*[0-9]): `)
+	// The first file position in a message, as in "... at foo.ail:12:5"
+	filePos = regexp.MustCompile(`([^\s:()\[\]]+\.ail):([0-9]+):([0-9]+)`)
+)
+
+// Diagnostic converts the report to an error diagnostic. Reports without a
+// span fall back to the first file position mentioned in the message.
+func (r *Report) Diagnostic() Diagnostic {
+	span := r.Span
+	if span == nil {
+		span = spanFromMessage(r.Message)
+	}
+	return Diagnostic{Code: r.Code, Message: r.Message, Span: span, Severity: SeverityError}
+}
+
+// DiagnosticFromError converts any error to an error diagnostic. Structured
+// reports keep their code and span. Plain errors take the code from a leading
+// "CODE: " prefix (or Unclassified) and the span from the message.
+func DiagnosticFromError(err error) Diagnostic {
+	if rep, ok := AsReport(err); ok {
+		return rep.Diagnostic()
+	}
+
+	msg := err.Error()
+	code := Unclassified
+	if m := codePrefix.FindStringSubmatch(msg); m != nil {
+		code = m[1]
+	}
+	return Diagnostic{Code: code, Message: msg, Span: spanFromMessage(msg), Severity: SeverityError}
+}
+
+// WarningDiagnostic builds a warning diagnostic at pos (no span if pos is unset)
+func WarningDiagnostic(code, message string, pos ast.Pos) Diagnostic {
+	return Diagnostic{Code: code, Message: message, Span: SpanAt(pos), Severity: SeverityWarning}
+}
+
+func spanFromMessage(msg string) *ast.Span {
+	m := filePos.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	line, _ := strconv.Atoi(m[2])
+	col, _ := strconv.Atoi(m[3])
+	pos := ast.Pos{File: m[1], Line: line, Column: col}
+	return &ast.Span{Start: pos, End: pos}
+}
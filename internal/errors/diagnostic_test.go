@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+)
+
+func TestDiagnosticFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     string
+		spanFile string
+		spanLine int
+	}{
+		{"code_prefix", fmt.Errorf("MOD010: module declaration 'a' doesn't match canonical path 'b'"), MOD010, "", 0},
+		{"position_in_message", fmt.Errorf("type error in m (decl 2): undefined variable: g at src/m.ail:8:16"), Unclassified, "src/m.ail", 8},
+		{"plain", fmt.Errorf("something went wrong"), Unclassified, "", 0},
+		{"wrapped_report", fmt.Errorf("loading: %w", WrapReport(&Report{
+			Code:    LDR001,
+			Message: "module not found: foo",
+			Span:    &ast.Span{Start: ast.Pos{File: "x.ail", Line: 3, Column: 1}},
+		})), LDR001, "x.ail", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DiagnosticFromError(tt.err)
+			if d.Code != tt.code {
+				t.Errorf("code = %s, want %s", d.Code, tt.code)
+			}
+			if d.Severity != SeverityError {
+				t.Errorf("severity = %s, want %s", d.Severity, SeverityError)
+			}
+			switch {
+			case tt.spanLine == 0 && d.Span != nil:
+				t.Errorf("expected no span, got %+v", d.Span)
+			case tt.spanLine > 0 && (d.Span == nil || d.Span.Start.File != tt.spanFile || d.Span.Start.Line != tt.spanLine):
+				t.Errorf("span = %+v, want %s:%d", d.Span, tt.spanFile, tt.spanLine)
+			}
+		})
+	}
+}
+
+func TestWarningDiagnostic(t *testing.T) {
+	d := WarningDiagnostic(ELB004, "non-exhaustive match", ast.Pos{File: "m.ail", Line: 3, Column: 33})
+	if d.Severity != SeverityWarning || d.Code != ELB004 {
+		t.Errorf("got %+v", d)
+	}
+	if d.Span == nil || d.Span.Start.Column != 33 {
+		t.Errorf("span = %+v, want m.ail:3:33", d.Span)
+	}
+	if d := WarningDiagnostic(MOD013, "deprecated", ast.Pos{}); d.Span != nil {
+		t.Errorf("expected no span for an unset position, got %+v", d.Span)
+	}
+}
+
+// testWarning is a minimal Warning implementation
+type testWarning struct{ pos ast.Pos }
+
+func (w testWarning) Code() string     { return ELB004 }
+func (w testWarning) Message() string  { return "non-exhaustive match" }
+func (w testWarning) Span() *ast.Span  { return SpanAt(w.pos) }
+func (w testWarning) Severity() string { return SeverityWarning }
+func (w testWarning) String() string   { return "warning: " + w.Message() }
+
+func TestDiagnosticFromWarning(t *testing.T) {
+	d := DiagnosticFromWarning(testWarning{pos: ast.Pos{File: "m.ail", Line: 3, Column: 33}})
+	want := WarningDiagnostic(ELB004, "non-exhaustive match", ast.Pos{File: "m.ail", Line: 3, Column: 33})
+	if d.Code != want.Code || d.Message != want.Message || d.Severity != want.Severity || *d.Span != *want.Span {
+		t.Errorf("got %+v, want %+v", d, want)
+	}
+	if d := DiagnosticFromWarning(testWarning{}); d.Span != nil {
+		t.Errorf("expected no span for an unset position, got %+v", d.Span)
+	}
+}
@@ -33,6 +33,13 @@ func (e *ReportError) Error() string {
 	return e.Rep.Code + ": " + e.Rep.Message
 }
 
+// Reporter is an error that can describe itself as a Report (such as a
+// parser error), without being wrapped in a ReportError
+type Reporter interface {
+	error
+	Report() *Report
+}
+
 // AsReport attempts to extract a Report from an error chain
 // Returns the Report and true if found, nil and false otherwise
 func AsReport(err error) (*Report, bool) {
@@ -40,6 +47,10 @@ func AsReport(err error) (*Report, bool) {
 	if errors.As(err, &re) {
 		return re.Rep, true
 	}
+	var r Reporter
+	if errors.As(err, &r) {
+		return r.Report(), true
+	}
 	return nil, false
 }
 
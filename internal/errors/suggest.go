@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ClosestName returns the candidate nearest to name by edit distance, or ""
+// if none is close enough to be a likely typo. Names shorter than six
+// characters allow one edit, longer ones one edit per three characters (at
+// most three), and a suggestion must share at least one character with
+// name, so x never suggests y. Ties go to the alphabetically first candidate. Internal
+// names (containing '$', or starting with '_' unless name does) are skipped.
+func ClosestName(name string, candidates []string) string {
+	limit := len(name) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 3 {
+		limit = 3
+	}
+
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	best, bestDist := "", limit+1
+	for _, c := range sorted {
+		if c == name || strings.Contains(c, "$") || (strings.HasPrefix(c, "_") && !strings.HasPrefix(name, "_")) {
+			continue
+		}
+		if d := editDistance(name, c); d < bestDist && d < len([]rune(name)) {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// DidYouMean renders the ClosestName suggestion as a message suffix, e.g.
+// " (did you mean 'food'?)", or "" if there is none
+func DidYouMean(name string, candidates []string) string {
+	if c := ClosestName(name, candidates); c != "" {
+		return fmt.Sprintf(" (did you mean '%s'?)", c)
+	}
+	return ""
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,31 @@
+package errors
+
+import "testing"
+
+func TestClosestName(t *testing.T) {
+	candidates := []string{"food", "foot", "total", "y", "println", "_io_println", "$adt.make_Box", "filterMap"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"fod", "food"},          // one edit; food sorts before foot
+		{"totl", "total"},        // one deletion
+		{"printn", "println"},    // internal _io_println is skipped
+		{"fltrMap", "filterMap"}, // two edits allowed for longer names
+		{"xyz", ""},              // nothing close
+		{"ttl", ""},              // two edits are too many for a short name
+		{"_io_printn", "_io_println"},
+	}
+	for _, tt := range tests {
+		if got := ClosestName(tt.name, candidates); got != tt.want {
+			t.Errorf("ClosestName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := DidYouMean("fod", candidates); got != " (did you mean 'food'?)" {
+		t.Errorf("DidYouMean: got %q", got)
+	}
+	if got := DidYouMean("xyz", candidates); got != "" {
+		t.Errorf("DidYouMean without a match: got %q", got)
+	}
+}
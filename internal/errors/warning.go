@@ -0,0 +1,27 @@
+package errors
+
+import "github.com/sunholo/ailang/internal/ast"
+
+// Warning is a problem that does not stop compilation. Every warning kind
+// (non-exhaustive matches, deprecated references, ...) implements it so that
+// they share one list in the pipeline result and one JSON representation.
+type Warning interface {
+	Code() string     // Structured code, e.g. ELB004
+	Message() string  // Human-readable text without the "warning: " prefix
+	Span() *ast.Span  // Source span, nil when unknown
+	Severity() string // SeverityWarning for all current kinds
+	String() string   // Message with the "warning: " prefix, for terminals
+}
+
+// DiagnosticFromWarning converts a warning to a diagnostic
+func DiagnosticFromWarning(w Warning) Diagnostic {
+	return Diagnostic{Code: w.Code(), Message: w.Message(), Span: w.Span(), Severity: w.Severity()}
+}
+
+// SpanAt returns a zero-width span at pos, or nil if pos is unset
+func SpanAt(pos ast.Pos) *ast.Span {
+	if pos.Line <= 0 {
+		return nil
+	}
+	return &ast.Span{Start: pos, End: pos}
+}
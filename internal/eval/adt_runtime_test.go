@@ -2,6 +2,8 @@ package eval
 
 import (
 	"testing"
+
+	"github.com/sunholo/ailang/internal/core"
 )
 
 // TestTaggedValue tests the TaggedValue runtime type
@@ -243,3 +245,67 @@ func TestGetField(t *testing.T) {
 		})
 	}
 }
+
+// TestTaggedValueNamedField tests field lookup on record-style constructor values
+func TestTaggedValueNamedField(t *testing.T) {
+	point := &TaggedValue{
+		TypeName:   "Point",
+		CtorName:   "Point",
+		Fields:     []Value{&IntValue{Value: 3}, &IntValue{Value: 4}},
+		FieldNames: []string{"x", "y"},
+	}
+	positional := &TaggedValue{
+		TypeName: "Pair",
+		CtorName: "Pair",
+		Fields:   []Value{&IntValue{Value: 1}, &IntValue{Value: 2}},
+	}
+
+	if v, ok := point.Field("y"); !ok || v.String() != "4" {
+		t.Errorf("Field(y) = %v, %v; want 4, true", v, ok)
+	}
+	if _, ok := point.Field("z"); ok {
+		t.Error("Field(z) should not be found")
+	}
+	if _, ok := positional.Field("x"); ok {
+		t.Error("positional constructor should have no named fields")
+	}
+}
+
+// TestRegisterConstructor tests that $adt references evaluate without a
+// resolver once their constructors are registered
+func TestRegisterConstructor(t *testing.T) {
+	e := NewCoreEvaluator()
+	e.RegisterConstructor(Constructor{TypeName: "Option", CtorName: "None", Index: 1})
+	e.RegisterConstructor(Constructor{TypeName: "Option", CtorName: "Some", Arity: 1})
+
+	ref := func(name string) *core.VarGlobal {
+		return &core.VarGlobal{Ref: core.GlobalRef{Module: "$adt", Name: name}}
+	}
+
+	none, err := e.Eval(ref("make_Option_None"))
+	if err != nil {
+		t.Fatalf("None: %v", err)
+	}
+	if tv, ok := none.(*TaggedValue); !ok || tv.CtorName != "None" || tv.CtorIndex != 1 {
+		t.Errorf("None: got %#v", none)
+	}
+	again, _ := e.Eval(ref("make_Option_None"))
+	if again != none {
+		t.Error("None: expected one shared value")
+	}
+
+	some, err := e.Eval(&core.App{
+		Func: ref("make_Option_Some"),
+		Args: []core.CoreExpr{&core.Lit{Kind: core.IntLit, Value: 42}},
+	})
+	if err != nil {
+		t.Fatalf("Some(42): %v", err)
+	}
+	if some.String() != "Some(42)" {
+		t.Errorf("Some(42): got %s", some)
+	}
+
+	if _, err := e.Eval(ref("make_Option_Nothing")); err == nil {
+		t.Error("expected an error for an unregistered constructor")
+	}
+}
@@ -2,10 +2,20 @@ package eval
 
 import "fmt"
 
+// CodedError is a runtime failure with a structured code such as RT_DIV0
+type CodedError struct {
+	Code    string
+	Message string
+}
+
+func (c *CodedError) Error() string {
+	return fmt.Sprintf("[%s] %s", c.Code, c.Message)
+}
+
 // NewRuntimeError creates a runtime error with structured information
 func NewRuntimeError(code, message string, pos interface{}) error {
 	// TODO: integrate with error encoder
-	return fmt.Errorf("[%s] %s", code, message)
+	return &CodedError{Code: code, Message: message}
 }
 
 // buildTypeMismatchError creates a detailed type mismatch error for builtin functions
@@ -38,4 +38,15 @@ func registerIOBuiltins() {
 			return &StringValue{Value: ""}, nil
 		},
 	}
+
+	// _io_readAll: read stdin until EOF (effectful: ! {IO})
+	// Implemented by the effect-aware spec in internal/builtins
+	Builtins["_io_readAll"] = &BuiltinFunc{
+		Name:    "_io_readAll",
+		NumArgs: 0,
+		IsPure:  false, // Effectful: IO
+		Impl: func() (*StringValue, error) {
+			return &StringValue{Value: ""}, nil
+		},
+	}
 }
@@ -3,6 +3,7 @@ package eval
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -344,10 +345,16 @@ func interfaceToJSON(v interface{}) (Value, error) {
 
 	case map[string]interface{}:
 		// JObject(List[{key: string, value: Json}]) constructor
-		// Preserve insertion order by extracting keys first
+		// The decoder's map has lost the source order, so the pairs are
+		// sorted by key: a program sees the same list on every run
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys) // Bytewise sort
 		kvPairs := make([]Value, 0, len(val))
-		for key, value := range val {
-			jsonValue, err := interfaceToJSON(value)
+		for _, key := range keys {
+			jsonValue, err := interfaceToJSON(val[key])
 			if err != nil {
 				return nil, fmt.Errorf("object field %q: %w", key, err)
 			}
@@ -0,0 +1,116 @@
+package eval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// Coverage records which branches of a program ran (--coverage). A branch
+// is one side of an if or one arm of a match. Branches are registered up
+// front with AddNode, so those never reached count towards the total and
+// code outside the registered programs (e.g. the stdlib) is not reported.
+type Coverage struct {
+	branches []Branch
+	index    map[branchKey]int // branch -> position in branches
+}
+
+// Branch is one if-branch or match arm and whether it ran
+type Branch struct {
+	NodeID uint64
+	Pos    ast.Pos
+	Kind   string // "then", "else" or "arm N" (1-based)
+	Hits   int
+}
+
+type branchKey struct {
+	node core.CoreExpr
+	arm  int
+}
+
+// NewCoverage creates an empty coverage record
+func NewCoverage() *Coverage {
+	return &Coverage{index: make(map[branchKey]int)}
+}
+
+// SetCoverage enables branch coverage recording. A nil record disables it.
+func (e *CoreEvaluator) SetCoverage(c *Coverage) {
+	e.coverage = c
+}
+
+// AddNode registers the branches of an if or match node; other nodes are
+// ignored, so it can be passed directly to a Core walker
+func (c *Coverage) AddNode(expr core.CoreExpr) {
+	switch n := expr.(type) {
+	case *core.If:
+		c.add(n, 0, "then")
+		c.add(n, 1, "else")
+	case *core.Match:
+		for i := range n.Arms {
+			c.add(n, i, fmt.Sprintf("arm %d", i+1))
+		}
+	}
+}
+
+func (c *Coverage) add(node core.CoreExpr, arm int, kind string) {
+	key := branchKey{node, arm}
+	if _, ok := c.index[key]; ok {
+		return
+	}
+	c.index[key] = len(c.branches)
+	c.branches = append(c.branches, Branch{NodeID: node.ID(), Pos: sourcePos(node), Kind: kind})
+}
+
+// hit records that a registered branch ran
+func (c *Coverage) hit(node core.CoreExpr, arm int) {
+	if i, ok := c.index[branchKey{node, arm}]; ok {
+		c.branches[i].Hits++
+	}
+}
+
+// Branches returns every registered branch ordered by source position
+func (c *Coverage) Branches() []Branch {
+	branches := append([]Branch(nil), c.branches...)
+	sort.SliceStable(branches, func(i, j int) bool {
+		a, b := branches[i].Pos, branches[j].Pos
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return branches
+}
+
+// Covered returns the number of branches that ran and the total
+func (c *Coverage) Covered() (covered, total int) {
+	for _, b := range c.branches {
+		if b.Hits > 0 {
+			covered++
+		}
+	}
+	return covered, len(c.branches)
+}
+
+// Summary renders covered vs. total branches followed by the branches that
+// never ran, one per line
+func (c *Coverage) Summary() string {
+	covered, total := c.Covered()
+	var sb strings.Builder
+	if total == 0 {
+		sb.WriteString("Coverage: no branches\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "Coverage: %d/%d branches (%.1f%%)\n", covered, total, 100*float64(covered)/float64(total))
+	for _, b := range c.Branches() {
+		if b.Hits == 0 {
+			fmt.Fprintf(&sb, "  not run: %s %s\n", b.Pos, b.Kind)
+		}
+	}
+	return sb.String()
+}
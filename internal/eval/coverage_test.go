@@ -0,0 +1,57 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// TestCoverage tests that --coverage counts the if-branches and match arms
+// that ran against all registered ones and lists the rest
+func TestCoverage(t *testing.T) {
+	at := func(col int) core.CoreNode {
+		return core.CoreNode{OrigSpan: ast.Pos{File: "t.ail", Line: 1, Column: col}}
+	}
+	lit := func(col int, v int64) core.CoreExpr {
+		return &core.Lit{CoreNode: at(col), Kind: core.IntLit, Value: v}
+	}
+	// if true then match 2 { 1 => 10, _ => 20 } else 0
+	match := &core.Match{
+		CoreNode:  at(14),
+		Scrutinee: lit(20, 2),
+		Arms: []core.MatchArm{
+			{Pattern: &core.LitPattern{Value: int64(1)}, Body: lit(29, 10)},
+			{Pattern: &core.WildcardPattern{}, Body: lit(38, 20)},
+		},
+	}
+	expr := &core.If{
+		CoreNode: at(1),
+		Cond:     &core.Lit{CoreNode: at(4), Kind: core.BoolLit, Value: true},
+		Then:     match,
+		Else:     lit(49, 0),
+	}
+
+	cov := NewCoverage()
+	cov.AddNode(expr)
+	cov.AddNode(match)
+	e := NewCoreEvaluator()
+	e.SetCoverage(cov)
+	if _, err := e.Eval(expr); err != nil {
+		t.Fatal(err)
+	}
+
+	if covered, total := cov.Covered(); covered != 2 || total != 4 {
+		t.Errorf("expected 2/4 branches covered, got %d/%d", covered, total)
+	}
+	want := "Coverage: 2/4 branches (50.0%)\n" +
+		"  not run: t.ail:1:1 else\n" +
+		"  not run: t.ail:1:14 arm 1\n"
+	if got := cov.Summary(); got != want {
+		t.Errorf("summary:\n%s\nwant:\n%s", got, want)
+	}
+	if !strings.Contains(NewCoverage().Summary(), "no branches") {
+		t.Errorf("expected an empty record to report no branches")
+	}
+}
@@ -111,7 +111,7 @@ func (e *CoreEvaluator) walkTree(scrutinee Value, tree dtree.DecisionTree, arms
 
 	case *dtree.FailNode:
 		// Reached a fail node - non-exhaustive match
-		return nil, fmt.Errorf("no pattern matched in match expression")
+		return nil, NewRuntimeError("RT_MATCH", "no pattern matched in match expression", nil)
 
 	default:
 		return nil, fmt.Errorf("unknown decision tree node type: %T", node)
@@ -2,7 +2,9 @@ package eval
 
 import (
 	"fmt"
+	"io"
 
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/types"
 )
@@ -16,11 +18,16 @@ type GlobalResolver interface {
 type CoreEvaluator struct {
 	env                   *Environment
 	registry              *types.DictionaryRegistry
-	resolver              GlobalResolver // Resolver for global references
-	experimentalBinopShim bool           // Feature flag for operator shim
-	effContext            interface{}    // Effect context (interface{} avoids import cycle with effects package)
-	recursionDepth        int            // Current recursion depth (for stack overflow detection)
-	maxRecursionDepth     int            // Maximum allowed recursion depth (default: 10,000)
+	resolver              GlobalResolver   // Resolver for global references
+	experimentalBinopShim bool             // Feature flag for operator shim
+	effContext            interface{}      // Effect context (interface{} avoids import cycle with effects package)
+	recursionDepth        int              // Current recursion depth (for stack overflow detection)
+	maxRecursionDepth     int              // Maximum allowed recursion depth (default: 10,000)
+	callStack             []Frame          // Active function calls (for runtime error traces)
+	evalTrace             io.Writer        // Destination for --trace-eval output (nil = disabled)
+	intOverflow           IntOverflow      // Int overflow behavior of arithmetic builtins (--int-overflow)
+	coverage              *Coverage        // Branch coverage record (--coverage, nil = disabled)
+	constructors          map[string]Value // $adt factories evaluated without the resolver, by name
 }
 
 // Env returns the current environment (for module evaluation)
@@ -67,6 +74,17 @@ func (e *CoreEvaluator) SetGlobalResolver(resolver GlobalResolver) {
 	e.resolver = resolver
 }
 
+// RegisterConstructor makes references to the constructor's $adt factory
+// (such as $adt.make_Option_None) evaluate without asking the resolver, so
+// ADT values work when no module resolver is set (single-file and REPL runs).
+// A nullary constructor evaluates to one shared TaggedValue.
+func (e *CoreEvaluator) RegisterConstructor(c Constructor) {
+	if e.constructors == nil {
+		e.constructors = make(map[string]Value)
+	}
+	e.constructors[fmt.Sprintf("make_%s_%s", c.TypeName, c.CtorName)] = c.Value()
+}
+
 // SetEffContext sets the effect context for this evaluator
 //
 // The effect context provides capability grants for effect operations.
@@ -121,6 +139,8 @@ func (e *CoreEvaluator) CallFunction(fn *FunctionValue, args []Value) (Value, er
 	// Evaluate body in new environment
 	oldEnv := e.env
 	e.env = newEnv
+	e.pushFrame(fn, ast.Pos{})
+	defer e.popFrame()
 
 	var result Value
 	var err error
@@ -167,6 +187,7 @@ func (e *CoreEvaluator) EvalLetRecBindings(letrec *core.LetRec) (map[string]Valu
 			if err != nil {
 				return nil, err
 			}
+			fv.Name = binding.Name
 			cells[binding.Name].Val = fv
 			cells[binding.Name].Init = true
 			bindings[binding.Name] = fv
@@ -210,6 +231,16 @@ func (e *CoreEvaluator) Eval(expr core.CoreExpr) (Value, error) {
 	return e.evalCore(expr)
 }
 
+// EvalIn evaluates an expression under the given environment and resolver,
+// restoring the evaluator's own afterwards. The module runtime uses it to
+// initialize a lazy top-level binding in the module that defines it.
+func (e *CoreEvaluator) EvalIn(env *Environment, resolver GlobalResolver, expr core.CoreExpr) (Value, error) {
+	oldEnv, oldResolver := e.env, e.resolver
+	e.env, e.resolver = env, resolver
+	defer func() { e.env, e.resolver = oldEnv, oldResolver }()
+	return e.evalCore(expr)
+}
+
 // EvalCoreProgram evaluates a Core program
 func (e *CoreEvaluator) EvalCoreProgram(prog *core.Program) (Value, error) {
 	var lastResult Value = &UnitValue{}
@@ -4,14 +4,28 @@ import (
 	"fmt"
 
 	"github.com/sunholo/ailang/internal/core"
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
 )
 
 // evalCore evaluates a Core expression
+// Failures are reported as *RuntimeError carrying the source position and call stack.
 func (e *CoreEvaluator) evalCore(expr core.CoreExpr) (Value, error) {
 	if expr == nil {
 		return &UnitValue{}, nil
 	}
+	if e.evalTrace != nil {
+		return e.evalCoreTraced(expr)
+	}
+
+	val, err := e.evalCoreNode(expr)
+	if err != nil {
+		return nil, e.runtimeError(err, expr)
+	}
+	return val, nil
+}
 
+// evalCoreNode dispatches on the Core node type
+func (e *CoreEvaluator) evalCoreNode(expr core.CoreExpr) (Value, error) {
 	switch n := expr.(type) {
 	case *core.Var:
 		return e.evalCoreVar(n)
@@ -82,7 +96,11 @@ func (e *CoreEvaluator) evalCore(expr core.CoreExpr) (Value, error) {
 func (e *CoreEvaluator) evalCoreVar(v *core.Var) (Value, error) {
 	val, ok := e.env.Get(v.Name)
 	if !ok {
-		return nil, fmt.Errorf("undefined variable: %s", v.Name)
+		var names []string
+		for name := range e.env.GetAllBindings() {
+			names = append(names, name)
+		}
+		return nil, fmt.Errorf("undefined variable '%s'%s", v.Name, ailangErrors.DidYouMean(v.Name, names))
 	}
 	// Force IndirectValue if needed (for LetRec recursion)
 	if iv, ok := val.(*IndirectValue); ok {
@@ -97,6 +115,14 @@ func (e *CoreEvaluator) evalCoreVar(v *core.Var) (Value, error) {
 
 // evalCoreVarGlobal evaluates a global variable reference
 func (e *CoreEvaluator) evalCoreVarGlobal(v *core.VarGlobal) (Value, error) {
+	if v.Ref.Module == "$adt" {
+		if val, ok := e.constructors[v.Ref.Name]; ok {
+			return val, nil
+		}
+	}
+	if v.Ref.Module == "$builtin" && v.Ref.Name == "try" {
+		return e.TryBuiltin(), nil
+	}
 	if e.resolver == nil {
 		return nil, fmt.Errorf("no resolver available to resolve global reference: %s.%s", v.Ref.Module, v.Ref.Name)
 	}
@@ -106,6 +132,11 @@ func (e *CoreEvaluator) evalCoreVarGlobal(v *core.VarGlobal) (Value, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve global %s.%s: %w", v.Ref.Module, v.Ref.Name, err)
 	}
+	if val == nil {
+		// Partial resolvers (such as the builtin-only one) return nil for
+		// names they do not know
+		return nil, fmt.Errorf("unresolved global reference: %s.%s", v.Ref.Module, v.Ref.Name)
+	}
 
 	return val, nil
 }
@@ -170,6 +201,13 @@ func (e *CoreEvaluator) evalCoreLet(let *core.Let) (Value, error) {
 		return nil, err
 	}
 
+	// Name closures bound directly to a lambda: let f = \x. ...
+	if fv, ok := val.(*FunctionValue); ok {
+		if _, isLam := let.Value.(*core.Lambda); isLam {
+			fv.Name = let.Name
+		}
+	}
+
 	// Create new environment with binding
 	newEnv := e.env.NewChildEnvironment()
 	newEnv.Set(let.Name, val)
@@ -208,6 +246,7 @@ func (e *CoreEvaluator) evalCoreLetRec(letrec *core.LetRec) (Value, error) {
 			if err != nil {
 				return nil, err
 			}
+			fv.Name = binding.Name
 			cells[binding.Name].Val = fv
 			cells[binding.Name].Init = true
 			continue
@@ -252,6 +291,15 @@ func (e *CoreEvaluator) evalCoreRecordAccess(access *core.RecordAccess) (Value,
 		return nil, err
 	}
 
+	// Record-style constructor values: Point { x: int } supports p.x
+	if tagged, ok := recordVal.(*TaggedValue); ok {
+		val, found := tagged.Field(access.Field)
+		if !found {
+			return nil, fmt.Errorf("constructor %s has no field: %s", tagged.CtorName, access.Field)
+		}
+		return val, nil
+	}
+
 	record, ok := recordVal.(*RecordValue)
 	if !ok {
 		return nil, fmt.Errorf("cannot access field of non-record value: %T", recordVal)
@@ -342,8 +390,14 @@ func (e *CoreEvaluator) evalCoreIf(ifExpr *core.If) (Value, error) {
 
 	// Evaluate appropriate branch
 	if boolVal.Value {
+		if e.coverage != nil {
+			e.coverage.hit(ifExpr, 0)
+		}
 		return e.evalCore(ifExpr.Then)
 	} else {
+		if e.coverage != nil {
+			e.coverage.hit(ifExpr, 1)
+		}
 		return e.evalCore(ifExpr.Else)
 	}
 }
@@ -3,6 +3,7 @@ package eval
 import (
 	"fmt"
 
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/core"
 )
 
@@ -32,9 +33,26 @@ func (e *CoreEvaluator) evalCoreApp(app *core.App) (Value, error) {
 		args = append(args, argVal)
 	}
 
-	// Apply function
+	return e.applyValue(fnVal, args, app.OriginalSpan())
+}
+
+// applyValue applies a function value to arguments with curried semantics:
+// fewer arguments than parameters return a closure expecting the rest, and
+// more apply the result to the remaining arguments
+func (e *CoreEvaluator) applyValue(fnVal Value, args []Value, site ast.Pos) (Value, error) {
 	switch fn := fnVal.(type) {
 	case *FunctionValue:
+		if len(args) < len(fn.Params) {
+			return partialApply(fn, args), nil
+		}
+		if len(args) > len(fn.Params) {
+			result, err := e.applyValue(fn, args[:len(fn.Params)], site)
+			if err != nil {
+				return nil, err
+			}
+			return e.applyValue(result, args[len(fn.Params):], site)
+		}
+
 		// Recursion depth guard
 		e.recursionDepth++
 		if e.recursionDepth > e.maxRecursionDepth {
@@ -43,9 +61,8 @@ func (e *CoreEvaluator) evalCoreApp(app *core.App) (Value, error) {
 		}
 		defer func() { e.recursionDepth-- }()
 
-		if len(args) != len(fn.Params) {
-			return nil, fmt.Errorf("function expects %d arguments, got %d", len(fn.Params), len(args))
-		}
+		e.pushFrame(fn, site)
+		defer e.popFrame()
 
 		// Create new environment with parameters bound
 		newEnv := fn.Env.Clone()
@@ -59,6 +76,7 @@ func (e *CoreEvaluator) evalCoreApp(app *core.App) (Value, error) {
 
 		// Body could be Core or TypedAST depending on origin
 		var result Value
+		var err error
 		if coreBody, ok := fn.Body.(core.CoreExpr); ok {
 			result, err = e.evalCore(coreBody)
 		} else {
@@ -76,6 +94,22 @@ func (e *CoreEvaluator) evalCoreApp(app *core.App) (Value, error) {
 	}
 }
 
+// partialApply binds the leading parameters of fn to args, returning a
+// closure over the same body that expects the remaining parameters
+func partialApply(fn *FunctionValue, args []Value) *FunctionValue {
+	env := fn.Env.Clone()
+	for i, arg := range args {
+		env.Set(fn.Params[i], arg)
+	}
+	return &FunctionValue{
+		Name:   fn.Name,
+		Params: fn.Params[len(args):],
+		Body:   fn.Body,
+		Env:    env,
+		Typed:  fn.Typed,
+	}
+}
+
 // evalCoreBinOp evaluates binary operation
 func (e *CoreEvaluator) evalCoreBinOp(binop *core.BinOp) (Value, error) {
 	// Evaluate operands
@@ -106,9 +140,16 @@ func (e *CoreEvaluator) evalCoreUnOp(unop *core.UnOp) (Value, error) {
 }
 
 // evalIntrinsic evaluates an intrinsic operation
-// This should typically be handled by OpLowering pass, but we provide
-// a fallback implementation using the experimental binop shim
+// This should typically be handled by OpLowering pass. Intrinsics that reach
+// the evaluator are run directly: the non-type-class operators are evaluated
+// in place, and arithmetic/comparison dispatch to the op_Type builtin chosen
+// by the operands' runtime types.
 func (e *CoreEvaluator) evalIntrinsic(intrinsic *core.Intrinsic) (Value, error) {
+	// Short-circuit && and || unless the shim asks for its eager semantics
+	if !e.experimentalBinopShim && (intrinsic.Op == core.OpAnd || intrinsic.Op == core.OpOr) {
+		return e.evalShortCircuit(intrinsic)
+	}
+
 	// Evaluate arguments
 	args := make([]Value, len(intrinsic.Args))
 	for i, arg := range intrinsic.Args {
@@ -174,14 +215,108 @@ func (e *CoreEvaluator) evalIntrinsic(intrinsic *core.Intrinsic) (Value, error)
 		}
 	}
 
-	return nil, fmt.Errorf("intrinsic operations require OpLowering pass or --experimental-binop-shim flag")
+	switch intrinsic.Op {
+	case core.OpConcat:
+		if len(args) == 2 {
+			return e.applyBinOp("++", args[0], args[1])
+		}
+	case core.OpNot:
+		if len(args) == 1 {
+			return applyUnOp("not", args[0])
+		}
+	case core.OpNeg:
+		if len(args) == 1 {
+			return applyUnOp("-", args[0])
+		}
+	default:
+		if prefix, ok := intrinsicBuiltinPrefix[intrinsic.Op]; ok && len(args) == 2 {
+			return callIntrinsicBuiltin(prefix, args)
+		}
+	}
+	return nil, fmt.Errorf("unknown intrinsic operation %v with %d arguments", intrinsic.Op, len(args))
+}
+
+// intrinsicBuiltinPrefix maps the type-class operators to the base name of
+// their builtins (see pipeline.OperatorTable)
+var intrinsicBuiltinPrefix = map[core.IntrinsicOp]string{
+	core.OpAdd: "add", core.OpSub: "sub", core.OpMul: "mul", core.OpDiv: "div", core.OpMod: "mod",
+	core.OpEq: "eq", core.OpNe: "ne", core.OpLt: "lt", core.OpLe: "le", core.OpGt: "gt", core.OpGe: "ge",
+}
+
+// callIntrinsicBuiltin calls prefix_Type, where Type is the runtime type
+// shared by both operands
+func callIntrinsicBuiltin(prefix string, args []Value) (Value, error) {
+	lType, rType := builtinTypeSuffix(args[0]), builtinTypeSuffix(args[1])
+	if lType == "" || lType != rType {
+		return nil, fmt.Errorf("operator %s has no implementation for operands %s and %s", prefix, args[0].Type(), args[1].Type())
+	}
+	name := prefix + "_" + lType
+	if _, ok := Builtins[name]; !ok {
+		return nil, fmt.Errorf("operator %s has no implementation for type %s", prefix, lType)
+	}
+	return CallBuiltin(name, args)
+}
+
+// builtinTypeSuffix returns the builtin type suffix for a primitive value,
+// or "" if operators on it have no builtin
+func builtinTypeSuffix(v Value) string {
+	switch v.(type) {
+	case *IntValue:
+		return "Int"
+	case *FloatValue:
+		return "Float"
+	case *StringValue:
+		return "String"
+	case *BoolValue:
+		return "Bool"
+	}
+	return ""
+}
+
+// evalShortCircuit evaluates && and || without evaluating the right operand
+// when the left one decides the result
+func (e *CoreEvaluator) evalShortCircuit(intrinsic *core.Intrinsic) (Value, error) {
+	if len(intrinsic.Args) != 2 {
+		return nil, fmt.Errorf("unknown intrinsic operation %v with %d arguments", intrinsic.Op, len(intrinsic.Args))
+	}
+	op := "&&"
+	if intrinsic.Op == core.OpOr {
+		op = "||"
+	}
+	left, err := e.evalCore(intrinsic.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	lBool, ok := left.(*BoolValue)
+	if !ok {
+		return nil, fmt.Errorf("'%s' requires boolean operands", op)
+	}
+	if lBool.Value == (intrinsic.Op == core.OpOr) {
+		return lBool, nil
+	}
+	right, err := e.evalCore(intrinsic.Args[1])
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := right.(*BoolValue); !ok {
+		return nil, fmt.Errorf("'%s' requires boolean operands", op)
+	}
+	return right, nil
 }
 
 // applyBinOp should NOT be called in dictionary-passing system except for special operators
 // This is a fail-fast guard to ensure BinOp nodes are properly elaborated to DictApp
 func (e *CoreEvaluator) applyBinOp(op string, left, right Value) (Value, error) {
-	// Special case: string concatenation doesn't use type classes
+	// Special case: string and list concatenation don't use type classes
 	if op == "++" {
+		if lList, ok := left.(*ListValue); ok {
+			rList, ok := right.(*ListValue)
+			if !ok {
+				return nil, fmt.Errorf("'++' requires list operands")
+			}
+			elems := make([]Value, 0, len(lList.Elements)+len(rList.Elements))
+			return &ListValue{Elements: append(append(elems, lList.Elements...), rList.Elements...)}, nil
+		}
 		lStr, lOk := left.(*StringValue)
 		rStr, rOk := right.(*StringValue)
 		if !lOk || !rOk {
@@ -291,7 +426,7 @@ func applyUnOp(op string, operand Value) (Value, error) {
 			return &FloatValue{Value: -v.Value}, nil
 		}
 
-	case "!":
+	case "!", "not":
 		if v, ok := operand.(*BoolValue); ok {
 			return &BoolValue{Value: !v.Value}, nil
 		}
@@ -28,7 +28,7 @@ func (e *CoreEvaluator) evalCoreMatch(match *core.Match) (Value, error) {
 
 	// Linear evaluation (current default implementation)
 	// Try each arm
-	for _, arm := range match.Arms {
+	for i, arm := range match.Arms {
 		bindings, matched := matchPattern(arm.Pattern, scrutineeVal)
 		if !matched {
 			continue
@@ -64,6 +64,9 @@ func (e *CoreEvaluator) evalCoreMatch(match *core.Match) (Value, error) {
 		}
 
 		// Pattern matched and guard passed - evaluate body with bindings
+		if e.coverage != nil {
+			e.coverage.hit(match, i)
+		}
 		newEnv := e.env.NewChildEnvironment()
 		for name, val := range bindings {
 			newEnv.Set(name, val)
@@ -77,7 +80,7 @@ func (e *CoreEvaluator) evalCoreMatch(match *core.Match) (Value, error) {
 		return result, err
 	}
 
-	return nil, fmt.Errorf("no pattern matched in match expression")
+	return nil, NewRuntimeError("RT_MATCH", "no pattern matched in match expression", nil)
 }
 
 // matchPattern attempts to match a pattern against a value
@@ -94,8 +97,16 @@ func matchPattern(pattern core.CorePattern, value Value) (map[string]Value, bool
 		// Literal pattern matches if values are equal
 		switch v := value.(type) {
 		case *IntValue:
-			if i, ok := p.Value.(int); ok && i == v.Value {
-				return bindings, true
+			// The parser stores integer literals as int64
+			switch i := p.Value.(type) {
+			case int:
+				if i == v.Value {
+					return bindings, true
+				}
+			case int64:
+				if int(i) == v.Value {
+					return bindings, true
+				}
 			}
 		case *FloatValue:
 			if f, ok := p.Value.(float64); ok && f == v.Value {
@@ -116,6 +127,27 @@ func matchPattern(pattern core.CorePattern, value Value) (map[string]Value, bool
 		// Wildcard always matches without binding
 		return bindings, true
 
+	case *core.OrPattern:
+		// Or-pattern takes the bindings of the first alternative that matches
+		for _, alt := range p.Alternatives {
+			if altBindings, ok := matchPattern(alt, value); ok {
+				return altBindings, true
+			}
+		}
+		return nil, false
+
+	case *core.AsPattern:
+		// As-pattern matches like its inner pattern and also binds the whole value
+		innerBindings, ok := matchPattern(p.Pattern, value)
+		if !ok {
+			return nil, false
+		}
+		for k, v := range innerBindings {
+			bindings[k] = v
+		}
+		bindings[p.Name] = value
+		return bindings, true
+
 	case *core.TuplePattern:
 		// Tuple pattern - value must be a tuple with matching arity
 		tupleVal, ok := value.(*TupleValue)
@@ -141,6 +173,14 @@ func matchPattern(pattern core.CorePattern, value Value) (map[string]Value, bool
 		return bindings, true
 
 	case *core.ConstructorPattern:
+		// Newtype values are not boxed, so the field pattern sees the value itself
+		if p.Newtype {
+			if len(p.Args) != 1 {
+				return nil, false
+			}
+			return matchPattern(p.Args[0], value)
+		}
+
 		// Constructor pattern - value must be a TaggedValue with matching constructor
 		tagged, ok := value.(*TaggedValue)
 		if !ok {
@@ -0,0 +1,113 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// TestMatchPattern_AsPattern tests that as-patterns bind the whole value and the inner bindings
+func TestMatchPattern_AsPattern(t *testing.T) {
+	some := &TaggedValue{TypeName: "Option", CtorName: "Some", Fields: []Value{&IntValue{Value: 42}}}
+	none := &TaggedValue{TypeName: "Option", CtorName: "None", Fields: []Value{}}
+
+	// whole @ Some(y)
+	pattern := &core.AsPattern{
+		Name:    "whole",
+		Pattern: &core.ConstructorPattern{Name: "Some", Args: []core.CorePattern{&core.VarPattern{Name: "y"}}},
+	}
+
+	bindings, ok := matchPattern(pattern, some)
+	if !ok {
+		t.Fatal("expected whole @ Some(y) to match Some(42)")
+	}
+	if bindings["whole"] != some {
+		t.Errorf("whole = %v, want the matched value", bindings["whole"])
+	}
+	if y, ok := bindings["y"].(*IntValue); !ok || y.Value != 42 {
+		t.Errorf("y = %v, want 42", bindings["y"])
+	}
+
+	if _, ok := matchPattern(pattern, none); ok {
+		t.Error("expected whole @ Some(y) not to match None")
+	}
+}
+
+// TestMatchPattern_StringLiteral tests literal string patterns
+func TestMatchPattern_StringLiteral(t *testing.T) {
+	pattern := &core.LitPattern{Value: "hi"}
+
+	if _, ok := matchPattern(pattern, &StringValue{Value: "hi"}); !ok {
+		t.Error("expected \"hi\" to match \"hi\"")
+	}
+	if _, ok := matchPattern(pattern, &StringValue{Value: "bye"}); ok {
+		t.Error("expected \"hi\" not to match \"bye\"")
+	}
+}
+
+// TestMatchPattern_OrPattern tests that or-patterns try each alternative in order
+func TestMatchPattern_OrPattern(t *testing.T) {
+	some := func(n int) Value {
+		return &TaggedValue{TypeName: "Option", CtorName: "Some", Fields: []Value{&IntValue{Value: n}}}
+	}
+	someLit := func(n int64) core.CorePattern {
+		// Integer literals come out of the parser as int64
+		return &core.ConstructorPattern{Name: "Some", Args: []core.CorePattern{&core.LitPattern{Value: n}}}
+	}
+
+	// Some(1) | Some(2)
+	pattern := &core.OrPattern{Alternatives: []core.CorePattern{someLit(1), someLit(2)}}
+	for n, want := range map[int]bool{1: true, 2: true, 3: false} {
+		if _, ok := matchPattern(pattern, some(n)); ok != want {
+			t.Errorf("Some(1) | Some(2) against Some(%d): matched = %v, want %v", n, ok, want)
+		}
+	}
+
+	// (0, x) | (x, 0) binds x from whichever alternative matched
+	tuple := func(a, b int) Value {
+		return &TupleValue{Elements: []Value{&IntValue{Value: a}, &IntValue{Value: b}}}
+	}
+	x := &core.VarPattern{Name: "x"}
+	zero := &core.LitPattern{Value: int64(0)}
+	pattern = &core.OrPattern{Alternatives: []core.CorePattern{
+		&core.TuplePattern{Elements: []core.CorePattern{zero, x}},
+		&core.TuplePattern{Elements: []core.CorePattern{x, zero}},
+	}}
+	bindings, ok := matchPattern(pattern, tuple(9, 0))
+	if !ok {
+		t.Fatal("expected (0, x) | (x, 0) to match (9, 0)")
+	}
+	if v, ok := bindings["x"].(*IntValue); !ok || v.Value != 9 {
+		t.Errorf("x = %v, want 9", bindings["x"])
+	}
+}
+
+// TestMatchPattern_Newtype tests that newtype patterns match the unboxed field value
+func TestMatchPattern_Newtype(t *testing.T) {
+	id, err := NewtypeConstructor("make_UserId_UserId").Fn([]Value{&IntValue{Value: 7}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := id.(*IntValue); !ok || v.Value != 7 {
+		t.Fatalf("UserId(7) = %v, want the unboxed 7", id)
+	}
+
+	// UserId(n)
+	pattern := &core.ConstructorPattern{Name: "UserId", Args: []core.CorePattern{&core.VarPattern{Name: "n"}}, Newtype: true}
+	bindings, ok := matchPattern(pattern, id)
+	if !ok {
+		t.Fatal("expected UserId(n) to match UserId(7)")
+	}
+	if n, ok := bindings["n"].(*IntValue); !ok || n.Value != 7 {
+		t.Errorf("n = %v, want 7", bindings["n"])
+	}
+
+	// Newtypes over ADTs match the wrapped constructor: Wrapped(Some(x))
+	some := &TaggedValue{TypeName: "Option", CtorName: "Some", Fields: []Value{&IntValue{Value: 1}}}
+	pattern = &core.ConstructorPattern{Name: "Wrapped", Newtype: true, Args: []core.CorePattern{
+		&core.ConstructorPattern{Name: "Some", Args: []core.CorePattern{&core.VarPattern{Name: "x"}}},
+	}}
+	if _, ok := matchPattern(pattern, some); !ok {
+		t.Error("expected Wrapped(Some(x)) to match an unboxed Some(1)")
+	}
+}
@@ -3,7 +3,6 @@ package eval
 import (
 	"fmt"
 	"math"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -151,6 +150,10 @@ func (e *SimpleEvaluator) evalExpr(expr ast.Expr) (Value, error) {
 		return val, nil
 
 	case *ast.BinaryOp:
+		if ex.Op == "|>" {
+			// Pipeline: x |> f is f(x)
+			return e.evalCall(&ast.FuncCall{Func: ex.Right, Args: []ast.Expr{ex.Left}, Pos: ex.Pos})
+		}
 		left, err := e.evalExpr(ex.Left)
 		if err != nil {
 			return nil, err
@@ -597,27 +600,7 @@ func showValue(v Value, depth int) string {
 		return strconv.Itoa(val.Value)
 
 	case *FloatValue:
-		// Handle special cases
-		if math.IsNaN(val.Value) {
-			return "NaN"
-		}
-		if math.IsInf(val.Value, 1) {
-			return "Inf"
-		}
-		if math.IsInf(val.Value, -1) {
-			return "-Inf"
-		}
-		// Handle negative zero explicitly
-		if val.Value == 0 && math.Signbit(val.Value) {
-			return "-0.0"
-		}
-		// Use %g for cleaner output, but ensure at least one decimal point
-		s := fmt.Sprintf("%g", val.Value)
-		// Ensure at least one decimal point for floats (5 -> 5.0)
-		if !strings.Contains(s, ".") && !strings.Contains(s, "e") && !strings.Contains(s, "E") {
-			s = s + ".0"
-		}
-		return s
+		return FormatFloat(val.Value)
 
 	case *StringValue:
 		// Quote and escape the string using JSON rules
@@ -647,25 +630,37 @@ func showValue(v Value, depth int) string {
 		if len(val.Fields) == 0 {
 			return "{}"
 		}
-		// Sort keys for deterministic output
-		keys := make([]string, 0, len(val.Fields))
-		for k := range val.Fields {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys) // Bytewise sort
 
 		var parts []string
-		for _, k := range keys {
+		for _, k := range val.FieldNames() {
 			parts = append(parts, fmt.Sprintf("%s: %s", k, showValue(val.Fields[k], depth+1)))
 		}
 		result := "{" + strings.Join(parts, ", ") + "}"
 		return truncateIfNeeded(result)
 
+	case *TupleValue:
+		var parts []string
+		for _, elem := range val.Elements {
+			parts = append(parts, showValue(elem, depth+1))
+		}
+		return truncateIfNeeded("(" + strings.Join(parts, ", ") + ")")
+
+	case *TaggedValue:
+		// ADT constructors: Some(42) → "Some(42)"
+		if len(val.Fields) == 0 {
+			return val.CtorName
+		}
+		var parts []string
+		for _, field := range val.Fields {
+			parts = append(parts, showValue(field, depth+1))
+		}
+		return truncateIfNeeded(val.CtorName + "(" + strings.Join(parts, ", ") + ")")
+
 	case *FunctionValue:
-		return "<function>"
+		return val.String()
 
 	case *BuiltinFunction:
-		return fmt.Sprintf("<builtin: %s>", val.Name)
+		return val.String()
 
 	case *ErrorValue:
 		return fmt.Sprintf("Error: %s", val.Message)
@@ -675,23 +670,17 @@ func showValue(v Value, depth int) string {
 	}
 }
 
-// toTextValue converts a value to string without quotes (for pretty printing)
+// toTextValue converts a value to string for display. It agrees with
+// showValue except that a string is not quoted and a function, which show
+// cannot render as source anyway, is just <function>.
 func toTextValue(v Value) string {
 	switch val := v.(type) {
 	case *StringValue:
-		// Return string without quotes
 		return val.Value
+	case *FunctionValue, *BuiltinFunction:
+		return "<function>"
 	default:
-		// For all other types, use show but strip quotes if string
-		result := showValue(v, 0)
-		// If it's a quoted string from show, unquote it
-		if len(result) >= 2 && result[0] == '"' && result[len(result)-1] == '"' {
-			unquoted, err := strconv.Unquote(result)
-			if err == nil {
-				return unquoted
-			}
-		}
-		return result
+		return showValue(v, 0)
 	}
 }
 
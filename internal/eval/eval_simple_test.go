@@ -78,9 +78,15 @@ func TestShowFunction(t *testing.T) {
 			"m": &IntValue{Value: 2},
 		}}, "{a: 1, m: 2, z: 3}"},
 
+		// Tuples and constructors
+		{"tuple", &TupleValue{Elements: []Value{&IntValue{Value: 1}, &StringValue{Value: "a"}}}, `(1, "a")`},
+		{"nullary constructor", &TaggedValue{CtorName: "None"}, "None"},
+		{"constructor", &TaggedValue{CtorName: "Some", Fields: []Value{&StringValue{Value: "a"}}}, `Some("a")`},
+
 		// Functions
-		{"function", &FunctionValue{Params: []string{"x", "y"}}, "<function>"},
-		{"builtin", &BuiltinFunction{Name: "print"}, "<builtin: print>"},
+		{"function", &FunctionValue{Params: []string{"x", "y"}}, "<function/2>"},
+		{"thunk", &FunctionValue{}, "<function/0>"},
+		{"builtin", &BuiltinFunction{Name: "print"}, "<builtin:print>"},
 	}
 
 	for _, tt := range tests {
@@ -199,6 +205,16 @@ func TestToTextFunction(t *testing.T) {
 			&IntValue{Value: 1},
 			&IntValue{Value: 2},
 		}}, "[1, 2]"},
+		{"nullary constructor", &TaggedValue{CtorName: "None"}, "None"},
+		{"constructor", &TaggedValue{CtorName: "Some", Fields: []Value{&IntValue{Value: 1}}}, "Some(1)"},
+		{"tuple", &TupleValue{Elements: []Value{&IntValue{Value: 1}, &IntValue{Value: 2}}}, "(1, 2)"},
+		{"record", &RecordValue{Fields: map[string]Value{
+			"b": &IntValue{Value: 2},
+			"a": &IntValue{Value: 1},
+		}}, "{a: 1, b: 2}"},
+		{"function", &FunctionValue{Params: []string{"x"}}, "<function>"},
+		{"builtin", &BuiltinFunction{Name: "show"}, "<function>"},
+		{"unit", &UnitValue{}, "()"},
 	}
 
 	for _, tt := range tests {
@@ -254,6 +270,32 @@ func TestShowDeterminism(t *testing.T) {
 	}
 }
 
+func TestRecordRenderingDeterminism(t *testing.T) {
+	record := &RecordValue{Fields: map[string]Value{
+		"name": &StringValue{Value: "x"},
+		"age":  &IntValue{Value: 3},
+		"ok":   &BoolValue{Value: true},
+	}}
+
+	renderers := map[string]func(Value) string{
+		"String":      func(v Value) string { return v.String() },
+		"showValue":   func(v Value) string { return showValue(v, 0) },
+		"toTextValue": toTextValue,
+	}
+	for name, render := range renderers {
+		first := render(record)
+		for i := 0; i < 20; i++ {
+			if again := render(record); again != first {
+				t.Fatalf("%s is not deterministic: %q vs %q", name, first, again)
+			}
+		}
+	}
+
+	if got, want := record.String(), `{age: 3, name: x, ok: true}`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
 func TestLambdaClosures(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -282,6 +324,15 @@ func TestLambdaClosures(t *testing.T) {
 
 		// Partial application preserving closures
 		{"partial application closure", `let base = 100 in let add = \x y. x + y + base in add(1)(2)`, "103"},
+
+		// Operator sections
+		{"right section", `(+ 1)(2)`, "3"},
+		{"left section", `(10 -)(3)`, "7"},
+		{"section closure", `let n = 4 in (* n)(5)`, "20"},
+
+		// Pipelines
+		{"pipeline", `3 |> (\x. x * 2) |> (+ 1)`, "7"},
+		{"pipeline partial application", `let add = \x y. x + y in 1 |> add(2)`, "3"},
 	}
 
 	for _, tt := range tests {
@@ -525,7 +525,7 @@ func (e *TypedEvaluator) evalMatch(match *typedast.TypedMatch) (Value, error) {
 		return result, err
 	}
 
-	return nil, fmt.Errorf("non-exhaustive pattern match at %s", match.Span)
+	return nil, NewRuntimeError("RT_MATCH", fmt.Sprintf("non-exhaustive pattern match at %s", match.Span), nil)
 }
 
 // matchPattern attempts to match a pattern against a value
@@ -546,6 +546,27 @@ func (e *TypedEvaluator) matchPattern(pat typedast.TypedPattern, val Value) (map
 		// Wildcard always matches
 		return nil, true
 
+	case typedast.TypedOrPattern:
+		// First matching alternative wins
+		for _, alt := range p.Alternatives {
+			if bindings, ok := e.matchPattern(alt, val); ok {
+				return bindings, true
+			}
+		}
+		return nil, false
+
+	case typedast.TypedAsPattern:
+		// As-pattern binds the whole value on top of the inner bindings
+		inner, ok := e.matchPattern(p.Pattern, val)
+		if !ok {
+			return nil, false
+		}
+		bindings := map[string]Value{p.Name: val}
+		for name, v := range inner {
+			bindings[name] = v
+		}
+		return bindings, true
+
 	default:
 		// TODO: Implement other pattern types
 		return nil, false
@@ -0,0 +1,87 @@
+package eval
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IntOverflow selects how Int arithmetic builtins behave on overflow
+type IntOverflow int
+
+const (
+	// IntOverflowWrap wraps around silently (two's complement, the default)
+	IntOverflowWrap IntOverflow = iota
+	// IntOverflowChecked fails with RT_INT_OVERFLOW instead of wrapping
+	IntOverflowChecked
+)
+
+// ParseIntOverflow parses an --int-overflow flag value: "wrap" or "checked"
+func ParseIntOverflow(s string) (IntOverflow, error) {
+	switch s {
+	case "wrap":
+		return IntOverflowWrap, nil
+	case "checked":
+		return IntOverflowChecked, nil
+	default:
+		return IntOverflowWrap, fmt.Errorf("invalid integer overflow mode %q (expected wrap or checked)", s)
+	}
+}
+
+// SetIntOverflow selects wrapping or checked Int arithmetic for builtins
+// called through this evaluator
+func (e *CoreEvaluator) SetIntOverflow(mode IntOverflow) {
+	e.intOverflow = mode
+}
+
+// IntOverflow returns the evaluator's Int overflow mode
+func (e *CoreEvaluator) IntOverflow() IntOverflow {
+	return e.intOverflow
+}
+
+// IntOverflowError is the RT_INT_OVERFLOW failure of a checked Int builtin
+func IntOverflowError(op string, args ...int) error {
+	operands := make([]string, len(args))
+	for i, a := range args {
+		operands[i] = strconv.Itoa(a)
+	}
+	return NewRuntimeError("RT_INT_OVERFLOW", fmt.Sprintf("Integer overflow in %s(%s)", op, strings.Join(operands, ", ")), nil)
+}
+
+// The Checked* helpers compute an Int operation and report whether it is
+// exact, i.e. did not wrap around. x == -x only holds for 0 and the minimum
+// value, which has no positive counterpart.
+
+// CheckedAdd returns a + b and whether it did not overflow
+func CheckedAdd[T ~int | ~int64](a, b T) (T, bool) {
+	c := a + b
+	return c, (c > a) == (b > 0)
+}
+
+// CheckedSub returns a - b and whether it did not overflow
+func CheckedSub[T ~int | ~int64](a, b T) (T, bool) {
+	c := a - b
+	return c, (c < a) == (b > 0)
+}
+
+// CheckedMul returns a * b and whether it did not overflow
+func CheckedMul[T ~int | ~int64](a, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (a == -1 && b == -b) || (b == -1 && a == -a) {
+		return c, false
+	}
+	return c, c/b == a
+}
+
+// CheckedNeg returns -a and whether it did not overflow
+func CheckedNeg[T ~int | ~int64](a T) (T, bool) {
+	return -a, a == 0 || a != -a
+}
+
+// CheckedDiv returns a / b (b != 0) and whether it did not overflow
+func CheckedDiv[T ~int | ~int64](a, b T) (T, bool) {
+	return a / b, !(b == -1 && a != 0 && a == -a)
+}
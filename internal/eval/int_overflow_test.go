@@ -0,0 +1,49 @@
+package eval
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCheckedIntOps(t *testing.T) {
+	result := func(c int, ok bool) [2]interface{} { return [2]interface{}{c, ok} }
+	tests := []struct {
+		name string
+		got  [2]interface{}
+		want int
+		ok   bool
+	}{
+		{"2+3", result(CheckedAdd(2, 3)), 5, true},
+		{"max+1", result(CheckedAdd(math.MaxInt, 1)), math.MinInt, false},
+		{"min+-1", result(CheckedAdd(math.MinInt, -1)), math.MaxInt, false},
+		{"min-1", result(CheckedSub(math.MinInt, 1)), math.MaxInt, false},
+		{"-1-min", result(CheckedSub(-1, math.MinInt)), math.MaxInt, true},
+		{"max/2*2", result(CheckedMul(math.MaxInt/2, 2)), math.MaxInt - 1, true},
+		{"(max/2+1)*2", result(CheckedMul(math.MaxInt/2+1, 2)), math.MinInt, false},
+		{"min*-1", result(CheckedMul(math.MinInt, -1)), math.MinInt, false},
+		{"-1*min", result(CheckedMul(-1, math.MinInt)), math.MinInt, false},
+		{"-min", result(CheckedNeg(math.MinInt)), math.MinInt, false},
+		{"-max", result(CheckedNeg(math.MaxInt)), -math.MaxInt, true},
+		{"min/-1", result(CheckedDiv(math.MinInt, -1)), math.MinInt, false},
+		{"min/2", result(CheckedDiv(math.MinInt, 2)), math.MinInt / 2, true},
+	}
+
+	for _, tt := range tests {
+		if tt.got != result(tt.want, tt.ok) {
+			t.Errorf("%s = %v, want (%d, %v)", tt.name, tt.got, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseIntOverflow(t *testing.T) {
+	if mode, err := ParseIntOverflow("checked"); err != nil || mode != IntOverflowChecked {
+		t.Errorf("checked: got (%v, %v)", mode, err)
+	}
+	if mode, err := ParseIntOverflow("wrap"); err != nil || mode != IntOverflowWrap {
+		t.Errorf("wrap: got (%v, %v)", mode, err)
+	}
+	if _, err := ParseIntOverflow("saturate"); err == nil || !strings.Contains(err.Error(), "saturate") {
+		t.Errorf("expected error naming the invalid mode, got %v", err)
+	}
+}
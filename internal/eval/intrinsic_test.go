@@ -0,0 +1,84 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/core"
+)
+
+func intLit(n int) core.CoreExpr       { return &core.Lit{Kind: core.IntLit, Value: n} }
+func floatLit(f float64) core.CoreExpr { return &core.Lit{Kind: core.FloatLit, Value: f} }
+func strLit(s string) core.CoreExpr    { return &core.Lit{Kind: core.StringLit, Value: s} }
+func boolLit(b bool) core.CoreExpr     { return &core.Lit{Kind: core.BoolLit, Value: b} }
+
+func intrinsic(op core.IntrinsicOp, args ...core.CoreExpr) *core.Intrinsic {
+	return &core.Intrinsic{Op: op, Args: args}
+}
+
+// TestIntrinsic_WithoutShim checks that intrinsics evaluate without the
+// experimental binop shim
+func TestIntrinsic_WithoutShim(t *testing.T) {
+	tests := []struct {
+		name string
+		expr *core.Intrinsic
+		want string
+	}{
+		{"add_Int", intrinsic(core.OpAdd, intLit(2), intLit(3)), "5"},
+		{"mod_Int", intrinsic(core.OpMod, intLit(7), intLit(3)), "1"},
+		{"mul_Float", intrinsic(core.OpMul, floatLit(1.5), floatLit(2)), "3.0"},
+		{"lt_String", intrinsic(core.OpLt, strLit("a"), strLit("b")), "true"},
+		{"eq_Bool", intrinsic(core.OpEq, boolLit(true), boolLit(false)), "false"},
+		{"ge_Int", intrinsic(core.OpGe, intLit(3), intLit(3)), "true"},
+		{"concat", intrinsic(core.OpConcat, strLit("ab"), strLit("cd")), "abcd"},
+		{"and", intrinsic(core.OpAnd, boolLit(true), boolLit(false)), "false"},
+		{"or", intrinsic(core.OpOr, boolLit(false), boolLit(true)), "true"},
+		{"not", intrinsic(core.OpNot, boolLit(false)), "true"},
+		{"neg_Int", intrinsic(core.OpNeg, intLit(4)), "-4"},
+		{"neg_Float", intrinsic(core.OpNeg, floatLit(0.5)), "-0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := NewCoreEvaluator().evalCore(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := val.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIntrinsic_ShortCircuit checks that the right operand of && and || is
+// only evaluated when needed
+func TestIntrinsic_ShortCircuit(t *testing.T) {
+	// The right operand is an undefined variable: evaluating it fails
+	undefined := &core.Var{Name: "boom"}
+
+	for _, expr := range []*core.Intrinsic{
+		intrinsic(core.OpAnd, boolLit(false), undefined),
+		intrinsic(core.OpOr, boolLit(true), undefined),
+	} {
+		if _, err := NewCoreEvaluator().evalCore(expr); err != nil {
+			t.Errorf("%v: right operand was evaluated: %v", expr.Op, err)
+		}
+	}
+}
+
+// TestIntrinsic_MismatchedOperands checks that operands without a shared
+// builtin type fail loudly
+func TestIntrinsic_MismatchedOperands(t *testing.T) {
+	tests := []*core.Intrinsic{
+		intrinsic(core.OpAdd, intLit(1), floatLit(2)),
+		intrinsic(core.OpAdd, strLit("a"), strLit("b")),
+		intrinsic(core.OpLt, boolLit(true), boolLit(false)),
+	}
+	for _, expr := range tests {
+		_, err := NewCoreEvaluator().evalCore(expr)
+		if err == nil || !strings.Contains(err.Error(), "has no implementation") {
+			t.Errorf("%v: expected missing implementation error, got %v", expr.Op, err)
+		}
+	}
+}
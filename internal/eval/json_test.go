@@ -347,3 +347,21 @@ func TestJSONEncodeBuiltin(t *testing.T) {
 		t.Errorf("Expected %q, got %q", expected, result.Value)
 	}
 }
+
+func TestJSONDecodeObjectKeyOrder(t *testing.T) {
+	decode := Builtins["_json_decode"].Impl.(func(Value) (Value, error))
+	want := "Ok(JObject([{key: a, value: JNumber(2.0)}, {key: b, value: JNumber(1.0)}, {key: c, value: JNull}]))"
+
+	// Go randomises map iteration, so decode each ordering several times
+	for _, input := range []string{`{"b": 1, "a": 2, "c": null}`, `{"c": null, "a": 2, "b": 1}`} {
+		for i := 0; i < 20; i++ {
+			result, err := decode(&StringValue{Value: input})
+			if err != nil {
+				t.Fatalf("decode %s: %v", input, err)
+			}
+			if got := result.String(); got != want {
+				t.Fatalf("decode %s:\n got: %s\nwant: %s", input, got, want)
+			}
+		}
+	}
+}
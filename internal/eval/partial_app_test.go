@@ -0,0 +1,65 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/core"
+)
+
+func app(fn core.CoreExpr, args ...core.CoreExpr) *core.App {
+	return &core.App{Func: fn, Args: args}
+}
+
+func lambda(body core.CoreExpr, params ...string) *core.Lambda {
+	return &core.Lambda{Params: params, Body: body}
+}
+
+func minus(l, r string) core.CoreExpr {
+	return intrinsic(core.OpSub, &core.Var{Name: l}, &core.Var{Name: r})
+}
+
+// TestApp_Currying checks that applications with fewer or more arguments
+// than the function has parameters behave like curried calls
+func TestApp_Currying(t *testing.T) {
+	sub := lambda(minus("x", "y"), "x", "y")
+	// \x y. \z. x - y - z
+	sub3 := lambda(lambda(intrinsic(core.OpSub, minus("x", "y"), &core.Var{Name: "z"}), "z"), "x", "y")
+
+	tests := []struct {
+		name string
+		expr core.CoreExpr
+		want string
+	}{
+		{"exact", app(sub, intLit(10), intLit(3)), "7"},
+		{"partial", app(sub, intLit(10)), "<function/1>"},
+		{"partial_then_rest", app(app(sub, intLit(10)), intLit(3)), "7"},
+		{"over_curried", app(lambda(lambda(minus("x", "y"), "y"), "x"), intLit(10), intLit(3)), "7"},
+		{"over_multi_param", app(sub3, intLit(10), intLit(3), intLit(2)), "5"},
+		{"partial_reused", &core.Let{
+			Name:  "dec",
+			Value: app(sub, intLit(10)),
+			Body:  intrinsic(core.OpAdd, app(&core.Var{Name: "dec"}, intLit(1)), app(&core.Var{Name: "dec"}, intLit(2))),
+		}, "17"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := NewCoreEvaluator().evalCore(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := val.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApp_OverApplicationOfValue checks that surplus arguments to a function
+// that does not return a function are reported
+func TestApp_OverApplicationOfValue(t *testing.T) {
+	_, err := NewCoreEvaluator().evalCore(app(lambda(minus("x", "y"), "x", "y"), intLit(10), intLit(3), intLit(1)))
+	if err == nil {
+		t.Fatal("expected an error applying an int")
+	}
+}
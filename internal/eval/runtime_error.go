@@ -0,0 +1,119 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// maxTraceFrames limits how many caller frames RuntimeError.Error prints
+const maxTraceFrames = 10
+
+// Frame is one entry of the evaluator's call stack
+type Frame struct {
+	Function string  // Name of the called function ("<lambda>" if anonymous)
+	CallSite ast.Pos // Where the call happened
+}
+
+// RuntimeError is an evaluation failure annotated with the surface position
+// of the Core node that failed and the call stack at that point.
+//
+// Error() renders as:
+//
+//	undefined variable 'x' at foo.ail:12:5 (in bar)
+//	  called from main at foo.ail:20:3
+type RuntimeError struct {
+	Err   error   // Underlying failure
+	Pos   ast.Pos // Surface position of the failing node
+	Stack []Frame // Innermost call last
+}
+
+func (r *RuntimeError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(r.Err.Error())
+	if r.Pos.Line > 0 {
+		sb.WriteString(" at ")
+		sb.WriteString(r.Pos.String())
+	}
+	if len(r.Stack) == 0 {
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, " (in %s)", r.Stack[len(r.Stack)-1].Function)
+
+	// Each frame's call site lies inside the function of the frame below it
+	shown := 0
+	for i := len(r.Stack) - 1; i > 0; i-- {
+		if shown == maxTraceFrames {
+			fmt.Fprintf(&sb, "\n  ... %d more frames", i)
+			break
+		}
+		fmt.Fprintf(&sb, "\n  called from %s", r.Stack[i-1].Function)
+		if site := r.Stack[i].CallSite; site.Line > 0 {
+			fmt.Fprintf(&sb, " at %s", site)
+		}
+		shown++
+	}
+	return sb.String()
+}
+
+// Unwrap returns the underlying error so errors.As/Is see through the annotation
+func (r *RuntimeError) Unwrap() error {
+	return r.Err
+}
+
+// UserErrorCode is the structured error code of failures raised by the
+// program itself through the `error` builtin
+const UserErrorCode = "RT_USER_ERROR"
+
+// UserError is raised by `error(msg)`. It reaches the caller wrapped in a
+// RuntimeError, which adds the span of the call and the call stack.
+type UserError struct {
+	Message string
+}
+
+func (u *UserError) Error() string {
+	return UserErrorCode + ": " + u.Message
+}
+
+// runtimeError annotates err with the position of expr and the current call
+// stack. Errors that are already annotated, even beneath added context such
+// as a failed module binding, pass through unchanged so the innermost failing
+// node wins.
+func (e *CoreEvaluator) runtimeError(err error, expr core.CoreExpr) error {
+	var annotated *RuntimeError
+	if errors.As(err, &annotated) {
+		return err
+	}
+	return &RuntimeError{
+		Err:   err,
+		Pos:   sourcePos(expr),
+		Stack: append([]Frame(nil), e.callStack...),
+	}
+}
+
+// sourcePos returns the surface position of a Core node, falling back to its
+// Core span for synthesized nodes
+func sourcePos(expr core.CoreExpr) ast.Pos {
+	pos := expr.OriginalSpan()
+	if pos.Line == 0 {
+		pos = expr.Span()
+	}
+	return pos
+}
+
+// pushFrame records a call to fn at the given call site
+func (e *CoreEvaluator) pushFrame(fn *FunctionValue, site ast.Pos) {
+	name := fn.Name
+	if name == "" {
+		name = "<lambda>"
+	}
+	e.callStack = append(e.callStack, Frame{Function: name, CallSite: site})
+}
+
+// popFrame removes the innermost call frame
+func (e *CoreEvaluator) popFrame() {
+	e.callStack = e.callStack[:len(e.callStack)-1]
+}
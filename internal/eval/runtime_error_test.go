@@ -0,0 +1,113 @@
+package eval
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+func at(line, col int) core.CoreNode {
+	pos := ast.Pos{File: "foo.ail", Line: line, Column: col}
+	return core.CoreNode{CoreSpan: pos, OrigSpan: pos}
+}
+
+// TestRuntimeError_UndefinedVariable checks that the failing node's span is reported
+func TestRuntimeError_UndefinedVariable(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	_, err := evaluator.evalCore(&core.Var{CoreNode: at(12, 5), Name: "x"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	want := "undefined variable 'x' at foo.ail:12:5"
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+
+	var rtErr *RuntimeError
+	if !errors.As(err, &rtErr) {
+		t.Fatalf("expected *RuntimeError, got %T", err)
+	}
+	if len(rtErr.Stack) != 0 {
+		t.Errorf("expected empty stack at top level, got %v", rtErr.Stack)
+	}
+}
+
+// TestRuntimeError_CallStack checks that nested calls show up as frames
+func TestRuntimeError_CallStack(t *testing.T) {
+	// letrec bar = λy. x
+	//        baz = λz. bar(z)
+	// in baz(1)
+	letrec := &core.LetRec{
+		Bindings: []core.RecBinding{
+			{Name: "bar", Value: &core.Lambda{
+				Params: []string{"y"},
+				Body:   &core.Var{CoreNode: at(12, 5), Name: "x"},
+			}},
+			{Name: "baz", Value: &core.Lambda{
+				Params: []string{"z"},
+				Body: &core.App{
+					CoreNode: at(20, 3),
+					Func:     &core.Var{Name: "bar"},
+					Args:     []core.CoreExpr{&core.Var{Name: "z"}},
+				},
+			}},
+		},
+		Body: &core.App{
+			CoreNode: at(30, 1),
+			Func:     &core.Var{Name: "baz"},
+			Args:     []core.CoreExpr{&core.Lit{Kind: core.IntLit, Value: 1}},
+		},
+	}
+
+	evaluator := NewCoreEvaluator()
+	_, err := evaluator.evalCore(letrec)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	want := "undefined variable 'x' at foo.ail:12:5 (in bar)\n  called from baz at foo.ail:20:3"
+	if err.Error() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", err.Error(), want)
+	}
+	if len(evaluator.callStack) != 0 {
+		t.Errorf("call stack not unwound: %v", evaluator.callStack)
+	}
+}
+
+// TestRuntimeError_TraceIsTruncated checks deep stacks are elided
+func TestRuntimeError_TraceIsTruncated(t *testing.T) {
+	stack := make([]Frame, 25)
+	for i := range stack {
+		stack[i] = Frame{Function: "loop"}
+	}
+	err := &RuntimeError{Err: errors.New("boom"), Stack: stack}
+
+	msg := err.Error()
+	if got := strings.Count(msg, "called from"); got != maxTraceFrames {
+		t.Errorf("expected %d frames, got %d:\n%s", maxTraceFrames, got, msg)
+	}
+	if !strings.Contains(msg, "... 14 more frames") {
+		t.Errorf("expected elision note, got:\n%s", msg)
+	}
+}
+
+// TestRuntimeError_UserError checks that errors raised by the program keep
+// their identity through the span annotation
+func TestRuntimeError_UserError(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	err := evaluator.runtimeError(&UserError{Message: "bad input"}, &core.Var{CoreNode: at(3, 7), Name: "f"})
+
+	want := "RT_USER_ERROR: bad input at foo.ail:3:7"
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+
+	var userErr *UserError
+	if !errors.As(err, &userErr) || userErr.Message != "bad input" {
+		t.Errorf("expected *UserError with message, got %#v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// maxTraceValueLen truncates long values in --trace-eval output
+const maxTraceValueLen = 80
+
+// SetEvalTrace enables the interpreter trace (--trace-eval): every Core node
+// is written to w once evaluated, indented by call depth, with its kind,
+// source position and resulting value. A nil writer disables tracing.
+//
+// This is a debugging aid distinct from the training TraceCollector.
+func (e *CoreEvaluator) SetEvalTrace(w io.Writer) {
+	e.evalTrace = w
+}
+
+// evalCoreTraced is evalCore with a trace line per node. Nodes are reported
+// when they finish, so children appear before their parent. A failure is
+// reported only at the node where it was raised.
+func (e *CoreEvaluator) evalCoreTraced(expr core.CoreExpr) (Value, error) {
+	depth := len(e.callStack)
+	val, err := e.evalCoreNode(expr)
+
+	indent := strings.Repeat("  ", depth)
+	kind := strings.TrimPrefix(fmt.Sprintf("%T", expr), "*core.")
+	pos := sourcePos(expr)
+	if err != nil {
+		if _, raised := err.(*RuntimeError); !raised {
+			fmt.Fprintf(e.evalTrace, "%s%s %s !! %v\n", indent, kind, pos, err)
+		}
+		return nil, e.runtimeError(err, expr)
+	}
+	fmt.Fprintf(e.evalTrace, "%s%s %s => %s\n", indent, kind, pos, traceValue(val))
+	return val, nil
+}
+
+// traceValue renders a value on one line, truncated to maxTraceValueLen
+func traceValue(v Value) string {
+	if v == nil {
+		return "<nil>"
+	}
+	s := strings.ReplaceAll(v.String(), "\n", `\n`)
+	if utf8.RuneCountInString(s) > maxTraceValueLen {
+		s = string([]rune(s)[:maxTraceValueLen]) + "..."
+	}
+	return s
+}
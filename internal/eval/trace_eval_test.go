@@ -0,0 +1,88 @@
+package eval
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// TestEvalTrace tests that --trace-eval reports each node with its position
+// and value, indenting nodes evaluated inside calls
+func TestEvalTrace(t *testing.T) {
+	at := func(col int) core.CoreNode {
+		return core.CoreNode{OrigSpan: ast.Pos{File: "t.ail", Line: 1, Column: col}}
+	}
+	// let f = \x. x in f(7)
+	expr := &core.Let{
+		CoreNode: at(1),
+		Name:     "f",
+		Value: &core.Lambda{
+			CoreNode: at(9),
+			Params:   []string{"x"},
+			Body:     &core.Var{CoreNode: at(13), Name: "x"},
+		},
+		Body: &core.App{
+			CoreNode: at(18),
+			Func:     &core.Var{CoreNode: at(18), Name: "f"},
+			Args:     []core.CoreExpr{&core.Lit{CoreNode: at(20), Kind: core.IntLit, Value: int64(7)}},
+		},
+	}
+
+	var buf bytes.Buffer
+	e := NewCoreEvaluator()
+	e.SetEvalTrace(&buf)
+	if _, err := e.Eval(expr); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"Lambda t.ail:1:9 => <function/1>",
+		"Var t.ail:1:18 => <function/1>",
+		"Lit t.ail:1:20 => 7",
+		"  Var t.ail:1:13 => 7",
+		"App t.ail:1:18 => 7",
+		"Let t.ail:1:1 => 7",
+	}
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("trace:\n%s\nwant:\n%s", buf.String(), strings.Join(want, "\n"))
+	}
+
+	// Disabled tracing writes nothing
+	buf.Reset()
+	e.SetEvalTrace(nil)
+	if _, err := e.Eval(expr); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no trace output when disabled, got %q", buf.String())
+	}
+}
+
+// TestEvalTrace_ErrorReportedOnce tests that a failure is traced only at the
+// node that raised it
+func TestEvalTrace_ErrorReportedOnce(t *testing.T) {
+	pos := core.CoreNode{OrigSpan: ast.Pos{File: "t.ail", Line: 2, Column: 5}}
+	expr := &core.Let{
+		CoreNode: pos,
+		Name:     "y",
+		Value:    &core.Var{CoreNode: pos, Name: "missing"},
+		Body:     &core.Var{CoreNode: pos, Name: "y"},
+	}
+
+	var buf bytes.Buffer
+	e := NewCoreEvaluator()
+	e.SetEvalTrace(&buf)
+	if _, err := e.Eval(expr); err == nil {
+		t.Fatal("expected an error for an undefined variable")
+	}
+	if n := strings.Count(buf.String(), "!!"); n != 1 {
+		t.Errorf("expected the error traced once, got %d times:\n%s", n, buf.String())
+	}
+	if !strings.HasPrefix(buf.String(), "Var t.ail:2:5 !! ") {
+		t.Errorf("expected the failing Var to be traced, got %q", buf.String())
+	}
+}
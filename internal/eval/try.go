@@ -0,0 +1,75 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Try applies a zero-argument function and converts a recoverable runtime
+// failure into Err(message); a normal result becomes Ok(value).
+//
+// Recoverable failures are the ones the program itself can cause at runtime:
+// errors raised with error(msg) and coded RT_* errors such as RT_DIV0 and
+// RT_MATCH (no arm of a match applies). Anything else (missing capabilities,
+// internal evaluator errors, exceeded recursion depth) keeps propagating.
+func (e *CoreEvaluator) Try(thunk Value) (Value, error) {
+	fn, ok := thunk.(*FunctionValue)
+	if !ok {
+		return nil, fmt.Errorf("try: expected a function, got %T", thunk)
+	}
+	if len(fn.Params) != 0 {
+		return nil, fmt.Errorf("try: expected a function of no arguments, got %d parameters", len(fn.Params))
+	}
+
+	// Evaluation may bail out before restoring its state
+	env, depth, stack := e.env, e.recursionDepth, len(e.callStack)
+
+	result, err := e.CallFunction(fn, nil)
+	if err == nil {
+		return resultValue("Ok", result), nil
+	}
+
+	msg, recoverable := recoverableMessage(err)
+	if !recoverable {
+		return nil, err
+	}
+	e.env, e.recursionDepth, e.callStack = env, depth, e.callStack[:stack]
+	return resultValue("Err", &StringValue{Value: msg}), nil
+}
+
+// TryBuiltin returns the try builtin bound to this evaluator. The thunk must
+// run on the evaluator executing the program, whose resolver and environment
+// its body needs, so $builtin.try resolves here rather than in the runtime's
+// builtin registry.
+func (e *CoreEvaluator) TryBuiltin() *BuiltinFunction {
+	return &BuiltinFunction{
+		Name: "try",
+		Fn: func(args []Value) (Value, error) {
+			return e.Try(args[0])
+		},
+	}
+}
+
+// recoverableMessage reports whether err may be caught by try, and the
+// message the Err carries
+func recoverableMessage(err error) (string, bool) {
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		return userErr.Message, true
+	}
+	var coded *CodedError
+	if errors.As(err, &coded) && strings.HasPrefix(coded.Code, "RT_") {
+		return coded.Error(), true
+	}
+	return "", false
+}
+
+func resultValue(ctor string, v Value) Value {
+	return &TaggedValue{
+		ModulePath: "std/result",
+		TypeName:   "Result",
+		CtorName:   ctor,
+		Fields:     []Value{v},
+	}
+}
@@ -0,0 +1,140 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// thunk builds a zero-argument function whose body is expr
+func thunk(expr core.CoreExpr) *FunctionValue {
+	return &FunctionValue{Params: []string{}, Body: expr, Env: NewEnvironment()}
+}
+
+// divZero calls a builtin that fails like div_Int does on a zero divisor
+func divZero(evaluator *CoreEvaluator) core.CoreExpr {
+	evaluator.env.Set("div", &BuiltinFunction{
+		Name: "div",
+		Fn: func(args []Value) (Value, error) {
+			return nil, NewRuntimeError("RT_DIV0", "Division by zero", nil)
+		},
+	})
+	return &core.App{
+		CoreNode: at(4, 9),
+		Func:     &core.Var{Name: "div"},
+		Args:     []core.CoreExpr{&core.Lit{Kind: core.IntLit, Value: 1}},
+	}
+}
+
+func expectResult(t *testing.T, v Value, ctor string) Value {
+	t.Helper()
+	tagged, ok := v.(*TaggedValue)
+	if !ok || tagged.TypeName != "Result" || tagged.CtorName != ctor || len(tagged.Fields) != 1 {
+		t.Fatalf("expected %s(...), got %v", ctor, v)
+	}
+	return tagged.Fields[0]
+}
+
+func TestTry_Ok(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	result, err := evaluator.Try(thunk(&core.Lit{Kind: core.IntLit, Value: 42}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := expectResult(t, result, "Ok"); got.String() != "42" {
+		t.Errorf("got Ok(%v), want Ok(42)", got)
+	}
+}
+
+func TestTry_DivisionByZero(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	body := divZero(evaluator)
+	fn := thunk(body)
+	fn.Env = evaluator.env.Clone()
+
+	outer := evaluator.env
+	result, err := evaluator.Try(fn)
+	if err != nil {
+		t.Fatalf("division by zero should be recovered, got error: %v", err)
+	}
+	msg := expectResult(t, result, "Err").(*StringValue).Value
+	if !strings.Contains(msg, "RT_DIV0") || !strings.Contains(msg, "Division by zero") {
+		t.Errorf("unexpected Err message %q", msg)
+	}
+
+	// The evaluator is usable again at the try boundary
+	if evaluator.env != outer || len(evaluator.callStack) != 0 || evaluator.recursionDepth != 0 {
+		t.Errorf("evaluator state not restored: stack=%v depth=%d", evaluator.callStack, evaluator.recursionDepth)
+	}
+}
+
+func TestTry_UserError(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	evaluator.env.Set("fail", &BuiltinFunction{
+		Name: "fail",
+		Fn: func(args []Value) (Value, error) {
+			return nil, &UserError{Message: "bad input"}
+		},
+	})
+	fn := thunk(&core.App{Func: &core.Var{Name: "fail"}})
+	fn.Env = evaluator.env.Clone()
+
+	result, err := evaluator.Try(fn)
+	if err != nil {
+		t.Fatalf("user error should be recovered, got error: %v", err)
+	}
+	if msg := expectResult(t, result, "Err").(*StringValue).Value; msg != "bad input" {
+		t.Errorf("got Err(%q), want Err(%q)", msg, "bad input")
+	}
+}
+
+// TestTry_MatchFailure checks that a match with no applicable arm is recovered
+func TestTry_MatchFailure(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	match := &core.Match{
+		CoreNode:  at(3, 5),
+		Scrutinee: &core.Lit{Kind: core.IntLit, Value: 2},
+		Arms: []core.MatchArm{
+			{Pattern: &core.LitPattern{Value: 1}, Body: &core.Lit{Kind: core.StringLit, Value: "one"}},
+		},
+	}
+	result, err := evaluator.Try(thunk(match))
+	if err != nil {
+		t.Fatalf("match failure should be recovered, got error: %v", err)
+	}
+	msg := expectResult(t, result, "Err").(*StringValue).Value
+	if !strings.Contains(msg, "RT_MATCH") || !strings.Contains(msg, "no pattern matched") {
+		t.Errorf("unexpected Err message %q", msg)
+	}
+}
+
+// TestTry_FatalErrorPropagates checks that evaluator failures are not swallowed
+func TestTry_FatalErrorPropagates(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	_, err := evaluator.Try(thunk(&core.Var{CoreNode: at(2, 3), Name: "missing"}))
+	if err == nil || !strings.Contains(err.Error(), "undefined variable 'missing'") {
+		t.Fatalf("expected undefined variable error to propagate, got %v", err)
+	}
+}
+
+func TestTry_RequiresThunk(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	if _, err := evaluator.Try(&IntValue{Value: 1}); err == nil {
+		t.Error("expected error for non-function argument")
+	}
+	fn := &FunctionValue{Params: []string{"x"}, Body: &core.Var{Name: "x"}, Env: NewEnvironment()}
+	if _, err := evaluator.Try(fn); err == nil {
+		t.Error("expected error for function with parameters")
+	}
+}
+
+func TestEvalVar_SuggestsClosestBinding(t *testing.T) {
+	evaluator := NewCoreEvaluator()
+	fn := thunk(&core.Var{CoreNode: at(2, 3), Name: "totl"})
+	fn.Env.Set("total", &IntValue{Value: 1})
+	_, err := evaluator.Try(fn)
+	if err == nil || !strings.Contains(err.Error(), "did you mean 'total'?") {
+		t.Fatalf("expected a suggestion for 'total', got %v", err)
+	}
+}
@@ -2,6 +2,10 @@ package eval
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -24,12 +28,30 @@ type FloatValue struct {
 	Value float64
 }
 
-func (f *FloatValue) Type() string { return "float" }
-func (f *FloatValue) String() string {
-	s := fmt.Sprintf("%g", f.Value)
-	// Ensure at least one decimal point for floats (5 -> 5.0)
-	if !strings.Contains(s, ".") && !strings.Contains(s, "e") && !strings.Contains(s, "E") {
-		s = s + ".0"
+func (f *FloatValue) Type() string   { return "float" }
+func (f *FloatValue) String() string { return FormatFloat(f.Value) }
+
+// FormatFloat renders a float the way show and toText do: the shortest
+// decimal that round-trips, in plain notation unless the magnitude is below
+// 1e-4 or at least 1e21, and always with a decimal point or exponent
+// (5 -> 5.0, 0.1+0.2 -> 0.30000000000000004, 1e21 -> 1e+21)
+func FormatFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	var s string
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-4 || abs >= 1e21) {
+		s = strconv.FormatFloat(f, 'e', -1, 64)
+	} else {
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
 	}
 	return s
 }
@@ -42,6 +64,22 @@ type StringValue struct {
 func (s *StringValue) Type() string   { return "string" }
 func (s *StringValue) String() string { return s.Value }
 
+// BytesValue represents an opaque byte sequence
+type BytesValue struct {
+	Value []byte
+}
+
+func (b *BytesValue) Type() string   { return "bytes" }
+func (b *BytesValue) String() string { return fmt.Sprintf("<bytes %x>", b.Value) }
+
+// BigIntValue represents an arbitrary-precision integer
+type BigIntValue struct {
+	Value *big.Int
+}
+
+func (b *BigIntValue) Type() string   { return "BigInt" }
+func (b *BigIntValue) String() string { return b.Value.String() }
+
 // BoolValue represents a boolean value
 type BoolValue struct {
 	Value bool
@@ -105,20 +143,30 @@ type RecordValue struct {
 func (r *RecordValue) Type() string { return "record" }
 func (r *RecordValue) String() string {
 	result := "{"
-	first := true
-	for k, v := range r.Fields {
-		if !first {
+	for i, k := range r.FieldNames() {
+		if i > 0 {
 			result += ", "
 		}
-		result += fmt.Sprintf("%s: %s", k, v.String())
-		first = false
+		result += fmt.Sprintf("%s: %s", k, r.Fields[k].String())
 	}
 	result += "}"
 	return result
 }
 
+// FieldNames returns the record's field names in sorted order.
+// Fields is a map, so anything that renders a record iterates over this.
+func (r *RecordValue) FieldNames() []string {
+	names := make([]string, 0, len(r.Fields))
+	for k := range r.Fields {
+		names = append(names, k)
+	}
+	sort.Strings(names) // Bytewise sort
+	return names
+}
+
 // FunctionValue represents a function value
 type FunctionValue struct {
+	Name   string // Binding name, if known (used in runtime error traces)
 	Params []string
 	Body   interface{} // Can be ast.Expr, core.CoreExpr, or typedast.TypedNode
 	Env    *Environment
@@ -126,7 +174,7 @@ type FunctionValue struct {
 }
 
 func (f *FunctionValue) Type() string   { return "function" }
-func (f *FunctionValue) String() string { return "<function>" }
+func (f *FunctionValue) String() string { return fmt.Sprintf("<function/%d>", len(f.Params)) }
 
 // BuiltinFunction represents a built-in function
 type BuiltinFunction struct {
@@ -135,7 +183,63 @@ type BuiltinFunction struct {
 }
 
 func (b *BuiltinFunction) Type() string   { return "builtin" }
-func (b *BuiltinFunction) String() string { return fmt.Sprintf("<builtin: %s>", b.Name) }
+func (b *BuiltinFunction) String() string { return fmt.Sprintf("<builtin:%s>", b.Name) }
+
+// NewtypeConstructor returns the factory for a newtype constructor. Newtype
+// values are represented by their unboxed field, so it returns its argument.
+func NewtypeConstructor(name string) *BuiltinFunction {
+	return &BuiltinFunction{
+		Name: name,
+		Fn: func(args []Value) (Value, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("newtype constructor %s expects 1 argument, got %d", name, len(args))
+			}
+			return args[0], nil
+		},
+	}
+}
+
+// Constructor describes an ADT constructor, so that its $adt factory can be
+// evaluated without a resolver (see CoreEvaluator.RegisterConstructor)
+type Constructor struct {
+	ModulePath string
+	TypeName   string
+	CtorName   string
+	Arity      int
+	FieldNames []string
+	Newtype    bool
+	Index      int
+}
+
+// Value returns what a reference to the constructor's factory evaluates to:
+// the value itself for a nullary constructor, otherwise a function that
+// builds it from the fields
+func (c Constructor) Value() Value {
+	name := fmt.Sprintf("make_%s_%s", c.TypeName, c.CtorName)
+	switch {
+	case c.Arity == 0:
+		return &TaggedValue{ModulePath: c.ModulePath, TypeName: c.TypeName, CtorName: c.CtorName, CtorIndex: c.Index}
+	case c.Newtype:
+		return NewtypeConstructor(name)
+	}
+	return &BuiltinFunction{
+		Name: name,
+		Fn: func(args []Value) (Value, error) {
+			if len(args) != c.Arity {
+				return nil, fmt.Errorf("constructor %s.%s expects %d arguments, got %d",
+					c.TypeName, c.CtorName, c.Arity, len(args))
+			}
+			return &TaggedValue{
+				ModulePath: c.ModulePath,
+				TypeName:   c.TypeName,
+				CtorName:   c.CtorName,
+				Fields:     args,
+				FieldNames: c.FieldNames,
+				CtorIndex:  c.Index,
+			}, nil
+		},
+	}
+}
 
 // ErrorValue represents an error value
 type ErrorValue struct {
@@ -147,13 +251,25 @@ func (e *ErrorValue) String() string { return fmt.Sprintf("Error: %s", e.Message
 
 // TaggedValue represents an ADT constructor at runtime
 type TaggedValue struct {
-	ModulePath string  // Module where type is defined (e.g., "std/option") - prevents ambiguity
-	TypeName   string  // The ADT name (e.g., "Option")
-	CtorName   string  // Constructor name (e.g., "Some", "None")
-	Fields     []Value // Constructor field values
+	ModulePath string   // Module where type is defined (e.g., "std/option") - prevents ambiguity
+	TypeName   string   // The ADT name (e.g., "Option")
+	CtorName   string   // Constructor name (e.g., "Some", "None")
+	Fields     []Value  // Constructor field values
+	FieldNames []string // Field names for record-style constructors (nil if positional)
+	CtorIndex  int      // Position of the constructor in its type declaration (orders derived Ord)
 }
 
 func (t *TaggedValue) Type() string { return t.TypeName }
+
+// Field looks up a named field of a record-style constructor value
+func (t *TaggedValue) Field(name string) (Value, bool) {
+	for i, fieldName := range t.FieldNames {
+		if fieldName == name && i < len(t.Fields) {
+			return t.Fields[i], true
+		}
+	}
+	return nil, false
+}
 func (t *TaggedValue) String() string {
 	if len(t.Fields) == 0 {
 		// Nullary constructor: None
@@ -177,6 +293,9 @@ type RefCell struct {
 	Val      Value // The actual value (once initialized)
 	Init     bool  // Has the value been set?
 	Visiting bool  // Currently being evaluated? (for cycle detection)
+
+	// Thunk computes the value on first Force (lazy module bindings)
+	Thunk func() (Value, error)
 }
 
 // IndirectValue defers to the cell at read-time
@@ -195,6 +314,16 @@ func (iv *IndirectValue) String() string {
 
 // Force resolves the indirection, checking for initialization and cycles
 func (iv *IndirectValue) Force() (Value, error) {
+	if !iv.Cell.Init && iv.Cell.Thunk != nil {
+		val, err := iv.Cell.Thunk()
+		if err != nil {
+			return nil, err
+		}
+		iv.Cell.Val = val
+		iv.Cell.Init = true
+		iv.Cell.Thunk = nil
+		return val, nil
+	}
 	if !iv.Cell.Init {
 		if iv.Cell.Visiting {
 			return nil, fmt.Errorf("RT_REC_001: recursive value used before initialization (non-function RHS). Consider making it a function or introducing laziness")
@@ -57,6 +57,7 @@ func Compare(baseline, new []*BenchmarkResult, baselineLabel, newLabel string) (
 				NewStatus:      false,
 				BaselineError:  "",
 				NewError:       newResult.ErrorCategory,
+				NewErrCode:     newResult.ErrCode,
 			})
 		} else if baselineSuccess && newSuccess {
 			// Still passing
@@ -1,6 +1,7 @@
 package eval_analysis
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -226,6 +227,39 @@ func TestFindRegressions(t *testing.T) {
 	}
 }
 
+func TestFormatRegressions(t *testing.T) {
+	baseline := []*BenchmarkResult{
+		{ID: "test1", Lang: "ailang", Model: "claude", StdoutOk: true, Timestamp: time.Now()},
+		{ID: "test2", Lang: "ailang", Model: "claude", StdoutOk: false, Timestamp: time.Now()},
+	}
+
+	new := []*BenchmarkResult{
+		{ID: "test1", Lang: "ailang", Model: "claude", StdoutOk: false, ErrorCategory: "compile_error", ErrCode: "TC_REC_001", Timestamp: time.Now()},
+		{ID: "test2", Lang: "ailang", Model: "claude", StdoutOk: true, Timestamp: time.Now()},
+	}
+
+	report, err := Compare(baseline, new, "v0.3.0", "current")
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if report.Broken[0].NewErrCode != "TC_REC_001" {
+		t.Errorf("Expected err code TC_REC_001, got %q", report.Broken[0].NewErrCode)
+	}
+
+	output := FormatRegressions(report, false)
+	for _, want := range []string{
+		"Regressions since v0.3.0",
+		"Regressed (1)",
+		"test1 (ailang, claude): compile_error [TC_REC_001]",
+		"Improved (1)",
+		"test2 (ailang, claude)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
 func TestFindImprovements(t *testing.T) {
 	baseline := []*BenchmarkResult{
 		{ID: "test1", Lang: "ailang", Model: "claude", StdoutOk: false, Timestamp: time.Now()},
@@ -31,6 +31,12 @@ func loadExistingDashboard(path string) (*DashboardJSON, error) {
 	return &dashboard, nil
 }
 
+// LoadDashboard reads a dashboard JSON file such as docs/static/benchmarks/latest.json
+// A missing file yields an empty dashboard
+func LoadDashboard(path string) (*DashboardJSON, error) {
+	return loadExistingDashboard(path)
+}
+
 // mergeHistory adds a new entry to the dashboard history or updates an existing entry
 // If the version already exists, it updates that entry. Otherwise, prepends the new entry.
 // History is maintained in reverse chronological order (newest first)
@@ -0,0 +1,77 @@
+package eval_analysis
+
+import (
+	"sort"
+)
+
+// AnalyzeStability groups results by benchmark ID and language and
+// classifies each group as consistently passing, consistently failing or
+// flaky. Results are sorted by benchmark ID, then language.
+func AnalyzeStability(results []*BenchmarkResult) []*BenchmarkStability {
+	type key struct {
+		id   string
+		lang string
+	}
+
+	groups := make(map[key]*BenchmarkStability)
+	models := make(map[key]map[string]bool)
+	for _, r := range results {
+		k := key{r.ID, r.Lang}
+		s, ok := groups[k]
+		if !ok {
+			s = &BenchmarkStability{ID: r.ID, Lang: r.Lang}
+			groups[k] = s
+			models[k] = make(map[string]bool)
+		}
+		s.Runs++
+		if r.StdoutOk {
+			s.Passes++
+		}
+		models[k][r.Model] = true
+	}
+
+	stability := make([]*BenchmarkStability, 0, len(groups))
+	for k, s := range groups {
+		s.PassRate = safeDiv(float64(s.Passes), float64(s.Runs))
+		s.Variance = s.PassRate * (1 - s.PassRate)
+		switch s.Passes {
+		case s.Runs:
+			s.Status = StabilityPassing
+		case 0:
+			s.Status = StabilityFailing
+		default:
+			s.Status = StabilityFlaky
+		}
+		for model := range models[k] {
+			s.Models = append(s.Models, model)
+		}
+		sort.Strings(s.Models)
+		stability = append(stability, s)
+	}
+
+	sort.Slice(stability, func(i, j int) bool {
+		if stability[i].ID != stability[j].ID {
+			return stability[i].ID < stability[j].ID
+		}
+		return stability[i].Lang < stability[j].Lang
+	})
+
+	return stability
+}
+
+// FindFlaky returns the benchmarks whose result varies across runs,
+// most unstable first
+func FindFlaky(results []*BenchmarkResult) []*BenchmarkStability {
+	var flaky []*BenchmarkStability
+	for _, s := range AnalyzeStability(results) {
+		if s.Status == StabilityFlaky {
+			flaky = append(flaky, s)
+		}
+	}
+
+	sort.SliceStable(flaky, func(i, j int) bool {
+		return flaky[i].Variance > flaky[j].Variance
+	})
+
+	return flaky
+}
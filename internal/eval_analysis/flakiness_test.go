@@ -0,0 +1,73 @@
+package eval_analysis
+
+import (
+	"testing"
+)
+
+func TestAnalyzeStability(t *testing.T) {
+	results := []*BenchmarkResult{
+		{ID: "fizzbuzz", Lang: "ailang", Model: "claude", StdoutOk: true},
+		{ID: "fizzbuzz", Lang: "ailang", Model: "gpt5", StdoutOk: true},
+		{ID: "records", Lang: "ailang", Model: "claude", Seed: 1, StdoutOk: true},
+		{ID: "records", Lang: "ailang", Model: "claude", Seed: 2, StdoutOk: false},
+		{ID: "records", Lang: "python", Model: "claude", StdoutOk: false},
+		{ID: "records", Lang: "python", Model: "gpt5", StdoutOk: false},
+	}
+
+	stability := AnalyzeStability(results)
+	if len(stability) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(stability))
+	}
+
+	tests := []struct {
+		id     string
+		lang   string
+		status Stability
+		passes int
+		runs   int
+	}{
+		{"fizzbuzz", "ailang", StabilityPassing, 2, 2},
+		{"records", "ailang", StabilityFlaky, 1, 2},
+		{"records", "python", StabilityFailing, 0, 2},
+	}
+
+	for i, tt := range tests {
+		s := stability[i]
+		if s.ID != tt.id || s.Lang != tt.lang {
+			t.Errorf("Group %d: expected %s/%s, got %s/%s", i, tt.id, tt.lang, s.ID, s.Lang)
+		}
+		if s.Status != tt.status {
+			t.Errorf("%s/%s: expected status %s, got %s", tt.id, tt.lang, tt.status, s.Status)
+		}
+		if s.Passes != tt.passes || s.Runs != tt.runs {
+			t.Errorf("%s/%s: expected %d/%d, got %d/%d", tt.id, tt.lang, tt.passes, tt.runs, s.Passes, s.Runs)
+		}
+	}
+
+	if stability[1].Variance != 0.25 {
+		t.Errorf("Expected variance 0.25 for a 50%% pass rate, got %v", stability[1].Variance)
+	}
+	if stability[0].Variance != 0 {
+		t.Errorf("Expected zero variance for a consistent benchmark, got %v", stability[0].Variance)
+	}
+}
+
+func TestFindFlaky(t *testing.T) {
+	results := []*BenchmarkResult{
+		{ID: "a", Lang: "ailang", StdoutOk: true},
+		{ID: "a", Lang: "ailang", StdoutOk: true},
+		{ID: "a", Lang: "ailang", StdoutOk: true},
+		{ID: "a", Lang: "ailang", StdoutOk: false},
+		{ID: "b", Lang: "ailang", StdoutOk: true},
+		{ID: "b", Lang: "ailang", StdoutOk: false},
+		{ID: "c", Lang: "ailang", StdoutOk: true},
+	}
+
+	flaky := FindFlaky(results)
+	if len(flaky) != 2 {
+		t.Fatalf("Expected 2 flaky benchmarks, got %d", len(flaky))
+	}
+	if flaky[0].ID != "b" || flaky[1].ID != "a" {
+		t.Errorf("Expected most unstable first (b, a), got (%s, %s)", flaky[0].ID, flaky[1].ID)
+	}
+}
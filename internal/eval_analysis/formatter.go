@@ -136,6 +136,43 @@ func FormatComparison(report *ComparisonReport, useColor bool) string {
 	return sb.String()
 }
 
+// FormatRegressions produces a concise report of benchmarks that regressed
+// or improved since a baseline, with the error codes of new failures
+func FormatRegressions(report *ComparisonReport, useColor bool) string {
+	var sb strings.Builder
+
+	sb.WriteString(colorize(fmt.Sprintf("Regressions since %s\n", report.BaselineLabel), colorBold, useColor))
+	sb.WriteString(fmt.Sprintf("Success rate: %.1f%% → %.1f%% (%+.1f%%)\n\n",
+		report.BaselineSuccessRate*100, report.NewSuccessRate*100, report.ImprovementPercent()))
+
+	if len(report.Broken) > 0 {
+		sb.WriteString(colorize(fmt.Sprintf("✗ Regressed (%d):\n", len(report.Broken)), colorRed, useColor))
+		for _, change := range report.Broken {
+			errCode := change.NewErrCode
+			if errCode == "" {
+				errCode = "no error code"
+			}
+			sb.WriteString(fmt.Sprintf("  • %s (%s, %s): %s [%s]\n",
+				change.ID, change.Lang, change.Model, change.NewError, errCode))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(report.Fixed) > 0 {
+		sb.WriteString(colorize(fmt.Sprintf("✓ Improved (%d):\n", len(report.Fixed)), colorGreen, useColor))
+		for _, change := range report.Fixed {
+			sb.WriteString(fmt.Sprintf("  • %s (%s, %s)\n", change.ID, change.Lang, change.Model))
+		}
+		sb.WriteString("\n")
+	}
+
+	if !report.HasRegressions() && !report.HasImprovements() {
+		sb.WriteString("No benchmarks changed status\n\n")
+	}
+
+	return sb.String()
+}
+
 // FormatMatrix produces a human-readable matrix summary
 func FormatMatrix(matrix *PerformanceMatrix, useColor bool) string {
 	var sb strings.Builder
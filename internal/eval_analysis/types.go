@@ -93,6 +93,7 @@ type BenchmarkChange struct {
 	NewStatus      bool
 	BaselineError  string
 	NewError       string
+	NewErrCode     string // Error code of the new failure, if any
 }
 
 // PerformanceMatrix contains aggregated performance data
@@ -162,6 +163,27 @@ type ErrorCodeStats struct {
 	RepairSuccess float64 `json:"repair_success"`
 }
 
+// Stability classifies how consistently a benchmark passes across runs
+type Stability string
+
+const (
+	StabilityPassing Stability = "passing" // Every run passed
+	StabilityFailing Stability = "failing" // Every run failed
+	StabilityFlaky   Stability = "flaky"   // Some runs passed and some failed
+)
+
+// BenchmarkStability summarizes repeated runs of one benchmark in one language
+type BenchmarkStability struct {
+	ID       string    `json:"id"`
+	Lang     string    `json:"lang"`
+	Runs     int       `json:"runs"`
+	Passes   int       `json:"passes"`
+	PassRate float64   `json:"pass_rate"`
+	Variance float64   `json:"variance"` // Variance of the pass/fail outcome, p(1-p)
+	Status   Stability `json:"status"`
+	Models   []string  `json:"models"`
+}
+
 // BenchmarkRun contains single benchmark execution stats
 type BenchmarkRun struct {
 	Success        bool `json:"success"`
@@ -218,6 +240,16 @@ type HistoryEntry struct {
 	LanguageStats map[string]interface{} `json:"languageStats,omitempty"`
 }
 
+// HistoryFor returns the history entry recorded for version, if any
+func (d *DashboardJSON) HistoryFor(version string) (*HistoryEntry, bool) {
+	for i := range d.History {
+		if d.History[i].Version == version {
+			return &d.History[i], true
+		}
+	}
+	return nil, false
+}
+
 // Validate checks if a DashboardJSON structure is valid
 func (d *DashboardJSON) Validate() error {
 	if d.Version == "" {
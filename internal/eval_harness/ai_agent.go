@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -14,6 +16,7 @@ type AIAgent struct {
 	model        string // API model name (e.g., "claude-sonnet-4-5-20250929") - used for API calls
 	apiKey       string
 	seed         int64
+	mock         *MockAIAgent // Canned solution; set for the --mock model
 }
 
 // NewAIAgent creates a new AI agent
@@ -58,8 +61,70 @@ func NewAIAgent(model string, seed int64) (*AIAgent, error) {
 	}, nil
 }
 
+// MockModel is the model name of agents that replay a stored solution
+// instead of calling an LLM
+const MockModel = "mock"
+
+// solutionExtensions maps a benchmark language to the file extension of its
+// stored solution
+var solutionExtensions = map[string]string{
+	"ailang": "ail",
+	"python": "py",
+}
+
+// SolutionPath returns where the known-good solution of a benchmark is
+// stored: <benchmarksDir>/<id>.solution.<ext>, next to the benchmark spec
+// (e.g. benchmarks/fizzbuzz.solution.ail).
+func SolutionPath(benchmarksDir, benchmarkID, lang string) (string, error) {
+	ext, ok := solutionExtensions[lang]
+	if !ok {
+		return "", fmt.Errorf("no solution file extension for language %s", lang)
+	}
+	return filepath.Join(benchmarksDir, benchmarkID+".solution."+ext), nil
+}
+
+// SolutionIDs lists the benchmarks in dir with a stored solution for lang, sorted
+func SolutionIDs(benchmarksDir, lang string) []string {
+	ext, ok := solutionExtensions[lang]
+	if !ok {
+		return nil
+	}
+	files, _ := filepath.Glob(filepath.Join(benchmarksDir, "*.solution."+ext))
+	ids := make([]string, 0, len(files))
+	for _, file := range files {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(file), ".solution."+ext))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// NewMockAgent creates an agent that answers every prompt with the stored
+// solution of a benchmark, so the harness runs offline and deterministically
+func NewMockAgent(benchmarksDir, benchmarkID, lang string, seed int64) (*AIAgent, error) {
+	path, err := SolutionPath(benchmarksDir, benchmarkID, lang)
+	if err != nil {
+		return nil, err
+	}
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no mock solution for %s (%s): %w; stored %s solutions: %s",
+			benchmarkID, lang, err, lang, strings.Join(SolutionIDs(benchmarksDir, lang), ", "))
+	}
+
+	return &AIAgent{
+		friendlyName: MockModel,
+		model:        MockModel,
+		seed:         seed,
+		mock:         NewMockAIAgent(MockModel, string(code)),
+	}, nil
+}
+
 // GenerateCode generates code using the LLM
 func (a *AIAgent) GenerateCode(ctx context.Context, prompt string) (*GenerateResult, error) {
+	if a.mock != nil {
+		return a.mock.GenerateCode(ctx, prompt)
+	}
+
 	// Determine provider from model name
 	provider := guessProvider(a.model)
 
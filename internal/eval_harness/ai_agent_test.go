@@ -0,0 +1,76 @@
+package eval_harness
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewMockAgent(t *testing.T) {
+	dir := t.TempDir()
+	code := "module benchmark/solution\n"
+	if err := os.WriteFile(filepath.Join(dir, "demo.solution.ail"), []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	agent, err := NewMockAgent(dir, "demo", "ailang", 7)
+	if err != nil {
+		t.Fatalf("NewMockAgent failed: %v", err)
+	}
+	result, err := agent.GenerateCode(context.Background(), "prompt")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	if result.Code != code {
+		t.Errorf("Expected stored solution, got %q", result.Code)
+	}
+	if result.Model != MockModel || agent.friendlyName != MockModel || agent.seed != 7 {
+		t.Errorf("Unexpected mock agent identity: model=%s name=%s seed=%d", result.Model, agent.friendlyName, agent.seed)
+	}
+
+	// Missing solutions fail loudly
+	if _, err := NewMockAgent(dir, "demo", "python", 7); err == nil || !strings.Contains(err.Error(), "no mock solution") {
+		t.Errorf("Expected missing solution error, got %v", err)
+	}
+	// ...and name the benchmarks that do have one
+	if _, err := NewMockAgent(dir, "dmeo", "ailang", 7); err == nil || !strings.Contains(err.Error(), "stored ailang solutions: demo") {
+		t.Errorf("Expected available solutions in error, got %v", err)
+	}
+	if _, err := NewMockAgent(dir, "demo", "cobol", 7); err == nil {
+		t.Error("Expected error for unknown language")
+	}
+}
+
+// TestStoredSolutions checks that every stored solution belongs to a
+// benchmark that supports its language
+func TestStoredSolutions(t *testing.T) {
+	dir := filepath.Join("..", "..", "benchmarks")
+	files, err := filepath.Glob(filepath.Join(dir, "*.solution.*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no stored solutions found")
+	}
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		id := name[:strings.Index(name, ".solution.")]
+		spec, err := LoadSpec(filepath.Join(dir, id+".yml"))
+		if err != nil {
+			t.Errorf("%s: no benchmark spec: %v", name, err)
+			continue
+		}
+		found := false
+		for lang := range solutionExtensions {
+			if path, _ := SolutionPath(dir, id, lang); path == file {
+				found = spec.SupportsLanguage(lang)
+			}
+		}
+		if !found {
+			t.Errorf("%s: benchmark %s does not support this solution's language", name, id)
+		}
+	}
+}
@@ -70,7 +70,7 @@ var Rules = []errorRule{
 	},
 	{
 		PAR_001,
-		regexp.MustCompile(`PAR_NO_PREFIX_PARSE|PAR_UNEXPECTED_TOKEN|parse errors? in|unexpected token`),
+		regexp.MustCompile(`PAR_NO_PREFIX_PARSE|PAR_UNEXPECTED_TOKEN|PAR_UNCLOSED_DELIM|parse errors? in|unexpected token`),
 		RepairHint{
 			Title: "Parse error",
 			Why:   "AILANG syntax error - common issues: missing semicolons in blocks, wrong syntax for let/lambda/records.",
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/sunholo/ailang/internal/schema"
 )
 
 // RunMetrics captures the results of a single benchmark run
@@ -112,6 +114,77 @@ func (l *MetricsLogger) Log(m *RunMetrics) error {
 	return nil
 }
 
+// RunSummary is the per-run record written to an `ailang eval --output
+// <file>.json` results file. It is the raw data of a run, without the
+// aggregation the dashboard exports apply.
+type RunSummary struct {
+	Benchmark    string  `json:"benchmark"`
+	Lang         string  `json:"lang"`
+	Model        string  `json:"model"`
+	Seed         int64   `json:"seed"`
+	CompileOk    bool    `json:"compile_ok"`
+	RuntimeOk    bool    `json:"runtime_ok"`
+	StdoutOk     bool    `json:"stdout_ok"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	TotalTokens  int     `json:"total_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	ErrorCode    string  `json:"error_code,omitempty"`
+	ErrorCat     string  `json:"error_category"`
+	DurationMs   int64   `json:"duration_ms"`
+	RepairUsed   bool    `json:"repair_used"`
+	RepairOk     bool    `json:"repair_ok"`
+}
+
+// ResultsFile is the document written by `ailang eval --output <file>.json`
+type ResultsFile struct {
+	Schema string       `json:"schema"`
+	Runs   []RunSummary `json:"runs"`
+}
+
+// NewRunSummary summarizes the metrics of one run
+func NewRunSummary(m *RunMetrics) RunSummary {
+	return RunSummary{
+		Benchmark:    m.ID,
+		Lang:         m.Lang,
+		Model:        m.Model,
+		Seed:         m.Seed,
+		CompileOk:    m.CompileOk,
+		RuntimeOk:    m.RuntimeOk,
+		StdoutOk:     m.StdoutOk,
+		InputTokens:  m.InputTokens,
+		OutputTokens: m.OutputTokens,
+		TotalTokens:  m.TotalTokens,
+		CostUSD:      m.CostUSD,
+		ErrorCode:    m.ErrCode,
+		ErrorCat:     m.ErrorCategory,
+		DurationMs:   m.DurationMs,
+		RepairUsed:   m.RepairUsed,
+		RepairOk:     m.RepairOk,
+	}
+}
+
+// WriteResultsFile writes runs to path as an ailang.eval/v1 document
+func WriteResultsFile(path string, runs []RunSummary) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if runs == nil {
+		runs = []RunSummary{}
+	}
+
+	data, err := json.MarshalIndent(ResultsFile{Schema: schema.EvalV1, Runs: runs}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal results: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	return nil
+}
+
 // CalculateCostWithBreakdown calculates cost using separate input/output token counts
 // This provides accurate pricing based on models.yml configuration
 // Returns 0.0 if model not found - FAIL LOUDLY, NO SILENT FALLBACKS
@@ -120,3 +120,47 @@ func TestNewRunMetrics(t *testing.T) {
 		t.Error("Timestamp is not recent")
 	}
 }
+
+func TestWriteResultsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "result.json")
+
+	metrics := NewRunMetrics("fizzbuzz", "ailang", "gpt-4", 42)
+	metrics.CompileOk = true
+	metrics.RuntimeOk = false
+	metrics.OutputTokens = 120
+	metrics.CostUSD = 0.01
+	metrics.ErrCode = "RT_001"
+	metrics.ErrorCategory = ErrorCategoryRuntime
+	metrics.DurationMs = 250
+	metrics.Stdout = "not part of the summary"
+
+	if err := WriteResultsFile(path, []RunSummary{NewRunSummary(metrics)}); err != nil {
+		t.Fatalf("WriteResultsFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	var loaded ResultsFile
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if loaded.Schema != "ailang.eval/v1" {
+		t.Errorf("Expected schema ailang.eval/v1, got %q", loaded.Schema)
+	}
+	if len(loaded.Runs) != 1 {
+		t.Fatalf("Expected 1 run, got %d", len(loaded.Runs))
+	}
+	run := loaded.Runs[0]
+	if run.Benchmark != "fizzbuzz" || run.Lang != "ailang" || run.Model != "gpt-4" {
+		t.Errorf("Unexpected run identity: %+v", run)
+	}
+	if !run.CompileOk || run.RuntimeOk || run.ErrorCode != "RT_001" || run.ErrorCat != ErrorCategoryRuntime {
+		t.Errorf("Unexpected run outcome: %+v", run)
+	}
+	if run.OutputTokens != 120 || run.CostUSD != 0.01 || run.DurationMs != 250 {
+		t.Errorf("Unexpected run measurements: %+v", run)
+	}
+}
@@ -127,7 +127,7 @@ func (r *RepairRunner) runSingleAttempt(ctx context.Context, prompt string) (*at
 	}
 
 	// Check if output matches expected
-	stdoutOk := CompareOutput(r.spec.ExpectedOut, runResult.Stdout)
+	stdoutOk := CompareOutputWith(r.spec.ExpectedOut, runResult.Stdout, r.spec.Normalize)
 
 	return &attemptResult{
 		Code:         genResult.Code,
@@ -3,9 +3,12 @@ package eval_harness
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -250,13 +253,68 @@ func (r *AILANGRunner) Run(code string, timeout time.Duration) (*RunResult, erro
 
 // CompareOutput checks if actual output matches expected output
 func CompareOutput(expected, actual string) bool {
-	// Normalize whitespace
-	expected = strings.TrimSpace(expected)
-	actual = strings.TrimSpace(actual)
+	return CompareOutputWith(expected, actual, OutputNormalization{})
+}
+
+// CompareOutputWith checks if actual output matches expected output after
+// applying the normalization of a benchmark spec to both.
+// Leading and trailing whitespace of the whole output is always ignored.
+func CompareOutputWith(expected, actual string, norm OutputNormalization) bool {
+	expectedLines := norm.lines(expected)
+	actualLines := norm.lines(actual)
+	if len(expectedLines) != len(actualLines) {
+		return false
+	}
+	for i := range expectedLines {
+		if !norm.lineMatches(expectedLines[i], actualLines[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// numberPattern matches integer and decimal literals in program output
+var numberPattern = regexp.MustCompile(`-?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?`)
+
+// lines splits output into the lines that are compared
+func (n OutputNormalization) lines(output string) []string {
+	output = strings.TrimSpace(output)
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if n.TrimTrailingWhitespace {
+			line = strings.TrimRight(line, " \t")
+		}
+		if n.IgnoreBlankLines && strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
 
-	// For now, do exact string comparison
-	// Could be enhanced with fuzzy matching or line-by-line comparison
-	return expected == actual
+// lineMatches compares two lines. With a numeric tolerance, numbers in the
+// same position may differ by up to the tolerance; the rest must be equal.
+func (n OutputNormalization) lineMatches(expected, actual string) bool {
+	if expected == actual {
+		return true
+	}
+	if n.NumericTolerance == 0 {
+		return false
+	}
+	if numberPattern.ReplaceAllString(expected, "#") != numberPattern.ReplaceAllString(actual, "#") {
+		return false
+	}
+
+	expectedNums := numberPattern.FindAllString(expected, -1)
+	actualNums := numberPattern.FindAllString(actual, -1)
+	for i := range expectedNums {
+		e, errE := strconv.ParseFloat(expectedNums[i], 64)
+		a, errA := strconv.ParseFloat(actualNums[i], 64)
+		if errE != nil || errA != nil || math.Abs(e-a) > n.NumericTolerance {
+			return false
+		}
+	}
+	return true
 }
 
 // GetRunner returns a LanguageRunner for the specified language
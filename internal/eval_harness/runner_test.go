@@ -96,6 +96,40 @@ func TestCompareOutput(t *testing.T) {
 	}
 }
 
+func TestCompareOutputWith(t *testing.T) {
+	trim := OutputNormalization{TrimTrailingWhitespace: true}
+	blank := OutputNormalization{IgnoreBlankLines: true}
+	numeric := OutputNormalization{NumericTolerance: 0.001}
+
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		norm     OutputNormalization
+		want     bool
+	}{
+		{"trailing spaces strict", "a\nb", "a  \nb", OutputNormalization{}, false},
+		{"trailing spaces trimmed", "a\nb", "a  \nb\t", trim, true},
+		{"leading spaces kept", "a\nb", "a\n  b", trim, false},
+		{"blank lines strict", "a\nb", "a\n\nb", OutputNormalization{}, false},
+		{"blank lines ignored", "a\nb", "a\n\n  \nb", blank, true},
+		{"line count differs", "a\nb", "a", blank, false},
+		{"numbers within tolerance", "pi = 3.1416\n2", "pi = 3.14159\n2", numeric, true},
+		{"numbers outside tolerance", "pi = 3.1416", "pi = 3.15", numeric, false},
+		{"scientific notation", "1e3", "1000.0001", numeric, true},
+		{"text differs around numbers", "x = 1.0", "y = 1.0", numeric, false},
+		{"number count differs", "1.0 2.0", "1.0", numeric, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareOutputWith(tt.expected, tt.actual, tt.norm); got != tt.want {
+				t.Errorf("CompareOutputWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetRunner(t *testing.T) {
 	spec := &BenchmarkSpec{
 		ID:     "test",
@@ -3,23 +3,41 @@ package eval_harness
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
 	"gopkg.in/yaml.v3"
 )
 
 // BenchmarkSpec defines a single benchmark task
 type BenchmarkSpec struct {
-	ID           string            `yaml:"id"`
-	Description  string            `yaml:"description"`
-	Languages    []string          `yaml:"languages"`
-	Entrypoint   string            `yaml:"entrypoint"`
-	Caps         []string          `yaml:"caps"`
-	Prompt       string            `yaml:"prompt"`       // Inline prompt text (language-agnostic)
-	PromptFiles  map[string]string `yaml:"prompt_files"` // Language-specific prompt files: {ailang: "prompts/v0.3.0.md"}
-	TaskPrompt   string            `yaml:"task_prompt"`  // Task-specific prompt appended after base prompt
-	ExpectedOut  string            `yaml:"expected_stdout"`
-	Difficulty   string            `yaml:"difficulty"`
-	ExpectedGain string            `yaml:"expected_gain"`
+	ID           string              `yaml:"id"`
+	Description  string              `yaml:"description"`
+	Languages    []string            `yaml:"languages"`
+	Entrypoint   string              `yaml:"entrypoint"`
+	Caps         []string            `yaml:"caps"`
+	Prompt       string              `yaml:"prompt"`       // Inline prompt text (language-agnostic)
+	PromptFiles  map[string]string   `yaml:"prompt_files"` // Language-specific prompt files: {ailang: "prompts/v0.3.0.md"}
+	TaskPrompt   string              `yaml:"task_prompt"`  // Task-specific prompt appended after base prompt
+	ExpectedOut  string              `yaml:"expected_stdout"`
+	Normalize    OutputNormalization `yaml:"normalize"` // How stdout is normalized before comparison
+	Difficulty   string              `yaml:"difficulty"`
+	ExpectedGain string              `yaml:"expected_gain"`
+}
+
+// OutputNormalization lists the normalizations applied to both the expected
+// and the actual stdout before they are compared:
+//
+//	normalize:
+//	  trim_trailing_whitespace: true
+//	  ignore_blank_lines: true
+//	  numeric_tolerance: 0.001
+type OutputNormalization struct {
+	TrimTrailingWhitespace bool    `yaml:"trim_trailing_whitespace"` // Strip trailing spaces/tabs from each line
+	IgnoreBlankLines       bool    `yaml:"ignore_blank_lines"`       // Drop lines that are empty or whitespace
+	NumericTolerance       float64 `yaml:"numeric_tolerance"`        // Max absolute difference between numbers in the same position
 }
 
 // LoadSpec loads a benchmark spec from a YAML file
@@ -42,6 +60,10 @@ func LoadSpec(path string) (*BenchmarkSpec, error) {
 		return nil, fmt.Errorf("spec missing required field: languages")
 	}
 
+	if spec.Normalize.NumericTolerance < 0 {
+		return nil, fmt.Errorf("spec %s: normalize.numeric_tolerance must not be negative", spec.ID)
+	}
+
 	// No backward compatibility - benchmarks must use prompt_files
 
 	// Note: We don't load prompts here anymore - they're loaded per-language in PromptForLanguage()
@@ -50,6 +72,33 @@ func LoadSpec(path string) (*BenchmarkSpec, error) {
 	return &spec, nil
 }
 
+// SpecPath returns the spec file of benchmark id in dir (<dir>/<id>.yml).
+// An empty id, or one without a spec, is an error naming the path that was
+// tried and listing the benchmarks that exist.
+func SpecPath(dir, id string) (string, error) {
+	if strings.TrimSpace(id) == "" {
+		return "", fmt.Errorf("empty benchmark id; available: %s", strings.Join(BenchmarkIDs(dir), ", "))
+	}
+	path := filepath.Join(dir, id+".yml")
+	if _, err := os.Stat(path); err != nil {
+		ids := BenchmarkIDs(dir)
+		return "", fmt.Errorf("benchmark %q not found (looked for %s)%s; available: %s",
+			id, path, ailangErrors.DidYouMean(id, ids), strings.Join(ids, ", "))
+	}
+	return path, nil
+}
+
+// BenchmarkIDs lists the benchmarks with a spec file in dir, sorted
+func BenchmarkIDs(dir string) []string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.yml"))
+	ids := make([]string, 0, len(files))
+	for _, file := range files {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(file), ".yml"))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // SupportsLanguage checks if the benchmark supports a given language
 func (s *BenchmarkSpec) SupportsLanguage(lang string) bool {
 	for _, l := range s.Languages {
@@ -3,6 +3,7 @@ package eval_harness
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -46,6 +47,40 @@ expected_stdout: "Hello"
 	}
 }
 
+func TestLoadSpec_Normalize(t *testing.T) {
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "norm.yml")
+
+	content := `id: norm
+languages: ["ailang"]
+expected_stdout: "3.14"
+normalize:
+  trim_trailing_whitespace: true
+  ignore_blank_lines: true
+  numeric_tolerance: 0.01
+`
+	if err := os.WriteFile(specPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	spec, err := LoadSpec(specPath)
+	if err != nil {
+		t.Fatalf("LoadSpec failed: %v", err)
+	}
+	want := OutputNormalization{TrimTrailingWhitespace: true, IgnoreBlankLines: true, NumericTolerance: 0.01}
+	if spec.Normalize != want {
+		t.Errorf("Expected normalize %+v, got %+v", want, spec.Normalize)
+	}
+
+	content = "id: bad\nlanguages: [\"ailang\"]\nnormalize:\n  numeric_tolerance: -1\n"
+	if err := os.WriteFile(specPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if _, err := LoadSpec(specPath); err == nil {
+		t.Error("Expected error for negative numeric_tolerance")
+	}
+}
+
 func TestLoadSpec_MissingRequired(t *testing.T) {
 	tmpDir := t.TempDir()
 	specPath := filepath.Join(tmpDir, "invalid.yml")
@@ -112,3 +147,37 @@ func TestPromptForLanguage(t *testing.T) {
 		}
 	}
 }
+
+func TestSpecPath(t *testing.T) {
+	dir := t.TempDir()
+	for _, id := range []string{"fizzbuzz", "float_eq"} {
+		if err := os.WriteFile(filepath.Join(dir, id+".yml"), []byte("id: "+id+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write spec: %v", err)
+		}
+	}
+
+	path, err := SpecPath(dir, "fizzbuzz")
+	if err != nil || path != filepath.Join(dir, "fizzbuzz.yml") {
+		t.Errorf("Expected %s, got %q (err: %v)", filepath.Join(dir, "fizzbuzz.yml"), path, err)
+	}
+
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{"fizzbuz", []string{`benchmark "fizzbuz" not found`, filepath.Join(dir, "fizzbuz.yml"), "did you mean 'fizzbuzz'?", "available: fizzbuzz, float_eq"}},
+		{"", []string{"empty benchmark id", "available: fizzbuzz, float_eq"}},
+	}
+	for _, tt := range tests {
+		_, err := SpecPath(dir, tt.id)
+		if err == nil {
+			t.Errorf("SpecPath(%q): expected an error", tt.id)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("SpecPath(%q): expected %q in error, got: %v", tt.id, want, err)
+			}
+		}
+	}
+}
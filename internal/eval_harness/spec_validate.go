@@ -0,0 +1,156 @@
+package eval_harness
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SpecProblem is a defect of the benchmark corpus found by ValidateSpecs
+type SpecProblem struct {
+	File    string // Spec or solution file the problem is in
+	Message string
+}
+
+func (p SpecProblem) String() string {
+	return p.File + ": " + p.Message
+}
+
+// ValidateSpecs checks every benchmark spec (*.yml) in dir and the stored
+// solutions next to them, without invoking a model or running any code.
+//
+// A spec must load (see LoadSpec), be named after its id (eval --benchmark
+// and --mock look it up by id), have a prompt (prompt, task_prompt or
+// prompt_files), an expected_stdout, and only languages the harness can run.
+// Its prompt_files must exist. A stored solution <id>.solution.<ext> must
+// belong to a spec supporting that language, and an AILANG one must declare
+// module benchmark/solution.
+//
+// Returns:
+//   - The problems found, sorted by file
+//   - The number of spec files checked
+//   - An error if dir cannot be read
+func ValidateSpecs(dir string) ([]SpecProblem, int, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, 0, fmt.Errorf("benchmarks directory: %w", err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.yml"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var problems []SpecProblem
+	specs := make(map[string]*BenchmarkSpec)
+	for _, file := range files {
+		spec, specProblems := validateSpec(file)
+		problems = append(problems, specProblems...)
+		if spec != nil {
+			specs[spec.ID] = spec
+		}
+	}
+	problems = append(problems, validateSolutions(dir, specs)...)
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].File < problems[j].File })
+	return problems, len(files), nil
+}
+
+// validateSpec checks one spec file, returning the spec if it loaded
+func validateSpec(file string) (*BenchmarkSpec, []SpecProblem) {
+	spec, err := LoadSpec(file)
+	if err != nil {
+		return nil, []SpecProblem{{File: file, Message: err.Error()}}
+	}
+
+	var problems []SpecProblem
+	report := func(format string, args ...interface{}) {
+		problems = append(problems, SpecProblem{File: file, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if name := strings.TrimSuffix(filepath.Base(file), ".yml"); name != spec.ID {
+		report("id %q does not match the file name (expected %s.yml)", spec.ID, spec.ID)
+	}
+	if spec.Prompt == "" && spec.TaskPrompt == "" && len(spec.PromptFiles) == 0 {
+		report("spec missing required field: prompt, task_prompt or prompt_files")
+	}
+	if spec.ExpectedOut == "" {
+		report("spec missing required field: expected_stdout")
+	}
+	for _, lang := range spec.Languages {
+		if _, ok := solutionExtensions[lang]; !ok {
+			report("unsupported language %q", lang)
+		}
+	}
+
+	langs := make([]string, 0, len(spec.PromptFiles))
+	for lang := range spec.PromptFiles {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		if !spec.SupportsLanguage(lang) {
+			report("prompt_files lists %s, which is not in languages", lang)
+		}
+		if _, err := os.Stat(spec.PromptFiles[lang]); err != nil {
+			report("prompt file for %s not found: %s", lang, spec.PromptFiles[lang])
+		}
+	}
+	return spec, problems
+}
+
+// validateSolutions checks the stored solutions in dir against the specs
+func validateSolutions(dir string, specs map[string]*BenchmarkSpec) []SpecProblem {
+	files, err := filepath.Glob(filepath.Join(dir, "*.solution.*"))
+	if err != nil {
+		return nil
+	}
+
+	var problems []SpecProblem
+	for _, file := range files {
+		base := filepath.Base(file)
+		id := base[:strings.Index(base, ".solution.")]
+		ext := strings.TrimPrefix(filepath.Ext(base), ".")
+
+		lang := ""
+		for l, e := range solutionExtensions {
+			if e == ext {
+				lang = l
+			}
+		}
+		spec, ok := specs[id]
+		switch {
+		case lang == "":
+			problems = append(problems, SpecProblem{File: file, Message: fmt.Sprintf("unknown solution language extension .%s", ext)})
+			continue
+		case !ok:
+			problems = append(problems, SpecProblem{File: file, Message: fmt.Sprintf("solution for unknown benchmark %q (no valid %s.yml)", id, id)})
+			continue
+		case !spec.SupportsLanguage(lang):
+			problems = append(problems, SpecProblem{File: file, Message: fmt.Sprintf("benchmark %s does not list language %s", id, lang)})
+		}
+
+		if lang == "ailang" {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				problems = append(problems, SpecProblem{File: file, Message: err.Error()})
+				continue
+			}
+			if !declaresSolutionModule(string(data)) {
+				problems = append(problems, SpecProblem{File: file, Message: "solution must declare module benchmark/solution"})
+			}
+		}
+	}
+	return problems
+}
+
+// declaresSolutionModule reports whether source has the module declaration
+// the AILANG runner expects (it writes solutions to benchmark/solution.ail)
+func declaresSolutionModule(source string) bool {
+	for _, line := range strings.Split(source, "\n") {
+		if strings.TrimSpace(line) == "module benchmark/solution" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package eval_harness
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSpecs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"good.yml": `id: good
+languages: ["ailang"]
+task_prompt: "Print hello in <LANG>"
+expected_stdout: "hello"
+`,
+		"good.solution.ail": "module benchmark/solution\n\nexport func main() -> () ! {IO} { println(\"hello\") }\n",
+		"renamed.yml": `id: other
+languages: ["ailang"]
+prompt: "p"
+expected_stdout: "x"
+`,
+		"incomplete.yml": `id: incomplete
+languages: ["ailang", "cobol"]
+prompt_files:
+  ailang: missing_prompt.md
+`,
+		"broken.yml":          "id: broken\n",
+		"good.solution.py":    "print('hello')\n",
+		"orphan.solution.ail": "module benchmark/solution\n",
+		"badmod.yml":          "id: badmod\nlanguages: [\"ailang\"]\nprompt: p\nexpected_stdout: x\n",
+		"badmod.solution.ail": "module benchmarks/badmod\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	problems, count, err := ValidateSpecs(dir)
+	if err != nil {
+		t.Fatalf("ValidateSpecs failed: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 specs checked, got %d", count)
+	}
+
+	want := []string{
+		"badmod.solution.ail: solution must declare module benchmark/solution",
+		"broken.yml: spec missing required field: languages",
+		"good.solution.py: benchmark good does not list language python",
+		"incomplete.yml: spec missing required field: expected_stdout",
+		"incomplete.yml: unsupported language \"cobol\"",
+		"incomplete.yml: prompt file for ailang not found: missing_prompt.md",
+		"orphan.solution.ail: solution for unknown benchmark \"orphan\" (no valid orphan.yml)",
+		"renamed.yml: id \"other\" does not match the file name (expected other.yml)",
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, strings.TrimPrefix(p.String(), dir+string(filepath.Separator)))
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected problems:\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateSpecs_Corpus(t *testing.T) {
+	problems, count, err := ValidateSpecs("../../benchmarks")
+	if err != nil {
+		t.Fatalf("ValidateSpecs failed: %v", err)
+	}
+	if count == 0 {
+		t.Fatal("Expected benchmark specs in benchmarks/")
+	}
+	for _, p := range problems {
+		// prompt_files are relative to the repository root, not this package
+		if strings.Contains(p.Message, "prompt file") {
+			continue
+		}
+		t.Errorf("benchmark corpus: %s", p)
+	}
+}
@@ -0,0 +1,151 @@
+// Package formatter pretty-prints AILANG source in canonical form.
+//
+// It works on the surface AST rather than Core: a file is parsed, printed back
+// with consistent indentation and spacing, and the output is parsed again to
+// check that the program is unchanged. Comments are carried over from the lexer.
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/lexer"
+	"github.com/sunholo/ailang/internal/parser"
+)
+
+// Source formats a complete AILANG source file.
+// It fails if the source does not parse, or if the formatted output would not
+// parse back to the same program (which indicates a formatter bug).
+func Source(src []byte, filename string) ([]byte, error) {
+	file, comments, err := parseSource(src, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	text := string(lexer.Normalize(src))
+	p := newPrinter(text, comments, tokenize(text, filename))
+	p.file(file)
+	if p.err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, p.err)
+	}
+	out := strings.TrimRight(p.buf.String(), "\n")
+	if out != "" {
+		out += "\n"
+	}
+
+	// Never hand back output that means something else
+	formatted, outComments, err := parseSource([]byte(out), filename)
+	if err != nil {
+		return nil, fmt.Errorf("internal formatter error: output does not parse: %w", err)
+	}
+	if !sameAST(reflect.ValueOf(file), reflect.ValueOf(formatted)) {
+		return nil, fmt.Errorf("internal formatter error: output of %s parses to a different program", filename)
+	}
+	if !sameComments(comments, outComments) {
+		return nil, fmt.Errorf("internal formatter error: comments of %s were not preserved", filename)
+	}
+	return []byte(out), nil
+}
+
+func parseSource(src []byte, filename string) (*ast.File, []lexer.Comment, error) {
+	l := lexer.New(string(src), filename)
+	p := parser.New(l)
+	file := p.ParseFile()
+	if errs := p.Errors(); len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	return file, l.Comments(), nil
+}
+
+func tokenize(src, filename string) []lexer.Token {
+	l := lexer.New(src, filename)
+	var tokens []lexer.Token
+	for {
+		tok := l.NextToken()
+		tokens = append(tokens, tok)
+		if tok.Type == lexer.EOF {
+			return tokens
+		}
+	}
+}
+
+var (
+	posType  = reflect.TypeOf(ast.Pos{})
+	spanType = reflect.TypeOf(ast.Span{})
+)
+
+// sameAST compares two syntax trees structurally, ignoring source positions.
+// Nil and empty slices are considered equal.
+func sameAST(a, b reflect.Value) bool {
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Kind() == reflect.Interface && a.Elem().Type() != b.Elem().Type() {
+			return false
+		}
+		return sameAST(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if a.Type() == posType || a.Type() == spanType {
+			return true
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !sameAST(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !sameAST(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			bv := b.MapIndex(key)
+			if !bv.IsValid() || !sameAST(a.MapIndex(key), bv) {
+				return false
+			}
+		}
+		return true
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	default:
+		return false
+	}
+}
+
+func sameComments(a, b []lexer.Comment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if commentText(a[i]) != commentText(b[i]) {
+			return false
+		}
+	}
+	return true
+}
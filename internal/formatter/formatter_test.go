@@ -0,0 +1,281 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/lexer"
+	"github.com/sunholo/ailang/internal/parser"
+)
+
+func mustFormat(t *testing.T, src string) string {
+	t.Helper()
+	out, err := Source([]byte(src), "test.ail")
+	if err != nil {
+		t.Fatalf("Source() error: %v\ninput:\n%s", err, src)
+	}
+	again, err := Source(out, "test.ail")
+	if err != nil {
+		t.Fatalf("formatting output again failed: %v\noutput:\n%s", err, out)
+	}
+	if string(again) != string(out) {
+		t.Fatalf("formatting is not idempotent\nfirst:\n%s\nsecond:\n%s", out, again)
+	}
+	return string(out)
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "spacing",
+			input: "module m\nimport std/io (println,print)\nexport func add(x:int,y:int)->int{x+y*2}",
+			want:  "module m\nimport std/io (println, print)\nexport func add(x: int, y: int) -> int {\n  x + y * 2\n}\n",
+		},
+		{
+			name:  "block_and_indentation",
+			input: "export func main() -> () ! {IO} {\nlet x = 1;\n    println(show(x));\n  ()\n}\n",
+			want:  "export func main() -> () ! {IO} {\n  let x = 1;\n  println(show(x));\n  ()\n}\n",
+		},
+		{
+			name:  "equation_form",
+			input: "export pure func double(x: int) -> int =   x*2\n",
+			want:  "export pure func double(x: int) -> int = x * 2\n",
+		},
+		{
+			name:  "module_values",
+			input: "module m\nexport let pi=3.14159\nconst sizes:[int]=[1,2,4]\n",
+			want:  "module m\nexport let pi = 3.14159\nconst sizes: [int] = [1, 2, 4]\n",
+		},
+		{
+			name:  "minimal_parens",
+			input: "func f(a: int, b: int) -> int { ((a + b)) * (a - (b - 1)) + ((a * b)) }",
+			want:  "func f(a: int, b: int) -> int {\n  (a + b) * (a - (b - 1)) + a * b\n}\n",
+		},
+		{
+			name:  "match_trailing_comma",
+			input: "func f(o) { match o { Some(x) if x > 0 => x, Some(_) => 0, None => -1, } }",
+			want:  "func f(o) {\n  match o {\n    Some(x) if x > 0 => x,\n    Some(_) => 0,\n    None => -1\n  }\n}\n",
+		},
+		{
+			name:  "or_and_as_patterns",
+			input: "func f(o) { match o { Some(1)|Some(2) => 0, w@Some(3|4) => 1, _ => 2 } }",
+			want:  "func f(o) {\n  match o {\n    Some(1) | Some(2) => 0,\n    w @ Some(3 | 4) => 1,\n    _ => 2\n  }\n}\n",
+		},
+		{
+			name:  "list_and_record_trailing_commas",
+			input: "func f() { {name: \"a\", tags: [1, 2, 3,],} }",
+			want:  "func f() {\n  {name: \"a\", tags: [1, 2, 3]}\n}\n",
+		},
+		{
+			name:  "multiline_list_kept",
+			input: "func f() {\n  [\n    1,\n    2,\n  ]\n}",
+			want:  "func f() {\n  [\n    1,\n    2\n  ]\n}\n",
+		},
+		{
+			name:  "curried_lambda",
+			input: "func f() { \\x. \\y. x + y }",
+			want:  "func f() {\n  \\x y. x + y\n}\n",
+		},
+		{
+			name:  "zero_param_lambda",
+			input: "func f() { try(func( ) => 1 / 0) }",
+			want:  "func f() {\n  try(func() => 1 / 0)\n}\n",
+		},
+		{
+			name:  "handle_expression",
+			input: "func f() { handle {g(1)} with {IO.println(s)=>print(s),IO.print(s) => ()} }",
+			want:  "func f() {\n  handle {\n    g(1)\n  } with {\n    IO.println(s) => print(s),\n    IO.print(s) => ()\n  }\n}\n",
+		},
+		{
+			name:  "lambda_operand",
+			input: "func f() { (\\x. x)(1) + (if true then 1 else 2) }",
+			want:  "func f() {\n  (\\x. x)(1) + (if true then 1 else 2)\n}\n",
+		},
+		{
+			name:  "operator_sections",
+			input: "func f(xs) { map((+ 1),xs) ++ map((2*), xs) ++ map((a*b +), xs) ++ [(- 1)] }",
+			want:  "func f(xs) {\n  map((+ 1), xs) ++ map((2 *), xs) ++ map((a * b +), xs) ++ [-1]\n}\n",
+		},
+		{
+			name:  "pipeline",
+			input: "func f(xs) { xs|>filter(p)|>map((* 2)) }\nfunc g(x) { -(x |> f) + (a + b |> h) }",
+			want:  "func f(xs) {\n  xs |> filter(p) |> map((* 2))\n}\nfunc g(x) {\n  -(x |> f) + (a + b |> h)\n}\n",
+		},
+		{
+			name:  "types",
+			input: "type Json =\n  | JNull\n  | JArray(List[Json])\ntype Pair[a,b] = {fst: a, snd: b}\ntype Shape = Circle { radius: int } | Dot",
+			want:  "type Json =\n  | JNull\n  | JArray(List[Json])\ntype Pair[a, b] = {fst: a, snd: b}\ntype Shape = Circle {radius: int} | Dot\n",
+		},
+		{
+			name:  "derive",
+			input: "type Color = Red|Green derive(Eq,Ord)\ntype Shape =\n  | Circle(int)\n  | Dot\n    derive( Show )\ntype T = | T derive(Eq)",
+			want:  "type Color = Red | Green derive(Eq, Ord)\ntype Shape =\n  | Circle(int)\n  | Dot\n  derive(Show)\ntype T = | T derive(Eq)\n",
+		},
+		{
+			name:  "inline_tests",
+			input: "func inc(x: int) -> int\n  tests [inc(1)==2]\n{ x + 1 }\nfunc sq(x) = x*x tests [sq(2) == 4,\n  sq(3) == 9,] where h(y) = y",
+			want:  "func inc(x: int) -> int {\n  x + 1\n} tests [\n  inc(1) == 2\n]\nfunc sq(x) = x * x tests [\n  sq(2) == 4,\n  sq(3) == 9\n]\n  where h(y) = y\n",
+		},
+		{
+			name:  "properties",
+			input: "func abs(x: int) -> int = x tests [abs(1)==1] properties [forall(x:int)=>abs(x)>=0,\n forall(s: string,b: bool) => b,]",
+			want:  "func abs(x: int) -> int = x tests [\n  abs(1) == 1\n] properties [\n  forall(x: int) => abs(x) >= 0,\n  forall(s: string, b: bool) => b\n]\n",
+		},
+		{
+			name:  "all_values",
+			input: "func f() { map(show, allValues[ Direction ]( )) ++ allValues[Pair[int,int]]() }",
+			want:  "func f() {\n  map(show, allValues[Direction]()) ++ allValues[Pair[int, int]]()\n}\n",
+		},
+		{
+			name:  "strings",
+			input: "func f() { \"a\\\"b\\n\" ++ 'c' }",
+			want:  "func f() {\n  \"a\\\"b\\n\" ++ \"c\"\n}\n",
+		},
+		{
+			name:  "annotations",
+			input: "@deprecated(\"use g\")\nexport func f() -> int { 1 }",
+			want:  "@deprecated(\"use g\")\nexport func f() -> int {\n  1\n}\n",
+		},
+		{
+			name:  "where_helpers",
+			input: "func f(x: int) -> int = g(x) + 1 where g(y: int) -> int = y*2\nfunc h(x) { a(x) } where a(y) = b(y), b(z) { z }",
+			want:  "func f(x: int) -> int = g(x) + 1\n  where g(y: int) -> int = y * 2\nfunc h(x) {\n  a(x)\n}\n  where\n    a(y) = b(y),\n    b(z) {\n      z\n    }\n",
+		},
+		{
+			name:  "script_let_chain",
+			input: "let x = 1 in\nlet y = 2 in\nprint(show(x + y))",
+			want:  "let x = 1 in\nlet y = 2 in\nprint(show(x + y))\n",
+		},
+		{
+			name:  "let_chain_value",
+			input: "let total =\n    let x = 1 in\n  let y = 2 in\n  x + y\nlet inline = let a = 1 in a",
+			want:  "let total =\n  let x = 1 in\n  let y = 2 in\n  x + y\nlet inline = let a = 1 in a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustFormat(t, tt.input); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatComments(t *testing.T) {
+	input := `-- Header comment
+module m
+
+-- Doc for f
+export func f(x: int) -> int {
+  -- leading
+  let y = x + 1; -- trailing
+  y
+
+  -- before close
+}
+
+-- Last words
+`
+	want := `-- Header comment
+module m
+
+-- Doc for f
+export func f(x: int) -> int {
+  -- leading
+  let y = x + 1; -- trailing
+  y
+
+  -- before close
+}
+
+-- Last words
+`
+	if got := mustFormat(t, input); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatLetChainComments(t *testing.T) {
+	input := `let simple =
+  let sum = 1 + 2 in     -- first
+  let diff = 10 - 5 in   -- second
+  sum + diff             -- result
+
+let double = \x. x + x  -- inline
+`
+	want := `let simple =
+  let sum = 1 + 2 in -- first
+  let diff = 10 - 5 in -- second
+  sum + diff -- result
+
+let double = \x. x + x -- inline
+`
+	if got := mustFormat(t, input); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatCollapsesBlankLines(t *testing.T) {
+	input := "func a() { 1 }\n\n\n\nfunc b() { 2 }\nfunc c() { 3 }\n"
+	want := "func a() {\n  1\n}\n\nfunc b() {\n  2\n}\nfunc c() {\n  3\n}\n"
+	if got := mustFormat(t, input); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatParseError(t *testing.T) {
+	if _, err := Source([]byte("func f( {"), "bad.ail"); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+// TestFormatCorpus formats every parseable .ail file in the repository and
+// checks that the output is stable. Source itself verifies that the output
+// parses back to the same AST and keeps every comment.
+func TestFormatCorpus(t *testing.T) {
+	var files []string
+	for _, dir := range []string{"examples", "stdlib", "tests"} {
+		_ = filepath.Walk(filepath.Join("..", "..", dir), func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() && strings.HasSuffix(path, ".ail") {
+				files = append(files, path)
+			}
+			return nil
+		})
+	}
+	if len(files) == 0 {
+		t.Skip("no .ail files found")
+	}
+
+	for _, path := range files {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Only files the parser accepts are the formatter's business
+		p := parser.New(lexer.New(string(src), path))
+		p.ParseFile()
+		if len(p.Errors()) > 0 {
+			continue
+		}
+		t.Run(path, func(t *testing.T) {
+			out, err := Source(src, path)
+			if err != nil {
+				t.Fatalf("Source() error: %v", err)
+			}
+			again, err := Source(out, path)
+			if err != nil {
+				t.Fatalf("formatting output again failed: %v", err)
+			}
+			if string(again) != string(out) {
+				t.Errorf("formatting is not idempotent\nfirst:\n%s\nsecond:\n%s", out, again)
+			}
+		})
+	}
+}
@@ -0,0 +1,1226 @@
+package formatter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/lexer"
+)
+
+const indentUnit = "  "
+
+// Binding strength of printed expressions, mirroring the parser's precedence table.
+// Operands that bind more loosely than their context get parentheses.
+const (
+	precOpen   = 0  // \x. e, func(x) => e, if, let, letrec, match: extend as far as possible
+	precPrefix = 10 // -x, !x, not x
+	precAtom   = 12 // identifiers, literals, calls, field access, brackets
+)
+
+// binaryPrec returns the parser precedence of a binary operator
+func binaryPrec(op string) int {
+	switch op {
+	case "||", "or":
+		return 2
+	case "&&", "and":
+		return 3
+	case "==", "!=":
+		return 4
+	case "<", ">", "<=", ">=":
+		return 5
+	case "++":
+		return 6
+	case "+", "-":
+		return 7
+	case "*", "/", "%":
+		return 8
+	case "|>":
+		return 9
+	default:
+		return 0
+	}
+}
+
+func exprPrec(e ast.Expr) int {
+	switch n := e.(type) {
+	case *ast.BinaryOp:
+		return binaryPrec(n.Op)
+	case *ast.UnaryOp:
+		return precPrefix
+	case *ast.Lambda:
+		if _, ok := section(n); ok {
+			return precAtom
+		}
+		return precOpen
+	case *ast.FuncLit, *ast.If, *ast.Let, *ast.LetRec, *ast.Match:
+		return precOpen
+	default:
+		return precAtom
+	}
+}
+
+// startLine returns the source line where an expression begins.
+// Infix nodes are positioned at their operator, so walk to the leftmost operand.
+func startLine(n ast.Node) int {
+	switch e := n.(type) {
+	case *ast.BinaryOp:
+		return startLine(e.Left)
+	case *ast.FuncCall:
+		return startLine(e.Func)
+	case *ast.RecordAccess:
+		return startLine(e.Record)
+	case *ast.FuncDecl:
+		if len(e.Annotations) > 0 {
+			return e.Annotations[0].Pos.Line
+		}
+		return e.Pos.Line
+	case nil:
+		return 0
+	default:
+		return n.Position().Line
+	}
+}
+
+// printer renders a surface AST as canonical source text.
+//
+// Layout is mostly fixed (blocks, match arms and function bodies are always
+// broken over lines); lists, records and call arguments are broken when the
+// source put their first element on a new line. Comments are emitted before
+// the next declaration, statement or arm that follows them, or appended to the
+// line they trailed when that line still prints as a single line.
+type printer struct {
+	buf    strings.Builder
+	indent int
+
+	comments []lexer.Comment // Pending comments, in source order
+	blank    []bool          // blank[n] reports whether source line n is empty
+	tokens   []lexer.Token
+	tokenAt  map[[2]int]int // (line, column) -> index into tokens
+
+	atLineStart bool // Nothing but indentation written on the current line
+	fresh       bool // No item printed yet in the innermost multi-line container
+	lineAnchor  int  // Source line of the item that started the current output line
+	err         error
+}
+
+func newPrinter(src string, comments []lexer.Comment, tokens []lexer.Token) *printer {
+	lines := strings.Split(src, "\n")
+	p := &printer{
+		comments:    comments,
+		blank:       make([]bool, len(lines)+2),
+		tokens:      tokens,
+		tokenAt:     make(map[[2]int]int, len(tokens)),
+		atLineStart: true,
+		fresh:       true,
+	}
+	for i, line := range lines {
+		p.blank[i+1] = strings.TrimSpace(line) == ""
+	}
+	for i, tok := range tokens {
+		p.tokenAt[[2]int{tok.Line, tok.Column}] = i
+	}
+	return p
+}
+
+func (p *printer) fail(format string, args ...interface{}) {
+	if p.err == nil {
+		p.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (p *printer) write(s string) {
+	if s == "" {
+		return
+	}
+	if p.atLineStart {
+		p.buf.WriteString(strings.Repeat(indentUnit, p.indent))
+		p.atLineStart = false
+	}
+	p.buf.WriteString(s)
+}
+
+func (p *printer) newline() {
+	p.buf.WriteByte('\n')
+	p.atLineStart = true
+	p.lineAnchor = 0
+}
+
+func (p *printer) blankLine() {
+	if p.atLineStart {
+		p.buf.WriteByte('\n')
+	}
+}
+
+func (p *printer) blankBefore(line int) bool {
+	return line > 1 && line-1 < len(p.blank) && p.blank[line-1]
+}
+
+// anchor marks the start of an item (declaration, statement, arm, element)
+// beginning at the given source line. The current output line must be empty.
+func (p *printer) anchor(line int) {
+	p.flushComments(line)
+	if !p.fresh && p.blankBefore(line) {
+		p.blankLine()
+	}
+	p.fresh = false
+	p.lineAnchor = line
+}
+
+// flushComments emits, one per line, every pending comment that starts before line
+func (p *printer) flushComments(line int) {
+	for len(p.comments) > 0 && (line == 0 || p.comments[0].Line < line) {
+		c := p.comments[0]
+		p.comments = p.comments[1:]
+		if !p.fresh && p.blankBefore(c.Line) {
+			p.blankLine()
+		}
+		p.fresh = false
+		p.write(commentText(c))
+		p.newline()
+	}
+}
+
+// trailing appends a comment that shared the source line of the item just printed.
+// nextLine is the start line of the following sibling (0 if none); a comment on
+// that line belongs to the sibling instead.
+func (p *printer) trailing(nextLine int) {
+	if p.lineAnchor == 0 || len(p.comments) == 0 {
+		return
+	}
+	c := p.comments[0]
+	if c.Line != p.lineAnchor || c.Line == nextLine {
+		return
+	}
+	p.comments = p.comments[1:]
+	p.write(" " + commentText(c))
+}
+
+func commentText(c lexer.Comment) string {
+	return strings.TrimRight(c.Text, " \t")
+}
+
+// closeLine returns the source line of the delimiter closing the one at pos, or 0
+func (p *printer) closeLine(pos ast.Pos) int {
+	i, ok := p.tokenAt[[2]int{pos.Line, pos.Column}]
+	if !ok {
+		return 0
+	}
+	if j := p.matchingClose(i); j >= 0 {
+		return p.tokens[j].Line
+	}
+	return 0
+}
+
+func (p *printer) matchingClose(i int) int {
+	depth := 0
+	for j := i; j < len(p.tokens); j++ {
+		switch p.tokens[j].Type {
+		case lexer.LBRACE, lexer.LBRACKET, lexer.LPAREN:
+			depth++
+		case lexer.RBRACE, lexer.RBRACKET, lexer.RPAREN:
+			depth--
+			if depth == 0 {
+				return j
+			}
+		case lexer.EOF:
+			return -1
+		}
+	}
+	return -1
+}
+
+// matchCloseLine finds the closing brace of a match expression's arm list
+func (p *printer) matchCloseLine(m *ast.Match) int {
+	i, ok := p.tokenAt[[2]int{m.Pos.Line, m.Pos.Column}]
+	if !ok {
+		return 0
+	}
+	depth := 0
+	for j := i + 1; j < len(p.tokens); j++ {
+		switch p.tokens[j].Type {
+		case lexer.LBRACE:
+			if depth == 0 && j > i+1 {
+				if k := p.matchingClose(j); k >= 0 {
+					return p.tokens[k].Line
+				}
+				return 0
+			}
+			depth++
+		case lexer.LBRACKET, lexer.LPAREN:
+			depth++
+		case lexer.RBRACE, lexer.RBRACKET, lexer.RPAREN:
+			depth--
+		case lexer.EOF:
+			return 0
+		}
+	}
+	return 0
+}
+
+// openContainer starts a multi-line bracketed section
+func (p *printer) openContainer(open string) {
+	p.write(open)
+	p.indent++
+	p.fresh = true
+}
+
+// closeContainer ends a multi-line section, keeping comments that sat before
+// the closing delimiter (at endLine) inside it
+func (p *printer) closeContainer(close string, endLine int) {
+	if endLine > 0 {
+		p.newline()
+		p.flushComments(endLine)
+		p.indent--
+		p.write(close)
+	} else {
+		p.indent--
+		p.newline()
+		p.write(close)
+	}
+	p.fresh = false
+}
+
+// File-level printing
+
+func (p *printer) file(f *ast.File) {
+	if f.Module != nil {
+		p.anchor(f.Module.Pos.Line)
+		p.write("module " + f.Module.Path)
+		p.trailing(0)
+		p.newline()
+	}
+
+	for _, imp := range f.Imports {
+		p.anchor(imp.Pos.Line)
+		p.write("import " + importPath(imp.Path) + " (" + strings.Join(imp.Symbols, ", ") + ")")
+		p.trailing(0)
+		p.newline()
+	}
+
+	if len(f.Exports) > 0 {
+		if !p.fresh {
+			p.blankLine()
+		}
+		p.fresh = false
+		p.write("export {" + strings.Join(f.Exports, ", ") + "}")
+		p.newline()
+	}
+
+	for i, decl := range f.Decls {
+		next := 0
+		if i+1 < len(f.Decls) {
+			next = startLine(f.Decls[i+1])
+		}
+		p.anchor(startLine(decl))
+		p.decl(decl)
+		p.trailing(next)
+		p.newline()
+	}
+
+	p.flushComments(0)
+}
+
+func importPath(path string) string {
+	for _, r := range path {
+		if !(r == '/' || r == '.' || r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return quote(path)
+		}
+	}
+	return path
+}
+
+func (p *printer) decl(n ast.Node) {
+	switch d := n.(type) {
+	case *ast.FuncDecl:
+		p.funcDecl(d)
+	case *ast.TypeDecl:
+		p.typeDecl(d)
+	case *ast.ConstDecl:
+		p.constDecl(d)
+	case ast.Expr:
+		p.stmt(d)
+	default:
+		p.fail("cannot format declaration %T", n)
+	}
+}
+
+func (p *printer) funcDecl(fn *ast.FuncDecl) {
+	for _, a := range fn.Annotations {
+		p.write("@" + a.Name)
+		if len(a.Args) > 0 {
+			args := make([]string, len(a.Args))
+			for i, arg := range a.Args {
+				args[i] = quote(arg)
+			}
+			p.write("(" + strings.Join(args, ", ") + ")")
+		}
+		p.newline()
+	}
+
+	if fn.IsExport {
+		p.write("export ")
+	}
+	if fn.IsPure {
+		p.write("pure ")
+	}
+	p.write("func ")
+	p.funcDef(fn)
+	p.tests(fn.Tests)
+	p.properties(fn.Properties)
+
+	if len(fn.Where) == 0 {
+		return
+	}
+	// Helpers go on the following lines, one per line when there are several:
+	//
+	//	func f(x: int) -> int = g(x) + 1
+	//	  where g(y: int) -> int = y * 2
+	p.indent++
+	p.newline()
+	if len(fn.Where) == 1 {
+		p.flushComments(fn.Where[0].Pos.Line)
+		p.lineAnchor = fn.Where[0].Pos.Line
+		p.write("where ")
+		p.funcDef(fn.Where[0])
+	} else {
+		p.write("where")
+		p.indent++
+		for i, helper := range fn.Where {
+			p.newline()
+			p.flushComments(helper.Pos.Line)
+			p.lineAnchor = helper.Pos.Line
+			p.funcDef(helper)
+			if i+1 < len(fn.Where) {
+				p.write(",")
+				p.trailing(fn.Where[i+1].Pos.Line)
+			}
+		}
+		p.indent--
+	}
+	p.indent--
+}
+
+// tests prints an inline tests block after the function body, one assertion
+// per line:
+//
+//	func double(x: int) -> int = x * 2 tests [
+//	  double(2) == 4
+//	]
+func (p *printer) tests(tests []*ast.TestCase) {
+	if len(tests) == 0 {
+		return
+	}
+	p.write(" tests [")
+	p.indent++
+	for i, tc := range tests {
+		p.newline()
+		p.flushComments(tc.Pos.Line)
+		p.lineAnchor = tc.Pos.Line
+		p.expr(tc.Expr)
+		if i+1 < len(tests) {
+			p.write(",")
+			p.trailing(tests[i+1].Pos.Line)
+		}
+	}
+	p.indent--
+	p.newline()
+	p.write("]")
+}
+
+// properties prints an inline properties block after the function body (and
+// its tests), one property per line
+func (p *printer) properties(props []*ast.Property) {
+	if len(props) == 0 {
+		return
+	}
+	p.write(" properties [")
+	p.indent++
+	for i, prop := range props {
+		p.newline()
+		p.flushComments(prop.Pos.Line)
+		p.lineAnchor = prop.Pos.Line
+		binders := make([]string, len(prop.Binders))
+		for j, b := range prop.Binders {
+			binders[j] = b.Name + ": " + p.typ(b.Type)
+		}
+		p.write("forall(" + strings.Join(binders, ", ") + ") => ")
+		p.expr(prop.Expr)
+		if i+1 < len(props) {
+			p.write(",")
+			p.trailing(props[i+1].Pos.Line)
+		}
+	}
+	p.indent--
+	p.newline()
+	p.write("]")
+}
+
+// funcDef prints what follows 'func' in a declaration (and all of a where
+// helper): name, signature and body
+func (p *printer) funcDef(fn *ast.FuncDecl) {
+	p.write(fn.Name)
+	if len(fn.TypeParams) > 0 {
+		p.write("[" + strings.Join(fn.TypeParams, ", ") + "]")
+	}
+	p.write("(" + p.params(fn.Params) + ")")
+	if fn.ReturnType != nil {
+		p.write(" -> " + p.typ(fn.ReturnType))
+	}
+	if len(fn.Effects) > 0 {
+		p.write(" ! " + effectSet(fn.Effects))
+	}
+
+	switch body := fn.Body.(type) {
+	case *ast.Block:
+		if len(body.Exprs) == 1 {
+			// Equation form: func f(x: int) -> int = x * 2
+			p.write(" = ")
+			p.expr(body.Exprs[0])
+			return
+		}
+		p.write(" ")
+		p.block(body.Exprs, fn.Span.End.Line)
+	default:
+		p.write(" ")
+		p.block([]ast.Expr{body}, fn.Span.End.Line)
+	}
+}
+
+func (p *printer) params(params []*ast.Param) string {
+	parts := make([]string, len(params))
+	for i, param := range params {
+		parts[i] = param.Name
+		if param.Type != nil {
+			parts[i] += ": " + p.typ(param.Type)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func effectSet(effects []string) string {
+	return "{" + strings.Join(effects, ", ") + "}"
+}
+
+func (p *printer) constDecl(d *ast.ConstDecl) {
+	if d.IsExport {
+		p.write("export ")
+	}
+	keyword := "let"
+	if d.IsConst {
+		keyword = "const"
+	}
+	p.binding(keyword, d.Name, d.Type, d.Value, d.Pos.Line)
+}
+
+// deriveClause renders the derive clause of a type declaration, if any,
+// after the given separator
+func deriveClause(d *ast.TypeDecl, sep string) string {
+	if len(d.Derive) == 0 {
+		return ""
+	}
+	return sep + "derive(" + strings.Join(d.Derive, ", ") + ")"
+}
+
+func (p *printer) typeDecl(d *ast.TypeDecl) {
+	if d.Exported {
+		p.write("export ")
+	}
+	p.write("type " + d.Name)
+	if len(d.TypeParams) > 0 {
+		p.write("[" + strings.Join(d.TypeParams, ", ") + "]")
+	}
+	p.write(" =")
+
+	switch def := d.Definition.(type) {
+	case *ast.AlgebraicType:
+		ctors := def.Constructors
+		multiline := len(ctors) > 1 && ctors[1].Pos.Line > d.Pos.Line
+		if !multiline {
+			if len(ctors) == 1 && len(ctors[0].Fields) == 0 {
+				// A lone nullary constructor needs the leading pipe to stay a sum type
+				p.write(" | " + p.constructor(ctors[0]) + deriveClause(d, " "))
+				return
+			}
+			parts := make([]string, len(ctors))
+			for i, c := range ctors {
+				parts[i] = p.constructor(c)
+			}
+			p.write(" " + strings.Join(parts, " | ") + deriveClause(d, " "))
+			return
+		}
+		p.indent++
+		p.fresh = true
+		for i, c := range ctors {
+			next := 0
+			if i+1 < len(ctors) {
+				next = ctors[i+1].Pos.Line
+			}
+			p.newline()
+			p.anchor(c.Pos.Line)
+			p.write("| " + p.constructor(c))
+			p.trailing(next)
+		}
+		if len(d.Derive) > 0 {
+			p.newline()
+			p.write(deriveClause(d, ""))
+		}
+		p.indent--
+		p.fresh = false
+
+	case *ast.RecordType:
+		if len(def.Fields) == 0 || def.Fields[0].Pos.Line == d.Pos.Line {
+			p.write(" " + p.typ(def))
+			return
+		}
+		p.write(" ")
+		p.openContainer("{")
+		for i, f := range def.Fields {
+			next := 0
+			if i+1 < len(def.Fields) {
+				next = def.Fields[i+1].Pos.Line
+			}
+			p.newline()
+			p.anchor(f.Pos.Line)
+			p.write(f.Name + ": " + p.typ(f.Type))
+			if i+1 < len(def.Fields) {
+				p.write(",")
+			}
+			p.trailing(next)
+		}
+		p.closeContainer("}", def.Pos.Line)
+
+	case *ast.TypeAlias:
+		p.write(" " + p.typ(def.Target))
+
+	default:
+		p.fail("cannot format type definition %T", d.Definition)
+	}
+}
+
+func (p *printer) constructor(c *ast.Constructor) string {
+	if c.HasNamedFields() {
+		fields := make([]string, len(c.Fields))
+		for i, t := range c.Fields {
+			fields[i] = c.FieldNames[i] + ": " + p.typ(t)
+		}
+		return c.Name + " {" + strings.Join(fields, ", ") + "}"
+	}
+	if len(c.Fields) == 0 {
+		return c.Name
+	}
+	fields := make([]string, len(c.Fields))
+	for i, t := range c.Fields {
+		fields[i] = p.typ(t)
+	}
+	return c.Name + "(" + strings.Join(fields, ", ") + ")"
+}
+
+// Statements and blocks
+
+// block prints { e1; e2; ... } broken over lines. endLine is the source line
+// of the closing brace when known, so comments before it stay inside.
+func (p *printer) block(exprs []ast.Expr, endLine int) {
+	if len(exprs) == 0 {
+		p.write("{}")
+		return
+	}
+	p.openContainer("{")
+	for i, e := range exprs {
+		next := 0
+		if i+1 < len(exprs) {
+			next = startLine(exprs[i+1])
+		}
+		p.newline()
+		p.anchor(startLine(e))
+		p.stmt(e)
+		if i+1 < len(exprs) {
+			p.write(";")
+		}
+		p.trailing(next)
+	}
+	p.closeContainer("}", endLine)
+}
+
+// stmt prints an expression in statement position, where let-chains are
+// laid out one binding per line:
+//
+//	let x = 1 in
+//	x + 1
+func (p *printer) stmt(e ast.Expr) {
+	switch n := e.(type) {
+	case *ast.Let:
+		if n.Body != nil {
+			p.binding("let", n.Name, n.Type, n.Value, n.Pos.Line)
+			p.write(" in")
+			p.stmtBody(n.Body)
+			return
+		}
+	case *ast.LetRec:
+		if n.Body != nil {
+			p.binding("letrec", n.Name, n.Type, n.Value, n.Pos.Line)
+			p.write(" in")
+			p.stmtBody(n.Body)
+			return
+		}
+	}
+	p.expr(e)
+}
+
+func (p *printer) stmtBody(body ast.Expr) {
+	line := startLine(body)
+	p.trailing(line)
+	p.newline()
+	p.anchor(line)
+	p.stmt(body)
+}
+
+// binding prints keyword name = value for a binding starting at source line.
+// A let-chain value that the source started on a later line is printed
+// indented below, one binding per line:
+//
+//	let total =
+//	  let x = 1 in
+//	  x + 1
+func (p *printer) binding(keyword, name string, t ast.Type, value ast.Expr, line int) {
+	p.write(keyword + " " + name)
+	if t != nil {
+		p.write(": " + p.typ(t))
+	}
+	if valueLine := startLine(value); isLetChain(value) && valueLine > line {
+		p.write(" =")
+		p.trailing(valueLine)
+		p.indent++
+		p.newline()
+		p.anchor(valueLine)
+		p.stmt(value)
+		p.indent--
+		return
+	}
+	p.write(" = ")
+	p.expr(value)
+}
+
+// isLetChain reports whether e is a let or letrec with an in-body
+func isLetChain(e ast.Expr) bool {
+	switch n := e.(type) {
+	case *ast.Let:
+		return n.Body != nil
+	case *ast.LetRec:
+		return n.Body != nil
+	}
+	return false
+}
+
+// Expressions
+
+// operand prints e, parenthesized when it binds no tighter than minPrec
+func (p *printer) operand(e ast.Expr, minPrec int) {
+	if exprPrec(e) <= minPrec {
+		p.write("(")
+		p.expr(e)
+		p.write(")")
+		return
+	}
+	p.expr(e)
+}
+
+func (p *printer) expr(e ast.Expr) {
+	switch n := e.(type) {
+	case *ast.Identifier:
+		p.write(n.Name)
+
+	case *ast.Literal:
+		p.write(p.literal(n))
+
+	case *ast.BinaryOp:
+		prec := binaryPrec(n.Op)
+		if prec == 0 {
+			p.fail("cannot format operator %q", n.Op)
+			return
+		}
+		// Operators are left-associative: a - (b - c) keeps its parens
+		p.operand(n.Left, prec-1)
+		p.write(" " + n.Op + " ")
+		p.operand(n.Right, prec)
+
+	case *ast.UnaryOp:
+		if n.Op == "not" {
+			p.write("not ")
+		} else {
+			p.write(n.Op)
+		}
+		p.operand(n.Expr, precPrefix)
+
+	case *ast.FuncCall:
+		p.operand(n.Func, precAtom-1)
+		p.list("(", ")", n.Pos, n.Args)
+
+	case *ast.RecordAccess:
+		p.operand(n.Record, precAtom-1)
+		p.write("." + n.Field)
+
+	case *ast.List:
+		p.list("[", "]", n.Pos, n.Elements)
+
+	case *ast.AllValues:
+		p.write("allValues[" + p.typ(n.Type) + "]")
+
+	case *ast.Tuple:
+		p.write("(")
+		for i, el := range n.Elements {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.expr(el)
+		}
+		if len(n.Elements) == 1 {
+			p.write(",")
+		}
+		p.write(")")
+
+	case *ast.Record:
+		p.fields("{", n.Pos, n.Fields)
+
+	case *ast.RecordUpdate:
+		p.write("{")
+		p.expr(n.Base)
+		p.fields(" |", n.Pos, n.Fields)
+
+	case *ast.Block:
+		p.block(n.Exprs, p.closeLine(n.Pos))
+
+	case *ast.If:
+		p.write("if ")
+		p.expr(n.Condition)
+		p.write(" then ")
+		p.expr(n.Then)
+		p.write(" else ")
+		p.expr(n.Else)
+
+	case *ast.Let:
+		if n.Body != nil && startLine(n.Body) > n.Pos.Line {
+			p.stmt(n)
+			return
+		}
+		p.binding("let", n.Name, n.Type, n.Value, n.Pos.Line)
+		if n.Body != nil {
+			p.write(" in ")
+			p.expr(n.Body)
+		}
+
+	case *ast.LetRec:
+		if n.Body != nil && startLine(n.Body) > n.Pos.Line {
+			p.stmt(n)
+			return
+		}
+		p.binding("letrec", n.Name, n.Type, n.Value, n.Pos.Line)
+		if n.Body != nil {
+			p.write(" in ")
+			p.expr(n.Body)
+		}
+
+	case *ast.Match:
+		p.match(n)
+
+	case *ast.Handle:
+		p.handle(n)
+
+	case *ast.Lambda:
+		p.lambda(n)
+
+	case *ast.FuncLit:
+		p.write("func(" + p.params(n.Params) + ")")
+		if n.ReturnType == nil {
+			p.fail("cannot format function literal without a return type")
+			return
+		}
+		p.write(" -> " + p.typ(n.ReturnType))
+		if len(n.Effects) > 0 {
+			p.write(" ! " + effectSet(n.Effects))
+		}
+		p.write(" ")
+		if block, ok := n.Body.(*ast.Block); ok && len(block.Exprs) > 1 {
+			p.block(block.Exprs, p.closeLine(block.Pos))
+		} else {
+			p.block([]ast.Expr{n.Body}, 0)
+		}
+
+	case nil:
+		p.fail("cannot format incomplete expression")
+
+	default:
+		p.fail("cannot format expression %T", e)
+	}
+}
+
+// list prints a bracketed, comma-separated expression list. It is broken over
+// lines when the source put the first element on a line after the open bracket.
+func (p *printer) list(open, close string, pos ast.Pos, elems []ast.Expr) {
+	if len(elems) == 0 || startLine(elems[0]) <= pos.Line {
+		p.write(open)
+		for i, el := range elems {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.expr(el)
+		}
+		p.write(close)
+		return
+	}
+
+	endLine := p.closeLine(pos)
+	p.openContainer(open)
+	for i, el := range elems {
+		next := 0
+		if i+1 < len(elems) {
+			next = startLine(elems[i+1])
+		}
+		p.newline()
+		p.anchor(startLine(el))
+		p.expr(el)
+		if i+1 < len(elems) {
+			p.write(",")
+		}
+		p.trailing(next)
+	}
+	p.closeContainer(close, endLine)
+}
+
+// fields prints the `name: value` part of a record literal or update and the
+// closing brace; open is written first ("{" or " |")
+func (p *printer) fields(open string, pos ast.Pos, fields []*ast.Field) {
+	if len(fields) == 0 || fields[0].Pos.Line <= pos.Line {
+		p.write(open)
+		if open != "{" {
+			p.write(" ")
+		}
+		for i, f := range fields {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.write(f.Name + ": ")
+			p.expr(f.Value)
+		}
+		p.write("}")
+		return
+	}
+
+	endLine := p.closeLine(pos)
+	p.openContainer(open)
+	for i, f := range fields {
+		next := 0
+		if i+1 < len(fields) {
+			next = fields[i+1].Pos.Line
+		}
+		p.newline()
+		p.anchor(f.Pos.Line)
+		p.write(f.Name + ": ")
+		p.expr(f.Value)
+		if i+1 < len(fields) {
+			p.write(",")
+		}
+		p.trailing(next)
+	}
+	p.closeContainer("}", endLine)
+}
+
+func (p *printer) match(m *ast.Match) {
+	p.write("match ")
+	p.expr(m.Expr)
+	p.write(" ")
+	if len(m.Cases) == 0 {
+		p.write("{}")
+		return
+	}
+	p.openContainer("{")
+	for i, c := range m.Cases {
+		next := 0
+		if i+1 < len(m.Cases) {
+			next = m.Cases[i+1].Pos.Line
+		}
+		p.newline()
+		p.anchor(c.Pos.Line)
+		p.write(p.pattern(c.Pattern))
+		if c.Guard != nil {
+			p.write(" if ")
+			p.expr(c.Guard)
+		}
+		p.write(" => ")
+		p.expr(c.Body)
+		if i+1 < len(m.Cases) {
+			p.write(",")
+		}
+		p.trailing(next)
+	}
+	p.closeContainer("}", p.matchCloseLine(m))
+}
+
+// handle prints handle { body } with { Effect.op(x) => expr, ... }, one
+// handler per line
+func (p *printer) handle(h *ast.Handle) {
+	p.write("handle ")
+	if block, ok := h.Body.(*ast.Block); ok && len(block.Exprs) > 1 {
+		p.block(block.Exprs, p.closeLine(block.Pos))
+	} else {
+		p.block([]ast.Expr{h.Body}, 0)
+	}
+	p.write(" with ")
+	p.openContainer("{")
+	for i, arm := range h.Handlers {
+		next := 0
+		if i+1 < len(h.Handlers) {
+			next = h.Handlers[i+1].Pos.Line
+		}
+		p.newline()
+		p.anchor(arm.Pos.Line)
+		p.write(fmt.Sprintf("%s.%s(%s) => ", arm.Effect, arm.Op, arm.Param))
+		p.expr(arm.Body)
+		if i+1 < len(h.Handlers) {
+			p.write(",")
+		}
+		p.trailing(next)
+	}
+	p.closeContainer("}", p.closeLine(h.HandlersPos))
+}
+
+// section returns the operator application of a lambda the parser
+// desugared from an operator section such as (+ 1) or (2 *)
+func section(l *ast.Lambda) (*ast.BinaryOp, bool) {
+	if len(l.Params) != 1 || l.Params[0].Name != ast.SectionParam {
+		return nil, false
+	}
+	op, ok := l.Body.(*ast.BinaryOp)
+	if !ok {
+		return nil, false
+	}
+	isParam := func(e ast.Expr) bool {
+		id, ok := e.(*ast.Identifier)
+		return ok && id.Name == ast.SectionParam
+	}
+	return op, isParam(op.Left) != isParam(op.Right)
+}
+
+func (p *printer) lambda(l *ast.Lambda) {
+	if op, ok := section(l); ok {
+		prec := binaryPrec(op.Op)
+		p.write("(")
+		if id, ok := op.Left.(*ast.Identifier); ok && id.Name == ast.SectionParam {
+			p.write(op.Op + " ")
+			p.operand(op.Right, prec)
+		} else {
+			p.operand(op.Left, prec-1)
+			p.write(" " + op.Op)
+		}
+		p.write(")")
+		return
+	}
+
+	if len(l.Params) != 1 || l.Params[0].Type != nil {
+		// func(x: int, y) => body has no effect syntax
+		if len(l.Effects) > 0 {
+			p.fail("cannot format lambda with effects and %d parameters", len(l.Params))
+			return
+		}
+		p.write("func(" + p.params(l.Params) + ") => ")
+		p.expr(l.Body)
+		return
+	}
+
+	// Curried sugar: \x y. body is how the parser spells nested single-param lambdas
+	names := []string{l.Params[0].Name}
+	body := l.Body
+	for {
+		inner, ok := body.(*ast.Lambda)
+		if !ok || len(inner.Params) != 1 || inner.Params[0].Type != nil || len(inner.Effects) > 0 {
+			break
+		}
+		names = append(names, inner.Params[0].Name)
+		body = inner.Body
+	}
+
+	p.write("\\" + strings.Join(names, " ") + ". ")
+	if len(l.Effects) > 0 {
+		// An open-ended body would capture the effect annotation itself
+		p.operand(body, precOpen)
+		p.write(" ! " + effectSet(l.Effects))
+		return
+	}
+	p.expr(body)
+}
+
+func (p *printer) literal(l *ast.Literal) string {
+	switch l.Kind {
+	case ast.IntLit:
+		if v, ok := l.Value.(int64); ok {
+			return strconv.FormatInt(v, 10)
+		}
+	case ast.FloatLit:
+		if v, ok := l.Value.(float64); ok {
+			return formatFloat(v)
+		}
+	case ast.StringLit:
+		if v, ok := l.Value.(string); ok {
+			return quote(v)
+		}
+	case ast.BoolLit:
+		if v, ok := l.Value.(bool); ok {
+			return strconv.FormatBool(v)
+		}
+	case ast.UnitLit:
+		return "()"
+	}
+	p.fail("cannot format literal %v", l.Value)
+	return ""
+}
+
+// formatFloat prints the shortest representation that lexes back as a float
+func formatFloat(v float64) string {
+	var s string
+	if abs := math.Abs(v); abs != 0 && (abs < 1e-4 || abs >= 1e21) {
+		s = strconv.FormatFloat(v, 'e', -1, 64)
+	} else {
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// quote produces a string literal using only the escapes the lexer understands
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// Patterns
+
+func (p *printer) pattern(pat ast.Pattern) string {
+	switch n := pat.(type) {
+	case *ast.Identifier:
+		return n.Name
+	case *ast.WildcardPattern:
+		return "_"
+	case *ast.AsPattern:
+		inner := p.pattern(n.Pattern)
+		if _, ok := n.Pattern.(*ast.OrPattern); ok {
+			inner = "(" + inner + ")"
+		}
+		return n.Name + " @ " + inner
+	case *ast.OrPattern:
+		parts := make([]string, len(n.Alternatives))
+		for i, alt := range n.Alternatives {
+			parts[i] = p.pattern(alt)
+		}
+		return strings.Join(parts, " | ")
+	case *ast.Literal:
+		if n.Kind == ast.UnitLit {
+			// () lexes as the unit token, which patterns don't accept
+			return "( )"
+		}
+		return p.literal(n)
+	case *ast.ConstructorPattern:
+		if len(n.Patterns) == 0 {
+			return n.Name + "( )"
+		}
+		return n.Name + "(" + p.patterns(n.Patterns) + ")"
+	case *ast.TuplePattern:
+		if len(n.Elements) == 1 {
+			return "(" + p.pattern(n.Elements[0]) + ",)"
+		}
+		return "(" + p.patterns(n.Elements) + ")"
+	case *ast.ListPattern:
+		elems := p.patterns(n.Elements)
+		if n.Rest != nil {
+			if elems != "" {
+				elems += ", "
+			}
+			elems += "..." + p.pattern(n.Rest)
+		}
+		return "[" + elems + "]"
+	case nil:
+		p.fail("cannot format incomplete pattern")
+	default:
+		p.fail("cannot format pattern %T", pat)
+	}
+	return ""
+}
+
+func (p *printer) patterns(pats []ast.Pattern) string {
+	parts := make([]string, len(pats))
+	for i, pat := range pats {
+		parts[i] = p.pattern(pat)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Types
+
+func (p *printer) typ(t ast.Type) string {
+	switch n := t.(type) {
+	case *ast.SimpleType:
+		return n.Name
+	case *ast.TypeVar:
+		return n.Name
+	case *ast.TypeApp:
+		return n.Name + "[" + p.types(n.Args) + "]"
+	case *ast.ListType:
+		return "[" + p.typ(n.Element) + "]"
+	case *ast.TupleType:
+		if len(n.Elements) == 1 {
+			return "(" + p.typ(n.Elements[0]) + ",)"
+		}
+		return "(" + p.types(n.Elements) + ")"
+	case *ast.FuncType:
+		ret := p.typ(n.Return)
+		if inner, ok := n.Return.(*ast.FuncType); ok && len(n.Effects) > 0 && len(inner.Effects) == 0 {
+			// Keep the effects on this arrow rather than the inner one
+			ret = "(" + ret + ")"
+		}
+		s := "(" + p.types(n.Params) + ") -> " + ret
+		if len(n.Effects) > 0 {
+			s += " ! " + effectSet(n.Effects)
+		}
+		return s
+	case *ast.RecordType:
+		fields := make([]string, len(n.Fields))
+		for i, f := range n.Fields {
+			fields[i] = f.Name + ": " + p.typ(f.Type)
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case nil:
+		p.fail("cannot format incomplete type")
+	default:
+		p.fail("cannot format type %T", t)
+	}
+	return ""
+}
+
+func (p *printer) types(ts []ast.Type) string {
+	parts := make([]string, len(ts))
+	for i, t := range ts {
+		parts[i] = p.typ(t)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -28,9 +28,17 @@ func NewBuilder(module string, typeEnv *types.TypeEnv) *Builder {
 
 // ConstructorInfo represents constructor information for interface building
 type ConstructorInfo struct {
-	TypeName string
-	CtorName string
-	Arity    int
+	TypeName   string
+	CtorName   string
+	Arity      int
+	FieldNames []string     // Record-style field names (nil if positional)
+	FieldTypes []types.Type // Declared field types (nil uses placeholders)
+	Newtype    bool         // Single-field newtype, represented by the unboxed field
+	Index      int          // Position of the constructor in its type declaration
+	TypeParams []string     // Type parameters of the ADT
+	// Declared types of a record-style constructor's named fields, checked by
+	// p.x field access (nil if positional)
+	RecordFieldTypes []types.Type
 }
 
 // BuildInterface extracts the typed interface from a Core program
@@ -94,6 +102,12 @@ func (b *Builder) Build(prog *core.Program, constructors map[string]*Constructor
 				Name:   name,
 			},
 		}
+		if meta, ok := prog.Meta[name]; ok {
+			item.Deprecated = meta.IsDeprecated
+			item.DeprecationMsg = meta.DeprecationMsg
+			item.Entry = meta.IsEntry
+			item.Doc = meta.Doc
+		}
 
 		iface.Exports[name] = item
 	}
@@ -106,12 +120,20 @@ func (b *Builder) Build(prog *core.Program, constructors map[string]*Constructor
 		resultType := &types.TCon{Name: ctorInfo.TypeName}
 
 		// Create placeholder field types (will be refined by type checker)
-		fieldTypes := make([]types.Type, ctorInfo.Arity)
-		for i := 0; i < ctorInfo.Arity; i++ {
-			fieldTypes[i] = &types.TVar2{Name: fmt.Sprintf("a%d", i), Kind: types.Star}
+		fieldTypes := ctorInfo.FieldTypes
+		if fieldTypes == nil {
+			fieldTypes = make([]types.Type, ctorInfo.Arity)
+			for i := 0; i < ctorInfo.Arity; i++ {
+				fieldTypes[i] = &types.TVar2{Name: fmt.Sprintf("a%d", i), Kind: types.Star}
+			}
 		}
 
 		iface.AddConstructor(ctorInfo.TypeName, ctorName, fieldTypes, resultType)
+		iface.Constructors[ctorName].FieldNames = ctorInfo.FieldNames
+		iface.Constructors[ctorName].Newtype = ctorInfo.Newtype
+		iface.Constructors[ctorName].Index = ctorInfo.Index
+		iface.Constructors[ctorName].TypeParams = ctorInfo.TypeParams
+		iface.Constructors[ctorName].RecordFieldTypes = ctorInfo.RecordFieldTypes
 	}
 
 	// Extract and add type declarations if AST is provided
@@ -129,6 +151,7 @@ func (b *Builder) Build(prog *core.Program, constructors map[string]*Constructor
 						// Add type to interface
 						arity := len(typeDecl.TypeParams)
 						iface.AddType(typeDecl.Name, arity)
+						iface.Types[typeDecl.Name].Doc = typeDecl.Doc
 						// DEBUG: fmt.Printf("DEBUG: Added type %s to interface (arity %d)\n", typeDecl.Name, arity)
 
 						// Extract constructors from algebraic types
@@ -147,6 +170,17 @@ func (b *Builder) Build(prog *core.Program, constructors map[string]*Constructor
 		}
 	}
 
+	// Type classes and instances declared by the module
+	if file, ok := astFile.(*ast.File); ok {
+		classes, instances := ClassesAndInstances(b.module, file)
+		for _, class := range classes {
+			iface.AddClass(class)
+		}
+		for _, inst := range instances {
+			iface.AddInstance(inst)
+		}
+	}
+
 	// Compute deterministic digest
 	iface.Schema = "ailang.iface/v1"
 	digest, err := b.computeDigest(iface)
@@ -292,10 +326,11 @@ func (b *Builder) determinePurity(expr core.CoreExpr) bool {
 
 // ifaceItem is used for JSON serialization
 type ifaceItem struct {
-	Name    string   `json:"name"`
-	Type    string   `json:"type"` // String representation of the scheme
-	Pure    bool     `json:"pure"`
-	Effects []string `json:"effects,omitempty"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"` // String representation of the scheme
+	Pure       bool     `json:"pure"`
+	Effects    []string `json:"effects,omitempty"`
+	Deprecated *string  `json:"deprecated,omitempty"` // Deprecation message (set only if deprecated)
 }
 
 // ctorItem is used for JSON serialization of constructors
@@ -305,6 +340,12 @@ type ctorItem struct {
 	FieldTypes []string `json:"field_types"`
 	ResultType string   `json:"result_type"`
 	Arity      int      `json:"arity"`
+	FieldNames []string `json:"field_names,omitempty"`
+	Newtype    bool     `json:"newtype,omitempty"`
+	Index      int      `json:"index,omitempty"`
+	TypeParams []string `json:"type_params,omitempty"`
+	// Declared types of record-style fields
+	RecordFieldTypes []string `json:"record_field_types,omitempty"`
 }
 
 // computeDigest computes a deterministic digest of the interface
@@ -315,6 +356,8 @@ func (b *Builder) computeDigest(iface *Iface) (string, error) {
 		Schema       string               `json:"schema"`
 		Exports      map[string]ifaceItem `json:"exports"`
 		Constructors map[string]ctorItem  `json:"constructors,omitempty"`
+		Classes      []ClassJSON          `json:"classes,omitempty"`
+		Instances    []InstanceJSON       `json:"instances,omitempty"`
 	}
 
 	// Convert to JSON-friendly format with sorted keys
@@ -340,6 +383,12 @@ func (b *Builder) computeDigest(iface *Iface) (string, error) {
 			Pure:    item.Purity,
 			Effects: []string{}, // Placeholder for future effect system
 		}
+		if item.Deprecated {
+			msg := item.DeprecationMsg
+			entry := ji.Exports[name]
+			entry.Deprecated = &msg
+			ji.Exports[name] = entry
+		}
 	}
 
 	// Sort constructor names for deterministic ordering
@@ -355,15 +404,27 @@ func (b *Builder) computeDigest(iface *Iface) (string, error) {
 		for i, ft := range ctor.FieldTypes {
 			fieldTypeStrs[i] = ft.String()
 		}
+		var recordFieldTypeStrs []string
+		for _, ft := range ctor.RecordFieldTypes {
+			recordFieldTypeStrs = append(recordFieldTypeStrs, ft.String())
+		}
 		ji.Constructors[name] = ctorItem{
-			TypeName:   ctor.TypeName,
-			CtorName:   ctor.CtorName,
-			FieldTypes: fieldTypeStrs,
-			ResultType: ctor.ResultType.String(),
-			Arity:      ctor.Arity,
+			TypeName:         ctor.TypeName,
+			CtorName:         ctor.CtorName,
+			FieldTypes:       fieldTypeStrs,
+			ResultType:       ctor.ResultType.String(),
+			Arity:            ctor.Arity,
+			FieldNames:       ctor.FieldNames,
+			Newtype:          ctor.Newtype,
+			Index:            ctor.Index,
+			TypeParams:       ctor.TypeParams,
+			RecordFieldTypes: recordFieldTypeStrs,
 		}
 	}
 
+	ji.Classes = classesJSON(iface)
+	ji.Instances = instancesJSON(iface)
+
 	// Marshal to canonical JSON
 	data, err := json.Marshal(ji)
 	if err != nil {
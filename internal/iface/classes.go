@@ -0,0 +1,104 @@
+package iface
+
+import (
+	"sort"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// ClassesAndInstances extracts the type class and instance declarations of a
+// surface file.
+//
+// Every class declared by a module is exported. Instances are global: each
+// one is recorded so that importers can add it to their InstanceEnv. Instance
+// method implementations are referenced by dictionary key in the declaring
+// module's namespace (module::Class::Type::method). Instances requested with
+// a derive clause on a type declaration are included too.
+//
+// The parser does not parse class or instance declarations yet, so for
+// source files only derived instances are exported; ClassDecl and
+// InstanceDecl nodes come from hand-built ASTs.
+func ClassesAndInstances(module string, file *ast.File) ([]*ClassExport, []*InstanceExport) {
+	if file == nil {
+		return nil, nil
+	}
+
+	var classes []*ClassExport
+	var instances []*InstanceExport
+	supers := make(map[string][]string)
+
+	allDecls := append(append([]ast.Node{}, file.Decls...), file.Statements...)
+	seen := make(map[ast.Node]bool)
+	for _, decl := range allDecls {
+		if seen[decl] {
+			continue
+		}
+		seen[decl] = true
+
+		switch d := decl.(type) {
+		case *ast.TypeClass:
+			class := &ClassExport{
+				Name:      d.Name,
+				TypeParam: d.TypeParam,
+				Methods:   make(map[string]*types.Scheme),
+			}
+			if d.Superclass != "" {
+				class.Super = []string{d.Superclass}
+			}
+			for _, m := range d.Methods {
+				class.Methods[m.Name] = &types.Scheme{
+					TypeVars: []string{d.TypeParam},
+					Type:     types.TypeFromAST(m.Type),
+				}
+			}
+			supers[d.Name] = class.Super
+			classes = append(classes, class)
+
+		case *ast.Instance:
+			head := types.TypeFromAST(d.Type)
+			inst := &InstanceExport{
+				ClassName: d.ClassName,
+				TypeHead:  head,
+				Module:    module,
+				Methods:   make(map[string]string, len(d.Methods)),
+			}
+			for method := range d.Methods {
+				inst.Methods[method] = types.MakeDictionaryKey(module, d.ClassName, head, method)
+			}
+			instances = append(instances, inst)
+
+		case *ast.TypeDecl:
+			// derive(...) generates structural instances. Classes that cannot
+			// be derived are reported when the module is type checked.
+			head := &types.TCon{Name: d.Name}
+			for _, class := range d.Derive {
+				derived, ok := types.DerivedInstance(class, head)
+				if !ok {
+					continue
+				}
+				inst := &InstanceExport{
+					ClassName: class,
+					TypeHead:  head,
+					Module:    module,
+					Methods:   make(map[string]string, len(derived.Dict)),
+					Super:     derived.Super,
+				}
+				for method, impl := range derived.Dict {
+					inst.Methods[method] = impl
+				}
+				instances = append(instances, inst)
+			}
+		}
+	}
+
+	// Superclasses are only known once every class has been seen
+	for _, inst := range instances {
+		if super, ok := supers[inst.ClassName]; ok {
+			inst.Super = super
+		}
+	}
+
+	sort.Slice(classes, func(i, j int) bool { return classes[i].Name < classes[j].Name })
+	return classes, instances
+}
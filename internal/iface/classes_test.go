@@ -0,0 +1,126 @@
+package iface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+func classFile() *ast.File {
+	class := &ast.TypeClass{
+		Name:       "Pretty",
+		TypeParam:  "a",
+		Superclass: "Show",
+		Methods: []*ast.Method{
+			{Name: "pretty", Type: &ast.FuncType{
+				Params: []ast.Type{&ast.SimpleType{Name: "a"}},
+				Return: &ast.SimpleType{Name: "string"},
+			}},
+		},
+	}
+	inst := &ast.Instance{
+		ClassName: "Pretty",
+		Type:      &ast.SimpleType{Name: "int"},
+		Methods:   map[string]ast.Expr{"pretty": &ast.Identifier{Name: "show"}},
+	}
+	return &ast.File{
+		Decls:      []ast.Node{class, inst},
+		Statements: []ast.Node{class, inst},
+	}
+}
+
+func TestClassesAndInstances(t *testing.T) {
+	classes, instances := ClassesAndInstances("my/pretty", classFile())
+
+	if len(classes) != 1 {
+		t.Fatalf("expected 1 class, got %d", len(classes))
+	}
+	class := classes[0]
+	if class.Name != "Pretty" || class.TypeParam != "a" {
+		t.Errorf("unexpected class %s[%s]", class.Name, class.TypeParam)
+	}
+	if len(class.Super) != 1 || class.Super[0] != "Show" {
+		t.Errorf("Super = %v, want [Show]", class.Super)
+	}
+	if _, ok := class.Methods["pretty"]; !ok {
+		t.Error("expected method pretty in class")
+	}
+
+	if len(instances) != 1 {
+		t.Fatalf("expected 1 instance, got %d", len(instances))
+	}
+	inst := instances[0]
+	if inst.Module != "my/pretty" || types.NormalizeTypeName(inst.TypeHead) != "Int" {
+		t.Errorf("unexpected instance %s[%s] from %s", inst.ClassName, inst.TypeHead, inst.Module)
+	}
+	if got := inst.Methods["pretty"]; got != "my/pretty::Pretty::Int::pretty" {
+		t.Errorf("method key = %q", got)
+	}
+
+	ci := inst.ToClassInstance()
+	if ci.ClassName != "Pretty" || ci.Dict["pretty"] != inst.Methods["pretty"] || len(ci.Super) != 1 {
+		t.Errorf("unexpected ClassInstance %+v", ci)
+	}
+}
+
+func TestIfaceJSONIncludesClasses(t *testing.T) {
+	i := NewIface("my/pretty")
+	classes, instances := ClassesAndInstances("my/pretty", classFile())
+	for _, c := range classes {
+		i.AddClass(c)
+	}
+	for _, inst := range instances {
+		i.AddInstance(inst)
+	}
+
+	if _, ok := i.GetClass("Pretty"); !ok {
+		t.Fatal("GetClass(\"Pretty\") returned false")
+	}
+
+	data, err := i.ToNormalizedJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{`"classes"`, `"name": "Pretty"`, `"instances"`, `"class": "Pretty"`, `"type": "Int"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in JSON:\n%s", want, out)
+		}
+	}
+
+	// Interfaces without classes keep their previous shape
+	data, err = NewIface("plain").ToNormalizedJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "classes") || strings.Contains(string(data), "instances") {
+		t.Errorf("unexpected class sections in:\n%s", data)
+	}
+}
+
+func TestClassesAndInstancesDerived(t *testing.T) {
+	decl := &ast.TypeDecl{
+		Name: "Color",
+		Definition: &ast.AlgebraicType{Constructors: []*ast.Constructor{
+			{Name: "Red"}, {Name: "Green"},
+		}},
+		Derive: []string{"Eq", "Ord", "Num"},
+	}
+	_, instances := ClassesAndInstances("my/color", &ast.File{Decls: []ast.Node{decl}, Statements: []ast.Node{decl}})
+
+	// Num cannot be derived and is left for the type checker to report
+	if len(instances) != 2 {
+		t.Fatalf("expected 2 derived instances, got %d", len(instances))
+	}
+	for _, inst := range instances {
+		if inst.Module != "my/color" || types.NormalizeTypeName(inst.TypeHead) != "Color" {
+			t.Errorf("unexpected instance %s[%s] from %s", inst.ClassName, inst.TypeHead, inst.Module)
+		}
+	}
+	ord := instances[1]
+	if ord.ClassName != "Ord" || len(ord.Super) != 1 || ord.Super[0] != "Eq" || ord.Methods["lt"] == "" {
+		t.Errorf("unexpected derived Ord instance %+v", ord)
+	}
+}
@@ -1,6 +1,7 @@
 package iface
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/sunholo/ailang/internal/types"
@@ -167,3 +168,25 @@ func TestDigestDifferentConstructors(t *testing.T) {
 		t.Errorf("Digests are identical but should differ:\n%s", digest1)
 	}
 }
+
+func TestIfaceConstructorsOf(t *testing.T) {
+	iface := NewIface("test/compass")
+	dirType := &types.TCon{Name: "Direction"}
+	for i, name := range []string{"North", "East", "South", "West"} {
+		iface.AddConstructor("Direction", name, nil, dirType)
+		iface.Constructors[name].Index = i
+	}
+	iface.AddConstructor("Other", "Thing", nil, &types.TCon{Name: "Other"})
+
+	ctors := iface.ConstructorsOf("Direction")
+	var names []string
+	for _, ctor := range ctors {
+		names = append(names, ctor.CtorName)
+	}
+	if got := strings.Join(names, ","); got != "North,East,South,West" {
+		t.Errorf("ConstructorsOf(Direction) = %s, want declaration order", got)
+	}
+	if ctors := iface.ConstructorsOf("Missing"); len(ctors) != 0 {
+		t.Errorf("ConstructorsOf(Missing) = %v, want none", ctors)
+	}
+}
@@ -1,6 +1,8 @@
 package iface
 
 import (
+	"sort"
+
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/types"
 )
@@ -11,22 +13,64 @@ type Iface struct {
 	Exports      map[string]*IfaceItem         // Exported symbols
 	Constructors map[string]*ConstructorScheme // Exported ADT constructors
 	Types        map[string]*TypeExport        // Exported type names
+	Classes      map[string]*ClassExport       // Exported type classes
+	Instances    []*InstanceExport             // Instances declared by this module
 	Schema       string                        // Schema version, e.g., "ailang.iface/v1"
 	Digest       string                        // Deterministic digest of interface
 }
 
 // TypeExport represents an exported type name
 type TypeExport struct {
-	Name  string // Type name (e.g., "Option", "Result")
-	Arity int    // Number of type parameters
+	Name  string     // Type name (e.g., "Option", "Result")
+	Arity int        // Number of type parameters
+	Doc   string     // Doc comment of the declaration
+	Alias types.Type // Type a transparent alias stands for (nil for other types)
+}
+
+// ClassExport represents an exported type class declaration
+type ClassExport struct {
+	Name      string                   // Class name (e.g., "Ord")
+	TypeParam string                   // Class type parameter (e.g., "a")
+	Super     []string                 // Superclasses (e.g., ["Eq"])
+	Methods   map[string]*types.Scheme // Method signatures, quantified over TypeParam
+}
+
+// InstanceExport represents a type class instance declared by a module
+//
+// Instances are not imported by name: importing a module makes all of its
+// instances visible to the importer's type checker.
+type InstanceExport struct {
+	ClassName string            // Class being instantiated (e.g., "Ord")
+	TypeHead  types.Type        // Instance type (e.g., Point)
+	Module    string            // Declaring module
+	Methods   map[string]string // Method name -> dictionary key of its implementation
+	Super     []string          // Superclasses provided alongside this instance
+}
+
+// ToClassInstance converts the exported instance into an InstanceEnv entry
+func (inst *InstanceExport) ToClassInstance() *types.ClassInstance {
+	dict := make(types.Dict, len(inst.Methods))
+	for method, impl := range inst.Methods {
+		dict[method] = impl
+	}
+	return &types.ClassInstance{
+		ClassName: inst.ClassName,
+		TypeHead:  inst.TypeHead,
+		Dict:      dict,
+		Super:     inst.Super,
+	}
 }
 
 // IfaceItem represents a single exported symbol
 type IfaceItem struct {
-	Name   string         // Symbol name
-	Type   *types.Scheme  // Generalized type scheme
-	Purity bool           // Whether the function is pure
-	Ref    core.GlobalRef // Global reference to this item
+	Name           string         // Symbol name
+	Type           *types.Scheme  // Generalized type scheme
+	Purity         bool           // Whether the function is pure
+	Ref            core.GlobalRef // Global reference to this item
+	Deprecated     bool           // Declared with @deprecated
+	DeprecationMsg string         // Optional deprecation message
+	Entry          bool           // Declared with @entry
+	Doc            string         // Doc comment of the declaration
 }
 
 // ConstructorScheme represents the type scheme of an ADT constructor
@@ -36,6 +80,13 @@ type ConstructorScheme struct {
 	FieldTypes []types.Type // Field types (empty for nullary constructors)
 	ResultType types.Type   // Result type after application
 	Arity      int          // Number of fields
+	FieldNames []string     // Field names for record-style constructors (nil if positional)
+	Newtype    bool         // Values are represented by the unboxed field (single-field newtype)
+	Index      int          // Position of the constructor in its type declaration
+	TypeParams []string     // Type parameters of the ADT
+	// Declared types of a record-style constructor's named fields, checked by
+	// p.x field access (nil if positional)
+	RecordFieldTypes []types.Type
 }
 
 // NewIface creates a new module interface
@@ -45,6 +96,7 @@ func NewIface(module string) *Iface {
 		Exports:      make(map[string]*IfaceItem),
 		Constructors: make(map[string]*ConstructorScheme),
 		Types:        make(map[string]*TypeExport),
+		Classes:      make(map[string]*ClassExport),
 		Schema:       "ailang.iface/v1",
 	}
 }
@@ -85,6 +137,18 @@ func (i *Iface) GetConstructor(name string) (*ConstructorScheme, bool) {
 	return ctor, ok
 }
 
+// ConstructorsOf returns the constructors of an ADT in declaration order
+func (i *Iface) ConstructorsOf(typeName string) []*ConstructorScheme {
+	var ctors []*ConstructorScheme
+	for _, ctor := range i.Constructors {
+		if ctor.TypeName == typeName {
+			ctors = append(ctors, ctor)
+		}
+	}
+	sort.Slice(ctors, func(a, b int) bool { return ctors[a].Index < ctors[b].Index })
+	return ctors
+}
+
 // AddType adds an exported type name to the interface
 func (i *Iface) AddType(name string, arity int) {
 	i.Types[name] = &TypeExport{
@@ -98,3 +162,22 @@ func (i *Iface) GetType(name string) (*TypeExport, bool) {
 	typ, ok := i.Types[name]
 	return typ, ok
 }
+
+// AddClass adds an exported type class to the interface
+func (i *Iface) AddClass(class *ClassExport) {
+	if i.Classes == nil {
+		i.Classes = make(map[string]*ClassExport)
+	}
+	i.Classes[class.Name] = class
+}
+
+// GetClass retrieves an exported type class
+func (i *Iface) GetClass(name string) (*ClassExport, bool) {
+	class, ok := i.Classes[name]
+	return class, ok
+}
+
+// AddInstance records a type class instance declared by the module
+func (i *Iface) AddInstance(inst *InstanceExport) {
+	i.Instances = append(i.Instances, inst)
+}
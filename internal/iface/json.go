@@ -9,10 +9,26 @@ import (
 
 // InterfaceJSON represents the normalized JSON format for module interfaces
 type InterfaceJSON struct {
-	Module string     `json:"module"`
-	Types  []TypeJSON `json:"types"`
-	Funcs  []FuncJSON `json:"funcs"`
-	Schema string     `json:"schema"`
+	Module    string         `json:"module"`
+	Types     []TypeJSON     `json:"types"`
+	Funcs     []FuncJSON     `json:"funcs"`
+	Classes   []ClassJSON    `json:"classes,omitempty"`
+	Instances []InstanceJSON `json:"instances,omitempty"`
+	Schema    string         `json:"schema"`
+}
+
+// ClassJSON represents an exported type class in normalized form
+type ClassJSON struct {
+	Name    string            `json:"name"`
+	Param   string            `json:"param"`
+	Super   []string          `json:"super,omitempty"`
+	Methods map[string]string `json:"methods,omitempty"` // Method name -> signature
+}
+
+// InstanceJSON represents a declared instance in normalized form
+type InstanceJSON struct {
+	Class string `json:"class"`
+	Type  string `json:"type"`
 }
 
 // TypeJSON represents an exported type in normalized form
@@ -20,14 +36,19 @@ type TypeJSON struct {
 	Name   string   `json:"name"`
 	Params []string `json:"params,omitempty"`
 	Ctors  []string `json:"ctors,omitempty"`
+	Doc    string   `json:"doc,omitempty"`
+	Alias  string   `json:"alias,omitempty"` // Aliased type of a transparent alias
 }
 
 // FuncJSON represents an exported function in normalized form
 type FuncJSON struct {
-	Name    string   `json:"name"`
-	Type    string   `json:"type"`
-	Effects []string `json:"effects"`
-	Pure    bool     `json:"pure"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Effects    []string `json:"effects"`
+	Pure       bool     `json:"pure"`
+	Deprecated *string  `json:"deprecated,omitempty"` // Deprecation message (set only if deprecated)
+	Entry      bool     `json:"entry,omitempty"`      // Declared with @entry
+	Doc        string   `json:"doc,omitempty"`
 }
 
 // ToNormalizedJSON converts an Iface to normalized JSON
@@ -75,6 +96,10 @@ func (i *Iface) ToNormalizedJSON() ([]byte, error) {
 			Name:   name,
 			Params: params,
 			Ctors:  typeToCtors[name], // Already sorted
+			Doc:    typeExport.Doc,
+		}
+		if typeExport.Alias != nil {
+			typeJSON.Alias = typeExport.Alias.String()
 		}
 
 		result.Types = append(result.Types, typeJSON)
@@ -101,15 +126,67 @@ func (i *Iface) ToNormalizedJSON() ([]byte, error) {
 			Type:    typeStr,
 			Effects: effects,
 			Pure:    export.Purity,
+			Entry:   export.Entry,
+			Doc:     export.Doc,
+		}
+		if export.Deprecated {
+			msg := export.DeprecationMsg
+			funcJSON.Deprecated = &msg
 		}
 
 		result.Funcs = append(result.Funcs, funcJSON)
 	}
 
+	result.Classes = classesJSON(i)
+	result.Instances = instancesJSON(i)
+
 	// Use deterministic JSON encoding
 	return json.MarshalIndent(result, "", "  ")
 }
 
+// classesJSON lists exported classes sorted by name
+func classesJSON(i *Iface) []ClassJSON {
+	names := make([]string, 0, len(i.Classes))
+	for name := range i.Classes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var classes []ClassJSON
+	for _, name := range names {
+		class := i.Classes[name]
+		methods := make(map[string]string, len(class.Methods))
+		for method, scheme := range class.Methods {
+			methods[method] = canonicalizeType(scheme)
+		}
+		classes = append(classes, ClassJSON{
+			Name:    class.Name,
+			Param:   class.TypeParam,
+			Super:   class.Super,
+			Methods: methods,
+		})
+	}
+	return classes
+}
+
+// instancesJSON lists declared instances sorted by class, then type
+func instancesJSON(i *Iface) []InstanceJSON {
+	var instances []InstanceJSON
+	for _, inst := range i.Instances {
+		instances = append(instances, InstanceJSON{
+			Class: inst.ClassName,
+			Type:  types.NormalizeTypeName(inst.TypeHead),
+		})
+	}
+	sort.Slice(instances, func(a, b int) bool {
+		if instances[a].Class != instances[b].Class {
+			return instances[a].Class < instances[b].Class
+		}
+		return instances[a].Type < instances[b].Type
+	})
+	return instances
+}
+
 // canonicalizeType converts a Scheme to canonical string form
 // Type variables are renamed to a, b, c, ...
 func canonicalizeType(scheme *types.Scheme) string {
@@ -16,6 +16,17 @@ type Lexer struct {
 	line         int
 	column       int
 	file         string
+	comments     []Comment
+}
+
+// Comment is a `--` line comment or `/* */` block comment recorded while
+// tokenizing. Comments never reach the parser as tokens; tools like the
+// formatter, and doc comment extraction, read them via Comments().
+type Comment struct {
+	Text    string // Full comment text including the leading "--" or "/*" and "*/"
+	Line    int
+	Column  int
+	EndLine int // Last line of the comment (Line, except for block comments)
 }
 
 // New creates a new Lexer with normalized input.
@@ -103,7 +114,7 @@ func (l *Lexer) NextToken() Token {
 			tok = NewToken(ARROW, string(ch)+string(l.ch), line, column, l.file)
 		} else if l.peekChar() == '-' {
 			// Handle single-line comments
-			l.skipComment()
+			l.skipComment(line, column)
 			return l.NextToken()
 		} else {
 			tok = NewToken(MINUS, string(l.ch), line, column, l.file)
@@ -119,6 +130,12 @@ func (l *Lexer) NextToken() Token {
 	case '*':
 		tok = NewToken(STAR, string(l.ch), line, column, l.file)
 	case '/':
+		if l.peekChar() == '*' {
+			if !l.skipBlockComment(line, column) {
+				return NewToken(ILLEGAL, "/*", line, column, l.file)
+			}
+			return l.NextToken()
+		}
 		// Check for regex literal
 		if l.isRegexStart() {
 			return l.readRegex(line, column)
@@ -159,6 +176,10 @@ func (l *Lexer) NextToken() Token {
 			ch := l.ch
 			l.readChar()
 			tok = NewToken(OR, string(ch)+string(l.ch), line, column, l.file)
+		} else if l.peekChar() == '>' {
+			ch := l.ch
+			l.readChar()
+			tok = NewToken(PIPEFWD, string(ch)+string(l.ch), line, column, l.file)
 		} else {
 			tok = NewToken(PIPE, string(l.ch), line, column, l.file)
 		}
@@ -265,11 +286,53 @@ func (l *Lexer) skipWhitespace() {
 	}
 }
 
-// skipComment skips single-line comments
-func (l *Lexer) skipComment() {
+// skipComment skips a single-line comment, recording its text and start position
+func (l *Lexer) skipComment(line, column int) {
+	start := l.position
 	for l.ch != '\n' && l.ch != 0 {
 		l.readChar()
 	}
+	l.comments = append(l.comments, Comment{
+		Text:    strings.TrimRight(l.input[start:l.position], "\r"),
+		Line:    line,
+		Column:  column,
+		EndLine: line,
+	})
+}
+
+// skipBlockComment skips a block comment, which may nest, recording its text
+// and start position. It returns false if the input ends before the comment
+// is closed.
+func (l *Lexer) skipBlockComment(line, column int) bool {
+	start := l.position
+	depth := 0
+	for l.ch != 0 {
+		switch {
+		case l.ch == '/' && l.peekChar() == '*':
+			depth++
+			l.readChar()
+		case l.ch == '*' && l.peekChar() == '/':
+			depth--
+			l.readChar()
+			if depth == 0 {
+				l.comments = append(l.comments, Comment{
+					Text:    l.input[start:l.readPosition],
+					Line:    line,
+					Column:  column,
+					EndLine: l.line,
+				})
+				l.readChar()
+				return true
+			}
+		}
+		l.readChar()
+	}
+	return false
+}
+
+// Comments returns the comments seen so far, in source order
+func (l *Lexer) Comments() []Comment {
+	return l.comments
 }
 
 // readString reads a string literal
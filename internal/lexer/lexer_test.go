@@ -194,14 +194,14 @@ func TestStringEscapes(t *testing.T) {
 }
 
 func TestOperators(t *testing.T) {
-	input := `+ - * / % == != < > <= >= && || ! -> => <- | ++ :: . ? @ $ #`
+	input := `+ - * / % == != < > <= >= && || ! -> => <- | |> ++ :: . ? @ $ #`
 
 	tests := []TokenType{
 		PLUS, MINUS, STAR, SLASH, PERCENT,
 		EQ, NEQ, LT, GT, LTE, GTE,
 		AND, OR, BANG,
 		ARROW, FARROW, LARROW,
-		PIPE, APPEND, DCOLON, // Note: :: becomes DCOLON
+		PIPE, PIPEFWD, APPEND, DCOLON, // Note: :: becomes DCOLON
 		DOT, QUESTION, AT, DOLLAR, HASH,
 		EOF,
 	}
@@ -335,3 +335,49 @@ func f() { x }`
 		}
 	}
 }
+
+func TestBlockComments(t *testing.T) {
+	input := `/* header /* nested */ still header */
+let x = 5 /* inline */ / 2
+/**
+ * doc
+ */
+func f() { x }`
+
+	expected := []TokenType{
+		LET, IDENT, ASSIGN, INT, SLASH, INT,
+		FUNC, IDENT, UNIT, LBRACE, IDENT, RBRACE,
+		EOF,
+	}
+	l := New(input, "test.ail")
+	for _, exp := range expected {
+		tok := l.NextToken()
+		if tok.Type != exp {
+			t.Fatalf("expected %v, got %v (%q)", exp, tok.Type, tok.Literal)
+		}
+	}
+
+	want := []Comment{
+		{Text: "/* header /* nested */ still header */", Line: 1, Column: 1, EndLine: 1},
+		{Text: "/* inline */", Line: 2, Column: 11, EndLine: 2},
+		{Text: "/**\n * doc\n */", Line: 3, Column: 1, EndLine: 5},
+	}
+	got := l.Comments()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d comments, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("comment %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestUnterminatedBlockComment(t *testing.T) {
+	l := New("let x = 1 /* never /* closed */", "test.ail")
+	for _, exp := range []TokenType{LET, IDENT, ASSIGN, INT, ILLEGAL} {
+		if tok := l.NextToken(); tok.Type != exp {
+			t.Fatalf("expected %v, got %v", exp, tok.Type)
+		}
+	}
+}
@@ -23,12 +23,14 @@ const (
 	PURE
 	LET
 	LETREC
+	CONST
 	IN
 	IF
 	THEN
 	ELSE
 	MATCH
 	WITH
+	HANDLE
 	TYPE
 	CLASS
 	INSTANCE
@@ -69,6 +71,7 @@ const (
 	FARROW    // =>
 	LARROW    // <-
 	PIPE      // |
+	PIPEFWD   // |>
 	APPEND    // ++
 	CONS      // ::
 	COMPOSE   // .
@@ -129,12 +132,14 @@ var tokens = map[TokenType]string{
 	PURE:       "pure",
 	LET:        "let",
 	LETREC:     "letrec",
+	CONST:      "const",
 	IN:         "in",
 	IF:         "if",
 	THEN:       "then",
 	ELSE:       "else",
 	MATCH:      "match",
 	WITH:       "with",
+	HANDLE:     "handle",
 	TYPE:       "type",
 	CLASS:      "class",
 	INSTANCE:   "instance",
@@ -174,6 +179,7 @@ var tokens = map[TokenType]string{
 	FARROW:    "=>",
 	LARROW:    "<-",
 	PIPE:      "|",
+	PIPEFWD:   "|>",
 	APPEND:    "++",
 	CONS:      "::",
 	COMPOSE:   ".",
@@ -227,12 +233,14 @@ var keywords = map[string]TokenType{
 	"pure":       PURE,
 	"let":        LET,
 	"letrec":     LETREC,
+	"const":      CONST,
 	"in":         IN,
 	"if":         IF,
 	"then":       THEN,
 	"else":       ELSE,
 	"match":      MATCH,
 	"with":       WITH,
+	"handle":     HANDLE,
 	"type":       TYPE,
 	"class":      CLASS,
 	"instance":   INSTANCE,
@@ -335,7 +343,7 @@ func (t Token) IsOperator() bool {
 		EQ, NEQ, LT, GT, LTE, GTE,
 		AND, OR, NOT,
 		APPEND, CONS, COMPOSE,
-		PIPE:
+		PIPE, PIPEFWD:
 		return true
 	}
 	return false
@@ -345,7 +353,7 @@ func (t Token) IsOperator() bool {
 func (t Token) IsKeyword() bool {
 	switch t.Type {
 	case FUNC, PURE, LET, IN, IF, THEN, ELSE,
-		MATCH, WITH, TYPE, CLASS, INSTANCE,
+		MATCH, WITH, HANDLE, TYPE, CLASS, INSTANCE,
 		MODULE, IMPORT, EXPORT,
 		FORALL, EXISTS, TEST, TESTS, PROPERTY, PROPERTIES, ASSERT,
 		SPAWN, PARALLEL, SELECT, CHANNEL,
@@ -375,12 +383,14 @@ func (t Token) Precedence() int {
 		return 7 // SUM
 	case STAR, SLASH, PERCENT:
 		return 8 // PRODUCT
+	case PIPEFWD:
+		return 9 // PIPELINE (x |> f)
 	case NOT:
-		return 9 // PREFIX (unary operators)
-	case LPAREN:
-		return 10 // CALL (function application)
+		return 10 // PREFIX (unary operators)
+	case LPAREN, UNIT:
+		return 11 // CALL (function application; f() lexes as IDENT UNIT)
 	case DOT:
-		return 11 // DOT_ACCESS (field access - highest)
+		return 12 // DOT_ACCESS (field access - highest)
 	default:
 		return 0
 	}
@@ -38,11 +38,7 @@ func registerFromSpecRegistry(ml *ModuleLinker) {
 		spec := specs[name]
 
 		// Build type scheme from spec
-		typ := spec.Type()
-		typeScheme := &types.Scheme{
-			TypeVars: []string{}, // TODO: Extract type vars if polymorphic
-			Type:     typ,
-		}
+		typeScheme := types.ClosedScheme(spec.Type())
 
 		builtinIface.Exports[name] = &iface.IfaceItem{
 			Name:   name,
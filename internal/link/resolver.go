@@ -22,6 +22,7 @@ type Resolver struct {
 	compiledCode  map[string]CompileUnit           // module -> compiled Core AST
 	mu            sync.RWMutex                     // For thread-safe memoization
 	builtinLookup func(string) (eval.Value, bool)  // Optional builtin lookup (v0.2.0 hotfix)
+	nullaryCache  sync.Map                         // "Type::Ctor" -> singleton TaggedValue
 }
 
 // NewResolver creates a new resolver backed by a module linker
@@ -182,10 +183,16 @@ func (r *Resolver) resolveAdtFactory(ref core.GlobalRef) (eval.Value, error) {
 	}
 
 	var arity int
+	var fieldNames []string
+	var newtype bool
+	var ctorIndex int
 	found := false
 	for _, ctor := range adtIface.Constructors {
 		if ctor.TypeName == typeName && ctor.CtorName == ctorName {
 			arity = ctor.Arity
+			fieldNames = ctor.FieldNames
+			newtype = ctor.Newtype
+			ctorIndex = ctor.Index
 			found = true
 			break
 		}
@@ -194,13 +201,24 @@ func (r *Resolver) resolveAdtFactory(ref core.GlobalRef) (eval.Value, error) {
 		return nil, fmt.Errorf("IMP011_UNKNOWN_CTOR: constructor %s.%s not found in $adt module (looking for factory %s)", typeName, ctorName, ref.Name)
 	}
 
-	// For nullary constructors, return the TaggedValue directly
+	// Nullary constructors: every reference shares one singleton
 	if arity == 0 {
-		return &eval.TaggedValue{
-			TypeName: typeName,
-			CtorName: ctorName,
-			Fields:   []eval.Value{},
-		}, nil
+		key := typeName + "::" + ctorName
+		if cached, ok := r.nullaryCache.Load(key); ok {
+			return cached.(eval.Value), nil
+		}
+		singleton, _ := r.nullaryCache.LoadOrStore(key, &eval.TaggedValue{
+			TypeName:  typeName,
+			CtorName:  ctorName,
+			Fields:    []eval.Value{},
+			CtorIndex: ctorIndex,
+		})
+		return singleton.(eval.Value), nil
+	}
+
+	// Newtypes are represented by the unboxed field
+	if newtype {
+		return eval.NewtypeConstructor(ref.Name), nil
 	}
 
 	// For constructors with fields, return a function
@@ -208,10 +226,16 @@ func (r *Resolver) resolveAdtFactory(ref core.GlobalRef) (eval.Value, error) {
 		Name: ref.Name,
 		Fn: func(args []eval.Value) (eval.Value, error) {
 			// Constructor factory: creates TaggedValue with given fields
+			if len(args) != arity {
+				return nil, fmt.Errorf("constructor %s.%s expects %d arguments, got %d",
+					typeName, ctorName, arity, len(args))
+			}
 			return &eval.TaggedValue{
-				TypeName: typeName,
-				CtorName: ctorName,
-				Fields:   args,
+				TypeName:   typeName,
+				CtorName:   ctorName,
+				Fields:     args,
+				FieldNames: fieldNames,
+				CtorIndex:  ctorIndex,
 			}, nil
 		},
 	}
@@ -0,0 +1,48 @@
+package link
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/core"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/iface"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// TestResolveAdtFactory verifies that $adt factories build TaggedValues,
+// check their arity, and share one value per nullary constructor
+func TestResolveAdtFactory(t *testing.T) {
+	ml := NewModuleLinker(&mockModuleLoader{})
+	opt := iface.NewIface("test/opt")
+	opt.AddConstructor("Opt", "Nothing", nil, &types.TCon{Name: "Opt"})
+	opt.AddConstructor("Opt", "Just", []types.Type{types.TInt}, &types.TCon{Name: "Opt"})
+	ml.RegisterIface(opt)
+	RegisterAdtModule(ml)
+	r := NewResolver(ml)
+
+	none1, err := r.ResolveValue(core.GlobalRef{Module: "$adt", Name: "make_Opt_Nothing"})
+	require.NoError(t, err)
+	none2, err := r.ResolveValue(core.GlobalRef{Module: "$adt", Name: "make_Opt_Nothing"})
+	require.NoError(t, err)
+	require.Same(t, none1, none2, "nullary constructor should be a singleton")
+	require.Equal(t, "Nothing", none1.String())
+
+	factory, err := r.ResolveValue(core.GlobalRef{Module: "$adt", Name: "make_Opt_Just"})
+	require.NoError(t, err)
+	fn, ok := factory.(*eval.BuiltinFunction)
+	require.True(t, ok, "expected a factory builtin, got %T", factory)
+
+	v, err := fn.Fn([]eval.Value{&eval.IntValue{Value: 1}})
+	require.NoError(t, err)
+	tagged, ok := v.(*eval.TaggedValue)
+	require.True(t, ok, "expected a TaggedValue, got %T", v)
+	require.Equal(t, "Opt", tagged.TypeName)
+	require.Equal(t, "Just(1)", tagged.String())
+
+	_, err = fn.Fn(nil)
+	require.ErrorContains(t, err, "expects 1 arguments, got 0")
+
+	_, err = r.ResolveValue(core.GlobalRef{Module: "$adt", Name: "make_Opt_Other"})
+	require.ErrorContains(t, err, "IMP011_UNKNOWN_CTOR")
+}
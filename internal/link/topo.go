@@ -44,20 +44,19 @@ func (ml *ModuleLinker) TopoSortFromRoot(root string, loaded map[string]*loader.
 
 		// Cycle detected
 		if inPath[module] {
-			// Build cycle path for error message
-			foundStart := false
-			for _, m := range cyclePath {
+			// Build cycle path for error message, starting where the
+			// cycle closes rather than at the root
+			var cycle []ModuleID
+			for i, m := range cyclePath {
 				if m == module {
-					foundStart = true
-				}
-				if foundStart {
-					cyclePath = append(cyclePath, m)
+					cycle = append(cycle, cyclePath[i:]...)
+					break
 				}
 			}
-			cyclePath = append(cyclePath, module) // Complete the cycle
+			cycle = append(cycle, module) // Complete the cycle
 			return &CycleError{
 				Code:  "LDR002",
-				Cycle: cyclePath,
+				Cycle: cycle,
 			}
 		}
 
@@ -0,0 +1,48 @@
+package link
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/loader"
+)
+
+func TestTopoSortFromRoot(t *testing.T) {
+	loaded := map[string]*loader.LoadedModule{
+		"main": {Path: "main", Imports: []string{"a", "b"}},
+		"a":    {Path: "a", Imports: []string{"b"}},
+		"b":    {Path: "b"},
+	}
+	sorted, err := NewModuleLinker(nil).TopoSortFromRoot("main", loaded)
+	if err != nil {
+		t.Fatalf("TopoSortFromRoot() error: %v", err)
+	}
+	want := []ModuleID{"b", "a", "main"}
+	if !reflect.DeepEqual(sorted, want) {
+		t.Errorf("sorted = %v, want %v", sorted, want)
+	}
+}
+
+// A value cycle across modules (a.x uses b.y, which uses a.x) needs the
+// modules to import each other, so it is reported here as an import cycle
+// before any module value is evaluated.
+func TestTopoSortFromRootCycle(t *testing.T) {
+	loaded := map[string]*loader.LoadedModule{
+		"main": {Path: "main", Imports: []string{"a"}},
+		"a":    {Path: "a", Imports: []string{"b"}},
+		"b":    {Path: "b", Imports: []string{"a"}},
+	}
+	_, err := NewModuleLinker(nil).TopoSortFromRoot("main", loaded)
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected *CycleError, got %v", err)
+	}
+	want := []ModuleID{"a", "b", "a"}
+	if !reflect.DeepEqual(cycleErr.Cycle, want) {
+		t.Errorf("cycle = %v, want %v", cycleErr.Cycle, want)
+	}
+	if got := cycleErr.Error(); got != "LDR002: dependency cycle detected: a -> b -> a" {
+		t.Errorf("Error() = %q", got)
+	}
+}
@@ -20,30 +20,63 @@ import (
 // ModuleLoader loads and caches modules
 type ModuleLoader struct {
 	cache              map[string]*LoadedModule
-	basePath           string // Base directory for relative imports
-	warnedLegacyStdlib bool   // Track if we've warned about stdlib/std/* usage
+	sources            map[string]memorySource // In-memory module sources by canonical ID
+	basePath           string                  // Base directory for relative imports
+	warnedLegacyStdlib bool                    // Track if we've warned about stdlib/std/* usage
+}
+
+// memorySource is module source code that does not come from a file
+type memorySource struct {
+	name    string // File name used in source positions, e.g. <stdin>
+	content []byte
 }
 
 // LoadedModule represents a loaded and parsed module
 type LoadedModule struct {
 	Path         string
+	Filename     string // File the source was read from (or its in-memory name)
 	File         *ast.File
-	Imports      []string                 // Module paths this module imports
-	Exports      map[string]*ast.FuncDecl // Export table (for now, just functions)
-	Types        map[string]*ast.TypeDecl // Exported type declarations
-	Constructors map[string]string        // Constructor name -> Type name mapping
-	Core         *core.Program            // Core representation (after elaboration)
-	Iface        *iface.Iface             // Module interface (after type checking)
+	Imports      []string                  // Module paths this module imports
+	Exports      map[string]*ast.FuncDecl  // Export table (for now, just functions)
+	Values       map[string]*ast.ConstDecl // Exported module values (top-level let/const)
+	Types        map[string]*ast.TypeDecl  // Exported type declarations
+	Constructors map[string]string         // Constructor name -> Type name mapping
+	Core         *core.Program             // Core representation (after elaboration)
+	Iface        *iface.Iface              // Module interface (after type checking)
+}
+
+// ParseErrors reports every parse error of a module file. The individual
+// errors stay reachable through errors.As and the multi-error Unwrap.
+type ParseErrors struct {
+	Path string
+	Errs []error
+}
+
+func (e *ParseErrors) Error() string {
+	return fmt.Sprintf("parse errors in %s: %v", e.Path, e.Errs)
+}
+
+// Unwrap returns the individual parse errors
+func (e *ParseErrors) Unwrap() []error {
+	return e.Errs
 }
 
 // NewModuleLoader creates a new module loader
 func NewModuleLoader(basePath string) *ModuleLoader {
 	return &ModuleLoader{
 		cache:    make(map[string]*LoadedModule),
+		sources:  make(map[string]memorySource),
 		basePath: basePath,
 	}
 }
 
+// AddSource registers the source code of the module at path, so that Load
+// parses content instead of reading a file. name is the file name reported
+// in source positions (such as <stdin> for a program piped to ailang run).
+func (ml *ModuleLoader) AddSource(path, name string, content []byte) {
+	ml.sources[CanonicalModuleID(path)] = memorySource{name: name, content: content}
+}
+
 // Preload adds a pre-loaded module to the cache
 //
 // This is used to inject modules that were already loaded and elaborated
@@ -90,11 +123,16 @@ func (ml *ModuleLoader) Load(path string) (*LoadedModule, error) {
 	// Use canonicalized path for all subsequent operations
 	canonicalID := CanonicalModuleID(canonPath)
 
-	// Check cache with canonical ID
-	if loaded, ok := ml.cache[canonicalID]; ok {
+	// Check cache with the same key the module is stored under (below). It keeps
+	// a legacy stdlib/std/* spelling, which is what importers look it up by.
+	if loaded, ok := ml.cache[CanonicalModuleID(path)]; ok {
 		return loaded, nil
 	}
 
+	if src, ok := ml.sources[CanonicalModuleID(path)]; ok {
+		return ml.parseModule(path, src.name, src.content)
+	}
+
 	// Track search attempts for error reporting
 	var searchTrace []string
 
@@ -135,12 +173,18 @@ func (ml *ModuleLoader) Load(path string) (*LoadedModule, error) {
 		return nil, errors.WrapReport(report)
 	}
 
+	return ml.parseModule(path, fullPath, content)
+}
+
+// parseModule parses the content of the module at path and caches it.
+// filename is the name used in source positions.
+func (ml *ModuleLoader) parseModule(path, filename string, content []byte) (*LoadedModule, error) {
 	// Parse file
-	l := lexer.New(string(content), fullPath)
+	l := lexer.New(string(content), filename)
 	p := parser.New(l)
 	file := p.ParseFile()
 	if len(p.Errors()) > 0 {
-		return nil, fmt.Errorf("parse errors in %s: %v", path, p.Errors())
+		return nil, &ParseErrors{Path: path, Errs: p.Errors()}
 	}
 
 	// Extract imports from the file
@@ -155,17 +199,20 @@ func (ml *ModuleLoader) Load(path string) (*LoadedModule, error) {
 
 	// Build types and constructors tables
 	types, constructors := ml.buildTypes(file)
+	values := ml.buildValues(file)
 
 	// Note: Core elaboration is done by the runtime to avoid import cycles
 	// (elaborate imports loader, so loader can't import elaborate)
 
 	// Cache and return with canonical ID
-	canonicalID = CanonicalModuleID(path)
+	canonicalID := CanonicalModuleID(path)
 	loaded := &LoadedModule{
 		Path:         canonicalID, // Store canonical form
+		Filename:     filename,
 		File:         file,
 		Imports:      imports,
 		Exports:      exports,
+		Values:       values,
 		Types:        types,
 		Constructors: constructors,
 		Core:         nil, // Will be populated by runtime
@@ -236,9 +283,31 @@ func (ml *ModuleLoader) buildExports(file *ast.File) map[string]*ast.FuncDecl {
 		}
 	}
 
+	// Field accessors of exported record-style constructors
+	for _, decl := range file.Decls {
+		if typeDecl, ok := decl.(*ast.TypeDecl); ok && typeDecl.Exported {
+			for _, fn := range ast.FieldAccessors(typeDecl) {
+				if _, exists := exports[fn.Name]; !exists {
+					exports[fn.Name] = fn
+				}
+			}
+		}
+	}
+
 	return exports
 }
 
+// buildValues builds the table of exported module values
+func (ml *ModuleLoader) buildValues(file *ast.File) map[string]*ast.ConstDecl {
+	values := make(map[string]*ast.ConstDecl)
+	for _, c := range file.Consts {
+		if c.IsExport && !strings.HasPrefix(c.Name, "_") {
+			values[c.Name] = c
+		}
+	}
+	return values
+}
+
 // buildTypes extracts type declarations and constructors from a module
 func (ml *ModuleLoader) buildTypes(file *ast.File) (map[string]*ast.TypeDecl, map[string]string) {
 	types := make(map[string]*ast.TypeDecl)
@@ -266,7 +335,7 @@ func (ml *ModuleLoader) buildTypes(file *ast.File) (map[string]*ast.TypeDecl, ma
 }
 
 // GetExport retrieves an exported symbol from a module
-// Returns (nil, nil) if the symbol is a type or constructor (not a function)
+// Returns (nil, nil) if the symbol is a value, type or constructor (not a function)
 func (ml *ModuleLoader) GetExport(modulePath, symbol string) (*ast.FuncDecl, error) {
 	module, err := ml.Load(modulePath)
 	if err != nil {
@@ -280,6 +349,11 @@ func (ml *ModuleLoader) GetExport(modulePath, symbol string) (*ast.FuncDecl, err
 		return decl, nil
 	}
 
+	// Check if it's a module value - return nil (not an error, just not a function)
+	if _, isValue := module.Values[symbol]; isValue {
+		return nil, nil
+	}
+
 	// Check if it's a type name - return nil (not an error, just not a function)
 	if _, isType := module.Types[symbol]; isType {
 		return nil, nil
@@ -295,6 +369,9 @@ func (ml *ModuleLoader) GetExport(modulePath, symbol string) (*ast.FuncDecl, err
 	for name := range module.Exports {
 		available = append(available, name)
 	}
+	for name := range module.Values {
+		available = append(available, name)
+	}
 	for name := range module.Types {
 		available = append(available, name+" (type)")
 	}
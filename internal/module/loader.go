@@ -426,6 +426,10 @@ func (l *Loader) extractExports(program *ast.Program) map[string]ast.Node {
 					if d.Name == name {
 						exports[name] = d
 					}
+				case *ast.ConstDecl:
+					if d.Name == name {
+						exports[name] = d
+					}
 				}
 			}
 		}
@@ -437,6 +441,8 @@ func (l *Loader) extractExports(program *ast.Program) map[string]ast.Node {
 				exports[d.Name] = d
 			case *ast.Let:
 				exports[d.Name] = d
+			case *ast.ConstDecl:
+				exports[d.Name] = d
 			}
 		}
 	}
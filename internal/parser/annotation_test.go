@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/lexer"
+)
+
+// TestDeprecatedAnnotation tests @deprecated annotations on function declarations
+func TestDeprecatedAnnotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantMsg []string
+	}{
+		{"bare", "@deprecated\nfunc foo() { 1 }", nil},
+		{"with_message", "@deprecated(\"use bar instead\")\nfunc foo() { 1 }", []string{"use bar instead"}},
+		{"exported", "@deprecated(\"old\")\nexport func foo() { 1 }", []string{"old"}},
+		{"pure", "@deprecated\nexport pure func foo() { 1 }", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input, "test.ail"))
+			file := p.ParseFile()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("unexpected parse errors: %v", p.Errors())
+			}
+			if len(file.Funcs) != 1 {
+				t.Fatalf("expected 1 function, got %d", len(file.Funcs))
+			}
+			annot := file.Funcs[0].FindAnnotation("deprecated")
+			if annot == nil {
+				t.Fatal("expected @deprecated annotation")
+			}
+			if len(annot.Args) != len(tt.wantMsg) || (len(tt.wantMsg) > 0 && annot.Args[0] != tt.wantMsg[0]) {
+				t.Errorf("annotation args = %v, want %v", annot.Args, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// TestInlineAnnotation tests @inline annotations on function declarations
+func TestInlineAnnotation(t *testing.T) {
+	p := New(lexer.New("@inline\nfunc sq(x: int) -> int = x * x", "test.ail"))
+	file := p.ParseFile()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parse errors: %v", p.Errors())
+	}
+	if len(file.Funcs) != 1 || file.Funcs[0].FindAnnotation("inline") == nil {
+		t.Fatal("expected @inline annotation")
+	}
+}
+
+// TestEntryAnnotation tests @entry annotations on exported functions
+func TestEntryAnnotation(t *testing.T) {
+	p := New(lexer.New("@entry\nexport func demo() -> int { 1 }", "test.ail"))
+	file := p.ParseFile()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parse errors: %v", p.Errors())
+	}
+	if len(file.Funcs) != 1 || file.Funcs[0].FindAnnotation("entry") == nil {
+		t.Fatal("expected @entry annotation")
+	}
+}
+
+// TestInvalidAnnotations tests annotation error reporting
+func TestInvalidAnnotations(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unknown_annotation", "@frobnicate\nfunc foo() { 1 }"},
+		{"non_string_arg", "@deprecated(42)\nfunc foo() { 1 }"},
+		{"too_many_args", "@deprecated(\"a\", \"b\")\nfunc foo() { 1 }"},
+		{"inline_with_args", "@inline(\"always\")\nfunc foo() { 1 }"},
+		{"entry_with_args", "@entry(\"main\")\nexport func foo() { 1 }"},
+		{"entry_not_exported", "@entry\nfunc foo() { 1 }"},
+		{"on_type_decl", "@deprecated\ntype Foo = Bar | Baz"},
+		{"unclosed", "@deprecated(\"a\"\nfunc foo() { 1 }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input, "test.ail"))
+			p.ParseFile()
+			if len(p.Errors()) == 0 {
+				t.Errorf("expected parse error for %q", tt.input)
+			}
+		})
+	}
+}
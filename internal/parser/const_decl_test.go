@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/lexer"
+)
+
+// TestConstDecl tests top-level value declarations
+func TestConstDecl(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantName   string
+		wantExport bool
+		wantConst  bool
+		wantType   bool
+	}{
+		{"export_let", "module m\nexport let pi = 3.14159", "pi", true, false, false},
+		{"export_const", "module m\nexport const sizes = [1, 2, 4]", "sizes", true, true, false},
+		{"private_const", "const limit: int = 10", "limit", false, true, true},
+		{"module_let", "module m\nlet secret = 42", "secret", false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input, "test.ail"))
+			file := p.ParseFile()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("unexpected parse errors: %v", p.Errors())
+			}
+			if len(file.Consts) != 1 {
+				t.Fatalf("expected 1 value declaration, got %d", len(file.Consts))
+			}
+			decl := file.Consts[0]
+			if decl.Name != tt.wantName || decl.IsExport != tt.wantExport || decl.IsConst != tt.wantConst {
+				t.Errorf("got %s (export=%v, const=%v), want %s (export=%v, const=%v)",
+					decl.Name, decl.IsExport, decl.IsConst, tt.wantName, tt.wantExport, tt.wantConst)
+			}
+			if (decl.Type != nil) != tt.wantType {
+				t.Errorf("type annotation = %v, want present=%v", decl.Type, tt.wantType)
+			}
+			if len(file.Statements) != 0 {
+				t.Errorf("value declaration also recorded as a statement: %v", file.Statements)
+			}
+		})
+	}
+}
+
+// TestConstDeclScriptLet tests that a let outside a module stays an expression
+func TestConstDeclScriptLet(t *testing.T) {
+	p := New(lexer.New("let x = 5 in x + 1", "test.ail"))
+	file := p.ParseFile()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parse errors: %v", p.Errors())
+	}
+	if len(file.Consts) != 0 || len(file.Statements) != 1 {
+		t.Errorf("expected a single let expression, got consts=%v statements=%v", file.Consts, file.Statements)
+	}
+}
+
+// TestConstDeclErrors tests value declaration error reporting
+func TestConstDeclErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"in_body", "module m\nexport let x = 1 in x", "PAR_CONST_DECL_BODY"},
+		{"const_in_body", "const x = 1 in x", "PAR_CONST_DECL_BODY"},
+		{"missing_value", "export const x", "PAR_"},
+		{"export_expression", "export 42", "PAR_EXPORT_REQUIRES_FUNC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input, "test.ail"))
+			p.ParseFile()
+			if len(p.Errors()) == 0 {
+				t.Fatalf("expected parse error for %q", tt.input)
+			}
+			if got := p.Errors()[0].Error(); !strings.Contains(got, tt.wantErr) {
+				t.Errorf("expected %s, got %s", tt.wantErr, got)
+			}
+		})
+	}
+}
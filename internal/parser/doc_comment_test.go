@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/lexer"
+)
+
+// TestDocComments tests that doc comments directly above a declaration are
+// attached to it, and plain or detached comments are not
+func TestDocComments(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"line", "--- Adds one.\n--- Total.\nfunc inc(x: int) -> int { x + 1 }", "Adds one.\nTotal."},
+		{"block", "/* Adds one. */\nfunc inc(x: int) -> int { x + 1 }", "Adds one."},
+		{"starred_block", "/**\n * Adds one.\n *\n * Total.\n */\nexport func inc(x: int) -> int { x + 1 }", "Adds one.\n\nTotal."},
+		{"annotated", "--- Adds one.\n@deprecated\nfunc inc(x: int) -> int { x + 1 }", "Adds one."},
+		{"plain_comment", "-- Adds one.\nfunc inc(x: int) -> int { x + 1 }", ""},
+		{"rule", "----------\nfunc inc(x: int) -> int { x + 1 }", ""},
+		{"detached", "--- Adds one.\n\nfunc inc(x: int) -> int { x + 1 }", ""},
+		{"only_nearest_run", "--- Stale.\n-- plain\n--- Adds one.\nfunc inc(x: int) -> int { x + 1 }", "Adds one."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input, "test.ail"))
+			file := p.ParseFile()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("unexpected parse errors: %v", p.Errors())
+			}
+			if len(file.Funcs) != 1 {
+				t.Fatalf("expected 1 function, got %d", len(file.Funcs))
+			}
+			if got := file.Funcs[0].Doc; got != tt.want {
+				t.Errorf("doc = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTypeDocComment tests that a type declaration keeps its doc comment
+func TestTypeDocComment(t *testing.T) {
+	p := New(lexer.New("module m\n\n--- A traffic light.\nexport type Light = Red | Green\n", "m.ail"))
+	file := p.ParseFile()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parse errors: %v", p.Errors())
+	}
+	for _, decl := range file.Decls {
+		if td, ok := decl.(*ast.TypeDecl); ok {
+			if td.Doc != "A traffic light." {
+				t.Errorf("doc = %q", td.Doc)
+			}
+			return
+		}
+	}
+	t.Fatal("type declaration not found")
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/sunholo/ailang/internal/errors"
 	"github.com/sunholo/ailang/internal/lexer"
 )
 
@@ -209,19 +210,66 @@ func TestErrorRecoveryResumption(t *testing.T) {
 
 // TestStructuredErrorFormat tests that errors have required structure
 func TestStructuredErrorFormat(t *testing.T) {
-	input := "[1, 2, 3"
-	errs := mustParseError(t, input)
+	inputs := []string{
+		"[1, 2, 3",
+		"{x: 1, y: 2",
+		"(1 + 2",
+		"match x { Some(y) => 1",
+		"{ 1; 2 3 }",
+		"func(x: int) { x",
+		"\\. 1",
+		"99999999999999999999",
+		"handle { 1 } with { }",
+	}
 
-	if len(errs) == 0 {
-		t.Fatal("Expected at least one error")
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			errs := mustParseError(t, input)
+			for _, err := range errs {
+				perr, ok := err.(*ParserError)
+				if !ok {
+					t.Errorf("Expected *ParserError, got %T: %v", err, err)
+					continue
+				}
+				if perr.Code == "" || perr.Message == "" || perr.Pos.Line == 0 {
+					t.Errorf("Incomplete ParserError: %+v", perr)
+				}
+			}
+		})
 	}
+}
+
+// TestParserErrorReport tests the structured report of a parser error
+func TestParserErrorReport(t *testing.T) {
+	errs := mustParseError(t, "(1 + 2")
+	assertHasCode(t, errs, "PAR_UNCLOSED_DELIM")
 
-	// Most parser errors are simple fmt.Errorf, not ParserError structs yet
-	// Just verify we get error messages
-	for _, err := range errs {
-		if err.Error() == "" {
-			t.Error("Error has empty message")
-		}
+	rep, ok := errors.AsReport(errs[0])
+	if !ok {
+		t.Fatalf("AsReport failed for %T", errs[0])
+	}
+	if rep.Phase != "parser" || rep.Code != "PAR_UNCLOSED_DELIM" {
+		t.Errorf("Expected parser phase and PAR_UNCLOSED_DELIM, got %s %s", rep.Phase, rep.Code)
+	}
+	if rep.Data["found"] != "EOF" {
+		t.Errorf("Expected found EOF, got %v", rep.Data["found"])
+	}
+	if expected, _ := rep.Data["expected"].([]string); len(expected) != 1 || expected[0] != ")" {
+		t.Errorf("Expected expected [)], got %v", rep.Data["expected"])
+	}
+	if rep.Span == nil || rep.Fix == nil {
+		t.Errorf("Expected span and fix, got %+v", rep)
+	}
+
+	// The span covers the token the error was reported at
+	errs = mustParseError(t, "let x = 1 in x )")
+	perr, ok := errs[0].(*ParserError)
+	if !ok {
+		t.Fatalf("Expected *ParserError, got %T", errs[0])
+	}
+	span := perr.Span()
+	if span.End.Column != span.Start.Column+len(perr.NearToken.Literal) {
+		t.Errorf("Span %v does not cover token %q", span, perr.NearToken.Literal)
 	}
 }
 
@@ -1,7 +1,11 @@
 package parser
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/lexer"
 )
 
 // TestLiterals tests parsing of all literal types
@@ -249,9 +253,11 @@ func TestLambdas(t *testing.T) {
 	}{
 		{"lambda_one_param", `\x. x + 1`, "expr/lambda_one_param"},
 		{"lambda_two_params", `\x y. x + y`, "expr/lambda_two_params"},
-		// TODO: Lambda type annotations not yet supported
-		// {"lambda_with_types", `\(x: int) (y: int). x + y`, "expr/lambda_with_types"},
+		{"lambda_with_types", `\(x: int) (y: int). x + y`, "expr/lambda_with_types"},
+		{"lambda_mixed_types", `\(x: float) y. x * y`, "expr/lambda_mixed_types"},
 		{"lambda_nested", `\x. \y. x + y`, "expr/lambda_nested"},
+		{"lambda_no_params", `try(func() => 1 / 0)`, "expr/lambda_no_params"},
+		{"handle_io", `handle { f(x) } with { IO.print(s) => (), IO.println(s) => g(s) }`, "expr/handle_io"},
 		// {"lambda_return_type", `\(x: int) -> int. x * 2`, "expr/lambda_return_type"},
 	}
 
@@ -411,3 +417,39 @@ func TestComplexExpressions(t *testing.T) {
 		})
 	}
 }
+
+// TestAllValues tests allValues[T], which is only special before '['
+func TestAllValues(t *testing.T) {
+	parse := func(input string) (ast.Node, []error) {
+		p := New(lexer.New(input, "test.ail"))
+		file := p.ParseFile()
+		if len(file.Statements) == 0 {
+			return nil, p.Errors()
+		}
+		return file.Statements[0], p.Errors()
+	}
+
+	node, errs := parse("allValues[Direction]()")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected parse errors: %v", errs)
+	}
+	call, ok := node.(*ast.FuncCall)
+	if !ok || len(call.Args) != 0 {
+		t.Fatalf("expected a call with no arguments, got %#v", node)
+	}
+	if av, ok := call.Func.(*ast.AllValues); !ok || av.Type.String() != "Direction" {
+		t.Fatalf("expected allValues[Direction], got %#v", call.Func)
+	}
+
+	node, errs = parse("allValues(xs)")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected parse errors: %v", errs)
+	}
+	if call, ok := node.(*ast.FuncCall); !ok || call.Func.String() != "allValues" {
+		t.Errorf("expected an ordinary call of allValues, got %#v", node)
+	}
+
+	if _, errs = parse("allValues[]"); len(errs) == 0 || !strings.Contains(errs[0].Error(), "PAR_ALLVALUES_TYPE") {
+		t.Error("expected a parse error for allValues without a type")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/lexer"
 )
 
@@ -38,6 +39,34 @@ func TestPatternMatchingInFunctions(t *testing.T) {
 					match p { (x, y) => (y, x) }
 				}`,
 		},
+		{
+			name: "as-pattern on list",
+			input: `module test
+				export func dupFirst(xs: [int]) -> [int] {
+					match xs { all @ [x, ...rest] => all, [] => [] }
+				}`,
+		},
+		{
+			name: "as-pattern inside constructor",
+			input: `module test
+				export func inner(o: Option[Option[int]]) -> Option[int] {
+					match o { Some(s @ Some(_)) => s, _ => None }
+				}`,
+		},
+		{
+			name: "or-patterns",
+			input: `module test
+				export func low(o: Option[int]) -> bool {
+					match o { Some(1) | Some(2) => true, Some(3 | 4) => true, _ => false }
+				}`,
+		},
+		{
+			name: "string literal patterns",
+			input: `module test
+				export func greet(s: string) -> string {
+					match s { "hi" => "hello", other => other }
+				}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -59,6 +88,27 @@ func TestPatternMatchingInFunctions(t *testing.T) {
 	}
 }
 
+// TestListPatternWildcardRest tests that ..._ ignores the rest of the list
+// instead of binding a variable named _
+func TestListPatternWildcardRest(t *testing.T) {
+	p := New(lexer.New("match xs { [x, ..._] => x }", "<test>"))
+	prog := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parse errors: %v", p.Errors())
+	}
+	match, ok := prog.File.Statements[0].(*ast.Match)
+	if !ok {
+		t.Fatalf("expected a match expression, got %T", prog.File.Statements[0])
+	}
+	list, ok := match.Cases[0].Pattern.(*ast.ListPattern)
+	if !ok {
+		t.Fatalf("expected a list pattern, got %T", match.Cases[0].Pattern)
+	}
+	if _, ok := list.Rest.(*ast.WildcardPattern); !ok {
+		t.Errorf("expected a wildcard rest, got %T", list.Rest)
+	}
+}
+
 // TestListPatternSpreadError tests error handling for spread without identifier
 func TestListPatternSpreadError(t *testing.T) {
 	input := `module test
@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/sunholo/ailang/internal/lexer"
 )
 
 // TestFunctionDeclarations tests basic function declaration parsing
@@ -253,3 +256,181 @@ func TestComplexFunctionBodies(t *testing.T) {
 		})
 	}
 }
+
+// TestWhereHelpers tests where blocks after function bodies
+func TestWhereHelpers(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		helpers []string
+	}{
+		{"equation_body", "func f(x: int) -> int = g(x) + 1 where g(y: int) -> int = y * 2", []string{"g"}},
+		{"block_body", "func f(x) { g(x) } where g(y) { y * 2 }", []string{"g"}},
+		{"several", "func f(x) = g(h(x))\n  where g(y) = y * 2,\n        h(z) = z + 1", []string{"g", "h"}},
+		{"unit_param", "func f(x) = x where k() = 42", []string{"k"}},
+		{"effects", "func f() -> () ! {IO} = say(\"hi\") where say(s: string) -> () ! {IO} = println(s)", []string{"say"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input, "test.ail"))
+			file := p.ParseFile()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("unexpected parse errors: %v", p.Errors())
+			}
+			if len(file.Funcs) != 1 {
+				t.Fatalf("expected 1 function, got %d", len(file.Funcs))
+			}
+			where := file.Funcs[0].Where
+			if len(where) != len(tt.helpers) {
+				t.Fatalf("expected %d helpers, got %d", len(tt.helpers), len(where))
+			}
+			for i, name := range tt.helpers {
+				if where[i].Name != name || where[i].Body == nil {
+					t.Errorf("helper %d = %q (body %v), want %q", i, where[i].Name, where[i].Body, name)
+				}
+			}
+		})
+	}
+}
+
+// TestWhereHelperErrors tests malformed where blocks
+func TestWhereHelperErrors(t *testing.T) {
+	for _, input := range []string{
+		"func f(x) = g(x) where",
+		"func f(x) = g(x) where g = 1",
+		"func f(x) = g(x) where g(y) = y, g(z) = z",
+		"func f(x) = g(x) where g(y) = y,",
+	} {
+		p := New(lexer.New(input, "test.ail"))
+		p.ParseFile()
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parse error for %q", input)
+		}
+	}
+}
+
+// TestInlineTests tests tests [...] blocks before and after function bodies
+func TestInlineTests(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+		where int
+	}{
+		{"after_block", "func double(x: int) -> int { x * 2 } tests [double(2) == 4, double(0) == 0]", []string{"((double 2) == 4)", "((double 0) == 0)"}, 0},
+		{"after_equation", "func inc(x) = x + 1 tests [inc(1) == 2,]", []string{"((inc 1) == 2)"}, 0},
+		{"before_body", "func inc(x: int) -> int\n  tests [inc(1) == 2]\n{\n  x + 1\n}", []string{"((inc 1) == 2)"}, 0},
+		{"with_where", "func f(x) = g(x) tests [f(1) == 2] where g(y) = y * 2", []string{"((f 1) == 2)"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input, "test.ail"))
+			file := p.ParseFile()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("unexpected parse errors: %v", p.Errors())
+			}
+			if len(file.Funcs) != 1 {
+				t.Fatalf("expected 1 function, got %d", len(file.Funcs))
+			}
+			fn := file.Funcs[0]
+			if len(fn.Tests) != len(tt.want) {
+				t.Fatalf("expected %d tests, got %d", len(tt.want), len(fn.Tests))
+			}
+			for i, want := range tt.want {
+				if got := fn.Tests[i].Expr.String(); got != want {
+					t.Errorf("test %d = %s, want %s", i, got, want)
+				}
+			}
+			if len(fn.Where) != tt.where {
+				t.Errorf("expected %d where helpers, got %d", tt.where, len(fn.Where))
+			}
+		})
+	}
+}
+
+// TestInlineTestsErrors tests malformed tests blocks
+func TestInlineTestsErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"func f(x) = x tests []", "PAR_TESTS_EMPTY"},
+		{"func f(x) tests [f(1) == 1] { x } tests [f(2) == 2]", "PAR_TESTS_DUPLICATE"},
+		{"func f(x) = x tests [f(1) == 1", "PAR_"},
+		{"func f(x) = x tests f(1) == 1", "PAR_"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input, "test.ail"))
+		p.ParseFile()
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parse error for %q", tt.input)
+			continue
+		}
+		if got := p.Errors()[0].Error(); !strings.Contains(got, tt.wantErr) {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.wantErr, got)
+		}
+	}
+}
+
+// TestProperties tests properties blocks with forall binders
+func TestProperties(t *testing.T) {
+	input := "func abs(x: int) -> int = x properties [\n  forall(x: int) => abs(x) >= 0,\n  forall(s: string, b: bool) => b,\n]"
+	p := New(lexer.New(input, "test.ail"))
+	file := p.ParseFile()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parse errors: %v", p.Errors())
+	}
+	fn := file.Funcs[0]
+	if len(fn.Properties) != 2 {
+		t.Fatalf("expected 2 properties, got %d", len(fn.Properties))
+	}
+	first, second := fn.Properties[0], fn.Properties[1]
+	if len(first.Binders) != 1 || first.Binders[0].Name != "x" || first.Binders[0].Type.String() != "int" {
+		t.Errorf("unexpected binders %v", first.Binders)
+	}
+	if got := first.Expr.String(); got != "((abs x) >= 0)" {
+		t.Errorf("property 0 = %s", got)
+	}
+	if len(second.Binders) != 2 || second.Binders[1].Name != "b" {
+		t.Errorf("unexpected binders %v", second.Binders)
+	}
+
+	// Tests and properties can both come before the body
+	p = New(lexer.New("func f(x) tests [f(1) == 1] properties [forall(x: int) => f(x) == x] { x }", "test.ail"))
+	file = p.ParseFile()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parse errors: %v", p.Errors())
+	}
+	if fn := file.Funcs[0]; len(fn.Tests) != 1 || len(fn.Properties) != 1 {
+		t.Errorf("expected 1 test and 1 property, got %d and %d", len(fn.Tests), len(fn.Properties))
+	}
+}
+
+// TestPropertiesErrors tests malformed properties blocks
+func TestPropertiesErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"func f(x) = x properties []", "PAR_PROPERTIES_EMPTY"},
+		{"func f(x) properties [forall(x: int) => true] { x } properties [forall(x: int) => true]", "PAR_PROPERTIES_DUPLICATE"},
+		{"func f(x) = x properties [forall(x) => true]", "PAR_FORALL_NEEDS_TYPE"},
+		{"func f(x) = x properties [forall(x: int, x: int) => true]", "PAR_FORALL_DUPLICATE"},
+		{"func f(x) = x properties [f(1) == 1]", "PAR_"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input, "test.ail"))
+		p.ParseFile()
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parse error for %q", tt.input)
+			continue
+		}
+		if got := p.Errors()[0].Error(); !strings.Contains(got, tt.wantErr) {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.wantErr, got)
+		}
+	}
+}
@@ -35,6 +35,7 @@ const (
 	APPEND          // ++
 	SUM             // +, -
 	PRODUCT         // *, /, %
+	PIPELINE        // x |> f
 	PREFIX          // -x, !x (unary)
 	CALL            // f(x) (application)
 	DotAccess       // r.field (field access - highest)
@@ -68,6 +69,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(lexer.LET, p.parseLetExpression)
 	p.registerPrefix(lexer.LETREC, p.parseLetRecExpression)
 	p.registerPrefix(lexer.MATCH, p.parseMatchExpression)
+	p.registerPrefix(lexer.HANDLE, p.parseHandleExpression)
 	p.registerPrefix(lexer.FUNC, p.parseLambda)
 	p.registerPrefix(lexer.PURE, p.parsePureLambda)
 	p.registerPrefix(lexer.BACKSLASH, p.parseBackslashLambda)
@@ -89,7 +91,9 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(lexer.OR, p.parseInfixExpression)
 	p.registerInfix(lexer.APPEND, p.parseInfixExpression)
 	p.registerInfix(lexer.CONS, p.parseInfixExpression)
+	p.registerInfix(lexer.PIPEFWD, p.parseInfixExpression)
 	p.registerInfix(lexer.LPAREN, p.parseCallExpression)
+	p.registerInfix(lexer.UNIT, p.parseUnitCall)
 	p.registerInfix(lexer.DOT, p.parseRecordAccess)
 	p.registerInfix(lexer.LARROW, p.parseSendExpression)
 
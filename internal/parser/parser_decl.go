@@ -61,16 +61,33 @@ func (p *Parser) ParseFile() (file *ast.File) {
 
 	// Export declarations (standalone export list)
 	if p.curTokenIs(lexer.EXPORT) && p.peekTokenIs(lexer.LBRACE) {
-		p.parseExportList()
+		file.Exports = p.parseExportList()
 		p.nextToken()
 	}
 
 	// Top-level declarations
 	for !p.curTokenIs(lexer.EOF) {
+		doc := p.docCommentBefore(p.curToken.Line)
 		if decl := p.parseTopLevelDecl(); decl != nil {
-			// Separate functions from other statements
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d != nil {
+					d.Doc = doc
+				}
+			case *ast.TypeDecl:
+				if d != nil {
+					d.Doc = doc
+				}
+			}
+			// In a module, a top-level let without an 'in' body declares a module value
+			if let, ok := decl.(*ast.Let); ok && let.Body == nil && file.Module != nil {
+				decl = &ast.ConstDecl{Name: let.Name, Type: let.Type, Value: let.Value, Pos: let.Pos}
+			}
+			// Separate functions and values from other statements
 			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
 				file.Funcs = append(file.Funcs, funcDecl)
+			} else if constDecl, ok := decl.(*ast.ConstDecl); ok {
+				file.Consts = append(file.Consts, constDecl)
 			} else {
 				file.Statements = append(file.Statements, decl)
 			}
@@ -242,7 +259,6 @@ func (p *Parser) parseExportList() []string {
 		return exports
 	}
 
-	// Store exports in File's metadata (we'll need to extend the File struct later)
 	return exports
 }
 
@@ -258,27 +274,17 @@ func (p *Parser) parseTopLevelDecl() ast.Node {
 		if p.curTokenIs(lexer.TYPE) {
 			return p.parseTypeDeclaration(true) // exported=true
 		}
-		if p.curTokenIs(lexer.LET) {
-			// Error: export let not supported
-			err := NewParserError(
-				"PAR_UNSUPPORTED_EXPORT_LET",
-				p.curPos(),
-				p.curToken,
-				"export let is not supported; use export func instead",
-				[]lexer.TokenType{lexer.FUNC},
-				"Change 'export let' to 'export func' with explicit parameters",
-			)
-			p.errors = append(p.errors, err)
-			return nil
+		if p.curTokenIs(lexer.LET) || p.curTokenIs(lexer.CONST) {
+			return p.parseConstDeclaration(true)
 		}
 		// Error: export must be followed by func, type, or pure
 		err := NewParserError(
 			"PAR_EXPORT_REQUIRES_FUNC",
 			p.curPos(),
 			p.curToken,
-			fmt.Sprintf("export must be followed by 'func' or 'type', got '%s'", p.curToken.Literal),
-			[]lexer.TokenType{lexer.FUNC, lexer.PURE, lexer.TYPE},
-			"Use 'export func name(...) { ... }' or 'export type Name = ...'",
+			fmt.Sprintf("export must be followed by 'func', 'type', 'let' or 'const', got '%s'", p.curToken.Literal),
+			[]lexer.TokenType{lexer.FUNC, lexer.PURE, lexer.TYPE, lexer.LET, lexer.CONST},
+			"Use 'export func name(...) { ... }', 'export type Name = ...' or 'export let name = ...'",
 		)
 		p.errors = append(p.errors, err)
 		return nil
@@ -292,18 +298,156 @@ func (p *Parser) parseTopLevelDecl() ast.Node {
 		return p.parseExpression(LOWEST)
 	case lexer.FUNC:
 		return p.parseFunctionDeclaration(false, false) // not pure, not export
+	case lexer.CONST:
+		return p.parseConstDeclaration(false)
 	case lexer.TYPE:
 		return p.parseTypeDeclaration(false) // exported=false
 	case lexer.CLASS:
 		return p.parseClassDeclaration()
 	case lexer.INSTANCE:
 		return p.parseInstanceDeclaration()
+	case lexer.AT:
+		return p.parseAnnotatedDecl()
 	default:
 		// Try to parse as an expression (for script-style files)
 		return p.parseExpression(LOWEST)
 	}
 }
 
+// parseConstDeclaration parses a top-level value declaration starting at
+// 'let' or 'const':
+//
+//	export let pi = 3.14159
+//	const table: [int] = [1, 2, 4, 8]
+func (p *Parser) parseConstDeclaration(isExport bool) ast.Node {
+	decl := &ast.ConstDecl{
+		IsExport: isExport,
+		IsConst:  p.curTokenIs(lexer.CONST),
+		Pos:      p.curPos(),
+	}
+
+	if !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+	decl.Name = p.curToken.Literal
+
+	// Optional type annotation
+	if p.peekTokenIs(lexer.COLON) {
+		p.nextToken()
+		p.nextToken()
+		decl.Type = p.parseType()
+	}
+
+	if !p.expectPeek(lexer.ASSIGN) {
+		return nil
+	}
+	p.nextToken()
+	decl.Value = p.parseExpression(LOWEST)
+	if decl.Value == nil {
+		decl.Value = &ast.Error{Pos: p.curPos()}
+	}
+
+	if p.peekTokenIs(lexer.IN) {
+		p.nextToken()
+		err := NewParserError(
+			"PAR_CONST_DECL_BODY",
+			p.curPos(),
+			p.curToken,
+			fmt.Sprintf("top-level declaration of '%s' cannot have an 'in' body", decl.Name),
+			nil,
+			"Remove 'in ...' to declare a module value, or drop 'export'/'const' to write a let expression",
+		)
+		p.errors = append(p.errors, err)
+		return nil
+	}
+
+	return decl
+}
+
+// knownAnnotations lists the declaration annotations the compiler understands
+var knownAnnotations = map[string]bool{
+	"deprecated": true, // @deprecated or @deprecated("use bar instead")
+	"inline":     true, // @inline: --optimize inlines calls within the module
+	"entry":      true, // @entry: the function run calls when --entry is not given
+}
+
+// parseAnnotatedDecl parses one or more annotations followed by a function declaration:
+//
+//	@deprecated("use bar instead")
+//	export func foo() -> int { 1 }
+func (p *Parser) parseAnnotatedDecl() ast.Node {
+	var annotations []*ast.Annotation
+	for p.curTokenIs(lexer.AT) {
+		annot := p.parseAnnotation()
+		if annot == nil {
+			return nil
+		}
+		annotations = append(annotations, annot)
+		p.nextToken() // advance past the annotation
+	}
+
+	decl := p.parseTopLevelDecl()
+	fn, ok := decl.(*ast.FuncDecl)
+	if !ok {
+		if decl != nil {
+			p.report("PAR_ANNOTATION_TARGET", "annotations can only be attached to function declarations", "Place the annotation directly before 'func'")
+		}
+		return decl
+	}
+	fn.Annotations = append(annotations, fn.Annotations...)
+	if entry := fn.FindAnnotation("entry"); entry != nil && !fn.IsExport {
+		p.errors = append(p.errors, NewParserError("PAR_ANNOTATION_TARGET", entry.Pos, p.curToken,
+			fmt.Sprintf("@entry function '%s' must be exported", fn.Name), nil, "Declare it with 'export func'"))
+	}
+	return fn
+}
+
+// parseAnnotation parses @name or @name("arg", ...)
+// The current token must be AT; the parser is left at the last token of the annotation.
+func (p *Parser) parseAnnotation() *ast.Annotation {
+	startPos := p.curPos()
+	if !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+
+	annot := &ast.Annotation{
+		Name: p.curToken.Literal,
+		Pos:  startPos,
+	}
+	if !knownAnnotations[annot.Name] {
+		p.report("PAR_UNKNOWN_ANNOTATION", fmt.Sprintf("unknown annotation '@%s'", annot.Name), "Supported annotations: @deprecated, @inline, @entry")
+		return nil
+	}
+
+	if !p.peekTokenIs(lexer.LPAREN) {
+		return annot
+	}
+	p.nextToken() // advance to LPAREN
+
+	for !p.peekTokenIs(lexer.RPAREN) {
+		if !p.expectPeek(lexer.STRING) {
+			return nil
+		}
+		annot.Args = append(annot.Args, p.curToken.Literal)
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
+		}
+		p.nextToken() // advance to COMMA
+	}
+	if !p.expectPeek(lexer.RPAREN) {
+		return nil
+	}
+	if annot.Name == "deprecated" && len(annot.Args) > 1 {
+		p.report("PAR_ANNOTATION_ARGS", "@deprecated takes at most one message argument", "Use @deprecated(\"message\")")
+		return nil
+	}
+	if (annot.Name == "inline" || annot.Name == "entry") && len(annot.Args) > 0 {
+		p.report("PAR_ANNOTATION_ARGS", fmt.Sprintf("@%s takes no arguments", annot.Name), "Use @"+annot.Name)
+		return nil
+	}
+	return annot
+}
+
 // parseFunctionDeclaration parses a function declaration
 func (p *Parser) parseFunctionDeclaration(isPure bool, isExport bool) *ast.FuncDecl {
 	startPos := p.curPos()
@@ -412,13 +556,12 @@ func (p *Parser) parseFunctionDeclaration(isPure bool, isExport bool) *ast.FuncD
 		for p.peekTokenIs(lexer.NEWLINE) {
 			p.nextToken()
 		}
-		if p.peekTokenIs(lexer.LBRACKET) {
-			p.nextToken() // move to LBRACKET
-			// fn.Tests = p._parseTestsBlock() // TODO: Implement tests block
-			// parseTestsBlock leaves us at RBRACKET, move past it
-			if p.curTokenIs(lexer.RBRACKET) {
-				p.nextToken()
-			}
+		if !p.expectPeek(lexer.LBRACKET) {
+			return nil
+		}
+		fn.Tests = p.parseTestsBlock()
+		if fn.Tests == nil {
+			return nil
 		}
 		// Skip newlines after tests block
 		for p.curTokenIs(lexer.NEWLINE) {
@@ -434,13 +577,12 @@ func (p *Parser) parseFunctionDeclaration(isPure bool, isExport bool) *ast.FuncD
 		for p.peekTokenIs(lexer.NEWLINE) {
 			p.nextToken()
 		}
-		if p.peekTokenIs(lexer.LBRACKET) {
-			p.nextToken() // move to LBRACKET
-			// fn.Properties = p._parsePropertiesBlock() // TODO: Implement properties block
-			// parsePropertiesBlock leaves us at RBRACKET, move past it
-			if p.curTokenIs(lexer.RBRACKET) {
-				p.nextToken()
-			}
+		if !p.expectPeek(lexer.LBRACKET) {
+			return nil
+		}
+		fn.Properties = p.parsePropertiesBlock()
+		if fn.Properties == nil {
+			return nil
 		}
 		// Skip newlines after properties block
 		for p.curTokenIs(lexer.NEWLINE) {
@@ -480,9 +622,229 @@ func (p *Parser) parseFunctionDeclaration(isPure bool, isExport bool) *ast.FuncD
 
 	endPos := p.curPos()
 	fn.Span = ast.Span{Start: startPos, End: endPos}
+
+	// Inline tests and properties may also follow the body:
+	// func f(x: int) -> int { x } tests [f(1) == 1] properties [forall(x: int) => f(x) == x]
+	if p.peekTokenIs(lexer.TESTS) || p.peekIsContextualKeyword("tests") {
+		if fn.Tests != nil {
+			p.report("PAR_TESTS_DUPLICATE", fmt.Sprintf("function '%s' has more than one tests block", fn.Name), "Merge the assertions into a single tests [...] block")
+			return nil
+		}
+		p.nextToken() // move to 'tests'
+		if !p.expectPeek(lexer.LBRACKET) {
+			return nil
+		}
+		fn.Tests = p.parseTestsBlock()
+		if fn.Tests == nil {
+			return nil
+		}
+	}
+	if p.peekTokenIs(lexer.PROPERTIES) || p.peekIsContextualKeyword("properties") {
+		if fn.Properties != nil {
+			p.report("PAR_PROPERTIES_DUPLICATE", fmt.Sprintf("function '%s' has more than one properties block", fn.Name), "Merge the properties into a single properties [...] block")
+			return nil
+		}
+		p.nextToken() // move to 'properties'
+		if !p.expectPeek(lexer.LBRACKET) {
+			return nil
+		}
+		fn.Properties = p.parsePropertiesBlock()
+		if fn.Properties == nil {
+			return nil
+		}
+	}
+
+	// Local helpers: func f(x: int) -> int = g(x) + 1 where g(y: int) -> int = y * 2
+	if p.peekIsContextualKeyword("where") {
+		p.nextToken() // move to 'where'
+		fn.Where = p.parseWhereHelpers()
+		if fn.Where == nil {
+			return nil
+		}
+	}
 	return fn
 }
 
+// parseTestsBlock parses the comma-separated assertions of a tests block.
+// Assumes we're AT the LBRACKET and leaves us at the RBRACKET. Each assertion
+// is a bool expression:
+//
+//	tests [double(2) == 4, double(0) == 0]
+func (p *Parser) parseTestsBlock() []*ast.TestCase {
+	tests := []*ast.TestCase{}
+	for !p.peekTokenIs(lexer.RBRACKET) {
+		p.nextToken() // move to the start of the assertion
+		pos := p.curPos()
+		expr := p.parseExpression(LOWEST)
+		if expr == nil {
+			return nil
+		}
+		end := ast.Pos{Line: p.peekToken.Line, Column: p.peekToken.Column, File: p.peekToken.File}
+		tests = append(tests, &ast.TestCase{Expr: expr, Pos: pos, End: end})
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
+		}
+		p.nextToken() // move to COMMA; a trailing comma is allowed
+	}
+	if !p.expectPeek(lexer.RBRACKET) {
+		return nil
+	}
+	if len(tests) == 0 {
+		p.report("PAR_TESTS_EMPTY", "tests block has no assertions", "Add assertions like tests [f(1) == 2], or remove the block")
+		return nil
+	}
+	return tests
+}
+
+// parsePropertiesBlock parses the comma-separated properties of a properties
+// block. Assumes we're AT the LBRACKET and leaves us at the RBRACKET. Each
+// property quantifies over typed binders:
+//
+//	properties [forall(x: int, s: string) => f(x, s) >= 0]
+func (p *Parser) parsePropertiesBlock() []*ast.Property {
+	props := []*ast.Property{}
+	for !p.peekTokenIs(lexer.RBRACKET) {
+		if !p.expectPeek(lexer.FORALL) {
+			return nil
+		}
+		prop := &ast.Property{Pos: p.curPos()}
+		if !p.expectPeek(lexer.LPAREN) {
+			return nil
+		}
+		seen := make(map[string]bool)
+		for {
+			if !p.expectPeek(lexer.IDENT) {
+				return nil
+			}
+			binder := &ast.Binder{Name: p.curToken.Literal, Pos: p.curPos()}
+			if seen[binder.Name] {
+				p.report("PAR_FORALL_DUPLICATE", fmt.Sprintf("'%s' is bound twice in forall", binder.Name), "Give each quantified variable its own name")
+				return nil
+			}
+			seen[binder.Name] = true
+			if !p.peekTokenIs(lexer.COLON) {
+				p.report("PAR_FORALL_NEEDS_TYPE", fmt.Sprintf("quantified variable '%s' needs a type", binder.Name), "Write the type to generate values of, like forall(x: int)")
+				return nil
+			}
+			p.nextToken() // move to COLON
+			p.nextToken() // move to the type
+			binder.Type = p.parseType()
+			prop.Binders = append(prop.Binders, binder)
+			if !p.peekTokenIs(lexer.COMMA) {
+				break
+			}
+			p.nextToken() // move to COMMA
+		}
+		if !p.expectPeek(lexer.RPAREN) || !p.expectPeek(lexer.FARROW) {
+			return nil
+		}
+		p.nextToken() // move to the start of the law
+		prop.Expr = p.parseExpression(LOWEST)
+		if prop.Expr == nil {
+			return nil
+		}
+		prop.End = ast.Pos{Line: p.peekToken.Line, Column: p.peekToken.Column, File: p.peekToken.File}
+		props = append(props, prop)
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
+		}
+		p.nextToken() // move to COMMA; a trailing comma is allowed
+	}
+	if !p.expectPeek(lexer.RBRACKET) {
+		return nil
+	}
+	if len(props) == 0 {
+		p.report("PAR_PROPERTIES_EMPTY", "properties block has no properties", "Add properties like properties [forall(x: int) => f(x) >= 0], or remove the block")
+		return nil
+	}
+	return props
+}
+
+// parseWhereHelpers parses the comma-separated helper functions of a where block.
+// Assumes we're currently AT the 'where' token. Each helper is written like a
+// function declaration without the 'func' keyword:
+//
+//	where g(y: int) -> int = y * 2, h(z) { z + 1 }
+func (p *Parser) parseWhereHelpers() []*ast.FuncDecl {
+	var helpers []*ast.FuncDecl
+	seen := make(map[string]bool)
+	for {
+		if !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		if seen[p.curToken.Literal] {
+			p.report("PAR_WHERE_DUPLICATE", fmt.Sprintf("helper '%s' is defined twice in the where block", p.curToken.Literal), "Rename or remove one of the helpers")
+			return nil
+		}
+		seen[p.curToken.Literal] = true
+
+		helper := p.parseWhereHelper()
+		if helper == nil {
+			return nil
+		}
+		helpers = append(helpers, helper)
+
+		if !p.peekTokenIs(lexer.COMMA) {
+			return helpers
+		}
+		p.nextToken() // move to COMMA
+	}
+}
+
+// parseWhereHelper parses a single where helper. Assumes we're AT its name.
+func (p *Parser) parseWhereHelper() *ast.FuncDecl {
+	startPos := p.curPos()
+	helper := &ast.FuncDecl{
+		Name:   p.curToken.Literal,
+		Pos:    startPos,
+		Origin: "where",
+	}
+
+	if p.peekTokenIs(lexer.UNIT) {
+		p.nextToken()
+		helper.Params = []*ast.Param{}
+	} else {
+		if !p.expectPeek(lexer.LPAREN) {
+			return nil
+		}
+		helper.Params = p.parseParams()
+	}
+
+	if p.peekTokenIs(lexer.ARROW) {
+		p.nextToken()
+		p.nextToken()
+		helper.ReturnType = p.parseType()
+		if p.peekTokenIs(lexer.BANG) {
+			p.nextToken() // move to BANG
+			helper.Effects = p.parseEffectAnnotation()
+		}
+	}
+
+	if p.peekTokenIs(lexer.ASSIGN) {
+		p.nextToken() // move to ASSIGN
+		p.nextToken() // move past ASSIGN to start of expression
+		body := p.parseExpression(LOWEST)
+		if body == nil {
+			return nil
+		}
+		helper.Body = &ast.Block{
+			Exprs: []ast.Expr{body},
+			Pos:   body.Position(),
+		}
+	} else {
+		if !p.expectPeek(lexer.LBRACE) {
+			return nil
+		}
+		helper.Body = p.parseFunctionBody()
+		if !p.expectPeek(lexer.RBRACE) {
+			return nil
+		}
+	}
+
+	helper.Span = ast.Span{Start: startPos, End: p.curPos()}
+	return helper
+}
+
 // parseFunctionBody parses a function body as a block of semicolon-separated expressions
 // Assumes we're currently AT the LBRACE token
 // Returns either a single expression or a Block containing multiple expressions
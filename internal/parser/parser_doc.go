@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/sunholo/ailang/internal/lexer"
+)
+
+// docCommentBefore returns the doc comment ending on the line just above
+// line: a run of `---` line comments, or a block comment. Plain `--`
+// comments are not documentation.
+//
+//	--- Clamp x into [lo, hi].
+//	--- Requires lo <= hi.
+//	export func clamp[a](x: a, lo: a, hi: a) -> a { ... }
+func (p *Parser) docCommentBefore(line int) string {
+	comments := p.l.Comments()
+	i := len(comments) - 1
+	for i >= 0 && comments[i].EndLine >= line {
+		i--
+	}
+	if i < 0 || comments[i].EndLine != line-1 {
+		return ""
+	}
+
+	if c := comments[i]; strings.HasPrefix(c.Text, "/*") {
+		return blockDocText(c.Text)
+	}
+
+	var lines []string
+	for ; i >= 0 && comments[i].EndLine == line-1 && isDocLine(comments[i]); i-- {
+		text := strings.TrimPrefix(comments[i].Text, "---")
+		lines = append([]string{strings.TrimPrefix(strings.TrimRight(text, " \t"), " ")}, lines...)
+		line = comments[i].Line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isDocLine reports whether c is a `---` doc line (and not a `----` rule)
+func isDocLine(c lexer.Comment) bool {
+	return strings.HasPrefix(c.Text, "---") && !strings.HasPrefix(c.Text, "----")
+}
+
+// blockDocText strips the delimiters of a block comment, and a leading `*`
+// on each line, leaving the documentation text
+func blockDocText(text string) string {
+	text = strings.TrimSuffix(strings.TrimLeft(strings.TrimPrefix(text, "/*"), "*"), "*/")
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		l = strings.TrimSpace(l)
+		if l == "*" {
+			l = ""
+		}
+		lines[i] = strings.TrimPrefix(l, "* ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
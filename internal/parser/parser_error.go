@@ -2,8 +2,10 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/errors"
 	"github.com/sunholo/ailang/internal/lexer"
 )
 
@@ -22,6 +24,49 @@ func (e *ParserError) Error() string {
 	return fmt.Sprintf("%s at %s: %s", e.Code, e.Pos, e.Message)
 }
 
+// Span returns the source range of the token the error was reported at. A
+// token whose extent is unknown (EOF, a multi-line string) spans its start.
+func (e *ParserError) Span() ast.Span {
+	end := e.Pos
+	lit := e.NearToken.Literal
+	if e.NearToken.Type == lexer.STRING {
+		lit = `"` + lit + `"`
+	}
+	if e.NearToken.Type != lexer.EOF && !strings.Contains(lit, "\n") {
+		end.Column += len([]rune(lit))
+	}
+	return ast.Span{Start: e.Pos, End: end}
+}
+
+// Report converts the error to a structured report, with the token found
+// and the tokens expected (if known) as data. AsReport finds it through
+// this method, so parse errors keep their code and span in JSON output.
+func (e *ParserError) Report() *errors.Report {
+	data := map[string]any{"found": e.NearToken.Type.String()}
+	if len(e.Expected) > 0 {
+		expected := make([]string, len(e.Expected))
+		for i, t := range e.Expected {
+			expected[i] = t.String()
+		}
+		data["expected"] = expected
+	}
+	rep := &errors.Report{
+		Schema:  "ailang.error/v1",
+		Code:    e.Code,
+		Phase:   "parser",
+		Message: e.Message,
+		Data:    data,
+	}
+	if e.Pos.Line > 0 {
+		span := e.Span()
+		rep.Span = &span
+	}
+	if e.Fix != "" {
+		rep.Fix = &errors.Fix{Suggestion: e.Fix, Confidence: e.Confidence}
+	}
+	return rep
+}
+
 // NewParserError creates a structured parser error with fix suggestion
 func NewParserError(code string, pos ast.Pos, nearToken lexer.Token, message string, expected []lexer.TokenType, fix string) *ParserError {
 	return &ParserError{
@@ -41,6 +86,21 @@ func (p *Parser) report(code string, message string, fix string) {
 	p.errors = append(p.errors, err)
 }
 
+// reportAt adds a structured error at tok, which is also reported as found
+func (p *Parser) reportAt(code string, tok lexer.Token, message string, expected []lexer.TokenType, fix string) {
+	pos := ast.Pos{Line: tok.Line, Column: tok.Column, File: tok.File}
+	p.errors = append(p.errors, NewParserError(code, pos, tok, message, expected, fix))
+}
+
+// reportUnclosed adds a PAR_UNCLOSED_DELIM error for a missing closing
+// delimiter, found in place of tok
+func (p *Parser) reportUnclosed(closing lexer.TokenType, tok lexer.Token, what string) {
+	p.reportAt("PAR_UNCLOSED_DELIM", tok,
+		fmt.Sprintf("expected %s to close %s, got %s", closing, what, tok.Type),
+		[]lexer.TokenType{closing},
+		fmt.Sprintf("Add the missing %s or remove the extra token", closing))
+}
+
 // reportExpected is a convenience helper for "expected X, got Y" errors
 func (p *Parser) reportExpected(expected lexer.TokenType, fix string) {
 	message := fmt.Sprintf("expected %s, got %s", expected, p.curToken.Type)
@@ -56,6 +116,12 @@ func (p *Parser) reportExpected(expected lexer.TokenType, fix string) {
 }
 
 func (p *Parser) peekError(t lexer.TokenType) {
+	// Running out of input where a closing delimiter belongs means it was
+	// never written
+	if p.peekTokenIs(lexer.EOF) && (t == lexer.RPAREN || t == lexer.RBRACKET || t == lexer.RBRACE) {
+		p.reportUnclosed(t, p.peekToken, "the expression")
+		return
+	}
 	msg := fmt.Sprintf("expected next token to be %s, got %s instead",
 		t, p.peekToken.Type)
 	err := NewParserError(
@@ -1,8 +1,6 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/lexer"
 )
@@ -184,7 +182,7 @@ func (p *Parser) parseMatchExpression() ast.Expr {
 
 	// We should already be at RBRACE
 	if !p.curTokenIs(lexer.RBRACE) {
-		p.errors = append(p.errors, fmt.Errorf("expected }, got %s", p.curToken.Type))
+		p.reportUnclosed(lexer.RBRACE, p.curToken, "match")
 	}
 
 	return match
@@ -211,16 +209,91 @@ func (p *Parser) parseCase() *ast.Case {
 	return c
 }
 
-func (p *Parser) parseLambda() ast.Expr {
-	pos := p.curPos()
+// parseHandleExpression parses handle { body } with { Effect.op(x) => expr, ... }
+func (p *Parser) parseHandleExpression() ast.Expr {
+	handle := &ast.Handle{
+		Pos: p.curPos(),
+	}
+
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	handle.Body = p.parseBlockOrExpression()
+	if handle.Body == nil || !p.expectPeek(lexer.WITH) || !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	handle.HandlersPos = p.curPos()
+	p.nextToken()
+
+	for !p.curTokenIs(lexer.RBRACE) && !p.curTokenIs(lexer.EOF) {
+		h := p.parseHandler()
+		if h == nil {
+			return nil
+		}
+		handle.Handlers = append(handle.Handlers, h)
 
-	// Expect opening parenthesis
-	if !p.expectPeek(lexer.LPAREN) {
+		// Move to next token after parsing the handler
+		p.nextToken()
+
+		// Skip comma if present
+		if p.curTokenIs(lexer.COMMA) {
+			p.nextToken()
+		}
+	}
+
+	if !p.curTokenIs(lexer.RBRACE) {
+		p.reportUnclosed(lexer.RBRACE, p.curToken, "handlers")
+	}
+	if len(handle.Handlers) == 0 {
+		p.errors = append(p.errors, NewParserError("PAR_HANDLE_EMPTY", handle.Pos, p.curToken,
+			"handle requires at least one handler", nil, "Add a handler such as IO.println(s) => ()"))
+	}
+
+	return handle
+}
+
+// parseHandler parses one handler arm: Effect.op(x) => expr
+func (p *Parser) parseHandler() *ast.Handler {
+	h := &ast.Handler{
+		Pos: p.curPos(),
+	}
+	if !p.curTokenIs(lexer.IDENT) {
+		p.reportAt("PAR_UNEXPECTED_TOKEN", p.curToken, "expected an effect operation such as IO.print(s)",
+			[]lexer.TokenType{lexer.IDENT}, "Start the handler with Effect.op(param) =>")
+		return nil
+	}
+	h.Effect = p.curToken.Literal
+	if !p.expectPeek(lexer.DOT) || !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+	h.Op = p.curToken.Literal
+	if !p.expectPeek(lexer.LPAREN) || !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+	h.Param = p.curToken.Literal
+	if !p.expectPeek(lexer.RPAREN) || !p.expectPeek(lexer.FARROW) {
 		return nil
 	}
+	p.nextToken()
+	h.Body = p.parseExpression(LOWEST)
 
-	// Parse parameters
-	params := p.parseParams()
+	return h
+}
+
+func (p *Parser) parseLambda() ast.Expr {
+	pos := p.curPos()
+
+	// Parse parameters; func() lexes as func followed by the unit token
+	var params []*ast.Param
+	if p.peekTokenIs(lexer.UNIT) {
+		p.nextToken()
+		params = []*ast.Param{}
+	} else {
+		if !p.expectPeek(lexer.LPAREN) {
+			return nil
+		}
+		params = p.parseParams()
+	}
 
 	// Check which syntax we're using:
 	// - func(x) -> type { body }  (new FuncLit syntax)
@@ -240,7 +313,8 @@ func (p *Parser) parseLambda() ast.Expr {
 		lambda.Body = p.parseExpression(LOWEST)
 		return lambda
 	} else {
-		p.errors = append(p.errors, fmt.Errorf("expected '->' or '=>' after function parameters at %s", p.peekToken.Position()))
+		p.reportAt("PAR_UNEXPECTED_TOKEN", p.peekToken, "expected '->' or '=>' after function parameters",
+			[]lexer.TokenType{lexer.ARROW, lexer.FARROW}, "Write func(x) -> T { body } or func(x) => body")
 		return nil
 	}
 }
@@ -270,7 +344,8 @@ func (p *Parser) parseFuncLitWithParams(pos ast.Pos, params []*ast.Param) ast.Ex
 
 	// Expect body in braces: { expr }
 	if !p.expectPeek(lexer.LBRACE) {
-		p.errors = append(p.errors, fmt.Errorf("expected '{' for function body at %s", p.peekToken.Position()))
+		p.reportAt("PAR_UNEXPECTED_TOKEN", p.peekToken, "expected '{' for function body",
+			[]lexer.TokenType{lexer.LBRACE}, "Wrap the function body in { }")
 		return nil
 	}
 
@@ -316,7 +391,7 @@ func (p *Parser) parseBlockOrExpression() ast.Expr {
 
 	// Expect closing brace
 	if !p.expectPeek(lexer.RBRACE) {
-		p.errors = append(p.errors, fmt.Errorf("expected '}' to close function body at %s", p.peekToken.Position()))
+		p.reportUnclosed(lexer.RBRACE, p.peekToken, "function body")
 		return nil
 	}
 
@@ -340,6 +415,7 @@ func (p *Parser) parsePureLambda() ast.Expr {
 }
 
 // parseBackslashLambda parses lambda expressions with \x. syntax
+// A parameter may be annotated in parentheses: \(x: int) y. body
 func (p *Parser) parseBackslashLambda() ast.Expr {
 	lambda := &ast.Lambda{
 		Pos: p.curPos(),
@@ -348,8 +424,12 @@ func (p *Parser) parseBackslashLambda() ast.Expr {
 	// Parse parameters - support curried sugar \x y z. body
 	var params []*ast.Param
 
-	// Keep consuming identifiers until we hit DOT
+	// Keep consuming parameters until we hit DOT
 	for {
+		annotated := p.peekTokenIs(lexer.LPAREN)
+		if annotated {
+			p.nextToken()
+		}
 		if !p.expectPeek(lexer.IDENT) {
 			return nil
 		}
@@ -357,15 +437,26 @@ func (p *Parser) parseBackslashLambda() ast.Expr {
 		param := &ast.Param{
 			Name: p.curToken.Literal,
 			Pos:  p.curPos(),
-			// Type will be inferred
+			// Type will be inferred unless annotated
+		}
+		if annotated {
+			if !p.expectPeek(lexer.COLON) {
+				return nil
+			}
+			p.nextToken()
+			param.Type = p.parseType()
+			if param.Type == nil || !p.expectPeek(lexer.RPAREN) {
+				return nil
+			}
 		}
 		params = append(params, param)
 
-		// Check if next token is DOT (end of params) or another IDENT (more params)
+		// Check if next token is DOT (end of params) or another parameter
 		if p.peekTokenIs(lexer.DOT) {
 			break
-		} else if !p.peekTokenIs(lexer.IDENT) {
-			p.errors = append(p.errors, fmt.Errorf("expected '.' after lambda parameter at %s", p.peekToken.Position()))
+		} else if !p.peekTokenIs(lexer.IDENT) && !p.peekTokenIs(lexer.LPAREN) {
+			p.reportAt("PAR_UNEXPECTED_TOKEN", p.peekToken, "expected '.' after lambda parameter",
+				[]lexer.TokenType{lexer.DOT}, "Write lambdas as \\x. body")
 			return nil
 		}
 	}
@@ -387,7 +478,8 @@ func (p *Parser) parseBackslashLambda() ast.Expr {
 
 	// Convert curried parameters to nested lambdas: \x y. body -> \x. \y. body
 	if len(params) == 0 {
-		p.errors = append(p.errors, fmt.Errorf("lambda requires at least one parameter at %s", lambda.Pos.String()))
+		p.errors = append(p.errors, NewParserError("PAR_LAMBDA_NO_PARAMS", lambda.Pos, p.curToken,
+			"lambda requires at least one parameter", nil, "Name a parameter, as in \\x. body"))
 		return nil
 	} else if len(params) == 1 {
 		lambda.Params = params
@@ -448,6 +540,16 @@ func (p *Parser) parseCallExpression(fn ast.Expr) ast.Expr {
 	return call
 }
 
+// parseUnitCall parses a call with no arguments. The lexer reads the "()" of
+// f() as a single unit token.
+func (p *Parser) parseUnitCall(fn ast.Expr) ast.Expr {
+	return &ast.FuncCall{
+		Func: fn,
+		Args: []ast.Expr{},
+		Pos:  p.curPos(),
+	}
+}
+
 func (p *Parser) parseCallArguments() []ast.Expr {
 	args := []ast.Expr{}
 
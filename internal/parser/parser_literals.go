@@ -11,16 +11,38 @@ import (
 // Prefix parse functions for literals and identifiers
 
 func (p *Parser) parseIdentifier() ast.Expr {
+	// allValues[T] names the constructor list of an enumeration. No other
+	// expression can follow an identifier with '[', so this is unambiguous.
+	if p.curToken.Literal == "allValues" && p.peekTokenIs(lexer.LBRACKET) {
+		return p.parseAllValues()
+	}
 	return &ast.Identifier{
 		Name: p.curToken.Literal,
 		Pos:  p.curPos(),
 	}
 }
 
+// parseAllValues parses allValues[T]. Assumes we're AT allValues and leaves
+// us at the RBRACKET.
+func (p *Parser) parseAllValues() ast.Expr {
+	pos := p.curPos()
+	p.nextToken() // move to LBRACKET
+	p.nextToken() // move to the type
+	if p.curTokenIs(lexer.RBRACKET) {
+		p.report("PAR_ALLVALUES_TYPE", "allValues needs a type: allValues[T]", "Name the enumeration, e.g. allValues[Direction]()")
+		return nil
+	}
+	typ := p.parseType()
+	if typ == nil || !p.expectPeek(lexer.RBRACKET) {
+		return nil
+	}
+	return &ast.AllValues{Type: typ, Pos: pos}
+}
+
 func (p *Parser) parseIntegerLiteral() ast.Expr {
 	value, err := strconv.ParseInt(p.curToken.Literal, 10, 64)
 	if err != nil {
-		p.errors = append(p.errors, fmt.Errorf("could not parse %q as integer", p.curToken.Literal))
+		p.report("PAR_INVALID_NUMBER", fmt.Sprintf("could not parse %q as integer", p.curToken.Literal), "Integers must fit in 64 bits")
 		return nil
 	}
 
@@ -34,7 +56,7 @@ func (p *Parser) parseIntegerLiteral() ast.Expr {
 func (p *Parser) parseFloatLiteral() ast.Expr {
 	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
 	if err != nil {
-		p.errors = append(p.errors, fmt.Errorf("could not parse %q as float", p.curToken.Literal))
+		p.report("PAR_INVALID_NUMBER", fmt.Sprintf("could not parse %q as float", p.curToken.Literal), "Write floats as digits with one decimal point, such as 3.14")
 		return nil
 	}
 
@@ -82,8 +104,10 @@ func (p *Parser) parseUnitLiteral() ast.Expr {
 //
 //	tuple_expr := "(" expr "," expr ("," expr)* ","? ")"
 //	grouped    := "(" expr ")"
+//	section    := "(" binop expr ")" | "(" expr binop ")"
 //
 // Disambiguation: A comma is required to form a tuple. (e) is grouping, (e,) is a tuple.
+// A leading minus is negation, so (- 1) is the literal -1 rather than a section.
 func (p *Parser) parseGroupedExpression() ast.Expr {
 	startPos := p.curPos()
 	p.nextToken() // consume LPAREN
@@ -97,8 +121,24 @@ func (p *Parser) parseGroupedExpression() ast.Expr {
 		}
 	}
 
+	// Right section: (+ 1) is \x. x + 1
+	if isSectionOperator(p.curToken) && !p.curTokenIs(lexer.MINUS) {
+		op := p.curToken
+		p.nextToken()
+		operand := p.parseExpression(op.Precedence())
+		if !p.expectPeek(lexer.RPAREN) {
+			p.reportExpected(lexer.RPAREN, "Add ')' to close operator section")
+		}
+		return sectionLambda(op, nil, operand, startPos)
+	}
+
 	// Parse first expression
-	expr := p.parseExpression(LOWEST)
+	expr, sectionOp := p.parseGroupOperand()
+	if sectionOp != nil {
+		// Left section: (2 *) is \x. 2 * x
+		p.nextToken() // move to RPAREN
+		return sectionLambda(*sectionOp, expr, nil, startPos)
+	}
 
 	// After parsing expression, we're at the last token of that expression
 	// Need to advance to see what comes next
@@ -140,6 +180,59 @@ func (p *Parser) parseGroupedExpression() ast.Expr {
 	}
 }
 
+// parseGroupOperand parses the first expression inside parentheses like
+// parseExpression(LOWEST), but stops before a binary operator that is
+// directly followed by ')' and returns that operator as a left section
+func (p *Parser) parseGroupOperand() (ast.Expr, *lexer.Token) {
+	prefix := p.prefixParseFns[p.curToken.Type]
+	if prefix == nil {
+		p.noPrefixParseFnError(p.curToken.Type)
+		return nil, nil
+	}
+
+	leftExp := prefix()
+
+	for !p.peekTokenIs(lexer.SEMICOLON) && LOWEST < p.peekPrecedence() {
+		infix := p.infixParseFns[p.peekToken.Type]
+		if infix == nil {
+			return leftExp, nil
+		}
+
+		p.nextToken()
+		if isSectionOperator(p.curToken) && p.peekTokenIs(lexer.RPAREN) {
+			op := p.curToken
+			return leftExp, &op
+		}
+		leftExp = infix(leftExp)
+	}
+
+	return leftExp, nil
+}
+
+// isSectionOperator reports whether tok is a binary operator that can be
+// sectioned, from || up to the multiplicative operators
+func isSectionOperator(tok lexer.Token) bool {
+	prec := tok.Precedence()
+	return prec >= LogicalOr && prec <= PRODUCT
+}
+
+// sectionLambda desugars an operator section to a one-parameter lambda.
+// The missing operand, left or right, is the lambda's parameter.
+func sectionLambda(op lexer.Token, left, right ast.Expr, pos ast.Pos) ast.Expr {
+	opPos := ast.Pos{Line: op.Line, Column: op.Column, File: op.File}
+	param := &ast.Identifier{Name: ast.SectionParam, Pos: opPos}
+	if left == nil {
+		left = param
+	} else {
+		right = param
+	}
+	return &ast.Lambda{
+		Params: []*ast.Param{{Name: ast.SectionParam, Pos: opPos}},
+		Body:   &ast.BinaryOp{Left: left, Op: op.Literal, Right: right, Pos: opPos},
+		Pos:    pos,
+	}
+}
+
 func (p *Parser) parseListLiteral() ast.Expr {
 	list := &ast.List{
 		Pos: p.curPos(),
@@ -208,7 +301,7 @@ func (p *Parser) parseRecordLiteral() ast.Expr {
 			}
 
 			if !p.curTokenIs(lexer.IDENT) {
-				p.errors = append(p.errors, fmt.Errorf("expected field name in record update, got %s", p.curToken.Type))
+				p.reportExpected(lexer.IDENT, "Record update fields are written name: value")
 				return nil
 			}
 
@@ -234,7 +327,7 @@ func (p *Parser) parseRecordLiteral() ast.Expr {
 		}
 
 		if !p.curTokenIs(lexer.RBRACE) {
-			p.errors = append(p.errors, fmt.Errorf("expected } in record update, got %s", p.curToken.Type))
+			p.reportUnclosed(lexer.RBRACE, p.curToken, "record update")
 			return nil
 		}
 
@@ -251,7 +344,7 @@ func (p *Parser) parseRecordLiteral() ast.Expr {
 			}
 
 			if !p.curTokenIs(lexer.IDENT) {
-				p.errors = append(p.errors, fmt.Errorf("expected field name, got %s", p.curToken.Type))
+				p.reportExpected(lexer.IDENT, "Record fields are written name: value")
 				return nil
 			}
 
@@ -277,7 +370,7 @@ func (p *Parser) parseRecordLiteral() ast.Expr {
 		}
 
 		if !p.curTokenIs(lexer.RBRACE) {
-			p.errors = append(p.errors, fmt.Errorf("expected }, got %s", p.curToken.Type))
+			p.reportUnclosed(lexer.RBRACE, p.curToken, "record")
 			return nil
 		}
 
@@ -305,7 +398,7 @@ func (p *Parser) parseRecordLiteral() ast.Expr {
 				}
 
 				if !p.curTokenIs(lexer.IDENT) {
-					p.errors = append(p.errors, fmt.Errorf("expected field name in record update, got %s", p.curToken.Type))
+					p.reportExpected(lexer.IDENT, "Record update fields are written name: value")
 					return nil
 				}
 
@@ -331,7 +424,7 @@ func (p *Parser) parseRecordLiteral() ast.Expr {
 			}
 
 			if !p.curTokenIs(lexer.RBRACE) {
-				p.errors = append(p.errors, fmt.Errorf("expected } in record update, got %s", p.curToken.Type))
+				p.reportUnclosed(lexer.RBRACE, p.curToken, "record update")
 				return nil
 			}
 
@@ -367,7 +460,7 @@ func (p *Parser) parseRecordLiteral() ast.Expr {
 		}
 
 		if !p.curTokenIs(lexer.RBRACE) {
-			p.errors = append(p.errors, fmt.Errorf("expected }, got %s", p.curToken.Type))
+			p.reportUnclosed(lexer.RBRACE, p.curToken, "block")
 			return nil
 		}
 
@@ -399,14 +492,15 @@ func (p *Parser) parseRecordLiteral() ast.Expr {
 
 			// Otherwise we expect a semicolon or RBRACE
 			if !p.curTokenIs(lexer.RBRACE) {
-				p.errors = append(p.errors, fmt.Errorf("expected ; or }, got %s", p.peekToken.Type))
+				p.reportAt("PAR_UNEXPECTED_TOKEN", p.peekToken, fmt.Sprintf("expected ; or }, got %s", p.peekToken.Type),
+					[]lexer.TokenType{lexer.SEMICOLON, lexer.RBRACE}, "Separate block expressions with ;")
 				return nil
 			}
 			break
 		}
 
 		if !p.curTokenIs(lexer.RBRACE) {
-			p.errors = append(p.errors, fmt.Errorf("expected }, got %s", p.curToken.Type))
+			p.reportUnclosed(lexer.RBRACE, p.curToken, "block")
 			return nil
 		}
 
@@ -7,11 +7,54 @@ import (
 	"github.com/sunholo/ailang/internal/lexer"
 )
 
+// parsePattern parses a pattern with optional alternatives: p1 | p2 | ...
 func (p *Parser) parsePattern() ast.Pattern {
+	startPos := p.curPos()
+	first := p.parsePrimaryPattern()
+	if first == nil || !p.peekTokenIs(lexer.PIPE) {
+		return first
+	}
+
+	alternatives := []ast.Pattern{first}
+	for p.peekTokenIs(lexer.PIPE) {
+		p.nextToken() // move to |
+		p.nextToken() // move to next alternative
+		alt := p.parsePrimaryPattern()
+		if alt == nil {
+			p.report("PAT_OR_NEEDS_PATTERN", "expected a pattern after '|' in or-pattern", "Write another alternative after |, like Some(1) | Some(2)")
+			return nil
+		}
+		alternatives = append(alternatives, alt)
+	}
+
+	return &ast.OrPattern{
+		Alternatives: alternatives,
+		Pos:          startPos,
+	}
+}
+
+// parsePrimaryPattern parses a single pattern without alternatives
+func (p *Parser) parsePrimaryPattern() ast.Pattern {
 	switch p.curToken.Type {
 	case lexer.IDENT:
 		// Could be a variable pattern or constructor
 		name := p.curToken.Literal
+		if p.peekTokenIs(lexer.AT) {
+			// As-pattern: name @ pattern
+			pos := p.curPos()
+			p.nextToken() // consume name
+			p.nextToken() // consume @
+			inner := p.parsePrimaryPattern()
+			if inner == nil {
+				p.report("PAT_AS_NEEDS_PATTERN", "expected a pattern after '@' in as-pattern", "Write the pattern to match after @, like xs @ [x, ...rest]")
+				return nil
+			}
+			return &ast.AsPattern{
+				Name:    name,
+				Pattern: inner,
+				Pos:     pos,
+			}
+		}
 		if p.peekTokenIs(lexer.LPAREN) {
 			// Constructor with arguments
 			p.nextToken()
@@ -96,9 +139,14 @@ func (p *Parser) parseListPattern() ast.Pattern {
 				p.report("PAT_SPREAD_NEEDS_IDENT", "spread in list pattern must bind to a name, e.g. [x, ...xs]", "Add an identifier after ..., like [x, ...rest]")
 				return nil
 			}
-			rest = &ast.Identifier{
-				Name: p.curToken.Literal,
-				Pos:  p.curPos(),
+			if p.curToken.Literal == "_" {
+				// [x, ..._] ignores the rest without binding it
+				rest = &ast.WildcardPattern{Pos: p.curPos()}
+			} else {
+				rest = &ast.Identifier{
+					Name: p.curToken.Literal,
+					Pos:  p.curPos(),
+				}
 			}
 			p.nextToken() // consume ident
 			break         // spread must be last
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/lexer"
 )
@@ -23,22 +25,20 @@ func (p *Parser) parseType() ast.Type {
 			p.nextToken() // consume IDENT
 			p.nextToken() // consume LBRACKET
 
-			// For now, parse type args but don't use them
-			// TODO: Proper type application parsing with TypeApp AST node
-			_ = p.parseType() // first arg
+			args := []ast.Type{p.parseType()} // first arg
 			for p.peekTokenIs(lexer.COMMA) {
 				p.nextToken() // move to COMMA
 				p.nextToken() // move past COMMA
-				_ = p.parseType()
+				args = append(args, p.parseType())
 			}
 
 			if !p.expectPeek(lexer.RBRACKET) {
 				return nil
 			}
 
-			// Return a SimpleType for now (proper generics parsing would be more complex)
-			return &ast.SimpleType{
+			return &ast.TypeApp{
 				Name: name, // e.g., "Option" or "List"
+				Args: args,
 				Pos:  startPos,
 			}
 		}
@@ -193,7 +193,7 @@ func (p *Parser) parseType() ast.Type {
 
 // Type declaration parsing
 // EBNF:
-//   type_decl      := export? "type" UIdent type_params? "=" type_body
+//   type_decl      := export? "type" UIdent type_params? "=" type_body derive?
 //   type_params    := "[" type_param ("," type_param)* "]"
 //   type_param     := LIdent
 //   type_body      := type_alias | sum_type | record_type
@@ -201,6 +201,7 @@ func (p *Parser) parseType() ast.Type {
 //   variant        := UIdent ("(" type_expr ("," type_expr)* ")")?
 //   record_type    := "{" field ("," field)* ","? "}"
 //   field          := LIdent ":" type_expr
+//   derive         := "derive" "(" UIdent ("," UIdent)* ")"
 
 func (p *Parser) parseTypeDeclaration(exported bool) ast.Node {
 	startPos := p.curPos()
@@ -241,13 +242,64 @@ func (p *Parser) parseTypeDeclaration(exported bool) ast.Node {
 		return nil
 	}
 
-	return &ast.TypeDecl{
+	decl := &ast.TypeDecl{
 		Name:       name,
 		TypeParams: typeParams,
 		Definition: definition,
 		Exported:   exported,
 		Pos:        startPos,
 	}
+
+	// Derived instances: type Color = Red | Green | Blue derive(Show, Eq, Ord)
+	if p.peekIsContextualKeyword("derive") {
+		p.nextToken() // move to 'derive'
+		if _, ok := definition.(*ast.AlgebraicType); !ok {
+			p.report("PAR_DERIVE_NOT_ADT", "derive is only supported on algebraic data types", "Declare the type with constructors, e.g. type T = A | B derive(Eq)")
+			return nil
+		}
+		decl.Derive = p.parseDeriveClasses()
+		if decl.Derive == nil {
+			return nil
+		}
+	}
+	return decl
+}
+
+// parseDeriveClasses parses the class list of a derive clause.
+// Assumes we're currently AT the 'derive' token and leaves us AT the ')'.
+func (p *Parser) parseDeriveClasses() []string {
+	if p.peekTokenIs(lexer.UNIT) {
+		p.nextToken()
+		p.report("PAR_DERIVE_EMPTY", "derive needs at least one class", "List the classes to derive, e.g. derive(Eq)")
+		return nil
+	}
+	if !p.expectPeek(lexer.LPAREN) {
+		return nil
+	}
+
+	var classes []string
+	seen := make(map[string]bool)
+	for {
+		if !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		class := p.curToken.Literal
+		if seen[class] {
+			p.report("PAR_DERIVE_DUPLICATE", fmt.Sprintf("class '%s' is derived twice", class), "Remove the repeated class")
+			return nil
+		}
+		seen[class] = true
+		classes = append(classes, class)
+
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
+		}
+		p.nextToken() // move to COMMA
+	}
+	if !p.expectPeek(lexer.RPAREN) {
+		return nil
+	}
+	return classes
 }
 
 func (p *Parser) parseTypeDeclBody() ast.TypeDef {
@@ -284,6 +336,7 @@ func (p *Parser) parseTypeDeclBody() ast.TypeDef {
 	// - type Shape = Circle(int)  → could be alias or sum depending on |
 	if p.curTokenIs(lexer.IDENT) {
 		name := p.curToken.Literal
+		namePos := p.curPos()
 		var firstVariant *ast.Constructor
 
 		// Check for constructor with fields: Circle(int, int)
@@ -309,15 +362,16 @@ func (p *Parser) parseTypeDeclBody() ast.TypeDef {
 			}
 			if !p.curTokenIs(lexer.RPAREN) {
 				p.reportExpected(lexer.RPAREN, "Add ')' to close constructor fields")
-			} else {
-				p.nextToken() // consume RPAREN
 			}
 			firstVariant = &ast.Constructor{
 				Name:   name,
 				Fields: fields,
-				Pos:    p.curPos(),
+				Pos:    namePos,
 			}
-			// Lexer skips whitespace, so we're already at next real token (PIPE or other)
+		} else if p.peekTokenIs(lexer.LBRACE) {
+			// Record-style constructor: Point { x: int, y: int }
+			p.nextToken() // advance to LBRACE
+			firstVariant = p.parseRecordConstructor(name)
 		} else {
 			// No fields - check if this is a simple type alias or sum type
 			// If we saw a leading PIPE, it's definitely a sum type
@@ -326,13 +380,12 @@ func (p *Parser) parseTypeDeclBody() ast.TypeDef {
 				firstVariant = &ast.Constructor{
 					Name:   name,
 					Fields: nil,
-					Pos:    p.curPos(),
+					Pos:    namePos,
 				}
-				// Advance past the name (lexer already skipped whitespace)
-				p.nextToken()
 			} else {
 				// Check if peek is PIPE to determine if it's a sum type
-				if !p.peekTokenIs(lexer.PIPE) {
+				// (a derive clause also makes a lone name a constructor)
+				if !p.peekTokenIs(lexer.PIPE) && !p.peekIsContextualKeyword("derive") {
 					// No pipe → simple type alias like: type UserId = int
 					// We're at the identifier, parse it as a type
 					typeExpr := p.parseType()
@@ -343,19 +396,18 @@ func (p *Parser) parseTypeDeclBody() ast.TypeDef {
 				}
 
 				// Has pipe → sum type like: type Color = Red | Green | Blue
-				// Advance past the name and save it as first variant
-				p.nextToken() // advance to PIPE
 				firstVariant = &ast.Constructor{
 					Name:   name, // We saved this earlier
 					Fields: nil,
-					Pos:    p.curPos(),
+					Pos:    namePos,
 				}
 			}
 		}
 
-		// Check if there are more variants (PIPE)
-		// At this point, we should be at a PIPE token if there are more variants
-		if p.curTokenIs(lexer.PIPE) {
+		// Every branch above leaves us AT the last token of the first variant,
+		// so a following PIPE means more variants
+		if p.peekTokenIs(lexer.PIPE) {
+			p.nextToken() // advance to PIPE
 			variants := []*ast.Constructor{firstVariant}
 			// Parse remaining variants
 			// After first variant, we're at PIPE or end of ADT
@@ -405,6 +457,13 @@ func (p *Parser) parseVariant() *ast.Constructor {
 		p.report("PAR_VARIANT_NEEDS_UIDENT", "variant must start with uppercase letter", "Change to UpperCamelCase")
 	}
 
+	// Record-style variant: Circle { radius: float }
+	// parseRecordConstructor leaves us AT the RBRACE, like the RPAREN case below
+	if p.peekTokenIs(lexer.LBRACE) {
+		p.nextToken() // advance to LBRACE
+		return p.parseRecordConstructor(name)
+	}
+
 	// Parse optional fields (peek ahead to see if there are any)
 	var fields []ast.Type
 	if p.peekTokenIs(lexer.LPAREN) {
@@ -438,6 +497,34 @@ func (p *Parser) parseVariant() *ast.Constructor {
 	}
 }
 
+// parseRecordConstructor parses the `{ name: Type, ... }` body of a record-style
+// constructor. The current token must be LBRACE; the parser is left at RBRACE.
+// Field order is preserved so positional patterns like Point(x, y) still work.
+func (p *Parser) parseRecordConstructor(name string) *ast.Constructor {
+	startPos := p.curPos()
+	rec, _ := p.parseRecordTypeExpr().(*ast.RecordType)
+
+	ctor := &ast.Constructor{
+		Name: name,
+		Pos:  startPos,
+	}
+	if rec == nil {
+		return ctor
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range rec.Fields {
+		if seen[field.Name] {
+			p.report("PAR_DUPLICATE_FIELD", fmt.Sprintf("duplicate field '%s' in constructor %s", field.Name, name), "Give each constructor field a unique name")
+			continue
+		}
+		seen[field.Name] = true
+		ctor.Fields = append(ctor.Fields, field.Type)
+		ctor.FieldNames = append(ctor.FieldNames, field.Name)
+	}
+	return ctor
+}
+
 func (p *Parser) parseRecordTypeDef() ast.TypeDef {
 	if !p.curTokenIs(lexer.LBRACE) {
 		p.report("PAR_TYPE_LBRACE_EXPECTED", "expected '{' for record type", "Add '{' to start record type")
@@ -55,6 +55,13 @@ func TestOperatorPrecedence(t *testing.T) {
 		{"mixed_1", "1 + 2 * 3 < 4 + 5", "((1 + (2 * 3)) < (4 + 5))"},
 		{"mixed_2", "x < y && a + b > c", "((x < y) && ((a + b) > c))"},
 		{"mixed_3", "a * b + c * d == e", "(((a * b) + (c * d)) == e)"},
+
+		// Pipeline binds tighter than binary operators, looser than prefix and calls
+		{"pipe_left_assoc", "x |> f |> g", "((x |> f) |> g)"},
+		{"pipe_vs_add", "a + b |> f", "(a + (b |> f))"},
+		{"pipe_vs_multiply", "a |> f * 2", "((a |> f) * 2)"},
+		{"pipe_vs_compare", "xs |> len == 0", "((xs |> len) == 0)"},
+		{"pipe_after_prefix", "-x |> f", "((-x) |> f)"},
 	}
 
 	for _, tt := range tests {
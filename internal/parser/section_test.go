@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+)
+
+// TestOperatorSections tests that operator sections desugar to one-parameter
+// lambdas whose body applies the operator, with the parameter as the
+// missing operand
+func TestOperatorSections(t *testing.T) {
+	x := ast.SectionParam
+	tests := []struct {
+		name     string
+		input    string
+		expected string // Parenthesized form of the lambda body
+	}{
+		{"right_add", "(+ 1)", "(" + x + " + 1)"},
+		{"right_multiply", "(* 2)", "(" + x + " * 2)"},
+		{"right_compare", "(== y)", "(" + x + " == y)"},
+		{"right_concat", `(++ "!")`, "(" + x + " ++ !)"},
+		{"right_tighter_operand", "(+ a * b)", "(" + x + " + (a * b))"},
+		{"left_multiply", "(2 *)", "(2 * " + x + ")"},
+		{"left_subtract", "(1 -)", "(1 - " + x + ")"},
+		{"left_looser_operand", "(a * b +)", "((a * b) + " + x + ")"},
+		{"left_logical", "(ok &&)", "(ok && " + x + ")"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lam, ok := parseExpr(t, tt.input).(*ast.Lambda)
+			if !ok {
+				t.Fatalf("expected a lambda, got %T", parseExpr(t, tt.input))
+			}
+			if len(lam.Params) != 1 || lam.Params[0].Name != ast.SectionParam {
+				t.Fatalf("expected a single %s parameter, got %v", ast.SectionParam, lam.Params)
+			}
+			if got := exprToParenForm(lam.Body); got != tt.expected {
+				t.Errorf("expected body %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestOperatorSectionsNotSections tests the forms that look like sections
+// but parse as ordinary expressions
+func TestOperatorSectionsNotSections(t *testing.T) {
+	// A leading minus is negation
+	assertPrecedence(t, "(- 1)", "(-1)")
+	assertPrecedence(t, "(- x)", "(-x)")
+	// Grouping and application are unchanged
+	assertPrecedence(t, "(1 + 2) * 3", "((1 + 2) * 3)")
+	if _, ok := parseExpr(t, "(+ 1)(2)").(*ast.FuncCall); !ok {
+		t.Error("expected a section applied to an argument to be a call")
+	}
+}
+
+// TestOperatorSectionErrors tests that a section operand binding more
+// loosely than the sectioned operator is rejected
+func TestOperatorSectionErrors(t *testing.T) {
+	for _, input := range []string{"(* a + b)", "(a + b *)", "(+)"} {
+		t.Run(input, func(t *testing.T) {
+			mustParseError(t, input)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/sunholo/ailang/internal/lexer"
 )
 
 // TestTypeAliases tests basic type alias declarations
@@ -105,6 +108,16 @@ func TestSumTypes(t *testing.T) {
 			"type Shape = Circle(int) | Rectangle(int, int) | Point",
 			"type/multiple_fields",
 		},
+		{
+			"record_constructor",
+			"type Point = Point { x: int, y: int }",
+			"type/record_constructor",
+		},
+		{
+			"record_variants",
+			"type Shape = Circle { radius: float } | Rect { width: float, height: float } | Empty",
+			"type/record_variants",
+		},
 	}
 
 	for _, tt := range tests {
@@ -239,6 +252,7 @@ func TestInvalidTypeSyntax(t *testing.T) {
 		{"type_no_body", "type Foo", true},
 		{"type_trailing_pipe", "type Color = Red | Green |", true},
 		{"type_empty_record", "type Empty = { }", false}, // Empty records are allowed
+		{"type_duplicate_ctor_field", "type P = P { x: int, x: int }", true},
 	}
 
 	for _, tt := range tests {
@@ -275,6 +289,44 @@ func TestMultipleTypes(t *testing.T) {
 			 type User = { id: UserId, name: string }`,
 			"type/dependent_types",
 		},
+		{
+			"single_constructor_then_func",
+			`type Point = Point { x: int, y: int }
+			 func origin() -> Point { Point(0, 0) }`,
+			"type/single_constructor_then_func",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := parseAndPrint(t, tt.input)
+			goldenCompare(t, tt.golden, output)
+		})
+	}
+}
+
+// TestDeriveClause tests derive clauses on algebraic type declarations
+func TestDeriveClause(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		golden string
+	}{
+		{
+			"derive_enum",
+			"type Color = Red | Green | Blue derive(Show, Eq, Ord)",
+			"type/derive_enum",
+		},
+		{
+			"derive_multiline",
+			"type Shape =\n  | Circle(int)\n  | Rect(int, int)\n  derive(Eq)",
+			"type/derive_multiline",
+		},
+		{
+			"derive_lone_constructor",
+			"export type Token = Token derive(Eq)",
+			"type/derive_lone_constructor",
+		},
 	}
 
 	for _, tt := range tests {
@@ -284,3 +336,31 @@ func TestMultipleTypes(t *testing.T) {
 		})
 	}
 }
+
+// TestDeriveClauseErrors tests derive clause error reporting
+func TestDeriveClauseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"record", "type P = { x: int } derive(Eq)", "PAR_DERIVE_NOT_ADT"},
+		{"alias", "type Names = [string] derive(Eq)", "PAR_DERIVE_NOT_ADT"},
+		{"empty", "type C = A | B derive()", "PAR_DERIVE_EMPTY"},
+		{"duplicate", "type C = A | B derive(Eq, Eq)", "PAR_DERIVE_DUPLICATE"},
+		{"unclosed", "type C = A | B derive(Eq", "PAR_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input, "test.ail"))
+			p.ParseFile()
+			if len(p.Errors()) == 0 {
+				t.Fatalf("expected parse error for %q", tt.input)
+			}
+			if got := p.Errors()[0].Error(); !strings.Contains(got, tt.wantErr) {
+				t.Errorf("expected %s, got %s", tt.wantErr, got)
+			}
+		})
+	}
+}
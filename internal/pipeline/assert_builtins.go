@@ -0,0 +1,33 @@
+// Package pipeline provides assertion functions for operator lowering
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// AssertOnlyBuiltinsForOps verifies that after lowering, operators only
+// appear as calls to registered builtins. A lowered operator whose builtin
+// does not exist (e.g. an operator table entry without an implementation)
+// would otherwise only fail when the call is evaluated.
+func AssertOnlyBuiltinsForOps(prog *core.Program) error {
+	var found error
+	WalkCore(prog, func(node core.CoreExpr) {
+		if found != nil {
+			return
+		}
+		ref, ok := node.(*core.VarGlobal)
+		if !ok || ref.Ref.Module != "$builtin" || IsBuiltinRegistered(ref.Ref.Name) {
+			return
+		}
+		found = &CoreSanityError{
+			Code:       "LNK_BUILTIN404",
+			Message:    fmt.Sprintf("Builtin '%s' not registered", ref.Ref.Name),
+			NodeID:     ref.ID(),
+			Pos:        ref.Span(),
+			Suggestion: "Check the operator table and builtin registry agree",
+		}
+	})
+	return found
+}
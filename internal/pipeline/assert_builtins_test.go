@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// TestAssertNoOperators_NamesLeftoverNode checks that an operator left after
+// lowering is reported with its symbol and source position
+func TestAssertNoOperators_NamesLeftoverNode(t *testing.T) {
+	pos := ast.Pos{File: "ops.ail", Line: 4, Column: 9}
+	prog := &core.Program{Decls: []core.CoreExpr{
+		&core.Lambda{Params: []string{"x"}, Body: &core.BinOp{
+			CoreNode: core.CoreNode{NodeID: 7, CoreSpan: pos},
+			Op:       "+",
+			Left:     &core.Var{Name: "x"},
+			Right:    &core.Lit{Kind: core.IntLit, Value: int64(1)},
+		}},
+	}}
+
+	err := AssertNoOperators(prog)
+	if err == nil {
+		t.Fatal("expected an error for the leftover BinOp")
+	}
+	for _, want := range []string{"ELB_OP002", "BinOp '+'", "ops.ail:4:9"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err.Error())
+		}
+	}
+}
+
+// TestAssertNoOperators_LoweredProgram checks that lowering leaves no
+// operators behind, including inside matches, tuples and record updates
+func TestAssertNoOperators_LoweredProgram(t *testing.T) {
+	add := func(id uint64) core.CoreExpr {
+		return &core.Intrinsic{
+			CoreNode: core.CoreNode{NodeID: id},
+			Op:       core.OpAdd,
+			Args: []core.CoreExpr{
+				&core.Var{Name: "x"},
+				&core.Lit{Kind: core.IntLit, Value: int64(1)},
+			},
+		}
+	}
+	prog := &core.Program{Decls: []core.CoreExpr{
+		&core.Match{
+			Scrutinee: &core.Var{Name: "x"},
+			Arms: []core.MatchArm{{
+				Pattern: &core.WildcardPattern{},
+				Body: &core.Tuple{Elements: []core.CoreExpr{
+					add(1),
+					&core.RecordUpdate{Base: &core.Var{Name: "r"}, Updates: map[string]core.CoreExpr{"a": add(2)}},
+				}},
+			}},
+		},
+	}}
+
+	lowerer := NewOpLowerer(types.NewTypeEnv())
+	lowered, err := lowerer.Lower(prog)
+	if err != nil {
+		t.Fatalf("lowering failed: %v", err)
+	}
+	if err := AssertNoOperators(lowered); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := AssertOnlyBuiltinsForOps(lowered); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestAssertOnlyBuiltinsForOps_UnknownBuiltin checks that a lowered operator
+// naming a builtin that is not registered is rejected
+func TestAssertOnlyBuiltinsForOps_UnknownBuiltin(t *testing.T) {
+	prog := &core.Program{Decls: []core.CoreExpr{
+		&core.App{
+			Func: &core.VarGlobal{Ref: core.GlobalRef{Module: "$builtin", Name: "add_Quaternion"}},
+			Args: []core.CoreExpr{&core.Lit{Kind: core.IntLit, Value: int64(1)}},
+		},
+	}}
+
+	err := AssertOnlyBuiltinsForOps(prog)
+	if err == nil || !strings.Contains(err.Error(), "add_Quaternion") {
+		t.Errorf("expected an error naming add_Quaternion, got %v", err)
+	}
+}
@@ -90,6 +90,7 @@ func TestBuiltinConsistency_SpecRegistryComplete(t *testing.T) {
 		{"_io_print", 1, "IO"},
 		{"_io_println", 1, "IO"},
 		{"_io_readLine", 0, "IO"},
+		{"_io_readAll", 0, "IO"},
 		{"_net_httpRequest", 4, "Net"},
 		{"_str_len", 1, ""},
 		{"concat_String", 2, ""}, // String concatenation (pure)
@@ -0,0 +1,170 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sunholo/ailang/internal/iface"
+	"github.com/sunholo/ailang/internal/loader"
+	"github.com/sunholo/ailang/internal/schema"
+)
+
+// CheckState remembers, for each file that last type-checked cleanly, the
+// content hash of the file and of every module it imported (directly or
+// not) together with their interfaces. ailang check --check-only-changed
+// uses it to skip files for which none of that changed.
+//
+// A dependency whose source changed still counts as unchanged if it was
+// re-checked earlier in the same run and its interface came out the same,
+// so editing a function body does not invalidate everything importing it.
+// A nil *CheckState records nothing and reports every file as changed.
+type CheckState struct {
+	Schema string                 `json:"schema"`
+	Files  map[string]CheckedFile `json:"files"` // Keyed by absolute file path
+
+	current map[string]string // Interface fingerprints computed in this run, by module ID
+}
+
+// CheckedFile is the state of one file after a clean check
+type CheckedFile struct {
+	Hash        string                `json:"hash"`
+	RequirePure bool                  `json:"require_pure,omitempty"`
+	Deps        map[string]CheckedDep `json:"deps,omitempty"` // Keyed by module ID
+}
+
+// CheckedDep is a module a checked file depended on
+type CheckedDep struct {
+	File      string `json:"file"`
+	Hash      string `json:"hash"`
+	Interface string `json:"interface"` // Fingerprint of the module interface
+}
+
+// NewCheckState creates an empty check state
+func NewCheckState() *CheckState {
+	return &CheckState{
+		Schema:  schema.CheckStateV1,
+		Files:   make(map[string]CheckedFile),
+		current: make(map[string]string),
+	}
+}
+
+// LoadCheckState reads the check state saved at path. A missing, unreadable
+// or outdated state file yields an empty state, so the next check is a full one.
+func LoadCheckState(path string) *CheckState {
+	state := NewCheckState()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	var saved CheckState
+	if json.Unmarshal(data, &saved) != nil || !schema.Accepts(saved.Schema, schema.CheckStateV1) || saved.Files == nil {
+		return state
+	}
+	state.Files = saved.Files
+	return state
+}
+
+// Save writes the check state to path, creating its directory
+func (s *CheckState) Save(path string) error {
+	if s == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Unchanged reports whether file checked cleanly before with the same
+// options, and neither it nor any of its dependencies changed since
+func (s *CheckState) Unchanged(file string, requirePure bool) bool {
+	if s == nil {
+		return false
+	}
+	entry, ok := s.Files[absPath(file)]
+	if !ok || entry.RequirePure != requirePure || hashFile(file) != entry.Hash {
+		return false
+	}
+	for modID, dep := range entry.Deps {
+		if hashFile(dep.File) == dep.Hash {
+			continue
+		}
+		if fp, ok := s.current[modID]; !ok || fp != dep.Interface {
+			return false
+		}
+	}
+	return true
+}
+
+// Record updates the state of file after a check. A clean check stores the
+// file and its dependencies; a failed one forgets the file, so it is checked
+// again next time.
+func (s *CheckState) Record(file string, requirePure bool, result Result, errs []error) {
+	if s == nil {
+		return
+	}
+	key := absPath(file)
+	rootID := loader.CanonicalModuleID(file)
+	for modID, mod := range result.Modules {
+		if mod.Iface != nil {
+			s.current[modID] = interfaceFingerprint(mod.Iface)
+		}
+	}
+	if len(errs) > 0 || result.Modules == nil {
+		delete(s.Files, key)
+		return
+	}
+
+	entry := CheckedFile{Hash: hashFile(file), RequirePure: requirePure, Deps: make(map[string]CheckedDep)}
+	for modID, mod := range result.Modules {
+		if modID == rootID || mod.Iface == nil {
+			continue
+		}
+		entry.Deps[modID] = CheckedDep{
+			File:      mod.Filename,
+			Hash:      hashFile(mod.Filename),
+			Interface: s.current[modID],
+		}
+	}
+	s.Files[key] = entry
+}
+
+// interfaceFingerprint identifies what importers see of a module: the
+// interface digest, plus the transparent aliases the digest leaves out
+func interfaceFingerprint(ifc *iface.Iface) string {
+	names := make([]string, 0, len(ifc.Types))
+	for name, typ := range ifc.Types {
+		if typ.Alias != nil {
+			names = append(names, name+"="+typ.Alias.String())
+		}
+	}
+	if len(names) == 0 {
+		return ifc.Digest
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(ifc.Digest, names))))
+}
+
+// hashFile returns the SHA-256 of a file's content, or "" if it cannot be read
+func hashFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCheckState_SkipsUnchanged verifies that a file is only reported as
+// unchanged while its source, its options and its dependencies' interfaces
+// stay the same
+func TestCheckState_SkipsUnchanged(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	// Project imports resolve from the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	lib, app := "lib.ail", "app.ail"
+	write := func(path, code string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(lib, "module lib\n\nexport func double(x: int) -> int { x * 2 }\n")
+	write(app, "module app\n\nimport lib (double)\n\nexport func run() -> int { double(21) }\n")
+
+	statePath := filepath.Join(dir, ".state", "check-state.json")
+	check := func(state *CheckState, path string) {
+		t.Helper()
+		code, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := Run(Config{Mode: ModeCheck}, Source{Code: string(code), Filename: path})
+		var errs []error
+		if err != nil {
+			errs = []error{err}
+		}
+		state.Record(path, false, result, errs)
+	}
+
+	state := LoadCheckState(statePath)
+	if state.Unchanged(app, false) {
+		t.Fatal("a file never checked was reported unchanged")
+	}
+	check(state, app)
+	if err := state.Save(statePath); err != nil {
+		t.Fatal(err)
+	}
+
+	state = LoadCheckState(statePath)
+	if !state.Unchanged(app, false) {
+		t.Error("app.ail should be unchanged after a clean check")
+	}
+	if state.Unchanged(app, true) {
+		t.Error("a different --require-pure setting must recheck")
+	}
+
+	// A new body with the same interface keeps app.ail unchanged once lib.ail
+	// has been rechecked in the same run
+	write(lib, "module lib\n\nexport func double(x: int) -> int { x + x }\n")
+	if state.Unchanged(app, false) {
+		t.Error("app.ail must be rechecked while lib.ail's new interface is unknown")
+	}
+	check(state, lib)
+	if !state.Unchanged(app, false) {
+		t.Error("app.ail should be unchanged: lib.ail's interface is the same")
+	}
+
+	// A changed interface does not
+	write(lib, "module lib\n\nexport func double(x: int) -> float { 1.0 }\n")
+	check(state, lib)
+	if state.Unchanged(app, false) {
+		t.Error("app.ail must be rechecked after lib.ail's interface changed")
+	}
+	check(state, app)
+	if _, ok := state.Files[filepath.Join(dir, app)]; ok {
+		t.Error("a failed check must not be recorded")
+	}
+}
@@ -11,7 +11,11 @@ type ConstructorInfo struct {
 	TypeName   string     // ADT type name (e.g., "Option")
 	CtorName   string     // Constructor name (e.g., "Some")
 	FieldTypes []ast.Type // Field types from AST
+	TypeParams []string   // Type parameters of the ADT
 	Arity      int        // Number of fields
+	FieldNames []string   // Field names for record-style constructors (nil if positional)
+	Newtype    bool       // Single-field newtype, represented by the unboxed field
+	Index      int        // Position of the constructor in its type declaration
 }
 
 // CompileUnit represents a module compilation unit
@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCheck_ModuleValues verifies that top-level values are exported with
+// their defaulted monomorphic type and can be used by functions and other
+// values declared before or after them, including recursively
+func TestCheck_ModuleValues(t *testing.T) {
+	code := `export let pi = 3.14159
+export const area2 = pi * radius * radius
+let radius = 2.0
+let secret = 42
+
+export func area(r: float) -> float { pi * r * r }
+
+export func unlock(n: int) -> bool { n == secret }
+`
+	result, err := checkModuleSource(t, "module_values", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{"pi": "float", "area2": "float"} {
+		item, ok := result.Interface.Exports[name]
+		if !ok {
+			t.Errorf("%s not exported", name)
+			continue
+		}
+		if got := item.Type.String(); got != want {
+			t.Errorf("%s: expected type %s, got %s", name, want, got)
+		}
+	}
+	for _, private := range []string{"radius", "secret"} {
+		if _, ok := result.Interface.Exports[private]; ok {
+			t.Errorf("private value %s leaked into the module interface", private)
+		}
+	}
+
+	// Values in a recursive group type-check; whether the cycle is legal
+	// (function values) or not (eager values, RT009) is decided at runtime
+	code = `export let isEven = \n. if n == 0 then true else isOdd(n - 1)
+export let isOdd = \n. if n == 0 then false else isEven(n - 1)
+`
+	result, err = checkModuleSource(t, "recursive_values", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Interface.Exports["isOdd"].Type.String(); !strings.Contains(got, "-> bool") {
+		t.Errorf("isOdd: expected a function returning bool, got %s", got)
+	}
+}
@@ -0,0 +1,396 @@
+package pipeline
+
+import (
+	"math"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/core"
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+// ConstantFolder evaluates pure operator applications whose arguments are
+// all literals at compile time, replacing them with the resulting literal.
+//
+// It runs after operator lowering and folds:
+//   - $builtin calls to the operator builtins (add_Int, concat_String, ...)
+//   - Intrinsic nodes left in place by the binop shim
+//   - DictApp nodes on Num/Eq/Ord dictionaries of primitive types
+//
+// Compiler temporaries ($tmpN) bound to a folded literal are inlined, so ANF
+// chains such as `let $tmp1 = 3 * 4 in 2 + $tmp1` fold completely.
+//
+// Folding is conservative: division and modulo by a zero literal are left
+// alone so they still fail (or produce Inf/NaN) at runtime exactly as before.
+type ConstantFolder struct {
+	folded int // Number of operations replaced by literals
+}
+
+// NewConstantFolder creates a new constant folder
+func NewConstantFolder() *ConstantFolder {
+	return &ConstantFolder{}
+}
+
+// Folded returns how many operations have been folded so far
+func (f *ConstantFolder) Folded() int {
+	return f.folded
+}
+
+// Fold returns a copy of prog with constant operations folded
+func (f *ConstantFolder) Fold(prog *core.Program) *core.Program {
+	folded := &core.Program{
+		Decls: make([]core.CoreExpr, len(prog.Decls)),
+		Meta:  prog.Meta,
+		Flags: prog.Flags,
+	}
+	for i, decl := range prog.Decls {
+		folded.Decls[i] = f.foldExpr(decl, nil)
+	}
+	return folded
+}
+
+// foldExpr folds expr; temps maps inlined compiler temporaries to their literal
+func (f *ConstantFolder) foldExpr(expr core.CoreExpr, temps map[string]*core.Lit) core.CoreExpr {
+	if expr == nil {
+		return nil
+	}
+
+	switch e := expr.(type) {
+	case *core.Var:
+		if lit, ok := temps[e.Name]; ok {
+			return lit
+		}
+		return e
+
+	case *core.Let:
+		value := f.foldExpr(e.Value, temps)
+		if lit, ok := value.(*core.Lit); ok && strings.HasPrefix(e.Name, "$tmp") {
+			inner := make(map[string]*core.Lit, len(temps)+1)
+			for k, v := range temps {
+				inner[k] = v
+			}
+			inner[e.Name] = lit
+			return f.foldExpr(e.Body, inner)
+		}
+		return &core.Let{CoreNode: e.CoreNode, Name: e.Name, Value: value, Body: f.foldExpr(e.Body, temps)}
+
+	case *core.LetRec:
+		bindings := make([]core.RecBinding, len(e.Bindings))
+		for i, b := range e.Bindings {
+			bindings[i] = core.RecBinding{Name: b.Name, Value: f.foldExpr(b.Value, temps)}
+		}
+		return &core.LetRec{CoreNode: e.CoreNode, Bindings: bindings, Body: f.foldExpr(e.Body, temps)}
+
+	case *core.Lambda:
+		return &core.Lambda{CoreNode: e.CoreNode, Params: e.Params, Body: f.foldExpr(e.Body, temps)}
+
+	case *core.App:
+		app := &core.App{CoreNode: e.CoreNode, Func: f.foldExpr(e.Func, temps), Args: f.foldExprs(e.Args, temps)}
+		if ref, ok := app.Func.(*core.VarGlobal); ok && ref.Ref.Module == "$builtin" {
+			if lit, ok := f.foldBuiltin(ref.Ref.Name, app.Args, e.CoreNode); ok {
+				return lit
+			}
+		}
+		return app
+
+	case *core.Intrinsic:
+		args := f.foldExprs(e.Args, temps)
+		if name, ok := intrinsicBuiltin(e.Op, args); ok {
+			if lit, ok := f.foldBuiltin(name, args, e.CoreNode); ok {
+				return lit
+			}
+		}
+		return &core.Intrinsic{CoreNode: e.CoreNode, Op: e.Op, Args: args}
+
+	case *core.DictApp:
+		args := f.foldExprs(e.Args, temps)
+		if ref, ok := e.Dict.(*core.DictRef); ok && foldableClasses[ref.ClassName] {
+			if lit, ok := f.foldBuiltin(e.Method+"_"+ref.TypeName, args, e.CoreNode); ok {
+				return lit
+			}
+		}
+		return &core.DictApp{CoreNode: e.CoreNode, Dict: f.foldExpr(e.Dict, temps), Method: e.Method, Args: args}
+
+	case *core.DictAbs:
+		return &core.DictAbs{CoreNode: e.CoreNode, Params: e.Params, Body: f.foldExpr(e.Body, temps)}
+
+	case *core.If:
+		return &core.If{
+			CoreNode: e.CoreNode,
+			Cond:     f.foldExpr(e.Cond, temps),
+			Then:     f.foldExpr(e.Then, temps),
+			Else:     f.foldExpr(e.Else, temps),
+		}
+
+	case *core.Match:
+		arms := make([]core.MatchArm, len(e.Arms))
+		for i, arm := range e.Arms {
+			arms[i] = core.MatchArm{Pattern: arm.Pattern, Guard: f.foldExpr(arm.Guard, temps), Body: f.foldExpr(arm.Body, temps)}
+		}
+		return &core.Match{CoreNode: e.CoreNode, Scrutinee: f.foldExpr(e.Scrutinee, temps), Arms: arms, Exhaustive: e.Exhaustive}
+
+	case *core.Record:
+		fields := make(map[string]core.CoreExpr, len(e.Fields))
+		for k, v := range e.Fields {
+			fields[k] = f.foldExpr(v, temps)
+		}
+		return &core.Record{CoreNode: e.CoreNode, Fields: fields}
+
+	case *core.RecordAccess:
+		return &core.RecordAccess{CoreNode: e.CoreNode, Record: f.foldExpr(e.Record, temps), Field: e.Field}
+
+	case *core.RecordUpdate:
+		updates := make(map[string]core.CoreExpr, len(e.Updates))
+		for k, v := range e.Updates {
+			updates[k] = f.foldExpr(v, temps)
+		}
+		return &core.RecordUpdate{CoreNode: e.CoreNode, Base: f.foldExpr(e.Base, temps), Updates: updates}
+
+	case *core.List:
+		return &core.List{CoreNode: e.CoreNode, Elements: f.foldExprs(e.Elements, temps)}
+
+	case *core.Tuple:
+		return &core.Tuple{CoreNode: e.CoreNode, Elements: f.foldExprs(e.Elements, temps)}
+
+	default:
+		// Literals, globals, dictionary refs and legacy operators pass through
+		return expr
+	}
+}
+
+// foldExprs folds a slice of expressions
+func (f *ConstantFolder) foldExprs(exprs []core.CoreExpr, temps map[string]*core.Lit) []core.CoreExpr {
+	result := make([]core.CoreExpr, len(exprs))
+	for i, e := range exprs {
+		result[i] = f.foldExpr(e, temps)
+	}
+	return result
+}
+
+// foldableClasses are the type classes whose primitive instances are
+// implemented by the operator builtins
+var foldableClasses = map[string]bool{"Num": true, "Eq": true, "Ord": true}
+
+// intrinsicBuiltin picks the builtin for an unlowered intrinsic from the kind
+// of its (literal) arguments. And/Or short-circuit and are left to lowering.
+func intrinsicBuiltin(op core.IntrinsicOp, args []core.CoreExpr) (string, bool) {
+	if op == core.OpAnd || op == core.OpOr || len(args) == 0 {
+		return "", false
+	}
+	lit, ok := args[0].(*core.Lit)
+	if !ok {
+		return "", false
+	}
+	name, err := GetBuiltinName(op, litTypeName(lit))
+	return name, err == nil
+}
+
+// litTypeName returns the builtin type suffix for a literal
+func litTypeName(lit *core.Lit) string {
+	switch lit.Kind {
+	case core.IntLit:
+		return "Int"
+	case core.FloatLit:
+		return "Float"
+	case core.StringLit:
+		return "String"
+	case core.BoolLit:
+		return "Bool"
+	}
+	return ""
+}
+
+// foldBuiltin evaluates the operator builtin name on literal arguments.
+// It reports false if any argument is not a literal of the builtin's type,
+// or if the operation would fail or misbehave at runtime.
+func (f *ConstantFolder) foldBuiltin(name string, args []core.CoreExpr, node core.CoreNode) (*core.Lit, bool) {
+	lits := make([]*core.Lit, len(args))
+	for i, arg := range args {
+		lit, ok := arg.(*core.Lit)
+		if !ok {
+			return nil, false
+		}
+		lits[i] = lit
+	}
+
+	var value interface{}
+	var kind core.LitKind
+	ok := false
+
+	switch len(lits) {
+	case 1:
+		kind, value, ok = foldUnary(name, lits[0])
+	case 2:
+		kind, value, ok = foldBinary(name, lits[0], lits[1])
+	}
+	if !ok {
+		return nil, false
+	}
+
+	f.folded++
+	return &core.Lit{CoreNode: node, Kind: kind, Value: value}, true
+}
+
+func foldUnary(name string, a *core.Lit) (core.LitKind, interface{}, bool) {
+	switch name {
+	case "neg_Int":
+		if x, ok := litInt(a); ok {
+			return foldedInt(eval.CheckedNeg(x))
+		}
+	case "neg_Float":
+		if x, ok := litFloat(a); ok {
+			return core.FloatLit, -x, true
+		}
+	case "not_Bool":
+		if x, ok := litBool(a); ok {
+			return core.BoolLit, !x, true
+		}
+	case "fromInt_Float":
+		if x, ok := litInt(a); ok {
+			return core.FloatLit, float64(x), true
+		}
+	}
+	return 0, nil, false
+}
+
+func foldBinary(name string, a, b *core.Lit) (core.LitKind, interface{}, bool) {
+	op, typ, found := strings.Cut(name, "_")
+	if !found {
+		return 0, nil, false
+	}
+
+	switch typ {
+	case "Int":
+		x, okA := litInt(a)
+		y, okB := litInt(b)
+		if !okA || !okB {
+			return 0, nil, false
+		}
+		switch op {
+		case "add":
+			return foldedInt(eval.CheckedAdd(x, y))
+		case "sub":
+			return foldedInt(eval.CheckedSub(x, y))
+		case "mul":
+			return foldedInt(eval.CheckedMul(x, y))
+		case "div":
+			if y != 0 {
+				return foldedInt(eval.CheckedDiv(x, y))
+			}
+		case "mod":
+			if y != 0 {
+				return core.IntLit, x % y, true
+			}
+		default:
+			return compareLits(op, x, y)
+		}
+
+	case "Float":
+		x, okA := litFloat(a)
+		y, okB := litFloat(b)
+		if !okA || !okB {
+			return 0, nil, false
+		}
+		switch op {
+		case "add":
+			return core.FloatLit, x + y, true
+		case "sub":
+			return core.FloatLit, x - y, true
+		case "mul":
+			return core.FloatLit, x * y, true
+		case "div":
+			if y != 0 {
+				return core.FloatLit, x / y, true
+			}
+		case "mod":
+			if y != 0 {
+				return core.FloatLit, math.Mod(x, y), true
+			}
+		default:
+			return compareLits(op, x, y)
+		}
+
+	case "String":
+		x, okA := a.Value.(string)
+		y, okB := b.Value.(string)
+		if a.Kind != core.StringLit || b.Kind != core.StringLit || !okA || !okB {
+			return 0, nil, false
+		}
+		if op == "concat" {
+			return core.StringLit, x + y, true
+		}
+		return compareLits(op, x, y)
+
+	case "Bool":
+		x, okA := litBool(a)
+		y, okB := litBool(b)
+		if !okA || !okB {
+			return 0, nil, false
+		}
+		switch op {
+		case "eq":
+			return core.BoolLit, x == y, true
+		case "ne":
+			return core.BoolLit, x != y, true
+		}
+	}
+
+	return 0, nil, false
+}
+
+// compareLits folds the comparison builtins shared by Int, Float and String
+func compareLits[T int | float64 | string](op string, x, y T) (core.LitKind, interface{}, bool) {
+	switch op {
+	case "eq":
+		return core.BoolLit, x == y, true
+	case "ne":
+		return core.BoolLit, x != y, true
+	case "lt":
+		return core.BoolLit, x < y, true
+	case "le":
+		return core.BoolLit, x <= y, true
+	case "gt":
+		return core.BoolLit, x > y, true
+	case "ge":
+		return core.BoolLit, x >= y, true
+	}
+	return 0, nil, false
+}
+
+// foldedInt yields an Int literal unless the operation overflowed; overflow
+// is left to the runtime so --int-overflow decides between wrapping and failing
+func foldedInt(v int, ok bool) (core.LitKind, interface{}, bool) {
+	if !ok {
+		return 0, nil, false
+	}
+	return core.IntLit, v, true
+}
+
+// litInt reads an integer literal; the parser may store it as int or int64
+func litInt(lit *core.Lit) (int, bool) {
+	if lit.Kind != core.IntLit {
+		return 0, false
+	}
+	switch v := lit.Value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+func litFloat(lit *core.Lit) (float64, bool) {
+	if lit.Kind != core.FloatLit {
+		return 0, false
+	}
+	v, ok := lit.Value.(float64)
+	return v, ok
+}
+
+func litBool(lit *core.Lit) (bool, bool) {
+	if lit.Kind != core.BoolLit {
+		return false, false
+	}
+	v, ok := lit.Value.(bool)
+	return v, ok
+}
@@ -0,0 +1,116 @@
+package pipeline
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+func intLit(n int) *core.Lit       { return &core.Lit{Kind: core.IntLit, Value: n} }
+func floatLit(f float64) *core.Lit { return &core.Lit{Kind: core.FloatLit, Value: f} }
+func stringLit(s string) *core.Lit { return &core.Lit{Kind: core.StringLit, Value: s} }
+func builtinCall(name string, args ...core.CoreExpr) *core.App {
+	return &core.App{
+		Func: &core.VarGlobal{Ref: core.GlobalRef{Module: "$builtin", Name: name}},
+		Args: args,
+	}
+}
+
+func foldOne(t *testing.T, expr core.CoreExpr) (core.CoreExpr, int) {
+	t.Helper()
+	folder := NewConstantFolder()
+	prog := folder.Fold(&core.Program{Decls: []core.CoreExpr{expr}})
+	require.Len(t, prog.Decls, 1)
+	return prog.Decls[0], folder.Folded()
+}
+
+func TestConstantFolder_ANFChain(t *testing.T) {
+	// 2 + 3 * 4 after lowering: let $tmp1 = mul_Int(3, 4) in add_Int(2, $tmp1)
+	expr := &core.Let{
+		Name:  "$tmp1",
+		Value: builtinCall("mul_Int", intLit(3), intLit(4)),
+		Body:  builtinCall("add_Int", intLit(2), &core.Var{Name: "$tmp1"}),
+	}
+
+	folded, count := foldOne(t, expr)
+	assert.Equal(t, intLit(14), folded)
+	assert.Equal(t, 2, count)
+
+	// The input program is not modified
+	assert.IsType(t, &core.App{}, expr.Value)
+}
+
+func TestConstantFolder_Operations(t *testing.T) {
+	tests := []struct {
+		name string
+		expr core.CoreExpr
+		want *core.Lit
+	}{
+		{"float arithmetic", builtinCall("div_Float", floatLit(3.0), floatLit(4.0)), floatLit(0.75)},
+		{"fromInt", builtinCall("fromInt_Float", intLit(3)), floatLit(3.0)},
+		{"concat", builtinCall("concat_String", stringLit("a"), stringLit("b")), stringLit("ab")},
+		{"string compare", builtinCall("lt_String", stringLit("a"), stringLit("b")), &core.Lit{Kind: core.BoolLit, Value: true}},
+		{"int modulo", builtinCall("mod_Int", intLit(7), intLit(3)), intLit(1)},
+		{"negation", builtinCall("neg_Int", intLit(5)), intLit(-5)},
+		{"shim intrinsic", &core.Intrinsic{Op: core.OpMul, Args: []core.CoreExpr{intLit(6), intLit(7)}}, intLit(42)},
+		{"dictionary method", &core.DictApp{
+			Dict:   &core.DictRef{ClassName: "Num", TypeName: "Int"},
+			Method: "sub",
+			Args:   []core.CoreExpr{intLit(10), intLit(4)},
+		}, intLit(6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded, count := foldOne(t, tt.expr)
+			assert.Equal(t, tt.want, folded)
+			assert.Equal(t, 1, count)
+		})
+	}
+}
+
+func TestConstantFolder_Conservative(t *testing.T) {
+	tests := []struct {
+		name string
+		expr core.CoreExpr
+	}{
+		{"int division by zero", builtinCall("div_Int", intLit(1), intLit(0))},
+		{"int modulo by zero", builtinCall("mod_Int", intLit(1), intLit(0))},
+		{"int overflow", builtinCall("add_Int", intLit(math.MaxInt), intLit(1))},
+		{"int negation overflow", builtinCall("neg_Int", intLit(math.MinInt))},
+		{"int division overflow", builtinCall("div_Int", intLit(math.MinInt), intLit(-1))},
+		{"float division by zero", builtinCall("div_Float", floatLit(1), floatLit(0))},
+		{"non-literal argument", builtinCall("add_Int", intLit(1), &core.Var{Name: "x"})},
+		{"mistyped literal", builtinCall("add_Float", intLit(1), floatLit(2))},
+		{"other builtin", builtinCall("_io_println", stringLit("hi"))},
+		{"short-circuit intrinsic", &core.Intrinsic{Op: core.OpAnd, Args: []core.CoreExpr{
+			&core.Lit{Kind: core.BoolLit, Value: true}, &core.Lit{Kind: core.BoolLit, Value: false},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded, count := foldOne(t, tt.expr)
+			assert.Equal(t, 0, count)
+			assert.Equal(t, tt.expr, folded)
+		})
+	}
+}
+
+func TestConstantFolder_KeepsUserBindings(t *testing.T) {
+	// Only compiler temporaries are inlined; user lets stay in place
+	expr := &core.Lambda{Params: []string{"y"}, Body: &core.Let{
+		Name:  "x",
+		Value: builtinCall("add_Int", intLit(1), intLit(2)),
+		Body:  builtinCall("add_Int", &core.Var{Name: "x"}, &core.Var{Name: "y"}),
+	}}
+
+	folded, count := foldOne(t, expr)
+	assert.Equal(t, 1, count)
+	let := folded.(*core.Lambda).Body.(*core.Let)
+	assert.Equal(t, "x", let.Name)
+	assert.Equal(t, intLit(3), let.Value)
+}
@@ -4,6 +4,8 @@ package pipeline
 import (
 	"fmt"
 
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/builtins"
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/eval"
 )
@@ -13,85 +15,58 @@ type CoreSanityError struct {
 	Code       string
 	Message    string
 	NodeID     uint64
+	Pos        ast.Pos // Source position of the node, zero when unknown
 	Suggestion string
 }
 
 func (e *CoreSanityError) Error() string {
+	msg := fmt.Sprintf("%s: %s (node %d)", e.Code, e.Message, e.NodeID)
+	if e.Pos.Line > 0 {
+		msg = fmt.Sprintf("%s: %s at %s (node %d)", e.Code, e.Message, e.Pos, e.NodeID)
+	}
 	if e.Suggestion != "" {
-		return fmt.Sprintf("%s: %s (node %d). Suggestion: %s", e.Code, e.Message, e.NodeID, e.Suggestion)
+		return fmt.Sprintf("%s. Suggestion: %s", msg, e.Suggestion)
 	}
-	return fmt.Sprintf("%s: %s (node %d)", e.Code, e.Message, e.NodeID)
+	return msg
 }
 
-// AssertNoOperators ensures no operator nodes remain after lowering
+// AssertNoOperators ensures no operator nodes remain after lowering. Every
+// operator is resolved to a builtin call (or an if for && and ||), so any
+// Intrinsic, BinOp or UnOp left over means the lowering pass missed a node.
 func AssertNoOperators(prog *core.Program) error {
-	var errors []error
-
-	// Walk all declarations
-	for _, decl := range prog.Decls {
-		walkCore(decl, func(node core.CoreExpr) {
-			switch n := node.(type) {
-			case *core.Intrinsic:
-				errors = append(errors, &CoreSanityError{
-					Code:       "ELB_OP002",
-					Message:    fmt.Sprintf("Intrinsic '%s' not lowered", GetOpSymbol(n.Op)),
-					NodeID:     n.ID(),
-					Suggestion: "Enable lowering pass or file internal bug",
-				})
-			case *core.BinOp:
-				errors = append(errors, &CoreSanityError{
-					Code:       "ELB_OP002",
-					Message:    fmt.Sprintf("BinOp '%s' not lowered", n.Op),
-					NodeID:     n.ID(),
-					Suggestion: "Enable lowering pass or file internal bug",
-				})
-			case *core.UnOp:
-				errors = append(errors, &CoreSanityError{
-					Code:       "ELB_OP002",
-					Message:    fmt.Sprintf("UnOp '%s' not lowered", n.Op),
-					NodeID:     n.ID(),
-					Suggestion: "Enable lowering pass or file internal bug",
-				})
-			case *core.Match:
-				errors = append(errors, &CoreSanityError{
-					Code:       "ELB_UNSUPPORTED_NODE",
-					Message:    "Match nodes not yet supported",
-					NodeID:     n.ID(),
-					Suggestion: "Use if-then-else for conditionals",
-				})
-			}
-		})
-	}
-
-	// Check VarGlobal references
-	for _, decl := range prog.Decls {
-		walkCore(decl, func(node core.CoreExpr) {
-			if vg, ok := node.(*core.VarGlobal); ok {
-				if vg.Ref.Module == "$builtin" {
-					// Check if builtin is registered
-					if !IsBuiltinRegistered(vg.Ref.Name) {
-						errors = append(errors, &CoreSanityError{
-							Code:       "LNK_BUILTIN404",
-							Message:    fmt.Sprintf("Builtin '%s' not registered", vg.Ref.Name),
-							NodeID:     vg.ID(),
-							Suggestion: "Check builtin name spelling",
-						})
-					}
-				} else { //nolint:staticcheck // TODO: Check against linker index for other modules
-					// For now, we'll assume they're valid if not $builtin
-				}
-			}
-		})
-	}
-
-	if len(errors) > 0 {
-		return errors[0] // Return first error
-	}
-	return nil
+	var found error
+	WalkCore(prog, func(node core.CoreExpr) {
+		if found != nil {
+			return
+		}
+		var what string
+		switch n := node.(type) {
+		case *core.Intrinsic:
+			what = fmt.Sprintf("Intrinsic '%s'", GetOpSymbol(n.Op))
+		case *core.BinOp:
+			what = fmt.Sprintf("BinOp '%s'", n.Op)
+		case *core.UnOp:
+			what = fmt.Sprintf("UnOp '%s'", n.Op)
+		default:
+			return
+		}
+		found = &CoreSanityError{
+			Code:       "ELB_OP002",
+			Message:    what + " not lowered",
+			NodeID:     node.ID(),
+			Pos:        node.Span(),
+			Suggestion: "Enable lowering pass or file internal bug",
+		}
+	})
+	return found
 }
 
-// IsBuiltinRegistered checks if a builtin is registered in the evaluator
+// IsBuiltinRegistered checks if a builtin is registered, either in the spec
+// registry or as a legacy evaluator builtin
 func IsBuiltinRegistered(name string) bool {
+	if _, ok := builtins.GetSpec(name); ok {
+		return true
+	}
 	_, ok := eval.Builtins[name]
 	return ok
 }
@@ -161,6 +136,17 @@ func walkCore(expr core.CoreExpr, visit func(core.CoreExpr)) {
 			walkCore(elem, visit)
 		}
 
+	case *core.Tuple:
+		for _, elem := range e.Elements {
+			walkCore(elem, visit)
+		}
+
+	case *core.RecordUpdate:
+		walkCore(e.Base, visit)
+		for _, value := range e.Updates {
+			walkCore(value, visit)
+		}
+
 	case *core.DictAbs:
 		walkCore(e.Body, visit)
 
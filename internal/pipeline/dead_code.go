@@ -0,0 +1,152 @@
+package pipeline
+
+import (
+	"github.com/sunholo/ailang/internal/core"
+	"github.com/sunholo/ailang/internal/loader"
+)
+
+// DeadCodeStats reports how many top-level bindings dead-code elimination kept
+type DeadCodeStats struct {
+	Kept    int // Bindings reachable from the entrypoint
+	Removed int // Bindings dropped as unreachable
+}
+
+// EliminateDeadCode drops every top-level binding that cannot be reached from
+// the entrypoint of the root module, following local references within a
+// module and VarGlobal references across modules.
+//
+// The result is a new module map; the Core of the input modules is shared with
+// the module cache and is left untouched. Pruned programs are marked with
+// Flags.Pruned so the runtime tolerates exports whose bindings were dropped.
+func EliminateDeadCode(modules map[string]*loader.LoadedModule, root, entry string) (map[string]*loader.LoadedModule, DeadCodeStats) {
+	reachable := make(map[core.GlobalRef]bool)
+	worklist := []core.GlobalRef{{Module: root, Name: entry}}
+
+	// Index the top-level binding values of every module by name
+	values := make(map[core.GlobalRef]core.CoreExpr)
+	for modID, mod := range modules {
+		if mod.Core == nil {
+			continue
+		}
+		for _, decl := range mod.Core.Decls {
+			forEachTopLevelBinding(decl, func(name string, value core.CoreExpr) {
+				values[core.GlobalRef{Module: modID, Name: name}] = value
+			})
+		}
+	}
+
+	for len(worklist) > 0 {
+		ref := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+
+		value, ok := values[ref]
+		if !ok || reachable[ref] {
+			continue // Builtins, $adt constructors, or already visited
+		}
+		reachable[ref] = true
+
+		// Local names may be shadowed by parameters; treating them as
+		// references only keeps more than necessary, never less.
+		walkCore(value, func(node core.CoreExpr) {
+			switch n := node.(type) {
+			case *core.Var:
+				worklist = append(worklist, core.GlobalRef{Module: ref.Module, Name: n.Name})
+			case *core.VarGlobal:
+				worklist = append(worklist, n.Ref)
+			}
+		})
+	}
+
+	var stats DeadCodeStats
+	pruned := make(map[string]*loader.LoadedModule, len(modules))
+	for modID, mod := range modules {
+		if mod.Core == nil {
+			pruned[modID] = mod
+			continue
+		}
+
+		var decls []core.CoreExpr
+		for _, decl := range mod.Core.Decls {
+			if kept := pruneDecl(decl, modID, reachable, &stats); kept != nil {
+				decls = append(decls, kept)
+			}
+		}
+
+		copied := *mod
+		copied.Core = &core.Program{
+			Decls: decls,
+			Meta:  mod.Core.Meta,
+			Flags: mod.Core.Flags,
+		}
+		copied.Core.Flags.Pruned = true
+		pruned[modID] = &copied
+	}
+
+	return pruned, stats
+}
+
+// forEachTopLevelBinding calls fn for each binding of a module-level
+// Let/LetRec chain
+func forEachTopLevelBinding(decl core.CoreExpr, fn func(name string, value core.CoreExpr)) {
+	switch d := decl.(type) {
+	case *core.Let:
+		fn(d.Name, d.Value)
+		forEachTopLevelBinding(d.Body, fn)
+	case *core.LetRec:
+		for _, b := range d.Bindings {
+			fn(b.Name, b.Value)
+		}
+		forEachTopLevelBinding(d.Body, fn)
+	}
+}
+
+// pruneDecl returns decl without its unreachable bindings, or nil if none remain
+func pruneDecl(decl core.CoreExpr, modID string, reachable map[core.GlobalRef]bool, stats *DeadCodeStats) core.CoreExpr {
+	pruned := pruneChain(decl, modID, reachable, stats)
+	switch pruned.(type) {
+	case *core.Let, *core.LetRec:
+		return pruned
+	}
+	if pruned != decl {
+		// Only the trailing result expression of a dropped chain is left
+		return nil
+	}
+	return decl
+}
+
+// pruneChain removes unreachable bindings from a Let/LetRec chain
+func pruneChain(expr core.CoreExpr, modID string, reachable map[core.GlobalRef]bool, stats *DeadCodeStats) core.CoreExpr {
+	switch e := expr.(type) {
+	case *core.Let:
+		body := pruneChain(e.Body, modID, reachable, stats)
+		if !reachable[core.GlobalRef{Module: modID, Name: e.Name}] {
+			stats.Removed++
+			return body
+		}
+		stats.Kept++
+		if body == e.Body {
+			return e
+		}
+		return &core.Let{CoreNode: e.CoreNode, Name: e.Name, Value: e.Value, Body: body}
+
+	case *core.LetRec:
+		body := pruneChain(e.Body, modID, reachable, stats)
+		var bindings []core.RecBinding
+		for _, b := range e.Bindings {
+			if reachable[core.GlobalRef{Module: modID, Name: b.Name}] {
+				bindings = append(bindings, b)
+			}
+		}
+		stats.Kept += len(bindings)
+		stats.Removed += len(e.Bindings) - len(bindings)
+		if len(bindings) == 0 {
+			return body
+		}
+		if len(bindings) == len(e.Bindings) && body == e.Body {
+			return e
+		}
+		return &core.LetRec{CoreNode: e.CoreNode, Bindings: bindings, Body: body}
+	}
+
+	return expr
+}
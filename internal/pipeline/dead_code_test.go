@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/core"
+	"github.com/sunholo/ailang/internal/loader"
+)
+
+// topLevelNames lists the binding names left in a module's Core
+func topLevelNames(prog *core.Program) []string {
+	var names []string
+	for _, decl := range prog.Decls {
+		forEachTopLevelBinding(decl, func(name string, _ core.CoreExpr) {
+			names = append(names, name)
+		})
+	}
+	return names
+}
+
+func TestEliminateDeadCode(t *testing.T) {
+	lam := func(body core.CoreExpr) core.CoreExpr {
+		return &core.Lambda{Params: []string{"x"}, Body: body}
+	}
+	app := func(fn core.CoreExpr, arg core.CoreExpr) core.CoreExpr {
+		return &core.App{Func: fn, Args: []core.CoreExpr{arg}}
+	}
+	global := func(mod, name string) core.CoreExpr {
+		return &core.VarGlobal{Ref: core.GlobalRef{Module: mod, Name: name}}
+	}
+
+	lib := &loader.LoadedModule{Path: "lib", Core: &core.Program{Decls: []core.CoreExpr{
+		&core.LetRec{Bindings: []core.RecBinding{
+			{Name: "even", Value: lam(app(&core.Var{Name: "odd"}, &core.Var{Name: "x"}))},
+			{Name: "odd", Value: lam(app(&core.Var{Name: "even"}, &core.Var{Name: "x"}))},
+			{Name: "unrelated", Value: lam(&core.Var{Name: "x"})},
+		}},
+		&core.Let{Name: "unused", Value: lam(&core.Var{Name: "x"}), Body: &core.Var{Name: "unused"}},
+	}}}
+	unusedLib := &loader.LoadedModule{Path: "other", Core: &core.Program{Decls: []core.CoreExpr{
+		&core.Let{Name: "f", Value: lam(&core.Var{Name: "x"}), Body: &core.Var{Name: "f"}},
+	}}}
+	root := &loader.LoadedModule{Path: "main", Core: &core.Program{Decls: []core.CoreExpr{
+		&core.Let{Name: "helper", Value: lam(app(global("lib", "even"), &core.Var{Name: "x"})),
+			Body: &core.Let{Name: "dead", Value: lam(global("other", "f")),
+				Body: &core.Let{Name: "main", Value: lam(app(&core.Var{Name: "helper"}, app(global("$builtin", "show"), &core.Var{Name: "x"}))),
+					Body: &core.Var{Name: "main"}}}},
+	}}}
+
+	modules := map[string]*loader.LoadedModule{"lib": lib, "other": unusedLib, "main": root}
+	pruned, stats := EliminateDeadCode(modules, "main", "main")
+
+	assert.Equal(t, []string{"helper", "main"}, topLevelNames(pruned["main"].Core))
+	assert.Equal(t, []string{"even", "odd"}, topLevelNames(pruned["lib"].Core))
+	assert.Empty(t, pruned["other"].Core.Decls)
+	assert.Equal(t, DeadCodeStats{Kept: 4, Removed: 4}, stats)
+
+	for id, mod := range pruned {
+		assert.True(t, mod.Core.Flags.Pruned, "module %s should be marked as pruned", id)
+	}
+
+	// The input modules are shared with the module cache and must be untouched
+	require.Len(t, lib.Core.Decls, 2)
+	assert.Len(t, lib.Core.Decls[0].(*core.LetRec).Bindings, 3)
+	assert.Equal(t, []string{"helper", "dead", "main"}, topLevelNames(root.Core))
+	assert.False(t, root.Core.Flags.Pruned)
+}
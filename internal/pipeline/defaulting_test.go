@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"testing"
+)
+
+// TestResultDefaulting checks that numeric defaulting decisions of the root
+// module are reported in source order with the span of the defaulted literal
+func TestResultDefaulting(t *testing.T) {
+	result, err := runFileSource(t, "app.ail", "let x = 1 + 2 in\nlet y = 2.5 in\nshow(x)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		class, def   string
+		line, column int
+	}{
+		{"Num", "int", 1, 9},
+		{"Fractional", "float", 2, 9},
+	}
+	if len(result.Defaulting) != len(want) {
+		t.Fatalf("expected %d defaulting decisions, got %+v", len(want), result.Defaulting)
+	}
+	for i, w := range want {
+		trace := result.Defaulting[i]
+		if trace.ClassName != w.class || trace.Default.String() != w.def {
+			t.Errorf("[%d] expected %s defaulted to %s, got %s defaulted to %s", i, w.class, w.def, trace.ClassName, trace.Default)
+		}
+		if trace.NodeID == 0 {
+			t.Errorf("[%d] expected the literal's node id", i)
+		}
+		if trace.Span.Line != w.line || trace.Span.Column != w.column {
+			t.Errorf("[%d] expected span %d:%d, got %s", i, w.line, w.column, trace.Span)
+		}
+	}
+}
+
+// TestResultResolved checks that the instances chosen for the root module's
+// operators are reported in source order with their methods
+func TestResultResolved(t *testing.T) {
+	result, err := runFileSource(t, "app.ail", "let s = \"a\" in\n1.5 < 2.0 && s != \"b\"")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		class, typ, method string
+		line, column       int
+	}{
+		{"Fractional", "Float", "", 2, 1},
+		{"Ord", "Float", "lt", 2, 5},
+		{"Fractional", "Float", "", 2, 7},
+		{"Eq", "String", "neq", 2, 16},
+	}
+	if len(result.Resolved) != len(want) {
+		t.Fatalf("expected %d resolved constraints, got %d", len(want), len(result.Resolved))
+	}
+	for i, w := range want {
+		rc := result.Resolved[i]
+		if rc.ClassName != w.class || rc.Type.String() != w.typ || rc.Method != w.method {
+			t.Errorf("[%d] expected %s[%s] %q, got %s[%s] %q", i, w.class, w.typ, w.method, rc.ClassName, rc.Type, rc.Method)
+		}
+		if rc.Span.Line != w.line || rc.Span.Column != w.column {
+			t.Errorf("[%d] expected span %d:%d, got %s", i, w.line, w.column, rc.Span)
+		}
+	}
+}
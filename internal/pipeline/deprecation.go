@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
+	"github.com/sunholo/ailang/internal/iface"
+)
+
+// DeprecationWarning reports a reference to a symbol declared with @deprecated
+type DeprecationWarning struct {
+	Location ast.Pos // Source location of the reference
+	Module   string  // Module that declares the deprecated symbol
+	Symbol   string  // Deprecated symbol name
+	Reason   string  // Optional message from @deprecated("...")
+}
+
+func (w *DeprecationWarning) Code() string { return ailangErrors.MOD013 }
+
+func (w *DeprecationWarning) Message() string {
+	msg := fmt.Sprintf("DEPRECATED: %s.%s is deprecated", w.Module, w.Symbol)
+	if w.Reason != "" {
+		msg += ": " + w.Reason
+	}
+	if w.Location.Line > 0 {
+		msg += fmt.Sprintf("\n  referenced at %s", w.Location)
+	}
+	return msg
+}
+
+func (w *DeprecationWarning) Span() *ast.Span  { return ailangErrors.SpanAt(w.Location) }
+func (w *DeprecationWarning) Severity() string { return ailangErrors.SeverityWarning }
+func (w *DeprecationWarning) String() string   { return "warning: " + w.Message() }
+
+// collectDeprecationWarnings finds every reference in prog to a deprecated
+// export of the given dependency interfaces. Warnings are sorted by location.
+func collectDeprecationWarnings(prog *core.Program, deps []*iface.Iface) []*DeprecationWarning {
+	deprecated := make(map[core.GlobalRef]*iface.IfaceItem)
+	for _, dep := range deps {
+		for _, item := range dep.Exports {
+			if item.Deprecated {
+				deprecated[item.Ref] = item
+			}
+		}
+	}
+	if len(deprecated) == 0 || prog == nil {
+		return nil
+	}
+
+	var warnings []*DeprecationWarning
+	WalkCore(prog, func(node core.CoreExpr) {
+		vg, ok := node.(*core.VarGlobal)
+		if !ok {
+			return
+		}
+		item, ok := deprecated[vg.Ref]
+		if !ok {
+			return
+		}
+		warnings = append(warnings, &DeprecationWarning{
+			Location: vg.Span(),
+			Module:   vg.Ref.Module,
+			Symbol:   vg.Ref.Name,
+			Reason:   item.DeprecationMsg,
+		})
+	})
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		a, b := warnings[i].Location, warnings[j].Location
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return warnings
+}
@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
+	"github.com/sunholo/ailang/internal/iface"
+)
+
+// TestCollectDeprecationWarnings verifies that references to @deprecated
+// imports produce one warning per use site, in source order
+func TestCollectDeprecationWarnings(t *testing.T) {
+	lib := iface.NewIface("lib")
+	lib.Exports["foo"] = &iface.IfaceItem{
+		Name:           "foo",
+		Ref:            core.GlobalRef{Module: "lib", Name: "foo"},
+		Deprecated:     true,
+		DeprecationMsg: "use bar instead",
+	}
+	lib.Exports["bar"] = &iface.IfaceItem{
+		Name: "bar",
+		Ref:  core.GlobalRef{Module: "lib", Name: "bar"},
+	}
+
+	ref := func(name string, line int) *core.VarGlobal {
+		return &core.VarGlobal{
+			CoreNode: core.CoreNode{CoreSpan: ast.Pos{File: "app.ail", Line: line, Column: 3}},
+			Ref:      core.GlobalRef{Module: "lib", Name: name},
+		}
+	}
+
+	prog := &core.Program{Decls: []core.CoreExpr{
+		&core.App{Func: ref("foo", 9), Args: []core.CoreExpr{ref("bar", 9)}},
+		&core.Tuple{Elements: []core.CoreExpr{ref("foo", 4)}},
+	}}
+
+	warnings := collectDeprecationWarnings(prog, []*iface.Iface{lib})
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %d", len(warnings))
+	}
+	if warnings[0].Location.Line != 4 || warnings[1].Location.Line != 9 {
+		t.Errorf("warnings not in source order: %v, %v", warnings[0].Location, warnings[1].Location)
+	}
+
+	msg := warnings[0].String()
+	for _, want := range []string{"DEPRECATED", "lib.foo", "use bar instead", "app.ail:4:3"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("warning %q missing %q", msg, want)
+		}
+	}
+
+	var w ailangErrors.Warning = warnings[0]
+	if d := ailangErrors.DiagnosticFromWarning(w); d.Code != ailangErrors.MOD013 || d.Span == nil || d.Span.Start.Line != 4 {
+		t.Errorf("unexpected diagnostic %+v", d)
+	}
+}
+
+// TestCollectDeprecationWarnings_NoDeprecatedImports verifies no warnings without deprecations
+func TestCollectDeprecationWarnings_NoDeprecatedImports(t *testing.T) {
+	lib := iface.NewIface("lib")
+	lib.Exports["bar"] = &iface.IfaceItem{Name: "bar", Ref: core.GlobalRef{Module: "lib", Name: "bar"}}
+
+	prog := &core.Program{Decls: []core.CoreExpr{
+		&core.VarGlobal{Ref: core.GlobalRef{Module: "lib", Name: "bar"}},
+	}}
+	if warnings := collectDeprecationWarnings(prog, []*iface.Iface{lib}); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+}
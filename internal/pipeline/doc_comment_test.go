@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/iface"
+)
+
+// TestDocCommentsInInterface checks that doc comments on exported functions
+// and types survive into the interface JSON, and block comments in between
+// are ignored
+func TestDocCommentsInInterface(t *testing.T) {
+	result, err := checkModuleSource(t, "docs", `/* Helpers /* nested */ for tests */
+
+--- A traffic light.
+export type Light = Red | Green
+
+/**
+ * Adds one.
+ */
+export func inc(x: int) -> int { x /* self */ + 1 }
+
+export func dec(x: int) -> int { x - 1 }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := result.Interface.ToNormalizedJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc iface.InterfaceJSON
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	funcDocs := make(map[string]string)
+	for _, fn := range doc.Funcs {
+		funcDocs[fn.Name] = fn.Doc
+	}
+	if funcDocs["inc"] != "Adds one." || funcDocs["dec"] != "" {
+		t.Errorf("unexpected function docs: %v", funcDocs)
+	}
+	if len(doc.Types) != 1 || doc.Types[0].Doc != "A traffic light." {
+		t.Errorf("unexpected type docs: %+v", doc.Types)
+	}
+}
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/types"
+)
+
+const effectPolyModule = `import std/io (println)
+
+export func mapL[a, b](f: (a) -> b, xs: [a]) -> [b] {
+  match xs { [] => [], [x, ...rest] => [f(x)] ++ mapL(f, rest) }
+}
+
+func double(x: int) -> int { x * 2 }
+
+export func say(x: int) -> () ! {IO} { println(show(x)) }
+
+export func pureUse(xs: List[int]) -> List[int] { mapL(double, xs) }
+
+export func ioUse(xs: List[int]) -> List[()] ! {IO} { mapL(say, xs) }
+`
+
+// TestEffectPolymorphism_HigherOrder checks that a higher-order function
+// takes on the effects of the function passed to it at each use
+func TestEffectPolymorphism_HigherOrder(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	result, err := checkModuleSource(t, "effpoly", effectPolyModule)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mapL := result.Interface.Exports["mapL"].Type
+	if len(mapL.RowVars) != 1 {
+		t.Errorf("expected mapL to quantify one effect row, got %s", mapL)
+	}
+
+	tests := []struct {
+		name    string
+		effects string
+	}{
+		{"pureUse", "{}"},
+		{"ioUse", "{IO}"},
+	}
+	for _, tt := range tests {
+		fn, ok := result.Interface.Exports[tt.name].Type.Type.(*types.TFunc2)
+		if !ok {
+			t.Fatalf("%s: expected a function type", tt.name)
+		}
+		var labels map[string]types.Type
+		if fn.EffectRow != nil {
+			labels = fn.EffectRow.Labels
+		}
+		if got := formatLabels(labels); got != tt.effects {
+			t.Errorf("%s: expected effects %s, got %s", tt.name, tt.effects, got)
+		}
+	}
+}
+
+// TestEffectPolymorphism_BuiltinInstantiation checks that each use of a
+// polymorphic builtin gets fresh type variables
+func TestEffectPolymorphism_BuiltinInstantiation(t *testing.T) {
+	_, err := checkModuleSource(t, "showtwice", `export func main() -> string {
+  { let s = show(1 + 2); s ++ show(true) }
+}
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package pipeline
+
+import "testing"
+
+// TestCheck_EntryAnnotation verifies that the interface marks the function
+// declared with @entry, which run calls when --entry is not given
+func TestCheck_EntryAnnotation(t *testing.T) {
+	code := `export func main() -> int { 1 }
+
+@entry
+export func demo() -> int { 2 }
+`
+	result, err := checkModuleSource(t, "entry", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Interface.Exports["demo"].Entry {
+		t.Error("demo: expected Entry to be set")
+	}
+	if result.Interface.Exports["main"].Entry {
+		t.Error("main: expected Entry to be unset")
+	}
+}
@@ -0,0 +1,32 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCheck_ExhaustivenessWarningLocation checks that a match warning in a
+// module names the source file, not the module path
+func TestCheck_ExhaustivenessWarningLocation(t *testing.T) {
+	tests := []struct {
+		name, body, code string
+	}{
+		{"guarded", "export func f(n: int) -> int {\n  match n { x if x > 0 => 1 }\n}\n", "EXHAUST003"},
+		{"missing", "export func f(b: bool) -> int {\n  match b { true => 1 }\n}\n", "non-exhaustive match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checkModuleSource(t, "exhaust", tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Warnings) != 1 {
+				t.Fatalf("expected one warning, got %v", result.Warnings)
+			}
+			msg := result.Warnings[0].Message()
+			if !strings.Contains(msg, tt.code) || !strings.Contains(msg, "exhaust.ail:4:3") {
+				t.Errorf("warning %q should be located at exhaust.ail:4:3", msg)
+			}
+		})
+	}
+}
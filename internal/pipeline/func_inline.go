@@ -0,0 +1,222 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// FunctionInliner replaces calls to the module's @inline functions with the
+// function body. Arguments are bound in order to fresh names before the
+// parameters, so each is still evaluated exactly once and cannot be captured
+// by the body:
+//
+//	sq(y + 1)  →  let $inline1 = add_Int(y, 1) in let x = $inline1 in mul_Int(x, x)
+//
+// A call is left alone when it passes a different number of arguments than
+// the function has parameters, or when a local binding at the call site
+// shadows the function or a name its body refers to. Recursive functions
+// cannot be @inline (the elaborator rejects them), so inlining terminates.
+//
+// It runs before the LetInliner, which then removes the argument bindings
+// that are used once or are atomic.
+type FunctionInliner struct {
+	funcs   map[string]*core.Lambda // @inline functions of the module
+	fresh   int                     // Counter for argument names
+	inlined int                     // Number of calls inlined
+}
+
+// NewFunctionInliner creates a new function inliner
+func NewFunctionInliner() *FunctionInliner {
+	return &FunctionInliner{}
+}
+
+// Inlined returns how many calls have been inlined so far
+func (f *FunctionInliner) Inlined() int {
+	return f.inlined
+}
+
+// Inline returns a copy of prog with calls to its @inline functions inlined
+func (f *FunctionInliner) Inline(prog *core.Program) *core.Program {
+	f.funcs = make(map[string]*core.Lambda)
+	for _, decl := range prog.Decls {
+		for let, ok := decl.(*core.Let); ok; let, ok = let.Body.(*core.Let) {
+			if meta := prog.Meta[let.Name]; meta != nil && meta.IsInline {
+				if lam, isLam := let.Value.(*core.Lambda); isLam {
+					f.funcs[let.Name] = lam
+				}
+			}
+		}
+	}
+	if len(f.funcs) == 0 {
+		return prog
+	}
+
+	inlined := &core.Program{
+		Decls: make([]core.CoreExpr, len(prog.Decls)),
+		Meta:  prog.Meta,
+		Flags: prog.Flags,
+	}
+	for i, decl := range prog.Decls {
+		inlined.Decls[i] = f.inlineTopLevel(decl)
+	}
+	return inlined
+}
+
+// inlineTopLevel keeps the module-level Let/LetRec chain, whose names are
+// the functions themselves rather than local bindings
+func (f *FunctionInliner) inlineTopLevel(expr core.CoreExpr) core.CoreExpr {
+	switch e := expr.(type) {
+	case *core.Let:
+		return &core.Let{CoreNode: e.CoreNode, Name: e.Name, Value: f.inlineExpr(e.Value, nil), Body: f.inlineTopLevel(e.Body)}
+	case *core.LetRec:
+		bindings := make([]core.RecBinding, len(e.Bindings))
+		for i, b := range e.Bindings {
+			bindings[i] = core.RecBinding{Name: b.Name, Value: f.inlineExpr(b.Value, nil)}
+		}
+		return &core.LetRec{CoreNode: e.CoreNode, Bindings: bindings, Body: f.inlineTopLevel(e.Body)}
+	}
+	return f.inlineExpr(expr, nil)
+}
+
+// inlineExpr inlines calls within expr; bound holds the local names in scope
+func (f *FunctionInliner) inlineExpr(expr core.CoreExpr, bound map[string]bool) core.CoreExpr {
+	if expr == nil {
+		return nil
+	}
+
+	switch e := expr.(type) {
+	case *core.Let:
+		value := f.inlineExpr(e.Value, bound)
+		return &core.Let{CoreNode: e.CoreNode, Name: e.Name, Value: value, Body: f.inlineExpr(e.Body, withBound(bound, e.Name))}
+
+	case *core.LetRec:
+		names := make([]string, len(e.Bindings))
+		for i, b := range e.Bindings {
+			names[i] = b.Name
+		}
+		inner := withBound(bound, names...)
+		bindings := make([]core.RecBinding, len(e.Bindings))
+		for i, b := range e.Bindings {
+			bindings[i] = core.RecBinding{Name: b.Name, Value: f.inlineExpr(b.Value, inner)}
+		}
+		return &core.LetRec{CoreNode: e.CoreNode, Bindings: bindings, Body: f.inlineExpr(e.Body, inner)}
+
+	case *core.Lambda:
+		return &core.Lambda{CoreNode: e.CoreNode, Params: e.Params, Body: f.inlineExpr(e.Body, withBound(bound, e.Params...))}
+
+	case *core.App:
+		args := f.inlineExprs(e.Args, bound)
+		if fn, ok := e.Func.(*core.Var); ok {
+			if body, ok := f.expand(e, fn.Name, args, bound); ok {
+				return body
+			}
+		}
+		return &core.App{CoreNode: e.CoreNode, Func: f.inlineExpr(e.Func, bound), Args: args}
+
+	case *core.Intrinsic:
+		return &core.Intrinsic{CoreNode: e.CoreNode, Op: e.Op, Args: f.inlineExprs(e.Args, bound)}
+
+	case *core.DictApp:
+		return &core.DictApp{CoreNode: e.CoreNode, Dict: f.inlineExpr(e.Dict, bound), Method: e.Method, Args: f.inlineExprs(e.Args, bound)}
+
+	case *core.DictAbs:
+		names := make([]string, len(e.Params))
+		for i, p := range e.Params {
+			names[i] = p.Name
+		}
+		return &core.DictAbs{CoreNode: e.CoreNode, Params: e.Params, Body: f.inlineExpr(e.Body, withBound(bound, names...))}
+
+	case *core.If:
+		return &core.If{CoreNode: e.CoreNode, Cond: f.inlineExpr(e.Cond, bound), Then: f.inlineExpr(e.Then, bound), Else: f.inlineExpr(e.Else, bound)}
+
+	case *core.Match:
+		arms := make([]core.MatchArm, len(e.Arms))
+		for i, arm := range e.Arms {
+			inner := withBound(bound, patternNames(arm.Pattern)...)
+			arms[i] = core.MatchArm{Pattern: arm.Pattern, Guard: f.inlineExpr(arm.Guard, inner), Body: f.inlineExpr(arm.Body, inner)}
+		}
+		return &core.Match{CoreNode: e.CoreNode, Scrutinee: f.inlineExpr(e.Scrutinee, bound), Arms: arms, Exhaustive: e.Exhaustive}
+
+	case *core.Record:
+		fields := make(map[string]core.CoreExpr, len(e.Fields))
+		for k, v := range e.Fields {
+			fields[k] = f.inlineExpr(v, bound)
+		}
+		return &core.Record{CoreNode: e.CoreNode, Fields: fields}
+
+	case *core.RecordAccess:
+		return &core.RecordAccess{CoreNode: e.CoreNode, Record: f.inlineExpr(e.Record, bound), Field: e.Field}
+
+	case *core.RecordUpdate:
+		updates := make(map[string]core.CoreExpr, len(e.Updates))
+		for k, v := range e.Updates {
+			updates[k] = f.inlineExpr(v, bound)
+		}
+		return &core.RecordUpdate{CoreNode: e.CoreNode, Base: f.inlineExpr(e.Base, bound), Updates: updates}
+
+	case *core.List:
+		return &core.List{CoreNode: e.CoreNode, Elements: f.inlineExprs(e.Elements, bound)}
+
+	case *core.Tuple:
+		return &core.Tuple{CoreNode: e.CoreNode, Elements: f.inlineExprs(e.Elements, bound)}
+
+	default:
+		return expr
+	}
+}
+
+func (f *FunctionInliner) inlineExprs(exprs []core.CoreExpr, bound map[string]bool) []core.CoreExpr {
+	result := make([]core.CoreExpr, len(exprs))
+	for i, e := range exprs {
+		result[i] = f.inlineExpr(e, bound)
+	}
+	return result
+}
+
+// expand returns the body of the @inline function name applied to args,
+// with the arguments bound to fresh names and then to the parameters
+func (f *FunctionInliner) expand(call *core.App, name string, args []core.CoreExpr, bound map[string]bool) (core.CoreExpr, bool) {
+	lam, ok := f.funcs[name]
+	if !ok || bound[name] || len(args) != len(lam.Params) {
+		return nil, false
+	}
+	// The body's own references must still mean the module's bindings
+	for local := range bound {
+		if countUses(local, lam) > 0 {
+			return nil, false
+		}
+	}
+
+	fresh := make([]string, len(args))
+	for i := range args {
+		f.fresh++
+		fresh[i] = fmt.Sprintf("$inline%d", f.fresh)
+	}
+	body := lam.Body
+	for i := len(lam.Params) - 1; i >= 0; i-- {
+		body = &core.Let{CoreNode: call.CoreNode, Name: lam.Params[i], Value: &core.Var{CoreNode: call.CoreNode, Name: fresh[i]}, Body: body}
+	}
+	for i := len(args) - 1; i >= 0; i-- {
+		body = &core.Let{CoreNode: call.CoreNode, Name: fresh[i], Value: args[i], Body: body}
+	}
+	f.inlined++
+
+	// The body may call further @inline functions
+	return f.inlineExpr(body, bound), true
+}
+
+// withBound returns bound extended with names
+func withBound(bound map[string]bool, names ...string) map[string]bool {
+	if len(names) == 0 {
+		return bound
+	}
+	inner := make(map[string]bool, len(bound)+len(names))
+	for k := range bound {
+		inner[k] = true
+	}
+	for _, n := range names {
+		inner[n] = true
+	}
+	return inner
+}
@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// inlineProgram declares sq = λx. mul_Int(x, x) as @inline followed by a
+// function main = λy. body, and returns main's inlined body
+func inlineProgram(t *testing.T, body core.CoreExpr) (core.CoreExpr, int) {
+	t.Helper()
+	x := &core.Var{Name: "x"}
+	decl := &core.Let{
+		Name:  "sq",
+		Value: &core.Lambda{Params: []string{"x"}, Body: builtinCall("mul_Int", x, x)},
+		Body: &core.Let{
+			Name:  "main",
+			Value: &core.Lambda{Params: []string{"y"}, Body: body},
+			Body:  &core.Var{Name: "main"},
+		},
+	}
+	prog := &core.Program{
+		Decls: []core.CoreExpr{decl},
+		Meta:  map[string]*core.DeclMeta{"sq": {Name: "sq", IsInline: true}},
+	}
+
+	inliner := NewFunctionInliner()
+	inlined := inliner.Inline(prog)
+	require.Len(t, inlined.Decls, 1)
+	main := inlined.Decls[0].(*core.Let).Body.(*core.Let)
+	return main.Value.(*core.Lambda).Body, inliner.Inlined()
+}
+
+func sqCall(arg core.CoreExpr) *core.App {
+	return &core.App{Func: &core.Var{Name: "sq"}, Args: []core.CoreExpr{arg}}
+}
+
+func TestFunctionInliner_InlinesCall(t *testing.T) {
+	// sq(add_Int(y, 1)) ⇒ let $inline1 = add_Int(y, 1) in let x = $inline1 in mul_Int(x, x)
+	y, x := &core.Var{Name: "y"}, &core.Var{Name: "x"}
+	body, count := inlineProgram(t, sqCall(builtinCall("add_Int", y, intLit(1))))
+
+	assert.Equal(t, 1, count)
+	assert.Equal(t, &core.Let{
+		Name:  "$inline1",
+		Value: builtinCall("add_Int", y, intLit(1)),
+		Body: &core.Let{
+			Name:  "x",
+			Value: &core.Var{Name: "$inline1"},
+			Body:  builtinCall("mul_Int", x, x),
+		},
+	}, body)
+}
+
+func TestFunctionInliner_NestedCalls(t *testing.T) {
+	// sq(sq(y)) inlines both calls
+	_, count := inlineProgram(t, sqCall(sqCall(&core.Var{Name: "y"})))
+	assert.Equal(t, 2, count)
+}
+
+func TestFunctionInliner_Conservative(t *testing.T) {
+	y := &core.Var{Name: "y"}
+	tests := []struct {
+		name string
+		expr core.CoreExpr
+	}{
+		{"partial application", &core.App{Func: &core.Var{Name: "sq"}, Args: []core.CoreExpr{}}},
+		{"shadowed function", &core.Let{
+			Name: "sq", Value: &core.Lambda{Params: []string{"z"}, Body: &core.Var{Name: "z"}},
+			Body: sqCall(y),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, count := inlineProgram(t, tt.expr)
+			assert.Equal(t, 0, count)
+			assert.Equal(t, tt.expr, body)
+		})
+	}
+}
+
+func TestFunctionInliner_ShadowedFreeName(t *testing.T) {
+	// let k = 2 in let scale = λx. mul_Int(x, k) in λk. scale(k)
+	// Inlining would make the body's k refer to the lambda's parameter
+	k := &core.Var{Name: "k"}
+	call := &core.App{Func: &core.Var{Name: "scale"}, Args: []core.CoreExpr{k}}
+	decl := &core.Let{
+		Name:  "k",
+		Value: intLit(2),
+		Body: &core.Let{
+			Name:  "scale",
+			Value: &core.Lambda{Params: []string{"x"}, Body: builtinCall("mul_Int", &core.Var{Name: "x"}, k)},
+			Body:  &core.Lambda{Params: []string{"k"}, Body: call},
+		},
+	}
+	prog := &core.Program{
+		Decls: []core.CoreExpr{decl},
+		Meta:  map[string]*core.DeclMeta{"scale": {Name: "scale", IsInline: true}},
+	}
+
+	inliner := NewFunctionInliner()
+	inlined := inliner.Inline(prog)
+	assert.Equal(t, 0, inliner.Inlined())
+	assert.Equal(t, decl, inlined.Decls[0])
+}
+
+func TestFunctionInliner_NoInlineFunctions(t *testing.T) {
+	prog := &core.Program{Decls: []core.CoreExpr{sqCall(intLit(2))}}
+	inliner := NewFunctionInliner()
+	assert.Same(t, prog, inliner.Inline(prog))
+	assert.Equal(t, 0, inliner.Inlined())
+}
+
+func TestCheck_InlineRecursive(t *testing.T) {
+	code := `@inline
+func fact(n: int) -> int = if n <= 1 then 1 else n * fact(n - 1)
+`
+	_, err := checkModuleSource(t, "inline_rec", code)
+	if err == nil || !strings.Contains(err.Error(), "@inline function fact is recursive") {
+		t.Errorf("expected recursive @inline function to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCheck_InlineTests verifies that inline test assertions are type-checked
+// as bool and stay out of the module interface
+func TestCheck_InlineTests(t *testing.T) {
+	code := `export func double(x: int) -> int { x * 2 } tests [double(2) == 4, double(0) == 0]
+`
+	result, err := checkModuleSource(t, "inline_tests", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Interface.Exports) != 1 {
+		t.Errorf("expected only double to be exported, got %v", result.Interface.Exports)
+	}
+
+	code = `export func greet(s: string) -> string = s tests ["hello"]
+`
+	_, err = checkModuleSource(t, "inline_tests_bad", code)
+	if err == nil || !strings.Contains(err.Error(), "string vs bool") {
+		t.Errorf("expected a non-bool assertion to be rejected, got %v", err)
+	}
+
+	code = `export func f(x: int) -> int = x tests [f(1) == missing]
+`
+	_, err = checkModuleSource(t, "inline_tests_scope", code)
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected an undefined name in a test to be reported, got %v", err)
+	}
+}
+
+// TestCheck_Properties verifies that property bodies are type-checked as bool
+// with the forall binders in scope
+func TestCheck_Properties(t *testing.T) {
+	code := `export func abs(x: int) -> int = if x < 0 then 0 - x else x
+  properties [forall(x: int) => abs(x) >= 0, forall(s: string, b: bool) => b || s == s]
+`
+	result, err := checkModuleSource(t, "properties", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Interface.Exports) != 1 {
+		t.Errorf("expected only abs to be exported, got %v", result.Interface.Exports)
+	}
+
+	code = `export func id(x: int) -> int = x properties [forall(x: int) => x + 1]
+`
+	_, err = checkModuleSource(t, "properties_bad", code)
+	if err == nil || !strings.Contains(err.Error(), "bool") {
+		t.Errorf("expected a non-bool property to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/iface"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// moduleInstanceEnv builds the InstanceEnv used to type check one module.
+//
+// It contains the base (builtin) instances, the instances the module declares
+// itself, and every instance declared by the modules it imports directly.
+// Instances of transitive imports are not merged. Instances are not selected
+// by name: importing a module is enough to bring them into scope.
+// The base environment is returned unchanged when nothing needs adding.
+func moduleInstanceEnv(base *types.InstanceEnv, modID string, file *ast.File, deps []*iface.Iface) (*types.InstanceEnv, error) {
+	_, own := iface.ClassesAndInstances(modID, file)
+
+	var imported []*iface.InstanceExport
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		if seen[dep.Module] {
+			continue
+		}
+		seen[dep.Module] = true
+		imported = append(imported, dep.Instances...)
+	}
+
+	if len(own) == 0 && len(imported) == 0 {
+		return base, checkDerives(base, modID, file)
+	}
+
+	env := base.Clone()
+	for _, inst := range append(own, imported...) {
+		if err := env.Add(inst.ToClassInstance()); err != nil {
+			return nil, fmt.Errorf("in module %s: instance %s[%s] declared in %s: %w",
+				modID, inst.ClassName, types.NormalizeTypeName(inst.TypeHead), inst.Module, err)
+		}
+	}
+	if err := checkDerives(env, modID, file); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// checkDerives verifies the derive clauses of the module's type declarations:
+// each class must be derivable, and every constructor field must already have
+// an instance of it. Fields of a type parameter are checked where the type is
+// applied, since the instance only exists for arguments that have one.
+func checkDerives(env *types.InstanceEnv, modID string, file *ast.File) error {
+	if file == nil {
+		return nil
+	}
+	seen := make(map[ast.Node]bool)
+	for _, node := range append(append([]ast.Node{}, file.Decls...), file.Statements...) {
+		decl, ok := node.(*ast.TypeDecl)
+		if !ok || seen[node] || len(decl.Derive) == 0 {
+			continue
+		}
+		seen[node] = true
+
+		alg, ok := decl.Definition.(*ast.AlgebraicType)
+		if !ok {
+			continue
+		}
+		for _, class := range decl.Derive {
+			if _, ok := types.DerivedInstance(class, nil); !ok {
+				return fmt.Errorf("in module %s: cannot derive %s for %s: only Show, Eq and Ord can be derived",
+					modID, class, decl.Name)
+			}
+			for _, ctor := range alg.Constructors {
+				for i, field := range ctor.Fields {
+					fieldType := types.TypeFromAST(field)
+					if _, isParam := fieldType.(*types.TVar2); isParam {
+						continue
+					}
+					if _, err := env.Lookup(class, fieldType); err != nil {
+						return fmt.Errorf("in module %s: cannot derive %s for %s: field %d of %s has type %s, which has no %s instance",
+							modID, class, decl.Name, i+1, ctor.Name, field, class)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
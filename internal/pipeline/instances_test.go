@@ -0,0 +1,157 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/iface"
+	"github.com/sunholo/ailang/internal/lexer"
+	"github.com/sunholo/ailang/internal/parser"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+func depWithInstance(module, class string, head types.Type) *iface.Iface {
+	dep := iface.NewIface(module)
+	dep.AddInstance(&iface.InstanceExport{
+		ClassName: class,
+		TypeHead:  head,
+		Module:    module,
+		Methods:   map[string]string{"show": types.MakeDictionaryKey(module, class, head, "show")},
+	})
+	return dep
+}
+
+func TestModuleInstanceEnv_ImportsInstances(t *testing.T) {
+	base := types.LoadBuiltinInstances()
+	point := &types.TCon{Name: "Point"}
+	dep := depWithInstance("geo/point", "Show", point)
+
+	env, err := moduleInstanceEnv(base, "app/main", &ast.File{}, []*iface.Iface{dep, dep})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := env.Lookup("Show", point); err != nil {
+		t.Errorf("expected imported Show[Point]: %v", err)
+	}
+	if _, err := env.Lookup("Show", types.TInt); err != nil {
+		t.Errorf("expected builtin Show[Int] to remain: %v", err)
+	}
+
+	// The shared base environment must not see module-local instances
+	if _, err := base.Lookup("Show", point); err == nil {
+		t.Error("imported instance leaked into the base environment")
+	}
+}
+
+func TestModuleInstanceEnv_NoInstancesReusesBase(t *testing.T) {
+	base := types.LoadBuiltinInstances()
+	env, err := moduleInstanceEnv(base, "app/main", &ast.File{}, []*iface.Iface{iface.NewIface("other")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env != base {
+		t.Error("expected base environment to be reused")
+	}
+}
+
+func TestModuleInstanceEnv_Overlap(t *testing.T) {
+	dep := depWithInstance("my/show", "Show", types.TInt)
+
+	_, err := moduleInstanceEnv(types.LoadBuiltinInstances(), "app/main", &ast.File{}, []*iface.Iface{dep})
+	if err == nil {
+		t.Fatal("expected overlapping instance error")
+	}
+	if !strings.Contains(err.Error(), "overlapping instance") || !strings.Contains(err.Error(), "my/show") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestRun_NoPrelude verifies that --no-prelude starts without the builtin
+// instances, so arithmetic fails with a missing-instance error
+func TestRun_NoPrelude(t *testing.T) {
+	check := func(noPrelude bool) error {
+		path := filepath.Join(t.TempDir(), "sum.ail")
+		code := "1 + 2"
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Run(Config{Mode: ModeCheck, NoPrelude: noPrelude}, Source{Code: code, Filename: path})
+		return err
+	}
+
+	if err := check(false); err != nil {
+		t.Fatalf("expected program to check with the prelude, got %v", err)
+	}
+	err := check(true)
+	if err == nil || !strings.Contains(err.Error(), "No instance for Num[int]") {
+		t.Errorf("expected missing Num[int] instance without the prelude, got %v", err)
+	}
+}
+
+func TestModuleInstanceEnv_Derive(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{"fields_supported", "type Color = Red | Green derive(Show, Eq)\ntype Shape = Dot(int, Color) derive(Eq)", ""},
+		{"recursive", "type Nums = Nil | Cons(int, Nums) derive(Ord)", ""},
+		{"type_parameter", "type Box[a] = Box(a) | Empty derive(Eq)", ""},
+		{"not_derivable", "type Color = Red | Green derive(Num)", "cannot derive Num for Color: only Show, Eq and Ord can be derived"},
+		{"field_without_instance", "type Bag = Bag([int]) derive(Eq)", "cannot derive Eq for Bag: field 1 of Bag has type [int], which has no Eq instance"},
+		{"field_not_derived", "type Color = Red | Green\ntype Shape = Dot(int, Color) derive(Ord)", "field 2 of Dot has type Color, which has no Ord instance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.New(lexer.New(tt.source, "test.ail"))
+			file := p.ParseFile()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("parse errors: %v", p.Errors())
+			}
+
+			_, err := moduleInstanceEnv(types.LoadBuiltinInstances(), "app/main", file, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestRun_ImportsDerivedInstances checks the one source-level path for
+// exported instances: a derive clause, seen by a module importing the type
+func TestRun_ImportsDerivedInstances(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	if err := os.MkdirAll("lib", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"lib/color.ail": "module lib/color\n\nexport type Color = Red | Green derive(Eq, Ord)\n",
+		"app.ail":       "module app\n\nimport lib/color (Red, Green)\n\nexport func main() -> bool = Red == Red && Red < Green\n",
+	}
+	for path, code := range files {
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Run(Config{Mode: ModeCheck}, Source{Code: files["app.ail"], Filename: "app.ail"}); err != nil {
+		t.Fatalf("expected the imported derived instances to be in scope, got %v", err)
+	}
+}
@@ -0,0 +1,596 @@
+package pipeline
+
+import (
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// LetInliner removes `let x = v in body` bindings where x is used exactly once
+// in body and v is pure, substituting v at the use site.
+//
+// Only values that cannot fail or perform effects are moved, so evaluation
+// order of effectful code is never changed:
+//   - atomic values (literals, variables, globals, dictionary refs, lambdas)
+//     are inlined anywhere
+//   - operator builtins on atomic arguments and records/lists/tuples of atomic
+//     elements are inlined only where they still run once, i.e. not into a
+//     lambda body. Division and modulo may fail and are never moved.
+//
+// Top-level module bindings are kept, since the runtime looks them up by name.
+type LetInliner struct {
+	inlined int // Number of let bindings removed
+}
+
+// NewLetInliner creates a new let inliner
+func NewLetInliner() *LetInliner {
+	return &LetInliner{}
+}
+
+// Inlined returns how many bindings have been inlined so far
+func (l *LetInliner) Inlined() int {
+	return l.inlined
+}
+
+// Inline returns a copy of prog with single-use pure bindings inlined
+func (l *LetInliner) Inline(prog *core.Program) *core.Program {
+	inlined := &core.Program{
+		Decls: make([]core.CoreExpr, len(prog.Decls)),
+		Meta:  prog.Meta,
+		Flags: prog.Flags,
+	}
+	for i, decl := range prog.Decls {
+		inlined.Decls[i] = l.inlineTopLevel(decl)
+	}
+	return inlined
+}
+
+// inlineTopLevel keeps the module-level Let/LetRec chain and inlines within
+// the bound values
+func (l *LetInliner) inlineTopLevel(expr core.CoreExpr) core.CoreExpr {
+	switch e := expr.(type) {
+	case *core.Let:
+		return &core.Let{CoreNode: e.CoreNode, Name: e.Name, Value: l.inlineExpr(e.Value), Body: l.inlineTopLevel(e.Body)}
+	case *core.LetRec:
+		bindings := make([]core.RecBinding, len(e.Bindings))
+		for i, b := range e.Bindings {
+			bindings[i] = core.RecBinding{Name: b.Name, Value: l.inlineExpr(b.Value)}
+		}
+		return &core.LetRec{CoreNode: e.CoreNode, Bindings: bindings, Body: l.inlineTopLevel(e.Body)}
+	}
+	return l.inlineExpr(expr)
+}
+
+func (l *LetInliner) inlineExpr(expr core.CoreExpr) core.CoreExpr {
+	if expr == nil {
+		return nil
+	}
+
+	switch e := expr.(type) {
+	case *core.Let:
+		value := l.inlineExpr(e.Value)
+		body := l.inlineExpr(e.Body)
+		if kind := inlineKindOf(value); kind != notInlinable && countUses(e.Name, body) == 1 {
+			s := &substitution{name: e.Name, value: value, atomic: kind == atomicValue, free: freeVars(value)}
+			if out, ok := s.apply(body, false); ok {
+				l.inlined++
+				return out
+			}
+		}
+		return &core.Let{CoreNode: e.CoreNode, Name: e.Name, Value: value, Body: body}
+
+	case *core.LetRec:
+		bindings := make([]core.RecBinding, len(e.Bindings))
+		for i, b := range e.Bindings {
+			bindings[i] = core.RecBinding{Name: b.Name, Value: l.inlineExpr(b.Value)}
+		}
+		return &core.LetRec{CoreNode: e.CoreNode, Bindings: bindings, Body: l.inlineExpr(e.Body)}
+
+	case *core.Lambda:
+		return &core.Lambda{CoreNode: e.CoreNode, Params: e.Params, Body: l.inlineExpr(e.Body)}
+
+	case *core.App:
+		return &core.App{CoreNode: e.CoreNode, Func: l.inlineExpr(e.Func), Args: l.inlineExprs(e.Args)}
+
+	case *core.Intrinsic:
+		return &core.Intrinsic{CoreNode: e.CoreNode, Op: e.Op, Args: l.inlineExprs(e.Args)}
+
+	case *core.DictApp:
+		return &core.DictApp{CoreNode: e.CoreNode, Dict: l.inlineExpr(e.Dict), Method: e.Method, Args: l.inlineExprs(e.Args)}
+
+	case *core.DictAbs:
+		return &core.DictAbs{CoreNode: e.CoreNode, Params: e.Params, Body: l.inlineExpr(e.Body)}
+
+	case *core.If:
+		return &core.If{CoreNode: e.CoreNode, Cond: l.inlineExpr(e.Cond), Then: l.inlineExpr(e.Then), Else: l.inlineExpr(e.Else)}
+
+	case *core.Match:
+		arms := make([]core.MatchArm, len(e.Arms))
+		for i, arm := range e.Arms {
+			arms[i] = core.MatchArm{Pattern: arm.Pattern, Guard: l.inlineExpr(arm.Guard), Body: l.inlineExpr(arm.Body)}
+		}
+		return &core.Match{CoreNode: e.CoreNode, Scrutinee: l.inlineExpr(e.Scrutinee), Arms: arms, Exhaustive: e.Exhaustive}
+
+	case *core.Record:
+		fields := make(map[string]core.CoreExpr, len(e.Fields))
+		for k, v := range e.Fields {
+			fields[k] = l.inlineExpr(v)
+		}
+		return &core.Record{CoreNode: e.CoreNode, Fields: fields}
+
+	case *core.RecordAccess:
+		return &core.RecordAccess{CoreNode: e.CoreNode, Record: l.inlineExpr(e.Record), Field: e.Field}
+
+	case *core.RecordUpdate:
+		updates := make(map[string]core.CoreExpr, len(e.Updates))
+		for k, v := range e.Updates {
+			updates[k] = l.inlineExpr(v)
+		}
+		return &core.RecordUpdate{CoreNode: e.CoreNode, Base: l.inlineExpr(e.Base), Updates: updates}
+
+	case *core.List:
+		return &core.List{CoreNode: e.CoreNode, Elements: l.inlineExprs(e.Elements)}
+
+	case *core.Tuple:
+		return &core.Tuple{CoreNode: e.CoreNode, Elements: l.inlineExprs(e.Elements)}
+
+	default:
+		return expr
+	}
+}
+
+func (l *LetInliner) inlineExprs(exprs []core.CoreExpr) []core.CoreExpr {
+	result := make([]core.CoreExpr, len(exprs))
+	for i, e := range exprs {
+		result[i] = l.inlineExpr(e)
+	}
+	return result
+}
+
+// inlineKind classifies how freely a bound value may be moved
+type inlineKind int
+
+const (
+	notInlinable inlineKind = iota
+	atomicValue             // Free to move anywhere
+	pureValue               // Total and pure, but must still run exactly once
+)
+
+// pureOperatorBuiltins are the operator builtins that can neither fail nor
+// perform effects. Division and modulo are excluded because they may fail.
+var pureOperatorBuiltins = func() map[string]bool {
+	names := make(map[string]bool)
+	for op, mapping := range OperatorTable {
+		if op == core.OpDiv || op == core.OpMod {
+			continue
+		}
+		for _, typ := range mapping.Types {
+			names[mapping.Builtin+"_"+typ] = true
+		}
+	}
+	names["fromInt_Float"] = true
+	return names
+}()
+
+func inlineKindOf(value core.CoreExpr) inlineKind {
+	switch v := value.(type) {
+	case *core.Lit, *core.Var, *core.VarGlobal, *core.DictRef, *core.Lambda:
+		return atomicValue
+	case *core.App:
+		ref, ok := v.Func.(*core.VarGlobal)
+		if ok && ref.Ref.Module == "$builtin" && pureOperatorBuiltins[ref.Ref.Name] && allAtomic(v.Args) {
+			return pureValue
+		}
+	case *core.List:
+		if allAtomic(v.Elements) {
+			return pureValue
+		}
+	case *core.Tuple:
+		if allAtomic(v.Elements) {
+			return pureValue
+		}
+	case *core.Record:
+		for _, f := range v.Fields {
+			if !isAtomic(f) {
+				return notInlinable
+			}
+		}
+		return pureValue
+	}
+	return notInlinable
+}
+
+func isAtomic(expr core.CoreExpr) bool {
+	switch expr.(type) {
+	case *core.Lit, *core.Var, *core.VarGlobal, *core.DictRef:
+		return true
+	}
+	return false
+}
+
+func allAtomic(exprs []core.CoreExpr) bool {
+	for _, e := range exprs {
+		if !isAtomic(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// freeVars over-approximates the local names a value refers to
+func freeVars(value core.CoreExpr) map[string]bool {
+	vars := make(map[string]bool)
+	walkCore(value, func(node core.CoreExpr) {
+		if v, ok := node.(*core.Var); ok {
+			vars[v.Name] = true
+		}
+	})
+	return vars
+}
+
+// countUses counts the free occurrences of name in expr
+func countUses(name string, expr core.CoreExpr) int {
+	if expr == nil {
+		return 0
+	}
+
+	switch e := expr.(type) {
+	case *core.Var:
+		if e.Name == name {
+			return 1
+		}
+		return 0
+
+	case *core.Let:
+		n := countUses(name, e.Value)
+		if e.Name != name {
+			n += countUses(name, e.Body)
+		}
+		return n
+
+	case *core.LetRec:
+		for _, b := range e.Bindings {
+			if b.Name == name {
+				return 0
+			}
+		}
+		n := countUses(name, e.Body)
+		for _, b := range e.Bindings {
+			n += countUses(name, b.Value)
+		}
+		return n
+
+	case *core.Lambda:
+		if containsName(e.Params, name) {
+			return 0
+		}
+		return countUses(name, e.Body)
+
+	case *core.App:
+		return countUses(name, e.Func) + countUsesIn(name, e.Args)
+
+	case *core.Intrinsic:
+		return countUsesIn(name, e.Args)
+
+	case *core.DictApp:
+		return countUses(name, e.Dict) + countUsesIn(name, e.Args)
+
+	case *core.DictAbs:
+		for _, p := range e.Params {
+			if p.Name == name {
+				return 0
+			}
+		}
+		return countUses(name, e.Body)
+
+	case *core.If:
+		return countUses(name, e.Cond) + countUses(name, e.Then) + countUses(name, e.Else)
+
+	case *core.Match:
+		n := countUses(name, e.Scrutinee)
+		for _, arm := range e.Arms {
+			if !containsName(patternNames(arm.Pattern), name) {
+				n += countUses(name, arm.Guard) + countUses(name, arm.Body)
+			}
+		}
+		return n
+
+	case *core.Record:
+		n := 0
+		for _, v := range e.Fields {
+			n += countUses(name, v)
+		}
+		return n
+
+	case *core.RecordAccess:
+		return countUses(name, e.Record)
+
+	case *core.RecordUpdate:
+		n := countUses(name, e.Base)
+		for _, v := range e.Updates {
+			n += countUses(name, v)
+		}
+		return n
+
+	case *core.List:
+		return countUsesIn(name, e.Elements)
+
+	case *core.Tuple:
+		return countUsesIn(name, e.Elements)
+	}
+
+	return 0
+}
+
+func countUsesIn(name string, exprs []core.CoreExpr) int {
+	n := 0
+	for _, e := range exprs {
+		n += countUses(name, e)
+	}
+	return n
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// patternNames returns the variables bound by a match pattern
+func patternNames(p core.CorePattern) []string {
+	var names []string
+	var collect func(core.CorePattern)
+	collect = func(p core.CorePattern) {
+		switch pat := p.(type) {
+		case *core.VarPattern:
+			names = append(names, pat.Name)
+		case *core.AsPattern:
+			names = append(names, pat.Name)
+			collect(pat.Pattern)
+		case *core.OrPattern:
+			// Alternatives bind the same names
+			for _, alt := range pat.Alternatives {
+				collect(alt)
+			}
+		case *core.ConstructorPattern:
+			for _, arg := range pat.Args {
+				collect(arg)
+			}
+		case *core.ListPattern:
+			for _, elem := range pat.Elements {
+				collect(elem)
+			}
+			if pat.Tail != nil {
+				collect(*pat.Tail)
+			}
+		case *core.RecordPattern:
+			for _, field := range pat.Fields {
+				collect(field)
+			}
+		case *core.TuplePattern:
+			for _, elem := range pat.Elements {
+				collect(elem)
+			}
+		}
+	}
+	collect(p)
+	return names
+}
+
+// substitution replaces the single use of name with value
+type substitution struct {
+	name   string
+	value  core.CoreExpr
+	atomic bool            // Value may be moved under a lambda
+	free   map[string]bool // Names the value refers to
+}
+
+// apply rebuilds expr with the use of s.name replaced. It reports false if
+// the use sits under a binder capturing one of the value's variables, or a
+// non-atomic value would move into a lambda body.
+func (s *substitution) apply(expr core.CoreExpr, underLambda bool) (core.CoreExpr, bool) {
+	if expr == nil || countUses(s.name, expr) == 0 {
+		return expr, true
+	}
+
+	switch e := expr.(type) {
+	case *core.Var:
+		if !s.atomic && underLambda {
+			return nil, false
+		}
+		return s.value, true
+
+	case *core.Let:
+		value, ok := s.apply(e.Value, underLambda)
+		if !ok {
+			return nil, false
+		}
+		body := e.Body
+		if e.Name != s.name && countUses(s.name, e.Body) > 0 {
+			if s.free[e.Name] {
+				return nil, false
+			}
+			if body, ok = s.apply(e.Body, underLambda); !ok {
+				return nil, false
+			}
+		}
+		return &core.Let{CoreNode: e.CoreNode, Name: e.Name, Value: value, Body: body}, true
+
+	case *core.LetRec:
+		bindings := make([]core.RecBinding, len(e.Bindings))
+		for i, b := range e.Bindings {
+			if s.free[b.Name] {
+				return nil, false
+			}
+			value, ok := s.apply(b.Value, true)
+			if !ok {
+				return nil, false
+			}
+			bindings[i] = core.RecBinding{Name: b.Name, Value: value}
+		}
+		body, ok := s.apply(e.Body, underLambda)
+		if !ok {
+			return nil, false
+		}
+		return &core.LetRec{CoreNode: e.CoreNode, Bindings: bindings, Body: body}, true
+
+	case *core.Lambda:
+		if s.captures(e.Params) {
+			return nil, false
+		}
+		body, ok := s.apply(e.Body, true)
+		if !ok {
+			return nil, false
+		}
+		return &core.Lambda{CoreNode: e.CoreNode, Params: e.Params, Body: body}, true
+
+	case *core.App:
+		fn, ok := s.apply(e.Func, underLambda)
+		if !ok {
+			return nil, false
+		}
+		args, ok := s.applyAll(e.Args, underLambda)
+		if !ok {
+			return nil, false
+		}
+		return &core.App{CoreNode: e.CoreNode, Func: fn, Args: args}, true
+
+	case *core.Intrinsic:
+		args, ok := s.applyAll(e.Args, underLambda)
+		if !ok {
+			return nil, false
+		}
+		return &core.Intrinsic{CoreNode: e.CoreNode, Op: e.Op, Args: args}, true
+
+	case *core.DictApp:
+		dict, ok := s.apply(e.Dict, underLambda)
+		if !ok {
+			return nil, false
+		}
+		args, ok := s.applyAll(e.Args, underLambda)
+		if !ok {
+			return nil, false
+		}
+		return &core.DictApp{CoreNode: e.CoreNode, Dict: dict, Method: e.Method, Args: args}, true
+
+	case *core.DictAbs:
+		for _, p := range e.Params {
+			if s.free[p.Name] {
+				return nil, false
+			}
+		}
+		body, ok := s.apply(e.Body, true)
+		if !ok {
+			return nil, false
+		}
+		return &core.DictAbs{CoreNode: e.CoreNode, Params: e.Params, Body: body}, true
+
+	case *core.If:
+		cond, ok1 := s.apply(e.Cond, underLambda)
+		then, ok2 := s.apply(e.Then, underLambda)
+		els, ok3 := s.apply(e.Else, underLambda)
+		if !ok1 || !ok2 || !ok3 {
+			return nil, false
+		}
+		return &core.If{CoreNode: e.CoreNode, Cond: cond, Then: then, Else: els}, true
+
+	case *core.Match:
+		scrutinee, ok := s.apply(e.Scrutinee, underLambda)
+		if !ok {
+			return nil, false
+		}
+		arms := make([]core.MatchArm, len(e.Arms))
+		for i, arm := range e.Arms {
+			arms[i] = arm
+			names := patternNames(arm.Pattern)
+			if containsName(names, s.name) {
+				continue
+			}
+			if countUses(s.name, arm.Guard)+countUses(s.name, arm.Body) == 0 {
+				continue
+			}
+			if s.captures(names) {
+				return nil, false
+			}
+			guard, ok1 := s.apply(arm.Guard, underLambda)
+			body, ok2 := s.apply(arm.Body, underLambda)
+			if !ok1 || !ok2 {
+				return nil, false
+			}
+			arms[i] = core.MatchArm{Pattern: arm.Pattern, Guard: guard, Body: body}
+		}
+		return &core.Match{CoreNode: e.CoreNode, Scrutinee: scrutinee, Arms: arms, Exhaustive: e.Exhaustive}, true
+
+	case *core.Record:
+		fields := make(map[string]core.CoreExpr, len(e.Fields))
+		for k, v := range e.Fields {
+			field, ok := s.apply(v, underLambda)
+			if !ok {
+				return nil, false
+			}
+			fields[k] = field
+		}
+		return &core.Record{CoreNode: e.CoreNode, Fields: fields}, true
+
+	case *core.RecordAccess:
+		record, ok := s.apply(e.Record, underLambda)
+		if !ok {
+			return nil, false
+		}
+		return &core.RecordAccess{CoreNode: e.CoreNode, Record: record, Field: e.Field}, true
+
+	case *core.RecordUpdate:
+		base, ok := s.apply(e.Base, underLambda)
+		if !ok {
+			return nil, false
+		}
+		updates := make(map[string]core.CoreExpr, len(e.Updates))
+		for k, v := range e.Updates {
+			update, ok := s.apply(v, underLambda)
+			if !ok {
+				return nil, false
+			}
+			updates[k] = update
+		}
+		return &core.RecordUpdate{CoreNode: e.CoreNode, Base: base, Updates: updates}, true
+
+	case *core.List:
+		elems, ok := s.applyAll(e.Elements, underLambda)
+		if !ok {
+			return nil, false
+		}
+		return &core.List{CoreNode: e.CoreNode, Elements: elems}, true
+
+	case *core.Tuple:
+		elems, ok := s.applyAll(e.Elements, underLambda)
+		if !ok {
+			return nil, false
+		}
+		return &core.Tuple{CoreNode: e.CoreNode, Elements: elems}, true
+	}
+
+	return nil, false
+}
+
+func (s *substitution) applyAll(exprs []core.CoreExpr, underLambda bool) ([]core.CoreExpr, bool) {
+	result := make([]core.CoreExpr, len(exprs))
+	for i, e := range exprs {
+		out, ok := s.apply(e, underLambda)
+		if !ok {
+			return nil, false
+		}
+		result[i] = out
+	}
+	return result, true
+}
+
+// captures reports whether binding names would shadow a variable of the value
+func (s *substitution) captures(names []string) bool {
+	for _, n := range names {
+		if s.free[n] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+func inlineOne(t *testing.T, expr core.CoreExpr) (core.CoreExpr, int) {
+	t.Helper()
+	inliner := NewLetInliner()
+	prog := inliner.Inline(&core.Program{Decls: []core.CoreExpr{&core.Lambda{Params: []string{"x"}, Body: expr}}})
+	require.Len(t, prog.Decls, 1)
+	lam, ok := prog.Decls[0].(*core.Lambda)
+	require.True(t, ok)
+	return lam.Body, inliner.Inlined()
+}
+
+func printCall(arg core.CoreExpr) *core.App {
+	return &core.App{Func: &core.VarGlobal{Ref: core.GlobalRef{Module: "std/io", Name: "println"}}, Args: []core.CoreExpr{arg}}
+}
+
+func TestLetInliner_PureTemporaries(t *testing.T) {
+	// let $tmp1 = mul_Int(x, 4) in let $tmp2 = [$tmp1] in f($tmp2)
+	f := &core.Var{Name: "f"}
+	expr := &core.Let{
+		Name:  "$tmp1",
+		Value: builtinCall("mul_Int", &core.Var{Name: "x"}, intLit(4)),
+		Body: &core.Let{
+			Name:  "$tmp2",
+			Value: &core.List{Elements: []core.CoreExpr{&core.Var{Name: "$tmp1"}}},
+			Body:  &core.App{Func: f, Args: []core.CoreExpr{&core.Var{Name: "$tmp2"}}},
+		},
+	}
+
+	inlined, count := inlineOne(t, expr)
+	assert.Equal(t, 2, count)
+	list := &core.List{Elements: []core.CoreExpr{builtinCall("mul_Int", &core.Var{Name: "x"}, intLit(4))}}
+	assert.Equal(t, &core.App{Func: f, Args: []core.CoreExpr{list}}, inlined)
+
+	// The input program is not modified
+	assert.IsType(t, &core.Let{}, expr.Body)
+}
+
+func TestLetInliner_AtomicValues(t *testing.T) {
+	// let y = x in let z = 1 in println(add_Int(y, z))
+	expr := &core.Let{
+		Name:  "y",
+		Value: &core.Var{Name: "x"},
+		Body: &core.Let{
+			Name:  "z",
+			Value: intLit(1),
+			Body:  printCall(builtinCall("add_Int", &core.Var{Name: "y"}, &core.Var{Name: "z"})),
+		},
+	}
+
+	inlined, count := inlineOne(t, expr)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, printCall(builtinCall("add_Int", &core.Var{Name: "x"}, intLit(1))), inlined)
+}
+
+func TestLetInliner_Conservative(t *testing.T) {
+	x := &core.Var{Name: "x"}
+	tests := []struct {
+		name string
+		expr core.CoreExpr
+	}{
+		{"effectful value", &core.Let{
+			Name: "$tmp1", Value: printCall(stringLit("a")),
+			Body: printCall(&core.Var{Name: "$tmp1"}),
+		}},
+		{"used twice", &core.Let{
+			Name: "y", Value: intLit(1),
+			Body: builtinCall("add_Int", &core.Var{Name: "y"}, &core.Var{Name: "y"}),
+		}},
+		{"unused", &core.Let{
+			Name: "y", Value: builtinCall("add_Int", x, x),
+			Body: intLit(0),
+		}},
+		{"division may fail", &core.Let{
+			Name: "y", Value: builtinCall("div_Int", intLit(1), x),
+			Body: printCall(&core.Var{Name: "y"}),
+		}},
+		{"pure value into lambda", &core.Let{
+			Name: "y", Value: builtinCall("add_Int", x, x),
+			Body: &core.Lambda{Params: []string{"z"}, Body: &core.Var{Name: "y"}},
+		}},
+		{"captured variable", &core.Let{
+			Name: "y", Value: x,
+			Body: &core.Lambda{Params: []string{"x"}, Body: &core.Var{Name: "y"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inlined, count := inlineOne(t, tt.expr)
+			assert.Equal(t, 0, count)
+			assert.Equal(t, tt.expr, inlined)
+		})
+	}
+}
+
+func TestLetInliner_ShadowedUse(t *testing.T) {
+	// let y = 1 in (let y = 2 in y) — the inner y is a different binding
+	expr := &core.Let{
+		Name:  "y",
+		Value: intLit(1),
+		Body:  &core.Let{Name: "y", Value: intLit(2), Body: &core.Var{Name: "y"}},
+	}
+
+	inlined, count := inlineOne(t, expr)
+	assert.Equal(t, 1, count) // Only the inner binding is used
+	assert.Equal(t, &core.Let{Name: "y", Value: intLit(1), Body: intLit(2)}, inlined)
+}
+
+func TestLetInliner_KeepsTopLevelBindings(t *testing.T) {
+	// let helper = λx. x in helper
+	decl := &core.Let{
+		Name:  "helper",
+		Value: &core.Lambda{Params: []string{"x"}, Body: &core.Var{Name: "x"}},
+		Body:  &core.Var{Name: "helper"},
+	}
+
+	inliner := NewLetInliner()
+	prog := inliner.Inline(&core.Program{Decls: []core.CoreExpr{decl}})
+	assert.Equal(t, 0, inliner.Inlined())
+	assert.Equal(t, decl, prog.Decls[0])
+}
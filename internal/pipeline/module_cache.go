@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"github.com/sunholo/ailang/internal/loader"
+)
+
+// ModuleCache carries loaded and compiled modules from one Run to the next,
+// so that checking many files parses and compiles each shared import once.
+//
+// The root module of a run is always recompiled (its warnings and defaulting
+// decisions belong to that run); only its dependencies are taken from the
+// cache. A cache is meant for check runs: cached Core is shared, not copied.
+type ModuleCache struct {
+	loader *loader.ModuleLoader
+	units  map[string]*CompileUnit
+}
+
+// NewModuleCache creates an empty module cache
+func NewModuleCache() *ModuleCache {
+	return &ModuleCache{
+		loader: loader.NewModuleLoader("."),
+		units:  make(map[string]*CompileUnit),
+	}
+}
+
+// moduleLoader returns the shared loader, or a fresh one without a cache
+func (c *ModuleCache) moduleLoader() *loader.ModuleLoader {
+	if c == nil {
+		return loader.NewModuleLoader(".")
+	}
+	return c.loader
+}
+
+// lookup returns the compiled unit for a module from an earlier run
+func (c *ModuleCache) lookup(modID string) (*CompileUnit, bool) {
+	if c == nil {
+		return nil, false
+	}
+	unit, ok := c.units[modID]
+	return unit, ok
+}
+
+// store records a successfully compiled unit
+func (c *ModuleCache) store(unit *CompileUnit) {
+	if c != nil {
+		c.units[unit.ID] = unit
+	}
+}
@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/loader"
+)
+
+// TestModuleCache_SharesDependencies verifies that runs sharing a cache
+// compile a common import once and still recompile each root
+func TestModuleCache_SharesDependencies(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	dir := t.TempDir()
+	cache := NewModuleCache()
+
+	check := func(name string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		code := "import std/option (Some, getOrElse)\n\ngetOrElse(Some(1), 0)\n"
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Run(Config{Mode: ModeCheck, Cache: cache}, Source{Code: code, Filename: path}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	check("a.ail")
+	option, ok := cache.lookup("std/option")
+	if !ok {
+		t.Fatal("std/option was not cached")
+	}
+	rootID := loader.CanonicalModuleID(filepath.Join(dir, "a.ail"))
+	rootA, ok := cache.lookup(rootID)
+	if !ok {
+		t.Fatal("root module was not cached")
+	}
+
+	check("b.ail")
+	if again, _ := cache.lookup("std/option"); again != option {
+		t.Error("std/option was recompiled despite the cache")
+	}
+	check("a.ail")
+	if again, _ := cache.lookup(rootID); again == rootA {
+		t.Error("root module was taken from the cache")
+	}
+}
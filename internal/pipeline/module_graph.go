@@ -0,0 +1,155 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/elaborate"
+	"github.com/sunholo/ailang/internal/link"
+	"github.com/sunholo/ailang/internal/loader"
+)
+
+// ModuleGraph is the import graph of the modules reachable from a root
+type ModuleGraph struct {
+	Root    link.ModuleID
+	Modules []link.ModuleID                   // Sorted
+	Imports map[link.ModuleID][]link.ModuleID // Module -> modules it imports, sorted
+}
+
+// BuildModuleGraph builds the import graph from the modules loaded for a root
+// (see loader.LoadAll). Unlike link.TopoSortFromRoot it accepts import cycles, so
+// that they can be shown.
+func BuildModuleGraph(root string, loaded map[string]*loader.LoadedModule) *ModuleGraph {
+	g := &ModuleGraph{
+		Root:    graphModuleID(root),
+		Imports: make(map[link.ModuleID][]link.ModuleID),
+	}
+	// The loader may hold a module under both its std/* and legacy
+	// stdlib/std/* spelling; both become one node
+	edges := make(map[link.ModuleID]map[link.ModuleID]bool)
+	for path, mod := range loaded {
+		from := graphModuleID(path)
+		if edges[from] == nil {
+			edges[from] = make(map[link.ModuleID]bool)
+		}
+		for _, imp := range mod.Imports {
+			to := graphModuleID(imp)
+			edges[from][to] = true
+			if edges[to] == nil {
+				edges[to] = make(map[link.ModuleID]bool)
+			}
+		}
+	}
+
+	for m, deps := range edges {
+		g.Modules = append(g.Modules, m)
+		for dep := range deps {
+			g.Imports[m] = append(g.Imports[m], dep)
+		}
+		sort.Slice(g.Imports[m], func(i, j int) bool { return g.Imports[m][i] < g.Imports[m][j] })
+	}
+	sort.Slice(g.Modules, func(i, j int) bool { return g.Modules[i] < g.Modules[j] })
+	return g
+}
+
+// graphModuleID returns the canonical module ID of an import path
+func graphModuleID(path string) link.ModuleID {
+	id := loader.CanonicalModuleID(path)
+	if strings.HasPrefix(id, "stdlib/std/") {
+		id = strings.TrimPrefix(id, "stdlib/")
+	}
+	return link.ModuleID(id)
+}
+
+// Cycles returns the groups of modules that import each other, found as the
+// strongly connected components of the graph. Each group and the list of
+// groups are sorted; a module that imports itself is a group of one.
+func (g *ModuleGraph) Cycles() [][]link.ModuleID {
+	calls := elaborate.NewCallGraph()
+	for _, m := range g.Modules {
+		calls.AddNode(string(m))
+	}
+	for _, m := range g.Modules {
+		for _, dep := range g.Imports[m] {
+			calls.AddEdge(string(m), string(dep))
+		}
+	}
+
+	var cycles [][]link.ModuleID
+	for _, scc := range calls.SCCs() {
+		if len(scc) == 1 && !g.imports(link.ModuleID(scc[0]), link.ModuleID(scc[0])) {
+			continue
+		}
+		group := make([]link.ModuleID, len(scc))
+		for i, m := range scc {
+			group[i] = link.ModuleID(m)
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i] < group[j] })
+		cycles = append(cycles, group)
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+func (g *ModuleGraph) imports(from, to link.ModuleID) bool {
+	for _, dep := range g.Imports[from] {
+		if dep == to {
+			return true
+		}
+	}
+	return false
+}
+
+// DOT renders the graph in Graphviz DOT format. Each import cycle is drawn as
+// a red cluster, standard library modules are grey and the root is bold.
+func (g *ModuleGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph modules {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+
+	inCycle := make(map[link.ModuleID]bool)
+	for i, group := range g.Cycles() {
+		fmt.Fprintf(&b, "  subgraph cluster_cycle%d {\n", i)
+		b.WriteString("    label=\"import cycle\";\n    color=red;\n    fontcolor=red;\n")
+		for _, m := range group {
+			inCycle[m] = true
+			fmt.Fprintf(&b, "    %s%s;\n", dotQuote(string(m)), g.nodeAttrs(m))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, m := range g.Modules {
+		if !inCycle[m] {
+			fmt.Fprintf(&b, "  %s%s;\n", dotQuote(string(m)), g.nodeAttrs(m))
+		}
+	}
+
+	for _, m := range g.Modules {
+		for _, dep := range g.Imports[m] {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(string(m)), dotQuote(string(dep)))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// nodeAttrs returns the DOT attribute list for a module node
+func (g *ModuleGraph) nodeAttrs(m link.ModuleID) string {
+	var attrs []string
+	if m == g.Root {
+		attrs = append(attrs, "style=bold")
+	}
+	if strings.HasPrefix(string(m), "std/") {
+		attrs = append(attrs, "color=gray50", "fontcolor=gray50")
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+// dotQuote quotes a module path as a DOT identifier
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
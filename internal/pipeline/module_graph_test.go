@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/link"
+	"github.com/sunholo/ailang/internal/loader"
+)
+
+func loadedGraph(imports map[string][]string) map[string]*loader.LoadedModule {
+	loaded := make(map[string]*loader.LoadedModule)
+	for path, deps := range imports {
+		loaded[path] = &loader.LoadedModule{Path: path, Imports: deps}
+	}
+	return loaded
+}
+
+func TestBuildModuleGraph(t *testing.T) {
+	loaded := loadedGraph(map[string][]string{
+		"app/main":          {"app/util", "std/io", "app/util"},
+		"app/util":          {"stdlib/std/list"},
+		"std/list":          {"std/option"},
+		"stdlib/std/list":   {"stdlib/std/option"},
+		"std/io":            nil,
+		"stdlib/std/option": nil,
+	})
+	g := BuildModuleGraph("app/main.ail", loaded)
+
+	if g.Root != "app/main" {
+		t.Errorf("Root = %s, want app/main", g.Root)
+	}
+	wantModules := []link.ModuleID{"app/main", "app/util", "std/io", "std/list", "std/option"}
+	if !reflect.DeepEqual(g.Modules, wantModules) {
+		t.Errorf("Modules = %v, want %v", g.Modules, wantModules)
+	}
+	if got := g.Imports["app/main"]; !reflect.DeepEqual(got, []link.ModuleID{"app/util", "std/io"}) {
+		t.Errorf("Imports[app/main] = %v", got)
+	}
+	if got := g.Imports["std/list"]; !reflect.DeepEqual(got, []link.ModuleID{"std/option"}) {
+		t.Errorf("legacy stdlib/std spelling not merged: Imports[std/list] = %v", got)
+	}
+	if cycles := g.Cycles(); len(cycles) != 0 {
+		t.Errorf("expected no cycles, got %v", cycles)
+	}
+}
+
+func TestModuleGraphCycles(t *testing.T) {
+	g := BuildModuleGraph("main", loadedGraph(map[string][]string{
+		"main": {"a"},
+		"a":    {"b"},
+		"b":    {"c", "a"},
+		"c":    {"a"},
+		"self": {"self"},
+	}))
+
+	want := [][]link.ModuleID{{"a", "b", "c"}, {"self"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+}
+
+func TestModuleGraphDOT(t *testing.T) {
+	g := BuildModuleGraph("main", loadedGraph(map[string][]string{
+		"main": {"a", "std/io"},
+		"a":    {"b"},
+		"b":    {"a"},
+	}))
+
+	want := `digraph modules {
+  rankdir=LR;
+  node [shape=box, fontname="Helvetica"];
+  subgraph cluster_cycle0 {
+    label="import cycle";
+    color=red;
+    fontcolor=red;
+    "a";
+    "b";
+  }
+  "main" [style=bold];
+  "std/io" [color=gray50, fontcolor=gray50];
+  "a" -> "b";
+  "b" -> "a";
+  "main" -> "a";
+  "main" -> "std/io";
+}
+`
+	if got := g.DOT(); got != want {
+		t.Errorf("DOT() =\n%s\nwant:\n%s", got, want)
+	}
+	if got := dotQuote(`odd"name`); !strings.Contains(got, `\"`) {
+		t.Errorf("dotQuote did not escape a quote: %s", got)
+	}
+}
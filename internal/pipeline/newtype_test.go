@@ -0,0 +1,105 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/runtime"
+	"github.com/sunholo/ailang/internal/types"
+)
+
+// TestCheck_Newtypes verifies that single-field newtypes are distinct from
+// their field type and from each other
+func TestCheck_Newtypes(t *testing.T) {
+	decls := `export type UserId = UserId(int)
+export type OrderId = OrderId(int)
+`
+	result, err := checkModuleSource(t, "newtype_ok", decls+`
+export func raw(u: UserId) -> int = match u { UserId(n) => n + 1 }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctor, ok := result.Interface.Constructors["UserId"]
+	if !ok || !ctor.Newtype {
+		t.Fatalf("expected UserId to be exported as a newtype constructor, got %+v", ctor)
+	}
+	if len(ctor.FieldTypes) != 1 || !ctor.FieldTypes[0].Equals(types.TInt) {
+		t.Errorf("UserId field types = %v, want [int]", ctor.FieldTypes)
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"used as field type", `export func f() -> UserId = UserId(5) + 1`, "Num[UserId]"},
+		{"wrong field type", `export func f() -> UserId = UserId("5")`, "cannot unify"},
+		{"mixed newtypes", `export func f() -> int = match OrderId(5) { UserId(n) => n }`, "OrderId vs UserId"},
+		{"field used at wrong type", `export func f() -> string = match UserId(5) { UserId(n) => n ++ "" }`, "cannot unify"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkModuleSource(t, "newtype_bad", decls+tt.body+"\n")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestCheck_GenericSingleConstructorIsNotNewtype verifies that only
+// non-generic single-field types are newtypes
+func TestCheck_GenericSingleConstructorIsNotNewtype(t *testing.T) {
+	result, err := checkModuleSource(t, "newtype_generic", `export type Box[a] = Box(a)
+export type Pair = Pair(int, int)
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Box", "Pair"} {
+		if ctor := result.Interface.Constructors[name]; ctor == nil || ctor.Newtype {
+			t.Errorf("%s should be an ordinary constructor, got %+v", name, ctor)
+		}
+	}
+}
+
+// TestRun_NewtypeShow verifies that show prints a newtype value inside its
+// constructor, although the value itself is the unboxed field
+func TestRun_NewtypeShow(t *testing.T) {
+	result, err := checkModuleSource(t, "newtype_show", `export type UserId = UserId(int)
+export type Name = Name(string)
+
+export func showId(u: UserId) -> string = show(u)
+
+export let shownId = showId(UserId(42))
+export let shownName = show(Name("bob"))
+export let shownField = match UserId(7) { UserId(n) => show(n) }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rt := runtime.NewModuleRuntime("/")
+	for path, loaded := range result.Modules {
+		rt.PreloadModule(path, loaded)
+	}
+	inst, err := rt.LoadAndEvaluate(result.Interface.Module)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"shownId":    "UserId(42)",
+		"shownName":  "Name(bob)",
+		"shownField": "7",
+	} {
+		val, err := inst.GetExport(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if s, ok := val.(*eval.StringValue); !ok || s.Value != want {
+			t.Errorf("%s = %v, want %q", name, val, want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package pipeline
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/types"
@@ -12,6 +14,7 @@ import (
 type OpLowerer struct {
 	typeEnv             *types.TypeEnv
 	resolvedConstraints map[uint64]*types.ResolvedConstraint // NodeID → resolved constraint
+	listConcats         map[uint64]bool                      // ++ nodes typed as list concatenation
 	errors              []error
 }
 
@@ -29,6 +32,12 @@ func (l *OpLowerer) SetResolvedConstraints(constraints map[uint64]*types.Resolve
 	l.resolvedConstraints = constraints
 }
 
+// SetListConcats sets the ++ nodes the type checker typed as list
+// concatenation; the others concatenate strings
+func (l *OpLowerer) SetListConcats(concats map[uint64]bool) {
+	l.listConcats = concats
+}
+
 // Lower performs type-directed lowering of intrinsic operations
 func (l *OpLowerer) Lower(prog *core.Program) (*core.Program, error) {
 	// Create new program with lowered expressions
@@ -221,8 +230,43 @@ func (l *OpLowerer) lowerExpr(expr core.CoreExpr) core.CoreExpr {
 			Elements: l.lowerExprs(e.Elements),
 		}
 
-	// Atomic expressions and dictionary operations - pass through
-	case *core.Var, *core.VarGlobal, *core.Lit, *core.DictRef, *core.DictAbs, *core.DictApp:
+	case *core.Tuple:
+		return &core.Tuple{
+			CoreNode: e.CoreNode,
+			Elements: l.lowerExprs(e.Elements),
+		}
+
+	case *core.RecordUpdate:
+		updates := make(map[string]core.CoreExpr)
+		for k, v := range e.Updates {
+			updates[k] = l.lowerExpr(v)
+		}
+		return &core.RecordUpdate{
+			CoreNode: e.CoreNode,
+			Base:     l.lowerExpr(e.Base),
+			Updates:  updates,
+		}
+
+	case *core.DictAbs:
+		return &core.DictAbs{
+			CoreNode: e.CoreNode,
+			Params:   e.Params,
+			Body:     l.lowerExpr(e.Body),
+		}
+
+	case *core.DictApp:
+		return &core.DictApp{
+			CoreNode: e.CoreNode,
+			Dict:     l.lowerExpr(e.Dict),
+			Method:   e.Method,
+			Args:     l.lowerExprs(e.Args),
+		}
+
+	case *core.Lit:
+		return l.lowerLit(e)
+
+	// Atomic expressions - pass through
+	case *core.Var, *core.VarGlobal, *core.DictRef:
 		return expr
 
 	default:
@@ -287,16 +331,35 @@ func (l *OpLowerer) lowerIntrinsic(intrinsic *core.Intrinsic) core.CoreExpr {
 			typeSuffix = "Bool"
 		case core.OpConcat:
 			typeSuffix = "String"
+			if l.listConcats[intrinsic.ID()] {
+				typeSuffix = "List"
+			}
 		default:
 			// Default to Int for backward compatibility
 			typeSuffix = "Int"
 
-			// Check if we have float literals as last resort
-			if len(args) > 0 {
-				if lit, ok := args[0].(*core.Lit); ok && lit.Kind == core.FloatLit {
-					typeSuffix = "Float"
+			// Check literal operands as last resort
+			literal := false
+			for _, arg := range args {
+				if suffix := literalTypeSuffix(arg); suffix != "" {
+					typeSuffix = suffix
+					literal = true
+					break
 				}
 			}
+
+			// A comparison with no ground constraint is at a type variable,
+			// e.g. `x < lo` in `func clamp[a](x: a, lo: a, hi: a)`: use the
+			// structural comparators, which dispatch on the runtime values
+			if !literal && isComparisonOp(intrinsic.Op) {
+				typeSuffix = "ADT"
+			}
+
+			// Likewise arithmetic at a type variable, e.g. `y + 1` in
+			// `let f = \y. y + 1`, dispatches on the runtime operands
+			if !literal && isArithmeticOp(intrinsic.Op) {
+				typeSuffix = "Num"
+			}
 		}
 	}
 
@@ -330,11 +393,77 @@ func (l *OpLowerer) lowerIntrinsic(intrinsic *core.Intrinsic) core.CoreExpr {
 	}
 }
 
+// lowerLit resolves integer literals used at Float or BigInt through the
+// instance's fromInt, so `let x: float = 3 in x + 0.5` evaluates 3 as 3.0
+// instead of handing an int to the float builtins. Float literals are only
+// ever typed Float (Fractional's fromRational is the identity there) and
+// pass through.
+func (l *OpLowerer) lowerLit(lit *core.Lit) core.CoreExpr {
+	if lit.Kind != core.IntLit {
+		return lit
+	}
+	constraint, ok := l.resolvedConstraints[lit.ID()]
+	if !ok {
+		return lit
+	}
+	suffix := getTypeSuffixFromType(constraint.Type)
+	if suffix != "Float" && suffix != "BigInt" {
+		return lit
+	}
+	return &core.App{
+		CoreNode: lit.CoreNode,
+		Func: &core.VarGlobal{
+			CoreNode: lit.CoreNode,
+			Ref: core.GlobalRef{
+				Module: "$builtin",
+				Name:   "fromInt_" + suffix,
+			},
+		},
+		Args: []core.CoreExpr{lit},
+	}
+}
+
 // AddError adds an error to the lowerer
 func (l *OpLowerer) AddError(err error) {
 	l.errors = append(l.errors, err)
 }
 
+// literalTypeSuffix returns the builtin type suffix of a literal operand,
+// or "" if the operand is not a Float, String or Bool literal
+func literalTypeSuffix(expr core.CoreExpr) string {
+	lit, ok := expr.(*core.Lit)
+	if !ok {
+		return ""
+	}
+	switch lit.Kind {
+	case core.FloatLit:
+		return "Float"
+	case core.StringLit:
+		return "String"
+	case core.BoolLit:
+		return "Bool"
+	}
+	return ""
+}
+
+// isComparisonOp reports whether op is an Eq or Ord operator
+func isComparisonOp(op core.IntrinsicOp) bool {
+	switch op {
+	case core.OpEq, core.OpNe, core.OpLt, core.OpLe, core.OpGt, core.OpGe:
+		return true
+	}
+	return false
+}
+
+// isArithmeticOp reports whether op is a Num operator
+func isArithmeticOp(op core.IntrinsicOp) bool {
+	switch op {
+	case core.OpAdd, core.OpSub, core.OpMul, core.OpDiv, core.OpMod, core.OpNeg:
+		return true
+	}
+	return false
+}
+
 // getTypeSuffixFromType extracts the type suffix from a resolved type
 // Maps TInt → "Int", TFloat → "Float", TBool → "Bool", TString → "String"
 func getTypeSuffixFromType(t types.Type) string {
@@ -363,11 +492,28 @@ func getTypeSuffixFromType(t types.Type) string {
 		if typeStr == "String" || typeStr == "string" {
 			return "String"
 		}
+		if typeStr == "BigInt" || typeStr == "Unit" {
+			return typeStr
+		}
+		// Normalized tuple types (Tuple<Int,String>) share the structural builtins
+		if strings.HasPrefix(typeStr, "Tuple<") {
+			return "Tuple"
+		}
+		// Algebraic data types compare through their derived instances
+		if isADTType(t) {
+			return "ADT"
+		}
 		// Default to Int for unknown types (backward compatibility)
 		return "Int"
 	}
 }
 
+// isADTType reports whether t is a user-defined algebraic data type
+func isADTType(t types.Type) bool {
+	con, ok := t.(*types.TCon)
+	return ok && con.Name != "" && unicode.IsUpper(rune(con.Name[0]))
+}
+
 // CreateTypeMismatchError creates a structured type mismatch error for operators
 func CreateTypeMismatchError(op core.IntrinsicOp, leftType, rightType types.Type) error {
 	opStr := map[core.IntrinsicOp]string{
@@ -1,9 +1,16 @@
 package pipeline
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/core"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/runtime"
 	"github.com/sunholo/ailang/internal/types"
 )
 
@@ -136,6 +143,316 @@ func TestOpLowering_FallbackToHeuristics(t *testing.T) {
 	}
 }
 
+// TestOpLowering_StringAndBoolComparison checks that comparison and equality
+// lower to the _String and _Bool builtins of the resolved constraint's type
+func TestOpLowering_StringAndBoolComparison(t *testing.T) {
+	tests := []struct {
+		op   core.IntrinsicOp
+		typ  types.Type
+		want string
+	}{
+		{core.OpLt, &types.TCon{Name: "String"}, "lt_String"},
+		{core.OpGe, &types.TCon{Name: "String"}, "ge_String"},
+		{core.OpEq, &types.TCon{Name: "String"}, "eq_String"},
+		{core.OpNe, types.TString, "ne_String"},
+		{core.OpEq, &types.TCon{Name: "Bool"}, "eq_Bool"},
+		{core.OpNe, types.TBool, "ne_Bool"},
+	}
+
+	for _, tt := range tests {
+		intrinsic := &core.Intrinsic{
+			CoreNode: core.CoreNode{NodeID: 300},
+			Op:       tt.op,
+			Args: []core.CoreExpr{
+				&core.Var{CoreNode: core.CoreNode{NodeID: 8}, Name: "a"},
+				&core.Var{CoreNode: core.CoreNode{NodeID: 9}, Name: "b"},
+			},
+		}
+		lowerer := NewOpLowerer(types.NewTypeEnv())
+		lowerer.SetResolvedConstraints(map[uint64]*types.ResolvedConstraint{
+			300: {NodeID: 300, ClassName: "Ord", Type: tt.typ},
+		})
+
+		app, ok := lowerer.lowerExpr(intrinsic).(*core.App)
+		if !ok {
+			t.Fatalf("%s: expected App node", tt.want)
+		}
+		if ref, ok := app.Func.(*core.VarGlobal); !ok || ref.Ref.Name != tt.want {
+			t.Errorf("expected %s builtin, got %v", tt.want, app.Func)
+		}
+	}
+}
+
+// TestOpLowering_LiteralHeuristics checks that without a resolved constraint
+// the builtin type comes from literal operands, and a comparison with none
+// (i.e. at a type variable) uses the structural comparators
+func TestOpLowering_LiteralHeuristics(t *testing.T) {
+	tests := []struct {
+		args []core.CoreExpr
+		want string
+	}{
+		{[]core.CoreExpr{&core.Var{Name: "s"}, &core.Lit{Kind: core.StringLit, Value: "x"}}, "eq_String"},
+		{[]core.CoreExpr{&core.Lit{Kind: core.BoolLit, Value: true}, &core.Var{Name: "b"}}, "eq_Bool"},
+		{[]core.CoreExpr{&core.Var{Name: "f"}, &core.Lit{Kind: core.FloatLit, Value: 1.0}}, "eq_Float"},
+		{[]core.CoreExpr{&core.Var{Name: "x"}, &core.Var{Name: "y"}}, "eq_ADT"},
+	}
+
+	for _, tt := range tests {
+		lowerer := NewOpLowerer(types.NewTypeEnv())
+		lowerer.SetResolvedConstraints(map[uint64]*types.ResolvedConstraint{})
+
+		lowered := lowerer.lowerExpr(&core.Intrinsic{Op: core.OpEq, Args: tt.args})
+		app, ok := lowered.(*core.App)
+		if !ok {
+			t.Fatalf("%s: expected App node, got %T", tt.want, lowered)
+		}
+		if ref, ok := app.Func.(*core.VarGlobal); !ok || ref.Ref.Name != tt.want {
+			t.Errorf("expected %s builtin, got %v", tt.want, app.Func)
+		}
+	}
+}
+
+// TestRun_StringComparison evaluates string and bool comparisons through the
+// module pipeline with operator lowering (no shim)
+func TestRun_StringComparison(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`"abc" < "abd"`, "true"},
+		{`"x" == "x"`, "true"},
+		{`"b" >= "c"`, "false"},
+		{`let s = "q" in s != "q"`, "false"},
+		{`true == false`, "false"},
+		{`true != false`, "true"},
+	}
+
+	for _, tt := range tests {
+		result, err := runFileSource(t, "compare.ail", tt.code)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.code, err)
+		}
+		if got := result.Value.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+// TestOpLowering_IntLiteralAtFloat checks that an integer literal whose type
+// resolved to Float goes through Num[Float]'s fromInt
+func TestOpLowering_IntLiteralAtFloat(t *testing.T) {
+	lit := &core.Lit{CoreNode: core.CoreNode{NodeID: 7}, Kind: core.IntLit, Value: int64(3)}
+
+	lowerer := NewOpLowerer(types.NewTypeEnv())
+	lowerer.SetResolvedConstraints(map[uint64]*types.ResolvedConstraint{
+		7: {NodeID: 7, ClassName: "Num", Type: &types.TCon{Name: "Float"}},
+	})
+
+	app, ok := lowerer.lowerExpr(lit).(*core.App)
+	if !ok {
+		t.Fatalf("Expected App node, got %T", lowerer.lowerExpr(lit))
+	}
+	builtinRef, ok := app.Func.(*core.VarGlobal)
+	if !ok || builtinRef.Ref.Name != "fromInt_Float" {
+		t.Errorf("Expected fromInt_Float builtin, got %v", app.Func)
+	}
+	if len(app.Args) != 1 || app.Args[0] != lit {
+		t.Errorf("Expected the literal as the only argument, got %v", app.Args)
+	}
+
+	// Literals that stay Int are untouched
+	lowerer.SetResolvedConstraints(map[uint64]*types.ResolvedConstraint{
+		7: {NodeID: 7, ClassName: "Num", Type: &types.TCon{Name: "Int"}},
+	})
+	if got := lowerer.lowerExpr(lit); got != lit {
+		t.Errorf("Expected Int literal to pass through, got %v", got)
+	}
+}
+
+// TestRun_IntLiteralAtFloat evaluates integer literals that inference types
+// as Float
+func TestRun_IntLiteralAtFloat(t *testing.T) {
+	for _, code := range []string{
+		"let x: Float = 3 in x + 0.5",
+		"let x = 3 in x + 0.5",
+		"let f = \\y. y + 1 in f(2.5)",
+		"3 + 0.5",
+	} {
+		result, err := runFileSource(t, "literal.ail", code)
+		if err != nil {
+			t.Fatalf("%s: %v", code, err)
+		}
+		if got := result.Value.String(); got != "3.5" {
+			t.Errorf("%s: got %s, want 3.5", code, got)
+		}
+	}
+}
+
+// TestRun_PolymorphicArithmetic evaluates a constrained let-bound function
+// at two Num instances
+func TestRun_PolymorphicArithmetic(t *testing.T) {
+	for code, want := range map[string]string{
+		"let f = \\y. y * 2 in (f(2), f(2.5))":          "(4, 5.0)",
+		"let f = \\y. -y in (f(2), f(2.5))":             "(-2, -2.5)",
+		"let half = \\y. y / 2 in (half(7), half(7.0))": "(3, 3.5)",
+		"let x = 3 in (x + 1, x)":                       "(4, 3)",
+	} {
+		result, err := runFileSource(t, "poly.ail", code)
+		if err != nil {
+			t.Fatalf("%s: %v", code, err)
+		}
+		if got := result.Value.String(); got != want {
+			t.Errorf("%s: got %s, want %s", code, got, want)
+		}
+	}
+}
+
+// TestRun_ListConcat evaluates ++ on lists, which lowers to concat_List
+func TestRun_ListConcat(t *testing.T) {
+	for code, want := range map[string]string{
+		"[1, 2] ++ [3]":            "[1, 2, 3]",
+		"let xs = [1] in xs ++ xs": "[1, 1]",
+		`["a"] ++ ["b" ++ "c"]`:    "[a, bc]",
+		"(\\x. [x] ++ [x + 1])(5)": "[5, 6]",
+	} {
+		result, err := runFileSource(t, "concat.ail", code)
+		if err != nil {
+			t.Fatalf("%s: %v", code, err)
+		}
+		if got := result.Value.String(); got != want {
+			t.Errorf("%s: got %s, want %s", code, got, want)
+		}
+	}
+}
+
+// TestOpLowering_KeepsSourcePosition checks that the builtin call replacing an
+// operator carries the operator's span, so runtime errors point at the source
+func TestOpLowering_KeepsSourcePosition(t *testing.T) {
+	pos := ast.Pos{File: "div.ail", Line: 3, Column: 7}
+	node := core.CoreNode{NodeID: 9, CoreSpan: pos, OrigSpan: pos}
+	div := &core.Intrinsic{
+		CoreNode: node,
+		Op:       core.OpDiv,
+		Args: []core.CoreExpr{
+			&core.Lit{Kind: core.IntLit, Value: int64(1)},
+			&core.Lit{Kind: core.IntLit, Value: int64(0)},
+		},
+	}
+
+	lowerer := NewOpLowerer(types.NewTypeEnv())
+	lowerer.SetResolvedConstraints(map[uint64]*types.ResolvedConstraint{
+		9: {NodeID: 9, ClassName: "Num", Type: &types.TCon{Name: "Int"}},
+	})
+	app, ok := lowerer.lowerExpr(div).(*core.App)
+	if !ok {
+		t.Fatalf("Expected App node, got %T", lowerer.lowerExpr(div))
+	}
+	if app.CoreNode != node {
+		t.Errorf("lowered call has node %+v, want %+v", app.CoreNode, node)
+	}
+	if ref, ok := app.Func.(*core.VarGlobal); !ok || ref.CoreNode != node {
+		t.Errorf("lowered builtin reference has node %v, want %+v", app.Func, node)
+	}
+}
+
+// TestRun_LoweredOperatorErrorPosition checks that a runtime error raised by a
+// lowered operator reports the operator's source position, with and without
+// the optimization passes
+func TestRun_LoweredOperatorErrorPosition(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"let x = 1 / 0 in x", "Division by zero at %s:1:11"},
+		{"let y = 4 in\nlet x = y % 0 in x", "Modulo by zero at %s:2:11"},
+	}
+
+	for _, optimize := range []bool{false, true} {
+		for _, tt := range tests {
+			path := filepath.Join(t.TempDir(), "div.ail")
+			if err := os.WriteFile(path, []byte(tt.code), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			builtins := runtime.NewBuiltinRegistry(eval.NewCoreEvaluator())
+			cfg := Config{Mode: ModeEval, GlobalResolver: runtime.NewBuiltinOnlyResolver(builtins), Optimize: optimize}
+			_, err := Run(cfg, Source{Code: tt.code, Filename: path})
+			want := fmt.Sprintf(tt.want, path)
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("%q (optimize=%v): expected error containing %q, got %v", tt.code, optimize, want, err)
+			}
+		}
+	}
+}
+
+// TestRun_IntOverflowMode checks that Int overflow wraps by default and fails
+// under checked mode, with or without constant folding
+func TestRun_IntOverflowMode(t *testing.T) {
+	code := "let x = 9223372036854775807 in x + 1"
+	path := filepath.Join(t.TempDir(), "overflow.ail")
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, optimize := range []bool{false, true} {
+		for _, mode := range []eval.IntOverflow{eval.IntOverflowWrap, eval.IntOverflowChecked} {
+			evaluator := eval.NewCoreEvaluator()
+			evaluator.SetIntOverflow(mode)
+			builtins := runtime.NewBuiltinRegistry(evaluator)
+			cfg := Config{Mode: ModeEval, GlobalResolver: runtime.NewBuiltinOnlyResolver(builtins), Optimize: optimize}
+			result, err := Run(cfg, Source{Code: code, Filename: path})
+
+			if mode == eval.IntOverflowChecked {
+				if err == nil || !strings.Contains(err.Error(), "RT_INT_OVERFLOW") {
+					t.Errorf("checked (optimize=%v): expected RT_INT_OVERFLOW, got %v", optimize, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("wrap (optimize=%v): %v", optimize, err)
+			}
+			if got := result.Value.String(); got != "-9223372036854775808" {
+				t.Errorf("wrap (optimize=%v): expected wrapped minimum Int, got %s", optimize, got)
+			}
+		}
+	}
+}
+
+// runFileSource runs code through the file pipeline as the CLI does
+func runFileSource(t *testing.T, name, code string) (Result, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	builtins := runtime.NewBuiltinRegistry(eval.NewCoreEvaluator())
+	cfg := Config{Mode: ModeEval, GlobalResolver: runtime.NewBuiltinOnlyResolver(builtins)}
+	return Run(cfg, Source{Code: code, Filename: path})
+}
+
+// TestRun_ResultType checks that a program's value comes with its type and
+// effect row (used by run --print-type)
+func TestRun_ResultType(t *testing.T) {
+	tests := []struct {
+		code string
+		typ  string
+	}{
+		{"1 + 2", "int"},
+		{"let xs = [1.5, 2.0] in xs", "[float]"},
+		{`"n" ++ show(1)`, "string"},
+	}
+	for _, tt := range tests {
+		result, err := runFileSource(t, "typed.ail", tt.code)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.code, err)
+		}
+		if result.Type == nil || result.Type.String() != tt.typ {
+			t.Errorf("%q: expected type %s, got %v", tt.code, tt.typ, result.Type)
+		}
+		if result.Effects == nil || len(result.Effects.Labels) != 0 {
+			t.Errorf("%q: expected a pure effect row, got %v", tt.code, result.Effects)
+		}
+	}
+}
+
 // TestGetTypeSuffixFromType verifies the type to suffix mapping
 func TestGetTypeSuffixFromType(t *testing.T) {
 	tests := []struct {
@@ -158,3 +475,267 @@ func TestGetTypeSuffixFromType(t *testing.T) {
 		})
 	}
 }
+
+// TestRun_BigIntOperators tests that operators on BigInt values lower to the
+// BigInt builtins, including literals promoted through fromInt_BigInt
+func TestRun_BigIntOperators(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{"mul beyond Int", "_bigint_fromInt(9223372036854775807) * _bigint_fromInt(4)", "36893488147419103228"},
+		{"literal operand", "_bigint_fromInt(7) % 3 + 10", "11"},
+		{"negation", "-_bigint_fromInt(5)", "-5"},
+		{"comparison", "_bigint_fromInt(3) < _bigint_fromInt(4)", "true"},
+		{"let-bound conversion", "let big = \\n. _bigint_fromInt(n) in big(6) / big(4)", "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runFileSource(t, "bigint.ail", tt.code)
+			if err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if got := result.Value.String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestRun_TupleComparisons tests that == and < on tuples and () dispatch
+// through the derived Eq/Ord instances
+func TestRun_TupleComparisons(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{`(1, "a") == (1, "a")`, "true"},
+		{`(1, "b") < (2, "a")`, "true"},
+		{`((1, 2.5), "x") >= ((1, 2.5), "y")`, "false"},
+		{`let key = \n. (n, "a") in key(1) != key(2)`, "true"},
+		{`() == ()`, "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			result, err := runFileSource(t, "tuples.ail", tt.code)
+			if err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if got := result.Value.String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	_, err := runFileSource(t, "tuples.ail", `(1, true) < (1, false)`)
+	if err == nil || !strings.Contains(err.Error(), "Tuple element bool has no Ord instance") {
+		t.Errorf("expected missing Ord instance for the bool element, got %v", err)
+	}
+}
+
+// TestRun_DerivedComparisons tests that == and < on types declared with
+// derive(Eq, Ord) compare constructors in declaration order, then fields
+func TestRun_DerivedComparisons(t *testing.T) {
+	decls := "type Color = Red | Green | Blue derive(Eq, Ord)\n" +
+		"type Shape = Circle(int) | Rect(int, Color) derive(Ord)\n"
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{`Red == Red`, "true"},
+		{`Green != Green`, "false"},
+		{`Green < Blue`, "true"},
+		{`Blue <= Red`, "false"},
+		{`Rect(1, Red) == Rect(1, Red)`, "true"},
+		{`Circle(9) < Rect(0, Red)`, "true"},
+		{`Rect(1, Blue) > Rect(1, Green)`, "true"},
+		{`(Red, 2) < (Red, 3)`, "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			result, err := runFileSource(t, "derived.ail", decls+tt.code)
+			if err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if got := result.Value.String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	_, err := runFileSource(t, "derived.ail", "type Color = Red | Green derive(Eq)\nRed < Green")
+	if err == nil || !strings.Contains(err.Error(), "No instance for Ord[Color]") {
+		t.Errorf("expected missing Ord instance without derive(Ord), got %v", err)
+	}
+}
+
+// allValues[T]() lists the constructors of an enumeration in declaration order
+func TestRun_AllValues(t *testing.T) {
+	decls := "type Color = Red | Green | Blue derive(Show, Eq)\n" +
+		"type Shape = Circle(int) | Dot\n"
+
+	result, err := runFileSource(t, "all_values.ail", decls+"allValues[Color]()")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if got := result.Value.String(); got != "[Red, Green, Blue]" {
+		t.Errorf("expected [Red, Green, Blue], got %s", got)
+	}
+
+	result, err = runFileSource(t, "all_values.ail", decls+"match allValues[Color]() { [_, c, ..._] => c == Green, _ => false }")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if got := result.Value.String(); got != "true" {
+		t.Errorf("expected the elements to be typed Color, got %s", got)
+	}
+
+	errTests := []struct {
+		code    string
+		wantErr string
+	}{
+		{"allValues[Shape]()", "constructor Circle has fields"},
+		{"allValues[Missing]()", "Missing is not an algebraic data type in scope"},
+	}
+	for _, tt := range errTests {
+		_, err := runFileSource(t, "all_values.ail", decls+tt.code)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.code, tt.wantErr, err)
+		}
+	}
+}
+
+// allValues[T]() also lists constructors of an imported type that were not
+// imported by name
+func TestRun_AllValuesImported(t *testing.T) {
+	// Imports resolve against the working directory
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	dep := "module compass\n\nexport type Direction = North | East | South | West\n"
+	if err := os.WriteFile("compass.ail", []byte(dep), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := "main.ail"
+	code := []byte("module main\n\nimport compass (Direction)\n\nallValues[Direction]()\n")
+	if err := os.WriteFile(path, code, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	builtins := runtime.NewBuiltinRegistry(eval.NewCoreEvaluator())
+	cfg := Config{Mode: ModeEval, GlobalResolver: runtime.NewBuiltinOnlyResolver(builtins)}
+	result, err := Run(cfg, Source{Code: string(code), Filename: path})
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if got := result.Value.String(); got != "[North, East, South, West]" {
+		t.Errorf("expected [North, East, South, West], got %s", got)
+	}
+}
+
+// TestRun_PreludeOrdEqHelpers runs the constrained generic helpers from
+// std/prelude at several instance types
+func TestRun_PreludeOrdEqHelpers(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`clamp(15, 0, 10)`, "10"},
+		{`clamp(2.5, 0.0, 1.0)`, "1.0"},
+		{`clamp("m", "a", "k")`, "k"},
+		{`between(5, 1, 10)`, "true"},
+		{`between(0.5, 1.0, 2.0)`, "false"},
+		{`elem("b", ["a", "b"])`, "true"},
+		{`elem(3, [1, 2])`, "false"},
+		{`elem((1, "x"), [(2, "y"), (1, "x")])`, "true"},
+	}
+
+	for _, tt := range tests {
+		result, err := runFileSource(t, "helpers.ail", "import std/prelude (clamp, between, elem)\n"+tt.code)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.code, err)
+		}
+		if got := result.Value.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestRun_PreludeCombinators(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`identity("s")`, "s"},
+		{`compose(\x. x + 1, \x. x * 2)(5)`, "11"},
+		{`compose(length, identity)([1, 2])`, "2"},
+		{`flip(\a b. a - b)(1)(10)`, "9"},
+		{`foldl(flip(\x acc. acc * 10 + x), 0, [1, 2, 3])`, "123"},
+		{`foldr(\x acc. compose(\n. n + 1, \n. n * 2)(x) + acc, 0, [1, 2, 3])`, "15"},
+		{`foldl(\acc f. compose(f, acc), identity, [\x. x + 1, \x. x * 2])(3)`, "8"},
+		{`foldl(\acc x. constant(x)(acc), 0, [1, 2, 3])`, "3"},
+		{`foldl(\n f. f(n), 1, [constant(7), \x. x * 2])`, "14"},
+	}
+
+	for _, tt := range tests {
+		result, err := runFileSource(t, "combinators.ail",
+			"import std/prelude (identity, compose, flip, constant)\nimport std/list (foldl, foldr, length)\n"+tt.code)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.code, err)
+		}
+		if got := result.Value.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+// TestRun_PreludeCombinatorsEffectful checks that compose and flip accept
+// functions with effects
+func TestRun_PreludeCombinatorsEffectful(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	code := `module effectful
+
+import std/prelude (compose, flip)
+import std/io (println)
+
+func say(s: string) -> int ! {IO} {
+  println(s);
+  1
+}
+
+func tell(s: string, n: int) -> int ! {IO} {
+  println(s);
+  n
+}
+
+export func run() -> int ! {IO} {
+  compose(\n. n + 1, say)("a") + compose(say, \s. s ++ "!")("b") + flip(tell)(5)("c")
+}
+`
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	if err := os.WriteFile("effectful.ail", []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Run(Config{Mode: ModeCheck}, Source{Code: code, Filename: "effectful.ail"}); err != nil {
+		t.Fatalf("composing effectful functions: %v", err)
+	}
+}
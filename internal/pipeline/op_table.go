@@ -18,22 +18,22 @@ type OpMapping struct {
 // OperatorTable defines all operator to builtin mappings
 var OperatorTable = map[core.IntrinsicOp]OpMapping{
 	// Arithmetic operations
-	core.OpAdd: {Builtin: "add", Types: []string{"Int", "Float"}},
-	core.OpSub: {Builtin: "sub", Types: []string{"Int", "Float"}},
-	core.OpMul: {Builtin: "mul", Types: []string{"Int", "Float"}},
-	core.OpDiv: {Builtin: "div", Types: []string{"Int", "Float"}},
-	core.OpMod: {Builtin: "mod", Types: []string{"Int", "Float"}},
+	core.OpAdd: {Builtin: "add", Types: []string{"Int", "Float", "BigInt", "Num"}},
+	core.OpSub: {Builtin: "sub", Types: []string{"Int", "Float", "BigInt", "Num"}},
+	core.OpMul: {Builtin: "mul", Types: []string{"Int", "Float", "BigInt", "Num"}},
+	core.OpDiv: {Builtin: "div", Types: []string{"Int", "Float", "BigInt", "Num"}},
+	core.OpMod: {Builtin: "mod", Types: []string{"Int", "Float", "BigInt", "Num"}},
 
 	// Comparison operations
-	core.OpEq: {Builtin: "eq", Types: []string{"Int", "Float", "String", "Bool"}},
-	core.OpNe: {Builtin: "ne", Types: []string{"Int", "Float", "String", "Bool"}},
-	core.OpLt: {Builtin: "lt", Types: []string{"Int", "Float", "String"}},
-	core.OpLe: {Builtin: "le", Types: []string{"Int", "Float", "String"}},
-	core.OpGt: {Builtin: "gt", Types: []string{"Int", "Float", "String"}},
-	core.OpGe: {Builtin: "ge", Types: []string{"Int", "Float", "String"}},
+	core.OpEq: {Builtin: "eq", Types: []string{"Int", "Float", "String", "Bool", "BigInt", "Unit", "Tuple", "ADT"}},
+	core.OpNe: {Builtin: "ne", Types: []string{"Int", "Float", "String", "Bool", "BigInt", "Unit", "Tuple", "ADT"}},
+	core.OpLt: {Builtin: "lt", Types: []string{"Int", "Float", "String", "BigInt", "Tuple", "ADT"}},
+	core.OpLe: {Builtin: "le", Types: []string{"Int", "Float", "String", "BigInt", "Tuple", "ADT"}},
+	core.OpGt: {Builtin: "gt", Types: []string{"Int", "Float", "String", "BigInt", "Tuple", "ADT"}},
+	core.OpGe: {Builtin: "ge", Types: []string{"Int", "Float", "String", "BigInt", "Tuple", "ADT"}},
 
-	// String operations
-	core.OpConcat: {Builtin: "concat", Types: []string{"String"}},
+	// Concatenation (strings and lists)
+	core.OpConcat: {Builtin: "concat", Types: []string{"String", "List"}},
 
 	// Boolean operations (short-circuit, handled specially)
 	core.OpAnd: {Builtin: "and", Types: []string{"Bool"}},
@@ -41,7 +41,7 @@ var OperatorTable = map[core.IntrinsicOp]OpMapping{
 
 	// Unary operations
 	core.OpNot: {Builtin: "not", Types: []string{"Bool"}},
-	core.OpNeg: {Builtin: "neg", Types: []string{"Int", "Float"}},
+	core.OpNeg: {Builtin: "neg", Types: []string{"Int", "Float", "BigInt", "Num"}},
 }
 
 // GetBuiltinName returns the monomorphic builtin name for an operator and type
@@ -113,8 +113,8 @@ func IsOperatorTableComplete() error {
 
 // OperatorSemantics documents the semantics of each operator
 var OperatorSemantics = map[string]string{
-	"div_Int":   "Integer division truncates toward zero (e.g., -7/2 = -3)",
-	"mod_Int":   "Integer modulo has the sign of the dividend (e.g., -7%3 = -1)",
+	"div_Int":   "Integer division truncates toward zero (e.g., -7/2 = -3); _int_div_floor rounds down (-4)",
+	"mod_Int":   "Integer modulo has the sign of the dividend (e.g., -7%3 = -1); _int_mod_floor has the sign of the divisor (2)",
 	"div_Float": "Float division follows IEEE 754 (division by zero produces ±Inf)",
 	"mod_Float": "Float modulo follows IEEE 754 (mod by zero produces NaN)",
 	"eq_Float":  "Float equality: NaN != NaN is false, all other comparisons standard",
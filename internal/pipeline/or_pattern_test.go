@@ -0,0 +1,33 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRun_OrPatternWildcards checks that `_` in an or-pattern binds nothing,
+// so alternatives that ignore different positions still agree
+func TestRun_OrPatternWildcards(t *testing.T) {
+	for code, want := range map[string]string{
+		"match (0, 5) { (0, x) | (x, _) => x }":                            "5",
+		"match (7, 0) { (0, x) | (x, _) => x }":                            "7",
+		`match (1, "a") { (_, _) => "both" }`:                              "both",
+		"type Opt = Has(int) | Empty\nmatch Empty { Has(_) | Empty => 1 }": "1",
+	} {
+		result, err := runFileSource(t, "orpat.ail", code)
+		if err != nil {
+			t.Fatalf("%s: %v", code, err)
+		}
+		if got := result.Value.String(); got != want {
+			t.Errorf("%s: got %s, want %s", code, got, want)
+		}
+	}
+}
+
+// TestRun_OrPatternBindingMismatch rejects alternatives binding different names
+func TestRun_OrPatternBindingMismatch(t *testing.T) {
+	_, err := runFileSource(t, "orpat.ail", "match (1, 2) { (0, x) | (_, _) => 1 }")
+	if err == nil || !strings.Contains(err.Error(), "does not bind x") {
+		t.Fatalf("expected a binding mismatch error, got %v", err)
+	}
+}
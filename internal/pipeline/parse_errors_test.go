@@ -0,0 +1,34 @@
+package pipeline
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/loader"
+)
+
+// TestRun_ParseErrorsKeepEachError verifies that a module with several parse
+// errors fails with all of them individually reachable
+func TestRun_ParseErrorsKeepEachError(t *testing.T) {
+	code := "module bad\nfunc a() { 1 + }\nfunc b( { }\nexport func main() -> int { 1 }\n"
+	_, err := runFileSource(t, "bad.ail", code)
+	if err == nil {
+		t.Fatal("expected parse errors")
+	}
+	var parseErrs *loader.ParseErrors
+	if !errors.As(err, &parseErrs) {
+		t.Fatalf("expected *loader.ParseErrors in the chain, got %T: %v", err, err)
+	}
+	if len(parseErrs.Errs) < 2 {
+		t.Fatalf("expected several parse errors, got %v", parseErrs.Errs)
+	}
+	for _, e := range parseErrs.Errs {
+		if !strings.HasPrefix(e.Error(), "PAR_") {
+			t.Errorf("expected a parser error, got %v", e)
+		}
+	}
+	if !strings.Contains(err.Error(), "parse errors in") {
+		t.Errorf("error message changed: %v", err)
+	}
+}
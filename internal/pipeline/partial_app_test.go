@@ -0,0 +1,43 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCheck_PartialApplication verifies that a multi-parameter function
+// applied to fewer arguments has the type of the remaining function, and
+// that surplus arguments are passed on to the function it returns
+func TestCheck_PartialApplication(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	code := `import std/list (map)
+
+export func add(x: int, y: int) -> int { x + y }
+export func scale(n: int) -> (int) -> int { \y. n * y }
+
+export const inc = add(1)
+export const incremented = map(add(1), [1, 2, 3])
+export const twelve = scale(3, 4)
+`
+	result, err := checkModuleSource(t, "partial_app", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := make(map[string]string)
+	for _, name := range []string{"inc", "incremented", "twelve"} {
+		item, ok := result.Interface.Exports[name]
+		if !ok {
+			t.Fatalf("%s not exported", name)
+		}
+		types[name] = item.Type.String()
+	}
+	if got := types["inc"]; strings.Count(got, "->") != 1 || !strings.HasSuffix(got, "-> int") {
+		t.Errorf("inc: expected a one-parameter function returning int, got %s", got)
+	}
+	if got := types["incremented"]; !strings.HasPrefix(got, "[") {
+		t.Errorf("incremented: expected a list, got %s", got)
+	}
+	if got := types["twelve"]; got != "int" {
+		t.Errorf("twelve: expected type int, got %s", got)
+	}
+}
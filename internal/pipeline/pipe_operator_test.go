@@ -0,0 +1,26 @@
+package pipeline
+
+import (
+	"testing"
+)
+
+// TestCheck_PipeOperator verifies that x |> f type-checks as f(x), composing
+// with partial application and operator sections
+func TestCheck_PipeOperator(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	code := `import std/list (filter, map, length)
+
+export const count = [1, 2, 3, 4] |> filter((> 1)) |> map((* 2)) |> length
+export const flag = 3 |> (+ 1) |> (== 4)
+`
+	result, err := checkModuleSource(t, "pipe_operator", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := result.Interface.Exports["count"]; !ok {
+		t.Error("count not exported")
+	}
+	if got := result.Interface.Exports["flag"].Type.String(); got != "bool" {
+		t.Errorf("flag: expected type bool, got %s", got)
+	}
+}
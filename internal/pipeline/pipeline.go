@@ -3,14 +3,14 @@ package pipeline
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/elaborate"
-
-	// "github.com/sunholo/ailang/internal/errors" // TODO: Use structured errors
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
 	"github.com/sunholo/ailang/internal/eval"
 	"github.com/sunholo/ailang/internal/iface"
 	"github.com/sunholo/ailang/internal/lexer"
@@ -18,7 +18,7 @@ import (
 	"github.com/sunholo/ailang/internal/linked"
 	"github.com/sunholo/ailang/internal/loader"
 	"github.com/sunholo/ailang/internal/parser"
-	_ "github.com/sunholo/ailang/internal/typedast" // For type checker return value
+	"github.com/sunholo/ailang/internal/typedast"
 	"github.com/sunholo/ailang/internal/types"
 )
 
@@ -44,7 +44,12 @@ type Config struct {
 	ExperimentalBinopShim bool                  // Feature flag for operator shim
 	FailOnShim            bool                  // Fail if shim would be used (CI mode)
 	TrackInstantiations   bool                  // Track polymorphic type instantiations
+	Optimize              bool                  // Inline single-use lets and fold constants after lowering
+	RequirePure           bool                  // Fail if any module references an effectful builtin
+	NoPrelude             bool                  // Start without the prelude's builtin instances (--no-prelude)
 	LedgerHook            func(decision string) // Optional decision hook
+	TraceEval             io.Writer             // Print each evaluated Core node (--trace-eval)
+	Coverage              *eval.Coverage        // Record the branches evaluated in ModeEval (--coverage)
 
 	// Environment from REPL (optional)
 	TypeEnv   *types.TypeEnv
@@ -55,6 +60,9 @@ type Config struct {
 
 	// Global resolver for non-module evaluation (v0.2.0 hotfix)
 	GlobalResolver eval.GlobalResolver
+
+	// Modules shared across runs, e.g. when checking a whole directory (optional)
+	Cache *ModuleCache
 }
 
 // Source represents input source
@@ -62,9 +70,13 @@ type Source struct {
 	Code     string
 	Filename string
 	IsREPL   bool
-	REPLNum  int // REPL snippet number
+	REPLNum  int  // REPL snippet number
+	Stdin    bool // Code was read from stdin; Filename is only the path it is loaded as
 }
 
+// StdinFilename is the file name in source positions of a program read from stdin
+const StdinFilename = "<stdin>"
+
 // Artifacts contains intermediate representations
 type Artifacts struct {
 	AST    *ast.File
@@ -77,14 +89,20 @@ type Artifacts struct {
 type Result struct {
 	Value          eval.Value
 	Type           types.Type
+	Effects        *types.Row // Effect row of Type (non-module programs)
 	Constraints    []types.Constraint
-	Errors         []error                            // TODO: Use structured errors
-	Warnings       []*elaborate.ExhaustivenessWarning // Exhaustiveness warnings
+	Errors         []error                     // TODO: Use structured errors
+	Warnings       []ailangErrors.Warning      // Warnings of every kind, in the order they were found
+	Defaulting     []types.DefaultingTrace     // Numeric defaulting decisions in the root module
+	Resolved       []*types.ResolvedConstraint // Instances chosen for the root module's operators and literals
 	Artifacts      Artifacts
 	Interface      *iface.Iface                    // Module interface (for modules only)
 	Modules        map[string]*loader.LoadedModule // Loaded modules with Core (for module execution)
 	EnvLockDigest  string
 	PhaseTimings   map[string]int64       // milliseconds
+	FoldedOps      int                    // Operations replaced by literals (with Optimize)
+	InlinedLets    int                    // Single-use let bindings inlined (with Optimize)
+	InlinedCalls   int                    // Calls to @inline functions inlined (with Optimize)
 	Instantiations map[string]interface{} // Polymorphic instantiation tracking
 }
 
@@ -101,6 +119,30 @@ func Run(cfg Config, src Source) (Result, error) {
 	return runModule(cfg, src)
 }
 
+// defaultInstances returns the instance environment a run starts from: the
+// prelude's builtin Num/Eq/Ord/Show instances, or none under NoPrelude. The
+// numeric literal defaults are kept either way, so `1 + 2` without the
+// prelude reports the missing Num[int] instance rather than an ambiguity.
+func defaultInstances(cfg Config) *types.InstanceEnv {
+	if !cfg.NoPrelude {
+		return types.LoadBuiltinInstances()
+	}
+	env := types.NewInstanceEnv()
+	env.SetDefault("Num", types.TInt)
+	env.SetDefault("Fractional", types.TFloat)
+	return env
+}
+
+// sortedResolved lists a type checker's resolved constraints in source order
+func sortedResolved(resolved map[uint64]*types.ResolvedConstraint) []*types.ResolvedConstraint {
+	rcs := make([]*types.ResolvedConstraint, 0, len(resolved))
+	for _, rc := range resolved {
+		rcs = append(rcs, rc)
+	}
+	types.SortResolvedConstraints(rcs)
+	return rcs
+}
+
 // runSingle runs the pipeline for a single file/expression (REPL mode)
 func runSingle(cfg Config, src Source) (Result, error) {
 	result := Result{
@@ -112,7 +154,7 @@ func runSingle(cfg Config, src Source) (Result, error) {
 		cfg.TypeEnv = types.NewTypeEnvWithBuiltins()
 	}
 	if cfg.InstEnv == nil {
-		cfg.InstEnv = types.LoadBuiltinInstances()
+		cfg.InstEnv = defaultInstances(cfg)
 	}
 	if cfg.DictReg == nil {
 		cfg.DictReg = types.NewDictionaryRegistry()
@@ -146,9 +188,10 @@ func runSingle(cfg Config, src Source) (Result, error) {
 			},
 			Statements: []ast.Node{},
 		}
-		// Convert program to statements
-		if program.Module != nil {
-			astFile.Statements = append(astFile.Statements, program.Module.Decls...)
+		// Convert program to statements. A bare expression has no module
+		// header, so it is only found in File, not in the legacy Module
+		if program.File != nil {
+			astFile.Statements = append(astFile.Statements, program.File.Statements...)
 		}
 	} else {
 		// For files, parse as complete file
@@ -171,13 +214,21 @@ func runSingle(cfg Config, src Source) (Result, error) {
 	}
 	// Add builtins to global environment so they can be referenced
 	elaborator.AddBuiltinsToGlobalEnv()
+	if src.IsREPL {
+		// REPL input can use std/option's Some and None without an import
+		for _, ctor := range OptionConstructors {
+			elaborator.RegisterConstructor(ctor.TypeName, ctor.CtorName, ctor.Arity, true)
+		}
+	}
 	coreProg, err := elaborator.ElaborateFile(astFile)
 	if err != nil {
 		return result, fmt.Errorf("elaboration error: %w", err)
 	}
 
 	// Collect exhaustiveness warnings
-	result.Warnings = elaborator.GetWarnings()
+	for _, w := range elaborator.GetWarnings() {
+		result.Warnings = append(result.Warnings, w)
+	}
 
 	result.Artifacts.Core = coreProg
 	result.PhaseTimings["elaborate"] = time.Since(start).Milliseconds()
@@ -190,6 +241,9 @@ func runSingle(cfg Config, src Source) (Result, error) {
 	start = time.Now()
 	typeChecker := types.NewCoreTypeCheckerWithInstances(cfg.InstEnv)
 	typeChecker.EnableTraceDefaulting(cfg.TraceDefaulting)
+	typeChecker.SetTypeAnnotations(elaborator.GetTypeAnnotations())
+	typeChecker.SetReturnAnnotations(elaborator.GetReturnAnnotations())
+	typeChecker.SetTypeAliases(types.ResolveTypeAliases(elaborator.GetTypeAliases(), nil))
 	if cfg.TrackInstantiations {
 		typeChecker.EnableInstantiationTracking()
 	}
@@ -206,12 +260,44 @@ func runSingle(cfg Config, src Source) (Result, error) {
 		return result, fmt.Errorf("empty program")
 	}
 
+	// $builtin functions (e.g. _io_println) are in scope, as in a module
+	globalTypes := make(map[string]*types.Scheme)
+	builtinLinker := link.NewModuleLinker(nil)
+	link.RegisterBuiltinModule(builtinLinker)
+	if builtinIface := builtinLinker.GetIface("$builtin"); builtinIface != nil {
+		for _, item := range builtinIface.Exports {
+			globalTypes[fmt.Sprintf("%s.%s", item.Ref.Module, item.Ref.Name)] = item.Type
+		}
+	}
+	// So are the $adt factories of the constructors declared here (and, for
+	// REPL input, of std/option's unless the input declares its own)
+	constructors := convertConstructors(elaborator.GetConstructors())
+	if src.IsREPL {
+		for _, ctor := range OptionConstructors {
+			if _, declared := constructors[ctor.CtorName]; !declared {
+				constructors[ctor.CtorName] = ctor
+			}
+		}
+	}
+	aliases := types.ResolveTypeAliases(elaborator.GetTypeAliases(), nil)
+	for ctorName, ctorInfo := range constructors {
+		globalTypes[fmt.Sprintf("$adt.make_%s_%s", ctorInfo.TypeName, ctorName)] = factoryScheme(ctorInfo, newtypeFieldTypes(ctorInfo, aliases))
+	}
+	typeChecker.SetGlobalTypes(globalTypes)
+
 	typedNode, _, qualType, constraints, err := typeChecker.InferWithConstraints(coreExpr, cfg.TypeEnv)
 	if err != nil {
 		return result, fmt.Errorf("type error: %w", err)
 	}
+	result.Defaulting = append(result.Defaulting, typeChecker.DefaultingTraces()...)
+	types.SortDefaultingTraces(result.Defaulting)
+	typeChecker.FillOperatorMethods(coreExpr)
+	result.Resolved = sortedResolved(typeChecker.GetResolvedConstraints())
 
 	result.Type = qualType
+	if row, ok := typedNode.GetEffectRow().(*types.Row); ok {
+		result.Effects = row
+	}
 	result.Constraints = constraints
 	result.PhaseTimings["typecheck"] = time.Since(start).Milliseconds()
 
@@ -232,22 +318,22 @@ func runSingle(cfg Config, src Source) (Result, error) {
 	// If shim is not enabled, we must lower
 	if cfg.RequireLowering || !cfg.ExperimentalBinopShim {
 		lowerer := NewOpLowerer(cfg.TypeEnv)
+		lowerer.SetResolvedConstraints(typeChecker.GetResolvedConstraints())
+		lowerer.SetListConcats(typeChecker.ListConcats())
 		loweredProg, err := lowerer.Lower(coreProg)
 		if err != nil {
 			return result, fmt.Errorf("lowering error: %w", err)
 		}
 
 		// Guard A: Assert no operators remain
-		// TODO: Re-enable after assert_builtins.go is fixed
-		// if err := AssertNoOperators(loweredProg); err != nil {
-		// 	return result, err
-		// }
+		if err := AssertNoOperators(loweredProg); err != nil {
+			return result, err
+		}
 
 		// Guard B: Assert only builtins appear for ops
-		// TODO: Re-enable after assert_builtins.go is fixed
-		// if err := AssertOnlyBuiltinsForOps(loweredProg); err != nil {
-		// 	return result, err
-		// }
+		if err := AssertOnlyBuiltinsForOps(loweredProg); err != nil {
+			return result, err
+		}
 
 		loweredProg.Flags.Lowered = true
 		coreProg = loweredProg
@@ -258,6 +344,21 @@ func runSingle(cfg Config, src Source) (Result, error) {
 	}
 	result.PhaseTimings["lower"] = time.Since(start).Milliseconds()
 
+	// Phase 3.6: Function Inlining, Let Inlining and Constant Folding (optional)
+	if cfg.Optimize {
+		start = time.Now()
+		funcInliner := NewFunctionInliner()
+		coreProg = funcInliner.Inline(coreProg)
+		result.InlinedCalls = funcInliner.Inlined()
+		inliner := NewLetInliner()
+		coreProg = inliner.Inline(coreProg)
+		result.InlinedLets = inliner.Inlined()
+		folder := NewConstantFolder()
+		coreProg = folder.Fold(coreProg)
+		result.FoldedOps = folder.Folded()
+		result.PhaseTimings["fold"] = time.Since(start).Milliseconds()
+	}
+
 	// Phase 4: Dictionary Elaboration
 	start = time.Now()
 	// TODO: Implement proper dictionary elaboration
@@ -300,18 +401,26 @@ func runSingle(cfg Config, src Source) (Result, error) {
 	if cfg.GlobalResolver != nil {
 		coreEval.SetGlobalResolver(cfg.GlobalResolver)
 	}
+	// Constructors evaluate without a module resolver
+	for _, ctorInfo := range constructors {
+		coreEval.RegisterConstructor(EvalConstructor(ctorInfo))
+	}
+	coreEval.SetEvalTrace(cfg.TraceEval)
+	if cfg.Coverage != nil {
+		WalkCore(coreProg, cfg.Coverage.AddNode)
+		coreEval.SetCoverage(cfg.Coverage)
+	}
 	// Set experimental flag only if allowed
 	if cfg.ExperimentalBinopShim && !cfg.RequireLowering && !cfg.FailOnShim {
 		coreEval.SetExperimentalBinopShim(true)
 	}
 
-	// Guard B: Ensure program was lowered (unless using allowed shim)
-	// TODO: Re-enable after assert_builtins.go is fixed
-	// if cfg.RequireLowering || !cfg.ExperimentalBinopShim {
-	// 	if err := AssertProgramLowered(coreProg); err != nil {
-	// 		return result, err
-	// 	}
-	// }
+	// Guard C: Ensure program was lowered (unless using allowed shim)
+	if cfg.RequireLowering || !cfg.ExperimentalBinopShim {
+		if err := AssertProgramLowered(coreProg); err != nil {
+			return result, err
+		}
+	}
 
 	// Evaluate the program ONLY in ModeEval (REPL)
 	if cfg.Mode == ModeEval {
@@ -335,6 +444,68 @@ func runSingle(cfg Config, src Source) (Result, error) {
 	return result, nil
 }
 
+// OptionConstructors are std/option's constructors, which REPL input (the
+// interactive REPL and eval-expr) can use without importing std/option
+var OptionConstructors = []*ConstructorInfo{
+	{TypeName: "Option", CtorName: "Some", Arity: 1, Index: 0},
+	{TypeName: "Option", CtorName: "None", Arity: 0, Index: 1},
+}
+
+// EvalConstructor describes a constructor to the evaluator, which then
+// evaluates its $adt factory without a module resolver
+func EvalConstructor(ctorInfo *ConstructorInfo) eval.Constructor {
+	return eval.Constructor{
+		TypeName:   ctorInfo.TypeName,
+		CtorName:   ctorInfo.CtorName,
+		Arity:      ctorInfo.Arity,
+		FieldNames: ctorInfo.FieldNames,
+		Newtype:    ctorInfo.Newtype,
+		Index:      ctorInfo.Index,
+	}
+}
+
+// FactoryTypes returns the types of the constructors' $adt factories, keyed
+// as the type checker's global types ($adt.make_Option_Some)
+func FactoryTypes(ctors map[string]*ConstructorInfo) map[string]*types.Scheme {
+	factories := make(map[string]*types.Scheme, len(ctors))
+	for _, ctorInfo := range ctors {
+		factories[fmt.Sprintf("$adt.make_%s_%s", ctorInfo.TypeName, ctorInfo.CtorName)] = factoryScheme(ctorInfo, nil)
+	}
+	return factories
+}
+
+// factoryScheme is the type of the $adt factory of a constructor declared in
+// the program: a0 -> a1 -> ... -> TypeName, or just TypeName if it has no
+// fields. A newtype takes exactly its declared field types.
+func factoryScheme(ctorInfo *ConstructorInfo, newtypeFields []types.Type) *types.Scheme {
+	// Use TVar2 (new type system) for type variables with Star kind
+	var typeVars []string
+	paramTypes := newtypeFields
+	if paramTypes == nil {
+		for i := 0; i < ctorInfo.Arity; i++ {
+			varName := fmt.Sprintf("a%d", i)
+			typeVars = append(typeVars, varName)
+			paramTypes = append(paramTypes, &types.TVar2{Name: varName, Kind: types.Star})
+		}
+	}
+
+	// Result type - monomorphic for now (M-P3 limitation)
+	// Full polymorphic ADTs (Option[Int]) will require type application support in unifier
+	resultType := &types.TCon{Name: ctorInfo.TypeName}
+	if ctorInfo.Arity == 0 {
+		return &types.Scheme{Type: resultType}
+	}
+	// TypeVars allows polymorphism over field types
+	return &types.Scheme{
+		TypeVars: typeVars,
+		Type: &types.TFunc2{
+			Params:    paramTypes,
+			EffectRow: nil, // Pure constructor
+			Return:    resultType,
+		},
+	}
+}
+
 // runModule runs the pipeline for a module with dependencies
 func runModule(cfg Config, src Source) (Result, error) {
 	// DEBUG: if cfg.TraceDefaulting { fmt.Printf("DEBUG: runModule called for %s\n", src.Filename) }
@@ -347,7 +518,7 @@ func runModule(cfg Config, src Source) (Result, error) {
 		cfg.TypeEnv = types.NewTypeEnvWithBuiltins()
 	}
 	if cfg.InstEnv == nil {
-		cfg.InstEnv = types.LoadBuiltinInstances()
+		cfg.InstEnv = defaultInstances(cfg)
 	}
 	if cfg.DictReg == nil {
 		cfg.DictReg = types.NewDictionaryRegistry()
@@ -361,7 +532,10 @@ func runModule(cfg Config, src Source) (Result, error) {
 
 	// Phase 1: Load module and dependencies
 	start := time.Now()
-	modLoader := loader.NewModuleLoader(".")
+	modLoader := cfg.Cache.moduleLoader()
+	if src.Stdin {
+		modLoader.AddSource(src.Filename, StdinFilename, []byte(src.Code))
+	}
 	modules, err := modLoader.LoadAll([]string{src.Filename})
 	if err != nil {
 		return result, fmt.Errorf("module loading error: %w", err)
@@ -375,6 +549,8 @@ func runModule(cfg Config, src Source) (Result, error) {
 	link.RegisterBuiltinModule(modLinker)
 	// Pass only the root module to TopoSort (dependencies will be discovered via DFS)
 	rootCanonical := loader.CanonicalModuleID(src.Filename)
+	var rootType types.Type // Type and effects of the root's first declaration
+	var rootEffects *types.Row
 	sortedModules, err := modLinker.TopoSortFromRoot(rootCanonical, modules)
 	if err != nil {
 		return result, fmt.Errorf("dependency cycle: %w", err)
@@ -396,6 +572,13 @@ func runModule(cfg Config, src Source) (Result, error) {
 	compiledUnits := make(map[string]*CompileUnit)
 
 	for _, modID := range sortedModules {
+		// Dependencies compiled by an earlier run only need their interface
+		if cached, ok := cfg.Cache.lookup(string(modID)); ok && string(modID) != rootCanonical {
+			modLinker.RegisterIface(cached.Iface)
+			compiledUnits[string(modID)] = cached
+			continue
+		}
+
 		mod := modules[string(modID)]
 		unit := &CompileUnit{
 			ID:      string(modID),
@@ -415,6 +598,10 @@ func runModule(cfg Config, src Source) (Result, error) {
 		// Build external environment from already-compiled dependencies
 		externalTypes := make(map[string]*types.Scheme)
 		globalRefs := make(map[string]core.GlobalRef)
+		adtFields := make(map[string]*types.ADTFields) // Record-style ADT fields for p.x access
+		newtypes := make(map[string]*types.Newtype)    // Newtype constructors in scope
+		// Every ADT of an imported module, for allValues[T]
+		importedADTs := make(map[string][]*iface.ConstructorScheme)
 
 		// Always include $builtin module exports (available to all modules)
 		if builtinIface := modLinker.GetIface("$builtin"); builtinIface != nil {
@@ -442,6 +629,15 @@ func runModule(cfg Config, src Source) (Result, error) {
 					}
 					continue
 				}
+				for _, ctor := range depIface.Constructors {
+					if _, seen := importedADTs[ctor.TypeName]; !seen {
+						importedADTs[ctor.TypeName] = depIface.ConstructorsOf(ctor.TypeName)
+					}
+					addADTFields(adtFields, ctor.TypeName, ctor.TypeParams, ctor.FieldNames, ctor.RecordFieldTypes)
+					if ctor.Newtype && len(ctor.FieldTypes) == 1 {
+						newtypes[ctor.CtorName] = &types.Newtype{TypeName: ctor.TypeName, Field: ctor.FieldTypes[0]}
+					}
+				}
 				if len(imp.Symbols) > 0 {
 					// Selective import
 					for _, sym := range imp.Symbols {
@@ -468,6 +664,14 @@ func runModule(cfg Config, src Source) (Result, error) {
 							found = true
 						}
 
+						// Try to import as a type class (its instances come with the module)
+						if class, ok := depIface.GetClass(sym); ok {
+							if cfg.TraceDefaulting {
+								fmt.Printf("  Import class %s[%s]\n", class.Name, class.TypeParam)
+							}
+							found = true
+						}
+
 						// Try to import as a constructor
 						// DEBUG: fmt.Printf("DEBUG: Checking if %s is a constructor in %s (has %d constructors)...\n", sym, imp.Path, len(depIface.Constructors))
 						for range depIface.Constructors {
@@ -529,9 +733,36 @@ func runModule(cfg Config, src Source) (Result, error) {
 
 		// Elaborate to Core
 		elaborator := elaborate.NewElaboratorWithPath(string(modID))
+		// Imports resolve as in loading, not from the module's own directory
+		elaborator.SetModuleLoader(modLoader)
 		elaborator.SetGlobalEnv(globalRefs)
 		// Add builtins to global environment so they can be referenced
 		elaborator.AddBuiltinsToGlobalEnv()
+		// Imported newtype patterns must match unboxed values
+		for ctorName, nt := range newtypes {
+			elaborator.RegisterNewtype(nt.TypeName, ctorName)
+		}
+		// allValues[T] may list constructors the module did not import by name
+		for typeName, ctors := range importedADTs {
+			infos := make([]*elaborate.ConstructorInfo, len(ctors))
+			for i, ctor := range ctors {
+				infos[i] = &elaborate.ConstructorInfo{
+					TypeName:   ctor.TypeName,
+					CtorName:   ctor.CtorName,
+					Arity:      ctor.Arity,
+					IsImported: true,
+					Index:      ctor.Index,
+				}
+				key := fmt.Sprintf("$adt.make_%s_%s", ctor.TypeName, ctor.CtorName)
+				if _, known := externalTypes[key]; !known && ctor.Arity == 0 && ctor.ResultType != nil {
+					externalTypes[key] = &types.Scheme{
+						TypeVars: extractTypeVarsFromType(ctor.ResultType),
+						Type:     ctor.ResultType,
+					}
+				}
+			}
+			elaborator.RegisterImportedADT(typeName, infos)
+		}
 
 		unit.Core, err = elaborator.ElaborateFile(mod.File)
 		if err != nil {
@@ -540,8 +771,23 @@ func runModule(cfg Config, src Source) (Result, error) {
 		}
 
 		// Collect exhaustiveness warnings
-		warnings := elaborator.GetWarnings()
-		result.Warnings = append(result.Warnings, warnings...)
+		for _, w := range elaborator.GetWarnings() {
+			result.Warnings = append(result.Warnings, w)
+		}
+
+		// Warn about references to @deprecated imports
+		var depIfaces []*iface.Iface
+		for _, imp := range mod.File.Imports {
+			if depIface := modLinker.GetIface(imp.Path); depIface != nil {
+				depIfaces = append(depIfaces, depIface)
+			}
+		}
+		for _, w := range collectDeprecationWarnings(unit.Core, depIfaces) {
+			result.Warnings = append(result.Warnings, w)
+		}
+
+		// Transparent type aliases declared here or imported by name
+		aliases := types.ResolveTypeAliases(elaborator.GetTypeAliases(), importedAliases(mod.File, modLinker))
 
 		// Extract constructors from elaborator and store in CompileUnit
 		unit.Constructors = convertConstructors(elaborator.GetConstructors())
@@ -549,63 +795,57 @@ func runModule(cfg Config, src Source) (Result, error) {
 		// Add $adt factory types for this module's constructors to externalTypes
 		// This allows the type checker to know about constructor factories
 		for ctorName, ctorInfo := range unit.Constructors {
-			factoryName := fmt.Sprintf("make_%s_%s", ctorInfo.TypeName, ctorName)
-			factoryKey := fmt.Sprintf("$adt.%s", factoryName)
-
-			// Build factory type: a0 -> a1 -> ... -> TypeName
-			// Use TVar2 (new type system) for type variables with Star kind
-			var typeVars []string
-			var paramTypes []types.Type
-			for i := 0; i < ctorInfo.Arity; i++ {
-				varName := fmt.Sprintf("a%d", i)
-				typeVars = append(typeVars, varName)
-				paramTypes = append(paramTypes, &types.TVar2{Name: varName, Kind: types.Star})
-			}
-
-			// Result type - monomorphic for now (M-P3 limitation)
-			// Full polymorphic ADTs (Option[Int]) will require type application support in unifier
-			resultType := &types.TCon{Name: ctorInfo.TypeName}
-
-			var factoryType types.Type
-			if ctorInfo.Arity == 0 {
-				// Nullary constructor: just the result type
-				factoryType = resultType
-			} else {
-				// Constructor with fields
-				// Use TFunc2 (new type system) for compatibility with unification
-				factoryType = &types.TFunc2{
-					Params:    paramTypes,
-					EffectRow: nil, // Pure constructor
-					Return:    resultType,
-				}
-			}
-
-			// Add to external types with Scheme wrapper
-			// TypeVars allows polymorphism over field types
-			externalTypes[factoryKey] = &types.Scheme{
-				TypeVars: typeVars,
-				Type:     factoryType,
+			fieldTypes := newtypeFieldTypes(ctorInfo, aliases)
+			if fieldTypes != nil {
+				newtypes[ctorName] = &types.Newtype{TypeName: ctorInfo.TypeName, Field: fieldTypes[0]}
 			}
+			externalTypes[fmt.Sprintf("$adt.make_%s_%s", ctorInfo.TypeName, ctorName)] = factoryScheme(ctorInfo, fieldTypes)
 		}
 
 		// Type check with external types from dependencies
 		// Create a local TypeEnv for this module (inherits from global builtins)
 		moduleTypeEnv := types.NewTypeEnvWithBuiltins()
 
-		typeChecker := types.NewCoreTypeCheckerWithInstances(cfg.InstEnv)
+		// Instances declared by this module and its imports
+		instEnv, err := moduleInstanceEnv(cfg.InstEnv, string(modID), mod.File, depIfaces)
+		if err != nil {
+			return result, err
+		}
+
+		typeChecker := types.NewCoreTypeCheckerWithInstances(instEnv)
 		typeChecker.EnableTraceDefaulting(cfg.TraceDefaulting)
 		if cfg.TrackInstantiations {
 			typeChecker.EnableInstantiationTracking()
 		}
 		typeChecker.SetGlobalTypes(externalTypes)
+		for _, ctorInfo := range unit.Constructors {
+			addADTFields(adtFields, ctorInfo.TypeName, ctorInfo.TypeParams, ctorInfo.FieldNames, recordFieldTypes(ctorInfo, aliases))
+		}
+		typeChecker.SetADTFields(adtFields)
+		typeChecker.SetNewtypes(newtypes)
+		typeChecker.SetTypeAnnotations(elaborator.GetTypeAnnotations())
+		typeChecker.SetReturnAnnotations(elaborator.GetReturnAnnotations())
+		typeChecker.SetTypeAliases(aliases)
 
 		// Type check ALL declarations in the module, accumulating types in moduleTypeEnv
 		for i, decl := range unit.Core.Decls {
 			// InferWithConstraints returns the updated env with new bindings
-			_, moduleTypeEnv, _, _, err = typeChecker.InferWithConstraints(decl, moduleTypeEnv)
+			var typed typedast.TypedNode
+			var declType types.Type
+			typed, moduleTypeEnv, declType, _, err = typeChecker.InferWithConstraints(decl, moduleTypeEnv)
 			if err != nil {
 				return result, fmt.Errorf("type error in %s (decl %d): %w", modID, i, err)
 			}
+			// The root's first declaration is what ModeEval evaluates
+			if string(modID) == rootCanonical && i == 0 {
+				rootType = declType
+				rootEffects, _ = typed.GetEffectRow().(*types.Row)
+			}
+		}
+
+		if string(modID) == rootCanonical {
+			result.Defaulting = append(result.Defaulting, typeChecker.DefaultingTraces()...)
+			types.SortDefaultingTraces(result.Defaulting)
 		}
 
 		// Fill operator methods (resolve operators to type class methods)
@@ -613,6 +853,9 @@ func runModule(cfg Config, src Source) (Result, error) {
 		for _, decl := range unit.Core.Decls {
 			typeChecker.FillOperatorMethods(decl)
 		}
+		if string(modID) == rootCanonical {
+			result.Resolved = sortedResolved(typeChecker.GetResolvedConstraints())
+		}
 
 		// Phase 3.5: Operator Lowering
 		// Check if shim is forbidden in CI mode (before any other logic)
@@ -626,37 +869,60 @@ func runModule(cfg Config, src Source) (Result, error) {
 			lowerer := NewOpLowerer(cfg.TypeEnv)
 			// Pass resolved constraints from type checker to lowerer
 			lowerer.SetResolvedConstraints(typeChecker.GetResolvedConstraints())
+			lowerer.SetListConcats(typeChecker.ListConcats())
 			unit.Core, err = lowerer.Lower(unit.Core)
 			if err != nil {
 				return result, fmt.Errorf("lowering error in %s: %w", modID, err)
 			}
 
 			// Guard A: Assert no operators remain
-			// TODO: Re-enable after assert_builtins.go is fixed
-			// if err := AssertNoOperators(unit.Core); err != nil {
-			// 	return result, fmt.Errorf("in module %s: %w", modID, err)
-			// }
+			if err := AssertNoOperators(unit.Core); err != nil {
+				return result, fmt.Errorf("in module %s: %w", modID, err)
+			}
 
 			// Guard B: Assert only builtins appear for ops
-			// TODO: Re-enable after assert_builtins.go is fixed
-			// if err := AssertOnlyBuiltinsForOps(unit.Core); err != nil {
-			// 	return result, fmt.Errorf("in module %s: %w", modID, err)
-			// }
+			if err := AssertOnlyBuiltinsForOps(unit.Core); err != nil {
+				return result, fmt.Errorf("in module %s: %w", modID, err)
+			}
 
 			unit.Core.Flags.Lowered = true
 		}
 
+		// Phase 3.6: Function Inlining, Let Inlining and Constant Folding (optional)
+		if cfg.Optimize {
+			funcInliner := NewFunctionInliner()
+			unit.Core = funcInliner.Inline(unit.Core)
+			result.InlinedCalls += funcInliner.Inlined()
+			inliner := NewLetInliner()
+			unit.Core = inliner.Inline(unit.Core)
+			result.InlinedLets += inliner.Inlined()
+			folder := NewConstantFolder()
+			unit.Core = folder.Fold(unit.Core)
+			result.FoldedOps += folder.Folded()
+		}
+
 		// Build and register interface (using module-local type environment)
 		// Convert pipeline constructors to iface constructors
-		ifaceCtors := convertToIfaceConstructors(unit.Constructors)
+		ifaceCtors := convertToIfaceConstructors(unit.Constructors, aliases)
 		unitIface, err := iface.BuildInterfaceWithTypesAndConstructors(string(modID), unit.Core, moduleTypeEnv, unit.Surface, ifaceCtors)
 		if err != nil {
 			return result, fmt.Errorf("interface build error in %s: %w", modID, err)
 		}
+		for name, typ := range unitIface.Types {
+			typ.Alias = aliases[name]
+		}
 		unit.Iface = unitIface
 		modLinker.RegisterIface(unitIface)
 
 		compiledUnits[string(modID)] = unit
+		cfg.Cache.store(unit)
+	}
+
+	// Phase 3.7: --require-pure forbids effectful builtins anywhere in the program
+	if cfg.RequirePure {
+		if err := checkRequirePure(compiledUnits, rootCanonical); err != nil {
+			return result, err
+		}
 	}
 
 	// Register $adt module after all modules are loaded and their interfaces are built
@@ -705,18 +971,22 @@ func runModule(cfg Config, src Source) (Result, error) {
 	// Create Core evaluator with global resolver
 	coreEval := eval.NewCoreEvaluator()
 	coreEval.SetGlobalResolver(resolver)
+	coreEval.SetEvalTrace(cfg.TraceEval)
+	if cfg.Coverage != nil {
+		WalkCore(rootUnit.Core, cfg.Coverage.AddNode)
+		coreEval.SetCoverage(cfg.Coverage)
+	}
 	// Pass experimental flag only if allowed
 	if cfg.ExperimentalBinopShim && !cfg.RequireLowering && !cfg.FailOnShim {
 		coreEval.SetExperimentalBinopShim(true)
 	}
 
-	// Guard B: Ensure program was lowered (unless using allowed shim)
-	// TODO: Re-enable after assert_builtins.go is fixed
-	// if cfg.RequireLowering || !cfg.ExperimentalBinopShim {
-	// 	if err := AssertProgramLowered(rootUnit.Core); err != nil {
-	// 		return result, err
-	// 	}
-	// }
+	// Guard C: Ensure program was lowered (unless using allowed shim)
+	if cfg.RequireLowering || !cfg.ExperimentalBinopShim {
+		if err := AssertProgramLowered(rootUnit.Core); err != nil {
+			return result, err
+		}
+	}
 
 	// Evaluate the root module ONLY in ModeEval (REPL)
 	// In ModeCheck (CLI run), defer all execution to ModuleRuntime
@@ -727,6 +997,8 @@ func runModule(cfg Config, src Source) (Result, error) {
 				return result, fmt.Errorf("evaluation error: %w", err)
 			}
 			result.Value = value
+			result.Type = rootType
+			result.Effects = rootEffects
 		}
 	}
 	result.PhaseTimings["evaluate"] = time.Since(start).Milliseconds()
@@ -745,11 +1017,12 @@ func runModule(cfg Config, src Source) (Result, error) {
 		}
 
 		loaded := &loader.LoadedModule{
-			Path:    unit.ID,
-			File:    unit.Surface,
-			Core:    unit.Core,
-			Iface:   unit.Iface,
-			Imports: []string{},
+			Path:     unit.ID,
+			File:     unit.Surface,
+			Filename: modules[modID].Filename,
+			Core:     unit.Core,
+			Iface:    unit.Iface,
+			Imports:  []string{},
 		}
 
 		// Extract import paths from AST
@@ -1,8 +1,10 @@
 package pipeline
 
 import (
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/elaborate"
 	"github.com/sunholo/ailang/internal/iface"
+	"github.com/sunholo/ailang/internal/link"
 	"github.com/sunholo/ailang/internal/types"
 )
 
@@ -26,29 +28,96 @@ func convertConstructors(elabCtors map[string]*elaborate.ConstructorInfo) map[st
 		ctors[name] = &ConstructorInfo{
 			TypeName:   elabCtor.TypeName,
 			CtorName:   elabCtor.CtorName,
-			FieldTypes: nil, // We don't have AST types here, will infer from Core
+			FieldTypes: elabCtor.FieldTypes,
+			TypeParams: elabCtor.TypeParams,
 			Arity:      elabCtor.Arity,
+			FieldNames: elabCtor.FieldNames,
+			Newtype:    elabCtor.Newtype,
+			Index:      elabCtor.Index,
 		}
 	}
 	return ctors
 }
 
 // convertToIfaceConstructors converts pipeline constructors to iface constructors
-func convertToIfaceConstructors(pipeCtors map[string]*ConstructorInfo) map[string]*iface.ConstructorInfo {
+func convertToIfaceConstructors(pipeCtors map[string]*ConstructorInfo, aliases map[string]types.Type) map[string]*iface.ConstructorInfo {
 	if pipeCtors == nil {
 		return nil
 	}
 	ifaceCtors := make(map[string]*iface.ConstructorInfo)
 	for name, pipeCtor := range pipeCtors {
 		ifaceCtors[name] = &iface.ConstructorInfo{
-			TypeName: pipeCtor.TypeName,
-			CtorName: pipeCtor.CtorName,
-			Arity:    pipeCtor.Arity,
+			TypeName:         pipeCtor.TypeName,
+			CtorName:         pipeCtor.CtorName,
+			Arity:            pipeCtor.Arity,
+			FieldNames:       pipeCtor.FieldNames,
+			FieldTypes:       newtypeFieldTypes(pipeCtor, aliases),
+			Newtype:          pipeCtor.Newtype,
+			Index:            pipeCtor.Index,
+			TypeParams:       pipeCtor.TypeParams,
+			RecordFieldTypes: recordFieldTypes(pipeCtor, aliases),
 		}
 	}
 	return ifaceCtors
 }
 
+// recordFieldTypes returns the declared field types of a record-style
+// constructor, with aliases expanded, or nil for a positional constructor
+func recordFieldTypes(ctor *ConstructorInfo, aliases map[string]types.Type) []types.Type {
+	if len(ctor.FieldNames) == 0 || len(ctor.FieldTypes) != len(ctor.FieldNames) {
+		return nil
+	}
+	fieldTypes := make([]types.Type, len(ctor.FieldTypes))
+	for i, ft := range ctor.FieldTypes {
+		fieldTypes[i] = types.ExpandAliases(types.TypeFromAST(ft), aliases)
+	}
+	return fieldTypes
+}
+
+// newtypeFieldTypes returns the declared field types of a newtype constructor.
+// Other constructors keep placeholder field types, so it returns nil for them.
+// Type aliases in the field type are expanded.
+func newtypeFieldTypes(ctor *ConstructorInfo, aliases map[string]types.Type) []types.Type {
+	if !ctor.Newtype || len(ctor.FieldTypes) != 1 {
+		return nil
+	}
+	return []types.Type{types.ExpandAliases(types.TypeFromAST(ctor.FieldTypes[0]), aliases)}
+}
+
+// importedAliases collects the type aliases a module imports by name
+func importedAliases(file *ast.File, linker *link.ModuleLinker) map[string]types.Type {
+	aliases := make(map[string]types.Type)
+	for _, imp := range file.Imports {
+		depIface := linker.GetIface(imp.Path)
+		if depIface == nil {
+			continue
+		}
+		for _, sym := range imp.Symbols {
+			if typ, ok := depIface.Types[sym]; ok && typ.Alias != nil {
+				aliases[sym] = typ.Alias
+			}
+		}
+	}
+	return aliases
+}
+
+// addADTFields records the named fields of a record-style constructor under its ADT
+func addADTFields(fields map[string]*types.ADTFields, typeName string, typeParams, fieldNames []string, fieldTypes []types.Type) {
+	if len(fieldNames) == 0 {
+		return
+	}
+	if fields[typeName] == nil {
+		fields[typeName] = &types.ADTFields{Params: typeParams, Fields: make(map[string]types.Type)}
+	}
+	for i, name := range fieldNames {
+		var fieldType types.Type // nil: unknown, only the field's presence is checked
+		if i < len(fieldTypes) {
+			fieldType = fieldTypes[i]
+		}
+		fields[typeName].Fields[name] = fieldType
+	}
+}
+
 // extractTypeVarsFromType extracts type variable names from a type
 // For example: Option[a] -> ["a"], Result[t, e] -> ["t", "e"]
 func extractTypeVarsFromType(typ types.Type) []string {
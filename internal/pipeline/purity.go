@@ -0,0 +1,106 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/builtins"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// ImpureReference is a reference to an effectful builtin found by --require-pure
+type ImpureReference struct {
+	Location ast.Pos // Source location of the reference
+	Module   string  // Module containing the reference
+	Builtin  string  // Effectful builtin name
+	Effect   string  // Effect the builtin performs (e.g., "IO")
+}
+
+func (r *ImpureReference) String() string {
+	effect := r.Effect
+	if effect == "" {
+		effect = "effectful"
+	}
+	msg := fmt.Sprintf("%s (%s) in %s", r.Builtin, effect, r.Module)
+	if r.Location.Line > 0 {
+		msg += fmt.Sprintf(" at %s", r.Location)
+	}
+	return msg
+}
+
+// PurityError reports every effectful builtin referenced by a program that was
+// required to be pure
+type PurityError struct {
+	References []*ImpureReference
+}
+
+func (e *PurityError) Error() string {
+	lines := make([]string, 0, len(e.References)+1)
+	lines = append(lines, fmt.Sprintf("ELB007: --require-pure: program references %d effectful builtin(s):", len(e.References)))
+	for _, ref := range e.References {
+		lines = append(lines, "  "+ref.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// collectImpureReferences finds every reference in prog to a builtin that the
+// spec registry marks as impure, regardless of which capabilities would be
+// granted at run time. References are sorted by location.
+func collectImpureReferences(modID string, prog *core.Program) []*ImpureReference {
+	if prog == nil {
+		return nil
+	}
+
+	var refs []*ImpureReference
+	WalkCore(prog, func(node core.CoreExpr) {
+		vg, ok := node.(*core.VarGlobal)
+		if !ok || vg.Ref.Module != "$builtin" {
+			return
+		}
+		spec, ok := builtins.GetSpec(vg.Ref.Name)
+		if !ok || spec.IsPure {
+			return
+		}
+		refs = append(refs, &ImpureReference{
+			Location: vg.Span(),
+			Module:   modID,
+			Builtin:  vg.Ref.Name,
+			Effect:   spec.Effect,
+		})
+	})
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		a, b := refs[i].Location, refs[j].Location
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return refs
+}
+
+// checkRequirePure returns a PurityError listing the effectful builtin
+// references in every compiled module, the root module's first
+func checkRequirePure(units map[string]*CompileUnit, rootID string) error {
+	ids := make([]string, 0, len(units))
+	for id := range units {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if (ids[i] == rootID) != (ids[j] == rootID) {
+			return ids[i] == rootID
+		}
+		return ids[i] < ids[j]
+	})
+
+	var refs []*ImpureReference
+	for _, id := range ids {
+		refs = append(refs, collectImpureReferences(id, units[id].Core)...)
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	return &PurityError{References: refs}
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
+)
+
+// TestCollectImpureReferences verifies that only effectful builtins are
+// reported, one per use site, in source order
+func TestCollectImpureReferences(t *testing.T) {
+	ref := func(module, name string, line int) *core.VarGlobal {
+		return &core.VarGlobal{
+			CoreNode: core.CoreNode{CoreSpan: ast.Pos{File: "app.ail", Line: line, Column: 3}},
+			Ref:      core.GlobalRef{Module: module, Name: name},
+		}
+	}
+
+	prog := &core.Program{Decls: []core.CoreExpr{
+		&core.App{Func: ref("$builtin", "_io_println", 7), Args: []core.CoreExpr{ref("$builtin", "_str_len", 7)}},
+		&core.App{Func: ref("$builtin", "_net_httpRequest", 2), Args: []core.CoreExpr{ref("lib", "_io_print", 2)}},
+	}}
+
+	refs := collectImpureReferences("app", prog)
+	if len(refs) != 2 {
+		t.Fatalf("expected 2 impure references, got %d", len(refs))
+	}
+	if refs[0].Builtin != "_net_httpRequest" || refs[1].Builtin != "_io_println" {
+		t.Errorf("references not in source order: %s, %s", refs[0].Builtin, refs[1].Builtin)
+	}
+	if got := refs[1].String(); got != "_io_println (IO) in app at app.ail:7:3" {
+		t.Errorf("unexpected reference description: %s", got)
+	}
+}
+
+// TestRun_RequirePure verifies that --require-pure rejects effectful builtins
+// and leaves pure programs alone
+func TestRun_RequirePure(t *testing.T) {
+	check := func(code string, requirePure bool) error {
+		path := filepath.Join(t.TempDir(), "kernel.ail")
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Run(Config{Mode: ModeCheck, RequirePure: requirePure}, Source{Code: code, Filename: path})
+		return err
+	}
+	impure := "let n = 3 in\n_io_println(\"hi\")"
+
+	err := check(impure, true)
+	var purityErr *PurityError
+	if !errors.As(err, &purityErr) {
+		t.Fatalf("expected PurityError, got %v", err)
+	}
+	if len(purityErr.References) != 1 || purityErr.References[0].Location.Line != 2 {
+		t.Errorf("expected one reference on line 2, got %v", purityErr.References)
+	}
+	if !strings.HasPrefix(err.Error(), "ELB007") {
+		t.Errorf("expected ELB007 error, got %v", err)
+	}
+
+	// Without the flag, the effect system (not the purity check) governs it
+	if err := check(impure, false); err != nil {
+		t.Errorf("expected impure program to check without --require-pure, got %v", err)
+	}
+
+	if err := check("let square = \\n. n * n in square(4)", true); err != nil {
+		t.Errorf("expected pure program to pass --require-pure, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCheck_RecordADTFieldTypes verifies that p.x on a record-style ADT has
+// the field's declared type
+func TestCheck_RecordADTFieldTypes(t *testing.T) {
+	decls := `export type Point = Point { x: int, name: string }
+export type Box[a] = Box { val: a, tag: string }
+`
+	if _, err := checkModuleSource(t, "record_adt_ok", decls+`
+export func label(p: Point) -> string = p.name ++ show(p.x + 1)
+export func unbox() -> int = Box(41, "t").val + 1
+`); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"int field used as string", `export func f() -> string = Point(1, "a").x ++ "oops"`},
+		{"string field used as int", `export func f() -> int = Point(1, "a").name + 1`},
+		{"non-parameter field of a generic ADT", `export func f() -> int = Box(1, "t").tag + 1`},
+		{"argument whose field is used at another type", `export func f(p: Point) -> string = p.x ++ "oops"
+export func g() -> string = f(Point(1, "a"))`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkModuleSource(t, "record_adt_bad", decls+tt.body+"\n")
+			if err == nil || !strings.Contains(err.Error(), "cannot unify") {
+				t.Errorf("expected a type error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/runtime"
+)
+
+// evalREPLExpr runs a single expression as ailang eval-expr does
+func evalREPLExpr(t *testing.T, code string, caps ...string) (Result, error) {
+	t.Helper()
+	evaluator := eval.NewCoreEvaluator()
+	effCtx := effects.NewEffContext()
+	for _, c := range caps {
+		effCtx.Grant(effects.NewCapability(c))
+	}
+	evaluator.SetEffContext(effCtx)
+	builtins := runtime.NewBuiltinRegistry(evaluator)
+	cfg := Config{Mode: ModeEval, GlobalResolver: runtime.NewBuiltinOnlyResolver(builtins)}
+	return Run(cfg, Source{Code: code, Filename: "<repl>", IsREPL: true})
+}
+
+func TestRun_REPLExpression(t *testing.T) {
+	tests := []struct {
+		code, value, typ string
+	}{
+		{"1 + 2 * 3", "7", "int"},
+		{"3.5 * 2.0", "7.0", "float"},
+		{"show(1)", "1", "string"},
+		{"(1, true)", "(1, true)", "(int, bool)"},
+		{"let x = 2 in x * x", "4", "int"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			result, err := evalREPLExpr(t, tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Value == nil || result.Value.String() != tt.value {
+				t.Errorf("value = %v, want %s", result.Value, tt.value)
+			}
+			if result.Type == nil || result.Type.String() != tt.typ {
+				t.Errorf("type = %v, want %s", result.Type, tt.typ)
+			}
+		})
+	}
+}
+
+func TestRun_REPLExpressionCaps(t *testing.T) {
+	if _, err := evalREPLExpr(t, `_io_print("")`); err == nil {
+		t.Error("expected an error without the IO capability")
+	}
+	if _, err := evalREPLExpr(t, `_io_print("")`, "IO"); err != nil {
+		t.Errorf("expected _io_print to run with IO granted, got %v", err)
+	}
+}
+
+// TestRun_REPLExpressionADT checks that constructors declared with the
+// expression, and std/option's Some and None, type-check and evaluate without
+// a module resolver
+func TestRun_REPLExpressionADT(t *testing.T) {
+	tests := []struct {
+		code, value, typ string
+	}{
+		{"type Color = Red | Green\nRed", "Red", "Color"},
+		{"type Box = Box(int) | Empty\nBox(3)", "Box(3)", "Box"},
+		{"type M = J(int) | N\nmatch J(4) { J(x) => x, N => 0 }", "4", "int"},
+		{"Some(3)", "Some(3)", "Option"},
+		{"None", "None", "Option"},
+		{"match Some(3) { Some(x) => x, None => 0 }", "3", "int"},
+		{"type Maybe = Some(int) | None\nSome(1)", "Some(1)", "Maybe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := evalREPLExpr(t, tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Value == nil || result.Value.String() != tt.value {
+				t.Errorf("value = %v, want %s", result.Value, tt.value)
+			}
+			if result.Type == nil || result.Type.String() != tt.typ {
+				t.Errorf("type = %v, want %s", result.Type, tt.typ)
+			}
+		})
+	}
+}
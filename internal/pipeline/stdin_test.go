@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// chdirTemp runs the test from a fresh temporary directory
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	return dir
+}
+
+// TestRun_StdinModule checks that a module read from stdin is loaded under
+// its declared path, imports project modules from the current directory and
+// reports positions in <stdin>
+func TestRun_StdinModule(t *testing.T) {
+	dir := chdirTemp(t)
+	lib := "module lib/util\n\nexport func inc(x: int) -> int { x + 1 }\n"
+	if err := os.MkdirAll(filepath.Join(dir, "lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lib", "util.ail"), []byte(lib), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code := "module app/main\n\nimport lib/util (inc)\n\nexport func main() -> int { inc(41) }\n"
+	result, err := Run(Config{Mode: ModeCheck}, Source{Code: code, Filename: "app/main.ail", Stdin: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Interface == nil || result.Interface.Module != "app/main" {
+		t.Fatalf("expected interface for app/main, got %+v", result.Interface)
+	}
+
+	bad := "module app/main\n\nexport func main() -> int { 1 + \"x\" }\n"
+	_, err = Run(Config{Mode: ModeCheck}, Source{Code: bad, Filename: "app/main.ail", Stdin: true})
+	if err == nil || !strings.Contains(err.Error(), StdinFilename+":3:") {
+		t.Errorf("expected an error at %s:3, got %v", StdinFilename, err)
+	}
+}
@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRun_TryInScript checks that try catches failures in a non-module file,
+// which is evaluated by a different evaluator than the builtin registry's
+func TestRun_TryInScript(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`try(func() => 10 / 0)`, "Err([RT_DIV0] Division by zero)"},
+		{`try(func() => error("boom"))`, "Err(boom)"},
+		{`try(func() => 1 + 1)`, "Ok(2)"},
+		{`try(func() => match 2 { 1 => "one" })`, "Err([RT_MATCH] no pattern matched in match expression)"},
+	}
+	for _, tt := range tests {
+		result, err := runFileSource(t, "try.ail", tt.code)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.code, err)
+		}
+		if got := result.Value.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+// TestCheck_TryEffects checks that try accepts an effectful thunk and that
+// the thunk's effects reach the caller
+func TestCheck_TryEffects(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	result, err := checkModuleSource(t, "try_io", `import std/io (println)
+
+export func f() -> Result[(), string] {
+  try(func() => println("x"))
+}
+`)
+	if err != nil {
+		t.Fatalf("effectful thunk should type-check under try: %v", err)
+	}
+	if got := result.Interface.Exports["f"].Type.String(); !strings.Contains(got, "! {IO}") {
+		t.Errorf("try should carry the thunk's IO effect, got %s", got)
+	}
+}
@@ -0,0 +1,163 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRun_TypeAnnotations checks that let and lambda parameter annotations
+// decide the type of literals and reject values of another type
+func TestRun_TypeAnnotations(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`let x: float = 3 in x`, "3.0"},
+		{`let x: Float = 3 in x / 2`, "1.5"},
+		{`(\(x: float). x + 1)(2)`, "3.0"},
+		{`(\(x: Int) y. x + y)(1)(2)`, "3"},
+		{`let f: (float) -> float = \x. x * 2 in f(1)`, "2.0"},
+		{`let id: (a) -> a = \x. x in id(true)`, "true"},
+		{`let xs: List[int] = [1] in xs ++ [2]`, "[1, 2]"},
+		{"type Box[a] = Box(a)\nlet b: Box[int] = Box(1) in b", "Box(1)"},
+	}
+	for _, tt := range tests {
+		result, err := runFileSource(t, "annot.ail", tt.code)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.code, err)
+		}
+		if got := result.Value.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+// TestRun_TypeAnnotationMismatch checks that a value disagreeing with its
+// annotation is a type error naming the binding
+func TestRun_TypeAnnotationMismatch(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`let n: int = "three" in n`, "type annotation of n"},
+		{`let x: int = 2.5 in x`, "Fractional[int]"},
+		{`(\(b: bool). b + 1)(true)`, "Num[bool]"},
+		{`let xs: List[int] = "hi" in xs`, "type annotation of xs"},
+		{`let xs: List[string] = [1] in xs ++ ["a"]`, "Num[string]"},
+		{`let ys: [int] = ["a"] in ys`, "type annotation of ys"},
+		{"type Box[a] = Box(a)\nlet b: Box[int] = [1] in b", "type annotation of b"},
+		{"type Opt = Has(int) | Empty\nlet o: Opt = true in o", "type annotation of o"},
+	}
+	for _, tt := range tests {
+		_, err := runFileSource(t, "annot.ail", tt.code)
+		if err == nil {
+			t.Errorf("%s: expected a type error", tt.code)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected %q in %v", tt.code, tt.want, err)
+		}
+	}
+}
+
+// TestCheck_ReturnTypeAnnotations checks that a declared return type
+// constrains the body: it picks the default of a literal result and rejects
+// a body of another type
+func TestCheck_ReturnTypeAnnotations(t *testing.T) {
+	code := `export func three() -> float { 3 }
+export func double() -> (float) -> float { \x. x * 2 }
+`
+	result, err := checkModuleSource(t, "return_annot", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"three":  "() -> float",
+		"double": "() -> float -> float",
+	} {
+		item, ok := result.Interface.Exports[name]
+		if !ok {
+			t.Errorf("%s not exported", name)
+			continue
+		}
+		if got := item.Type.String(); got != want {
+			t.Errorf("%s: expected type %s, got %s", name, want, got)
+		}
+	}
+
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	for name, code := range map[string]string{
+		"return_annot_bad":    "export func f() -> int { \"x\" }\n",
+		"return_annot_option": "import std/option (Option, Some)\n\nexport func f(x: int) -> Option[int] { x }\n",
+		"return_annot_list":   "export func f(x: int) -> List[string] { [x] }\n",
+	} {
+		_, err = checkModuleSource(t, name, code)
+		if err == nil || !strings.Contains(err.Error(), "declared return type") {
+			t.Errorf("%s: expected a declared return type error, got %v", name, err)
+		}
+	}
+
+	_, err = checkModuleSource(t, "return_annot_some", "import std/option (Option, Some)\n\nexport func f(x: int) -> Option[int] { Some(x) }\n")
+	if err != nil {
+		t.Errorf("Some(x) should satisfy Option[int]: %v", err)
+	}
+}
+
+// TestCheck_ParamTypeAnnotations checks that the parameter types of a
+// function declaration constrain its callers, and that an unannotated
+// function type parameter still accepts an effectful function
+func TestCheck_ParamTypeAnnotations(t *testing.T) {
+	_, err := checkModuleSource(t, "param_annot_bad", "export func f(x: int) -> int { x + 1 }\nexport func g() -> int { f(\"a\") }\n")
+	if err == nil || !strings.Contains(err.Error(), "cannot unify") {
+		t.Errorf("expected f(\"a\") to be rejected, got %v", err)
+	}
+
+	t.Setenv("AILANG_STDLIB_PATH", findStdlibPath(t))
+	_, err = checkModuleSource(t, "param_annot_effects", `import std/io (println)
+
+export func apply(f: (int) -> (), x: int) -> () ! {IO} { f(x) }
+export func main() -> () ! {IO} { apply(\n. println(show(n)), 1) }
+`)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestCheck_TypeAliases checks that an alias is interchangeable with the type
+// it stands for and is recorded in the module interface
+func TestCheck_TypeAliases(t *testing.T) {
+	code := `export type Celsius = Float
+export type Reading = Reading(Celsius)
+
+export func freeze() -> Celsius { 0 }
+export func warm(r: Reading) -> bool { match r { Reading(c) => c > freeze() } }
+export func mk() -> Reading { Reading(21.5) }
+`
+	result, err := checkModuleSource(t, "aliases", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Interface.Exports["freeze"].Type.String(); got != "() -> float" {
+		t.Errorf("freeze: expected () -> float, got %s", got)
+	}
+	celsius, ok := result.Interface.Types["Celsius"]
+	if !ok || celsius.Alias == nil || celsius.Alias.String() != "float" {
+		t.Errorf("expected Celsius recorded as an alias of float, got %+v", celsius)
+	}
+
+	_, err = checkModuleSource(t, "aliases_bad", "type Celsius = float\nexport func f() -> Celsius { \"hot\" }\n")
+	if err == nil || !strings.Contains(err.Error(), "declared return type") {
+		t.Errorf("expected a declared return type error, got %v", err)
+	}
+}
+
+func TestCheck_UndefinedVariableSuggestion(t *testing.T) {
+	code := `export func f(total: int) -> int {
+  totl + 1
+}
+`
+	_, err := checkModuleSource(t, "suggest_name", code)
+	if err == nil || !strings.Contains(err.Error(), "undefined variable: totl") || !strings.Contains(err.Error(), "(did you mean 'total'?)") {
+		t.Errorf("expected a suggestion for 'total', got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCheck_WhereHelpers verifies that where helpers see the enclosing
+// function's parameters and each other, and are not visible outside it
+func TestCheck_WhereHelpers(t *testing.T) {
+	code := `export func f(x: int) -> int = g(x) + 1 where g(y: int) -> int = y * 2
+
+export func scale(x: int) -> int = by(3) where by(k) = k * x
+
+export func isEven(n: int) -> bool {
+  ev(n)
+} where ev(k) = if k == 0 then true else od(k - 1),
+        od(k) = if k == 0 then false else ev(k - 1)
+`
+	result, err := checkModuleSource(t, "where_helpers", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"f", "scale", "isEven"} {
+		if _, ok := result.Interface.Exports[name]; !ok {
+			t.Errorf("%s not exported", name)
+		}
+	}
+	for _, helper := range []string{"g", "by", "ev", "od"} {
+		if _, ok := result.Interface.Exports[helper]; ok {
+			t.Errorf("where helper %s leaked into the module interface", helper)
+		}
+	}
+
+	code = `export func f(x: int) -> int = g(x) where g(y) = y
+
+export func h(x: int) -> int = g(x)
+`
+	_, err = checkModuleSource(t, "where_scope", code)
+	if err == nil || !strings.Contains(err.Error(), "undefined variable: g") {
+		t.Errorf("expected where helper to be out of scope in h, got %v", err)
+	}
+}
+
+// checkModuleSource typechecks a module with the given body without
+// evaluating it. The module declaration is derived from the temp file path.
+func checkModuleSource(t *testing.T, name, body string) (Result, error) {
+	t.Helper()
+	// t.TempDir ends in a numeric segment, which is not a valid module path
+	dir, err := os.MkdirTemp("", "ailang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, name+".ail")
+	code := "module " + strings.TrimPrefix(filepath.ToSlash(strings.TrimSuffix(path, ".ail")), "/") + "\n\n" + body
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return Run(Config{Mode: ModeCheck}, Source{Code: code, Filename: path})
+}
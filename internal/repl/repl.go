@@ -1,6 +1,7 @@
 package repl
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"os"
@@ -14,18 +15,20 @@ import (
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/effects"
 	"github.com/sunholo/ailang/internal/eval"
+	"github.com/sunholo/ailang/internal/pipeline"
 	"github.com/sunholo/ailang/internal/runtime"
+	"github.com/sunholo/ailang/internal/termcolor"
 	"github.com/sunholo/ailang/internal/types"
 )
 
 // Color functions for pretty output
 var (
-	green  = color.New(color.FgGreen).SprintFunc()
-	red    = color.New(color.FgRed).SprintFunc()
-	yellow = color.New(color.FgYellow).SprintFunc()
-	cyan   = color.New(color.FgCyan).SprintFunc()
-	bold   = color.New(color.Bold).SprintFunc()
-	dim    = color.New(color.Faint).SprintFunc()
+	green  = termcolor.Func(color.FgGreen)
+	red    = termcolor.Func(color.FgRed)
+	yellow = termcolor.Func(color.FgYellow)
+	cyan   = termcolor.Func(color.FgCyan)
+	bold   = termcolor.Func(color.Bold)
+	dim    = termcolor.Func(color.Faint)
 )
 
 // Config holds REPL configuration
@@ -35,6 +38,7 @@ type Config struct {
 	ShowTyped       bool
 	DryLink         bool
 	Verbose         bool
+	NoPrelude       bool // Skip the std/prelude auto-import (--no-prelude)
 	ImportedModules []string
 }
 
@@ -46,6 +50,7 @@ type REPL struct {
 	instEnv    *types.InstanceEnv // Type-level instances and defaults
 	dictReg    *types.DictionaryRegistry
 	instances  map[string]core.DictValue
+	ctors      map[string]*pipeline.ConstructorInfo // ADT constructors in scope (std/option's)
 	history    []string
 	lastResult interface{}
 	version    string // Version info from build
@@ -93,6 +98,7 @@ func NewWithVersion(version, buildTime string) *REPL {
 		instEnv:         types.NewInstanceEnv(),
 		dictReg:         types.NewDictionaryRegistry(),
 		instances:       make(map[string]core.DictValue),
+		ctors:           make(map[string]*pipeline.ConstructorInfo),
 		history:         []string{},
 		version:         version,
 		buildTime:       buildTime,
@@ -112,6 +118,20 @@ func (r *REPL) EnableTrace() {
 	r.config.Verbose = true
 }
 
+// DisablePrelude stops sessions (and :reset) from auto-importing std/prelude,
+// so the core language can be used without its type class instances
+func (r *REPL) DisablePrelude() {
+	r.config.NoPrelude = true
+}
+
+// SetInput makes readLine/readAll in evaluated expressions read from in
+//
+// Embedders without a terminal (such as the browser REPL) use this to supply
+// program input; by default the REPL reads from os.Stdin.
+func (r *REPL) SetInput(in io.Reader) {
+	r.effContext.ProvideInput(in)
+}
+
 // getPrompt returns the REPL prompt with active capabilities
 func (r *REPL) getPrompt() string {
 	if len(r.effContext.Caps) == 0 {
@@ -130,7 +150,16 @@ func (r *REPL) getPrompt() string {
 }
 
 // Start begins the REPL session
+//
+// When in is not a terminal (for example `echo '1 + 2' | ailang repl`) the
+// session runs in pipe mode: no banner or prompts, every line of in is
+// evaluated in order, and the REPL exits at EOF.
 func (r *REPL) Start(in io.Reader, out io.Writer) {
+	if !isInteractive(in) {
+		r.startPiped(in, out)
+		return
+	}
+
 	// Create liner instance for readline functionality
 	line := liner.NewLiner()
 	defer line.Close()
@@ -163,18 +192,14 @@ func (r *REPL) Start(in io.Reader, out io.Writer) {
 	fmt.Fprintln(out, dim("Use ↑/↓ arrows to navigate history"))
 	fmt.Fprintln(out)
 
-	// Initialize built-in instances
-	r.initBuiltins()
-
-	// Auto-import prelude for type class instances
-	r.importModule("std/prelude", io.Discard)
+	r.PrepareSession()
 
 	// Add command completion
 	line.SetCompleter(func(line string) (c []string) {
 		if strings.HasPrefix(line, ":") {
 			commands := []string{":help", ":quit", ":type", ":import", ":dump-core",
 				":dump-typed", ":dry-link", ":trace-defaulting", ":instances",
-				":history", ":clear", ":reset"}
+				":kind", ":history", ":clear", ":reset"}
 			for _, cmd := range commands {
 				if strings.HasPrefix(cmd, line) {
 					c = append(c, cmd)
@@ -204,11 +229,8 @@ func (r *REPL) Start(in io.Reader, out io.Writer) {
 			continue
 		}
 
-		// Check if input needs continuation (ends with "in" or other indicators)
-		needsContinuation := strings.HasSuffix(input, " in") || strings.HasSuffix(input, "\tin")
-
 		// Multi-line input support
-		if needsContinuation {
+		if needsContinuation(input) {
 			// Continue reading lines until we get a complete expression
 			var lines []string
 			lines = append(lines, input)
@@ -226,10 +248,7 @@ func (r *REPL) Start(in io.Reader, out io.Writer) {
 
 				lines = append(lines, contInput)
 
-				// Check if we have a complete expression
-				// For now, just check if the line is non-empty and doesn't end with certain keywords
-				trimmed := strings.TrimSpace(contInput)
-				if trimmed != "" && !strings.HasSuffix(trimmed, " in") && !strings.HasSuffix(trimmed, ",") {
+				if completesInput(contInput) {
 					break
 				}
 			}
@@ -240,22 +259,10 @@ func (r *REPL) Start(in io.Reader, out io.Writer) {
 		// Add to liner history
 		line.AppendHistory(input)
 
-		// Add to our internal history
-		r.history = append(r.history, input)
-
-		// Handle commands
-		if strings.HasPrefix(input, ":") {
-			// Check if it's a quit command
-			if strings.HasPrefix(input, ":quit") || strings.HasPrefix(input, ":q") || strings.HasPrefix(input, ":exit") {
-				fmt.Fprintln(out, green("Goodbye!"))
-				break // Exit the loop
-			}
-			r.HandleCommand(input, out)
-			continue
+		if r.handleInput(input, out) {
+			fmt.Fprintln(out, green("Goodbye!"))
+			break // Exit the loop
 		}
-
-		// Process expression through full pipeline
-		r.ProcessExpression(input, out)
 	}
 
 	// Save history before exiting
@@ -264,3 +271,128 @@ func (r *REPL) Start(in io.Reader, out io.Writer) {
 		f.Close()
 	}
 }
+
+// startPiped runs a non-interactive session over in, stopping at EOF or :quit
+func (r *REPL) startPiped(in io.Reader, out io.Writer) {
+	r.PrepareSession()
+	r.runLines(in, out, false)
+}
+
+// RunScript runs a file of REPL inputs (expressions and :commands, one per
+// line, continued as at the prompt) and stops at its end or at :quit. Like
+// pipe mode it prints only the results, without prompts, so the output can
+// be kept as a snapshot. Lines starting with -- are comments.
+//
+// Example (ailang repl --script demo.repl):
+//
+//	-- demo.repl
+//	:type \x. x + 1
+//	1 + 2
+func (r *REPL) RunScript(in io.Reader, out io.Writer) {
+	r.PrepareSession()
+	r.runLines(in, out, true)
+}
+
+// runLines evaluates the inputs read from in, skipping -- comments if script
+// is set
+func (r *REPL) runLines(in io.Reader, out io.Writer, script bool) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" || (script && strings.HasPrefix(input, "--")) {
+			continue
+		}
+
+		// Multi-line input follows the same rules as the interactive loop
+		if needsContinuation(input) {
+			lines := []string{input}
+			complete := false
+			for !complete && scanner.Scan() {
+				lines = append(lines, scanner.Text())
+				complete = completesInput(scanner.Text())
+			}
+			if !complete {
+				fmt.Fprintln(out, red("Incomplete expression"))
+			}
+			input = strings.Join(lines, "\n")
+		}
+
+		if r.handleInput(input, out) {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(out, "%s: %v\n", red("Error"), err)
+	}
+}
+
+// PrepareSession loads the instances and modules every session starts with
+//
+// Start calls it; embedders that drive the REPL without Start (such as the
+// browser REPL) call it once before evaluating anything.
+func (r *REPL) PrepareSession() {
+	// Initialize built-in instances
+	r.initBuiltins()
+
+	r.importDefaults()
+}
+
+// importDefaults auto-imports prelude for type class instances, std/io
+// so print works without --caps (the REPL grants IO), and std/option for
+// Some and None. Without the prelude the
+// numeric literal defaults stay, so `1 + 2` reports the missing instance.
+// It also binds try, whose thunk must run on the session's evaluator.
+func (r *REPL) importDefaults() {
+	if r.config.NoPrelude {
+		r.instEnv.SetDefault("Num", types.TInt)
+		r.instEnv.SetDefault("Fractional", types.TFloat)
+	} else {
+		r.importModule("std/prelude", io.Discard)
+	}
+	r.importModule("std/io", io.Discard)
+	r.importModule("std/option", io.Discard)
+	r.env.Set("try", r.evaluator.TryBuiltin())
+}
+
+// handleInput runs one complete input (a command or an expression) and
+// reports whether it asked to end the session
+func (r *REPL) handleInput(input string, out io.Writer) bool {
+	r.history = append(r.history, input)
+
+	// Handle commands
+	if strings.HasPrefix(input, ":") {
+		if strings.HasPrefix(input, ":quit") || strings.HasPrefix(input, ":q") || strings.HasPrefix(input, ":exit") {
+			return true
+		}
+		r.HandleCommand(input, out)
+		return false
+	}
+
+	// Process expression through full pipeline
+	r.ProcessExpression(input, out)
+	return false
+}
+
+// needsContinuation reports whether input ends in a way that needs more lines
+func needsContinuation(input string) bool {
+	return strings.HasSuffix(input, " in") || strings.HasSuffix(input, "\tin")
+}
+
+// completesInput reports whether a continuation line ends a multi-line input
+// For now, just check if the line is non-empty and doesn't end with certain keywords
+func completesInput(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" && !strings.HasSuffix(trimmed, " in") && !strings.HasSuffix(trimmed, ",")
+}
+
+// isInteractive reports whether in is a terminal. Anything else (a pipe, a
+// file or an in-memory reader) is read in pipe mode.
+func isInteractive(in io.Reader) bool {
+	f, ok := in.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -3,6 +3,7 @@ package repl
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/sunholo/ailang/internal/elaborate"
 	"github.com/sunholo/ailang/internal/eval"
 	"github.com/sunholo/ailang/internal/lexer"
+	"github.com/sunholo/ailang/internal/loader"
 	"github.com/sunholo/ailang/internal/parser"
+	"github.com/sunholo/ailang/internal/pipeline"
 	"github.com/sunholo/ailang/internal/schema"
 	"github.com/sunholo/ailang/internal/test"
 	"github.com/sunholo/ailang/internal/types"
@@ -79,8 +82,30 @@ func (r *REPL) HandleCommand(cmd string, out io.Writer) {
 		fmt.Fprintf(out, "Defaulting trace %s\n", yellow(parts[1]))
 
 	case ":instances":
+		if len(parts) >= 2 {
+			r.showClassInstances(parts[1], out)
+			return
+		}
 		r.showInstances(out)
 
+	case ":doc", ":d":
+		if len(parts) < 2 {
+			fmt.Fprintln(out, "Usage: :doc <module> [name]")
+			return
+		}
+		name := ""
+		if len(parts) > 2 {
+			name = parts[2]
+		}
+		showDoc(parts[1], name, out)
+
+	case ":kind", ":k":
+		if len(parts) < 2 {
+			fmt.Fprintln(out, "Usage: :kind <type>")
+			return
+		}
+		showKind(parts[1], out)
+
 	case ":history":
 		r.showHistory(out)
 
@@ -91,9 +116,13 @@ func (r *REPL) HandleCommand(cmd string, out io.Writer) {
 		r.env = eval.NewEnvironment()
 		r.typeEnv = types.NewTypeEnvWithBuiltins() // Reload builtins on reset
 		r.instEnv = types.NewInstanceEnv()
-		// Re-import prelude after reset
-		r.importModule("std/prelude", io.Discard)
-		fmt.Fprintln(out, green("Environment reset (prelude auto-imported)"))
+		// Re-import prelude, std/io and std/option after reset
+		r.importDefaults()
+		if r.config.NoPrelude {
+			fmt.Fprintln(out, green("Environment reset (std/io auto-imported)"))
+		} else {
+			fmt.Fprintln(out, green("Environment reset (prelude and std/io auto-imported)"))
+		}
 
 	case ":effects":
 		if len(parts) < 2 {
@@ -202,6 +231,8 @@ func (r *REPL) showType(input string, out io.Writer) {
 	// Type check with instance environment for defaulting
 	typeChecker := types.NewCoreTypeCheckerWithInstances(r.instEnv)
 	typeChecker.EnableTraceDefaulting(r.config.TraceDefaulting)
+	typeChecker.SetTypeAnnotations(elaborator.GetTypeAnnotations())
+	typeChecker.SetReturnAnnotations(elaborator.GetReturnAnnotations())
 
 	typedNode, _, qualType, constraints, err := typeChecker.InferWithConstraints(coreExpr, r.typeEnv)
 	if err != nil {
@@ -222,6 +253,14 @@ func (r *REPL) showType(input string, out io.Writer) {
 	fmt.Fprintf(out, "%s :: %s\n", input, cyan(prettyType))
 }
 
+// replIOBindings are the std/io functions available after :import std/io
+var replIOBindings = []struct{ name, builtin string }{
+	{"print", "_io_print"},
+	{"println", "_io_println"},
+	{"readLine", "_io_readLine"},
+	{"readAll", "_io_readAll"},
+}
+
 // importModule loads type class instances from a module
 func (r *REPL) importModule(module string, out io.Writer) {
 	switch module {
@@ -296,7 +335,7 @@ func (r *REPL) importModule(module string, out io.Writer) {
 			TypeClass: "Show",
 			Type:      "Float",
 			Methods: map[string]interface{}{
-				"show": func(a float64) string { return fmt.Sprintf("%g", a) },
+				"show": func(a float64) string { return eval.FormatFloat(a) },
 			},
 		}
 
@@ -324,6 +363,38 @@ func (r *REPL) importModule(module string, out io.Writer) {
 		r.config.ImportedModules = append(r.config.ImportedModules, module)
 		fmt.Fprintf(out, "%s Imported %s\n", green("✓"), module)
 
+	case "std/io":
+		// Bind the print family straight to the IO builtins. The REPL's
+		// effect context grants IO, and the builtins still check it.
+		for _, binding := range replIOBindings {
+			typ, err := r.typeEnv.Lookup(binding.builtin)
+			val, ok := r.builtinRegistry.Get(binding.builtin)
+			if err != nil || !ok {
+				fmt.Fprintf(out, "%s: builtin %s is not registered\n", red("Error"), binding.builtin)
+				return
+			}
+			if scheme, isScheme := typ.(*types.Scheme); isScheme {
+				r.typeEnv = r.typeEnv.ExtendScheme(binding.name, scheme)
+			} else {
+				r.typeEnv = r.typeEnv.Extend(binding.name, typ.(types.Type))
+			}
+			r.env.Set(binding.name, val)
+		}
+
+		r.config.ImportedModules = append(r.config.ImportedModules, module)
+		fmt.Fprintf(out, "%s Imported %s\n", green("✓"), module)
+
+	case "std/option":
+		// Some and None elaborate to $adt factories, which the evaluator
+		// builds directly
+		for _, ctor := range pipeline.OptionConstructors {
+			r.ctors[ctor.CtorName] = ctor
+			r.evaluator.RegisterConstructor(pipeline.EvalConstructor(ctor))
+		}
+
+		r.config.ImportedModules = append(r.config.ImportedModules, module)
+		fmt.Fprintf(out, "%s Imported %s\n", green("✓"), module)
+
 	default:
 		fmt.Fprintf(out, "%s: Unknown module %s\n", red("Error"), module)
 	}
@@ -356,6 +427,131 @@ func (r *REPL) showInstances(out io.Writer) {
 	}
 }
 
+// showClassInstances lists the types with an instance of class, from both
+// the type-level InstanceEnv and the runtime dictionaries
+func (r *REPL) showClassInstances(class string, out io.Writer) {
+	seen := make(map[string]bool)
+	for _, name := range r.instEnv.InstancesOf(class) {
+		seen[name] = true
+	}
+	for _, dict := range r.instances {
+		if dict.TypeClass == class {
+			seen[dict.Type] = true
+		}
+	}
+	if len(seen) == 0 {
+		fmt.Fprintf(out, "No instances of %s\n", class)
+		return
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(out, "%s: %s\n", yellow(class), strings.Join(names, ", "))
+}
+
+// typeConstructorArity gives the number of type parameters of the type
+// constructors a REPL session knows about
+var typeConstructorArity = map[string]int{
+	"Int":    0,
+	"Float":  0,
+	"String": 0,
+	"Bool":   0,
+	"Unit":   0,
+	"Bytes":  0,
+	"BigInt": 0,
+	"List":   1,
+	"Option": 1,
+	"Result": 2,
+}
+
+// showKind prints the kind of a type constructor, e.g. List : * -> *
+func showKind(name string, out io.Writer) {
+	// Primitive types are written either way: int or Int
+	canonical := name
+	if name != "" {
+		canonical = strings.ToUpper(name[:1]) + name[1:]
+	}
+	arity, ok := typeConstructorArity[canonical]
+	if !ok {
+		fmt.Fprintf(out, "%s: unknown type %s\n", red("Error"), name)
+		return
+	}
+	kind := strings.Repeat(types.Star.String()+" -> ", arity) + types.Star.String()
+	fmt.Fprintf(out, "%s : %s\n", canonical, cyan(kind))
+}
+
+// showDoc prints the doc comments of a module's exported functions and
+// types, or of the one export given by name
+func showDoc(module, name string, out io.Writer) {
+	mod, err := loader.NewModuleLoader(".").Load(module)
+	if err != nil {
+		fmt.Fprintf(out, "%s: %v\n", red("Error"), err)
+		return
+	}
+
+	entries := make(map[string]string) // export name -> signature and doc
+	for fname, fn := range mod.Exports {
+		params := make([]string, len(fn.Params))
+		for i, p := range fn.Params {
+			params[i] = p.Name
+			if p.Type != nil {
+				params[i] += ": " + p.Type.String()
+			}
+		}
+		sig := "func " + fname
+		if len(fn.TypeParams) > 0 {
+			sig += "[" + strings.Join(fn.TypeParams, ", ") + "]"
+		}
+		sig += "(" + strings.Join(params, ", ") + ")"
+		if fn.ReturnType != nil {
+			sig += " -> " + fn.ReturnType.String()
+		}
+		entries[fname] = docEntry(sig, fn.Doc)
+	}
+	for tname, td := range mod.Types {
+		sig := "type " + tname
+		if len(td.TypeParams) > 0 {
+			sig += "[" + strings.Join(td.TypeParams, ", ") + "]"
+		}
+		entries[tname] = docEntry(sig, td.Doc)
+	}
+
+	if name != "" {
+		entry, ok := entries[name]
+		if !ok {
+			fmt.Fprintf(out, "%s: %s does not export %s\n", red("Error"), module, name)
+			return
+		}
+		fmt.Fprint(out, entry)
+		return
+	}
+	names := make([]string, 0, len(entries))
+	for n := range entries {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprint(out, entries[n])
+	}
+}
+
+// docEntry renders a signature with its doc comment indented below it
+func docEntry(sig, doc string) string {
+	var sb strings.Builder
+	sb.WriteString(cyan(sig) + "\n")
+	if doc == "" {
+		sb.WriteString("  (undocumented)\n")
+		return sb.String()
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		sb.WriteString(strings.TrimRight("  "+line, " ") + "\n")
+	}
+	return sb.String()
+}
+
 // showHistory displays command history
 func (r *REPL) showHistory(out io.Writer) {
 	for i, cmd := range r.history {
@@ -375,7 +571,9 @@ func (r *REPL) printHelp(out io.Writer) {
 	fmt.Fprintln(out, "  :dump-typed             Toggle Typed AST display")
 	fmt.Fprintln(out, "  :dry-link               Show required instances without evaluating")
 	fmt.Fprintln(out, "  :trace-defaulting on|off Enable/disable defaulting trace")
-	fmt.Fprintln(out, "  :instances              Show available type class instances")
+	fmt.Fprintln(out, "  :instances [class]      Show available type class instances")
+	fmt.Fprintln(out, "  :kind <type>            Show the kind of a type constructor")
+	fmt.Fprintln(out, "  :doc <module> [name]    Show the doc comments of a module's exports")
 	fmt.Fprintln(out, "  :test [--json]          Run tests (with optional JSON output)")
 	fmt.Fprintln(out, "  :compact on|off         Enable/disable compact JSON mode")
 	fmt.Fprintln(out, "  :propose <plan.json>    Validate an architecture plan")
@@ -391,6 +589,8 @@ func (r *REPL) printHelp(out io.Writer) {
 	fmt.Fprintln(out, "  :effects 1 + 2")
 	fmt.Fprintln(out, "  :test --json")
 	fmt.Fprintln(out, "  :import std/prelude")
+	fmt.Fprintln(out, "  :kind Result")
+	fmt.Fprintln(out, "  :instances Num")
 }
 
 // printParserErrors displays parser errors nicely
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/elaborate"
 	"github.com/sunholo/ailang/internal/eval"
@@ -29,6 +30,9 @@ func (r *REPL) ProcessExpression(input string, out io.Writer) {
 
 	// Step 2: Elaborate to Core (with dictionary-passing)
 	elaborator := elaborate.NewElaborator()
+	for _, ctor := range r.ctors {
+		elaborator.RegisterConstructor(ctor.TypeName, ctor.CtorName, ctor.Arity, true)
+	}
 	coreProg, err := elaborator.Elaborate(program)
 	if err != nil {
 		fmt.Fprintf(out, "%s: %v\n", red("Elaboration error"), err)
@@ -50,6 +54,9 @@ func (r *REPL) ProcessExpression(input string, out io.Writer) {
 	// Step 3: Type check with constraints
 	typeChecker := types.NewCoreTypeCheckerWithInstances(r.instEnv)
 	typeChecker.EnableTraceDefaulting(r.config.TraceDefaulting)
+	typeChecker.SetTypeAnnotations(elaborator.GetTypeAnnotations())
+	typeChecker.SetReturnAnnotations(elaborator.GetReturnAnnotations())
+	typeChecker.SetGlobalTypes(pipeline.FactoryTypes(r.ctors))
 
 	typedNode, updatedEnv, qualType, constraints, err := typeChecker.InferWithConstraints(coreExpr, r.typeEnv)
 	if err != nil {
@@ -111,6 +118,7 @@ func (r *REPL) ProcessExpression(input string, out io.Writer) {
 
 	// Step 5.5: Lower intrinsic operations to dictionary calls
 	lowerer := pipeline.NewOpLowerer(r.typeEnv)
+	lowerer.SetListConcats(typeChecker.ListConcats())
 	loweredProg, err := lowerer.Lower(elaboratedProg)
 	if err != nil {
 		fmt.Fprintf(out, "%s: %v\n", red("Op lowering error"), err)
@@ -181,10 +189,29 @@ func (r *REPL) ProcessExpression(input string, out io.Writer) {
 	// Store result
 	r.lastResult = result
 
+	// A binding statement (let x = 5) prints nothing, like a definition
+	if isLetStatement(program) {
+		return
+	}
+
 	// Pretty print result with type on the same line
 	fmt.Fprintf(out, "%s :: %s\n", formatValue(result), cyan(prettyType))
 }
 
+// isLetStatement reports whether program is a single let without an 'in' body
+func isLetStatement(program *ast.Program) bool {
+	if program.File == nil || len(program.File.Statements) != 1 {
+		return false
+	}
+	switch let := program.File.Statements[0].(type) {
+	case *ast.Let:
+		return let.Body == nil
+	case *ast.LetRec:
+		return let.Body == nil
+	}
+	return false
+}
+
 // initBuiltins initializes built-in type class instances
 func (r *REPL) initBuiltins() {
 	// Wrapper functions to convert Go functions to uniform eval signatures
@@ -78,7 +78,7 @@ func formatValue(val interface{}) string {
 	case int64:
 		return fmt.Sprintf("%d", v)
 	case float64:
-		return fmt.Sprintf("%g", v)
+		return eval.FormatFloat(v)
 	case bool:
 		if v {
 			return "true"
@@ -219,6 +219,12 @@ func (r *REPL) getFinalTypeAfterDefaulting(typedNode typedast.TypedNode, qualTyp
 		}
 	}
 
+	// The typed node carries the fully substituted type (including the
+	// effect rows of functions); the qualified type may still be unsolved
+	if t, ok := nodeType.(types.Type); ok && t != nil {
+		return t
+	}
+
 	// Fall back to the original qualified type
 	return qualType
 }
@@ -263,6 +269,8 @@ func (r *REPL) normalizeTypeName(typ types.Type) string {
 	case *types.TVar:
 		// Format type variables nicely
 		return t.Name
+	case *types.TFunc2:
+		return formatFuncWithEffects(t)
 	case *types.TVar2:
 		// Check if it was defaulted to a concrete type
 		if t.Name == "int" || t.Name == "Int" {
@@ -299,3 +307,51 @@ func (r *REPL) filterResolvedConstraints(constraints []types.Constraint, finalTy
 
 	return remaining
 }
+
+// formatFuncWithEffects renders a function type with its effect row, e.g.
+// "string -> User ! {Net}". Effect labels are sorted and row variables are
+// dropped: a function whose row has no labels is shown without "! {...}".
+// Function-typed parameters are parenthesized so curried HOFs read unambiguously.
+func formatFuncWithEffects(fn *types.TFunc2) string {
+	params := make([]string, len(fn.Params))
+	for i, p := range fn.Params {
+		params[i] = formatEffectType(p)
+		if _, isFunc := p.(*types.TFunc2); isFunc {
+			params[i] = "(" + params[i] + ")"
+		}
+	}
+
+	var sb strings.Builder
+	if len(params) == 1 {
+		sb.WriteString(params[0])
+	} else {
+		sb.WriteString("(" + strings.Join(params, ", ") + ")")
+	}
+	sb.WriteString(" -> ")
+	sb.WriteString(formatEffectType(fn.Return))
+
+	if effects := effectLabels(fn.EffectRow); len(effects) > 0 {
+		sb.WriteString(" ! {" + strings.Join(effects, ", ") + "}")
+	}
+	return sb.String()
+}
+
+func formatEffectType(t types.Type) string {
+	if fn, ok := t.(*types.TFunc2); ok {
+		return formatFuncWithEffects(fn)
+	}
+	return t.String()
+}
+
+// effectLabels returns the sorted effect labels of a row
+func effectLabels(row *types.Row) []string {
+	if row == nil {
+		return nil
+	}
+	labels := make([]string, 0, len(row.Labels))
+	for label := range row.Labels {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -133,6 +133,42 @@ func TestREPLSmoke_TypeCommand(t *testing.T) {
 	t.Logf("✅ All %d REPL :type smoke tests passed", len(tests))
 }
 
+// TestREPLTypeCommand_InferredEffects checks that :type shows the effect row
+// inferred for user-written functions, not just for builtins.
+func TestREPLTypeCommand_InferredEffects(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{`:type \x. _io_println(x)`, "string -> () ! {IO}"},
+		{`:type \f. \x. f(x)`, "(α2 -> α3) -> α2 -> α3"},
+		{`:type \s. _str_len(s)`, "string -> int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			var buf bytes.Buffer
+			New().HandleCommand(tt.command, &buf)
+			assert.Contains(t, buf.String(), ":: "+tt.want)
+		})
+	}
+}
+
+// TestFormatFuncWithEffects checks effect rows are sorted and row variables dropped
+func TestFormatFuncWithEffects(t *testing.T) {
+	row := types.EmptyEffectRow()
+	row.Labels["Net"] = &types.TCon{Name: "Net"}
+	row.Labels["IO"] = &types.TCon{Name: "IO"}
+	row.Tail = &types.RowVar{Name: "ε1", Kind: types.EffectRow}
+
+	fn := &types.TFunc2{
+		Params:    []types.Type{&types.TCon{Name: "string"}},
+		EffectRow: row,
+		Return:    &types.TCon{Name: "User"},
+	}
+	assert.Equal(t, "string -> User ! {IO, Net}", formatFuncWithEffects(fn))
+}
+
 // TestREPLSmoke_EnvInitialization verifies REPL initializes with all builtins.
 //
 // This is a sanity check that NewTypeEnvWithBuiltins() is called during REPL creation
@@ -198,3 +234,151 @@ func TestREPLSmoke_EffectRowPreservation(t *testing.T) {
 
 	t.Log("✅ _io_print effect row preserved: ! {IO}")
 }
+
+// TestREPLImportIO checks that std/io's print family works in the REPL
+// without any --caps flag, through the IO capability the REPL grants
+func TestREPLImportIO(t *testing.T) {
+	r := New()
+	r.importModule("std/prelude", &bytes.Buffer{})
+	r.importModule("std/io", &bytes.Buffer{})
+	printed := r.effContext.CaptureOutput()
+
+	var out bytes.Buffer
+	r.ProcessExpression(`println("hello")`, &out)
+	assert.Equal(t, "hello\n", printed.String(), "REPL output: %s", out.String())
+
+	var typeOut bytes.Buffer
+	r.HandleCommand(":type print", &typeOut)
+	assert.Contains(t, typeOut.String(), ":: string -> () ! {IO}")
+}
+
+// TestREPLTry checks that try catches failures in the REPL, also after :reset
+func TestREPLTry(t *testing.T) {
+	var out bytes.Buffer
+	New().Start(strings.NewReader("try(func() => 10 / 0)\ntry(func() => 1 + 1)\n:reset\ntry(func() => 10 / 0)\n"), &out)
+	output := out.String()
+
+	assert.Equal(t, 2, strings.Count(output, "Err([RT_DIV0] Division by zero)"), "output:\n%s", output)
+	assert.Contains(t, output, "Ok(2)")
+}
+
+// TestREPLOption checks that std/option's Some and None are in scope in the
+// REPL, also after :reset
+func TestREPLOption(t *testing.T) {
+	var out bytes.Buffer
+	New().Start(strings.NewReader("Some(3)\nNone\nmatch Some(3) { Some(x) => x + 1, None => 0 }\n:reset\nmatch None { Some(x) => x, None => 7 }\n"), &out)
+	output := out.String()
+
+	assert.Contains(t, output, "Some(3) :: Option\n")
+	assert.Contains(t, output, "None :: Option\n")
+	assert.Contains(t, output, "4 :: Int\n")
+	assert.Contains(t, output, "7 :: Int\n")
+	assert.NotContains(t, output, "error")
+}
+
+// TestREPLPipeMode checks that a non-terminal input is evaluated line by line
+// without banner or prompts, and that the session ends at EOF or :quit
+func TestREPLPipeMode(t *testing.T) {
+	var out bytes.Buffer
+	New().Start(strings.NewReader("1 + 2\n\n:type 42\nlet x = 5 in\nx * 2\n:quit\n3 + 4\n"), &out)
+	output := out.String()
+
+	assert.Equal(t, "3 :: Int\n42 :: Int\n10 :: Int\n", output)
+	assert.NotContains(t, output, "λ>")
+	assert.NotContains(t, output, "AILANG")
+}
+
+// TestREPLLetStatement checks that a let without 'in' binds its name for
+// later inputs and prints nothing itself
+func TestREPLLetStatement(t *testing.T) {
+	var out bytes.Buffer
+	New().Start(strings.NewReader("let x = 5\nx + 1\nlet y = 2 in y\n"), &out)
+
+	assert.Equal(t, "6 :: Int\n2 :: Int\n", out.String())
+}
+
+// TestREPLRunScript checks that a script prints only results, without
+// prompts, skips comments, joins continued lines and stops at :quit
+func TestREPLRunScript(t *testing.T) {
+	var out bytes.Buffer
+	New().RunScript(strings.NewReader("-- demo\n1 + 2\n\nlet x = 5 in\nx * 2\n:type 42\n:quit\n3 + 4\n"), &out)
+
+	assert.Equal(t, "3 :: Int\n10 :: Int\n42 :: Int\n", out.String())
+}
+
+// TestREPLNoPrelude checks that DisablePrelude skips the prelude's instances
+// while keeping the std/io bindings
+func TestREPLNoPrelude(t *testing.T) {
+	r := New()
+	r.DisablePrelude()
+
+	var out bytes.Buffer
+	r.Start(strings.NewReader("1 + 2\n:type println\n:reset\n1 + 2\n"), &out)
+	output := out.String()
+
+	assert.Equal(t, 2, strings.Count(output, "No instance for Num[int]"), "output:\n%s", output)
+	assert.Contains(t, output, ":: string -> () ! {IO}")
+	assert.Contains(t, output, "Environment reset (std/io auto-imported)")
+}
+
+// TestREPLKindCommand checks the kinds reported for type constructors
+func TestREPLKindCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{":kind Int", "Int : *"},
+		{":kind int", "Int : *"},
+		{":kind List", "List : * -> *"},
+		{":k Result", "Result : * -> * -> *"},
+		{":kind Frob", "unknown type Frob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			var buf bytes.Buffer
+			New().HandleCommand(tt.command, &buf)
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+// TestREPLInstancesOfClass checks that :instances <class> lists the types
+// from both the type-level and the runtime instances
+func TestREPLInstancesOfClass(t *testing.T) {
+	r := New()
+	r.importModule("std/prelude", &bytes.Buffer{})
+
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{":instances Num", "Num: Float, Int"},
+		{":instances Eq", "Eq: Float, Int"},
+		{":instances Show", "Show: Bool, Float, Int, String"},
+		{":instances Frob", "No instances of Frob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			var buf bytes.Buffer
+			r.HandleCommand(tt.command, &buf)
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+// TestREPLDocCommand checks that :doc shows the doc comments of a module's
+// exports
+func TestREPLDocCommand(t *testing.T) {
+	t.Setenv("AILANG_STDLIB_PATH", "../../stdlib")
+
+	var buf bytes.Buffer
+	New().HandleCommand(":doc std/prelude clamp", &buf)
+	assert.Contains(t, buf.String(), "func clamp[a](x: a, lo: a, hi: a) -> a")
+	assert.Contains(t, buf.String(), "  Clamp x into [lo, hi]")
+
+	buf.Reset()
+	New().HandleCommand(":doc std/prelude frob", &buf)
+	assert.Contains(t, buf.String(), "does not export frob")
+}
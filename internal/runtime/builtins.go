@@ -41,6 +41,12 @@ func NewBuiltinRegistry(evaluator *eval.CoreEvaluator) *BuiltinRegistry {
 	// Use new spec-based registry (M-DX1 migration complete in v0.3.10)
 	br.registerFromSpecRegistry()
 
+	// _io_handle runs its body with handlers installed, which call back into the evaluator
+	br.builtins["_io_handle"] = &eval.BuiltinFunction{
+		Name: "_io_handle",
+		Fn:   br.ioHandle,
+	}
+
 	return br
 }
 
@@ -65,6 +71,7 @@ func (br *BuiltinRegistry) registerFromSpecRegistry() {
 	for name, spec := range specs {
 		// Capture spec for closure
 		builtinSpec := spec
+		checkedImpl, hasChecked := builtins.CheckedIntImpl(name)
 
 		br.builtins[name] = &eval.BuiltinFunction{
 			Name: name,
@@ -73,12 +80,48 @@ func (br *BuiltinRegistry) registerFromSpecRegistry() {
 				if ctx == nil && !builtinSpec.IsPure {
 					return nil, fmt.Errorf("%s: no effect context available", builtinSpec.Name)
 				}
+				// Int arithmetic fails on overflow under --int-overflow=checked
+				if hasChecked && br.evaluator != nil && br.evaluator.IntOverflow() == eval.IntOverflowChecked {
+					return checkedImpl(ctx, args)
+				}
 				return builtinSpec.Impl(ctx, args)
 			},
 		}
 	}
 }
 
+// ioHandle implements handle { body } with { IO.op(x) => h, ... }
+//
+// args are the handlers of IO.print and IO.println, then the body as a
+// thunk. The handlers intercept the operations performed while the body
+// runs, including inside functions it calls.
+func (br *BuiltinRegistry) ioHandle(args []eval.Value) (eval.Value, error) {
+	ctx := br.getEffContext()
+	if ctx == nil || br.evaluator == nil {
+		return nil, fmt.Errorf("handle: no effect context available")
+	}
+	fns := make([]*eval.FunctionValue, len(args))
+	for i, arg := range args {
+		fn, ok := arg.(*eval.FunctionValue)
+		if !ok {
+			return nil, fmt.Errorf("handle: expected a function, got %T", arg)
+		}
+		fns[i] = fn
+	}
+
+	call := func(fn *eval.FunctionValue) effects.HandlerFunc {
+		return func(opArgs []eval.Value) (eval.Value, error) {
+			return br.evaluator.CallFunction(fn, opArgs)
+		}
+	}
+	ctx.PushHandlers("IO", map[string]effects.HandlerFunc{
+		"print":   call(fns[0]),
+		"println": call(fns[1]),
+	})
+	defer ctx.PopHandlers()
+	return br.evaluator.CallFunction(fns[2], nil)
+}
+
 // getEffContext retrieves the EffContext from the evaluator
 //
 // Returns:
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/sunholo/ailang/internal/effects"
+	"github.com/sunholo/ailang/internal/elaborate"
 	"github.com/sunholo/ailang/internal/eval"
 )
 
@@ -250,3 +252,350 @@ func TestIntegration_ExportFiltering(t *testing.T) {
 		t.Error("Expected error when getting non-existent export")
 	}
 }
+
+func TestIntegration_ModuleValues(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+
+	rt := NewModuleRuntime(testPath)
+
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/with_consts")
+	if err != nil {
+		t.Fatalf("Failed to load module with value imports: %v", err)
+	}
+
+	consts := rt.GetInstance("tests/runtime_integration/consts")
+	if consts == nil {
+		t.Fatal("Expected consts module to be loaded")
+	}
+
+	// Exported values are evaluated once, on first access
+	piVal, err := consts.GetExport("pi")
+	if err != nil {
+		t.Fatalf("Failed to get pi export: %v", err)
+	}
+	if f, ok := piVal.(*eval.FloatValue); !ok || f.Value != 3.14159 {
+		t.Errorf("Expected pi to be 3.14159, got %v", piVal)
+	}
+	if powers, err := consts.GetExport("powers"); err != nil {
+		t.Errorf("Failed to get powers export: %v", err)
+	} else if list, ok := powers.(*eval.ListValue); !ok || len(list.Elements) != 4 {
+		t.Errorf("Expected powers to be a 4-element list, got %v", powers)
+	}
+
+	// Private values are bound but not exported
+	if consts.HasExport("secret") {
+		t.Error("Expected secret not to be exported")
+	}
+	if _, err := consts.GetBinding("secret"); err != nil {
+		t.Errorf("Expected secret to be bound: %v", err)
+	}
+
+	// Values can be computed from imported values
+	tauVal, err := inst.GetExport("tau")
+	if err != nil {
+		t.Fatalf("Failed to get tau export: %v", err)
+	}
+	if f, ok := tauVal.(*eval.FloatValue); !ok || f.Value != 6.28318 {
+		t.Errorf("Expected tau to be 6.28318, got %v", tauVal)
+	}
+}
+
+func TestIntegration_LazyMutualFunctions(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+
+	rt := NewModuleRuntime(testPath)
+
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/lazy_funcs")
+	if err != nil {
+		t.Fatalf("Failed to load module with mutually referential values: %v", err)
+	}
+
+	// Function values may refer to each other in either order
+	val, err := inst.GetExport("tenIsEven")
+	if err != nil {
+		t.Fatalf("Failed to get tenIsEven export: %v", err)
+	}
+	if b, ok := val.(*eval.BoolValue); !ok || !b.Value {
+		t.Errorf("Expected tenIsEven to be true, got %v", val)
+	}
+
+	fn, err := inst.GetExport("sevenIsOdd")
+	if err != nil {
+		t.Fatalf("Failed to get sevenIsOdd export: %v", err)
+	}
+	result, err := rt.GetEvaluator().CallFunction(fn.(*eval.FunctionValue), nil)
+	if err != nil {
+		t.Fatalf("sevenIsOdd() failed: %v", err)
+	}
+	if b, ok := result.(*eval.BoolValue); !ok || !b.Value {
+		t.Errorf("Expected sevenIsOdd() to be true, got %v", result)
+	}
+}
+
+func TestIntegration_ValueInitCycle(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+
+	rt := NewModuleRuntime(testPath)
+
+	// Bindings are lazy, so the cycle does not stop the module from loading
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/value_cycle")
+	if err != nil {
+		t.Fatalf("Failed to load module with a value cycle: %v", err)
+	}
+	if _, err := inst.GetExport("safe"); err != nil {
+		t.Errorf("Expected unrelated export to be available: %v", err)
+	}
+
+	_, err = inst.GetExport("a")
+	if err == nil {
+		t.Fatal("Expected value initialization cycle error")
+	}
+	for _, want := range []string{"RT009", "a → b → a"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+
+	// The failed initialization is not cached as a value
+	if _, err := inst.GetExport("b"); err == nil || !strings.Contains(err.Error(), "b → a → b") {
+		t.Errorf("Expected cycle error starting from b, got: %v", err)
+	}
+
+	// Forced from a call, the error carries the cycle's location only once
+	_, err = CallEntrypoint(rt, inst, "main", nil)
+	want := "failed to evaluate a in module tests/runtime_integration/value_cycle: " +
+		"[RT009] value initialization cycle in module tests/runtime_integration/value_cycle: a → b → a " +
+		"at " + filepath.Join(testPath, "tests/runtime_integration/value_cycle.ail") + ":4:16 (in main)"
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected error\n  %s\ngot\n  %v", want, err)
+	}
+}
+
+// TestIntegration_PartialApplication tests under- and over-application of
+// multi-parameter functions
+func TestIntegration_PartialApplication(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+
+	rt := NewModuleRuntime(testPath)
+
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/partial_app")
+	if err != nil {
+		t.Fatalf("Failed to load module with partial applications: %v", err)
+	}
+
+	// Supplying one of add's two arguments yields a function of the other
+	val, err := inst.GetExport("inc")
+	if err != nil {
+		t.Fatalf("Failed to get inc export: %v", err)
+	}
+	inc, ok := val.(*eval.FunctionValue)
+	if !ok || len(inc.Params) != 1 {
+		t.Fatalf("Expected inc to be a one-parameter function, got %v", val)
+	}
+	result, err := rt.GetEvaluator().CallFunction(inc, []eval.Value{&eval.IntValue{Value: 41}})
+	if err != nil {
+		t.Fatalf("inc(41) failed: %v", err)
+	}
+	if n, ok := result.(*eval.IntValue); !ok || n.Value != 42 {
+		t.Errorf("Expected inc(41) to be 42, got %v", result)
+	}
+
+	for name, want := range map[string]int{"incrementedTotal": 9, "twelve": 12, "piped": 5} {
+		val, err := inst.GetExport(name)
+		if err != nil {
+			t.Fatalf("Failed to get %s export: %v", name, err)
+		}
+		if n, ok := val.(*eval.IntValue); !ok || n.Value != want {
+			t.Errorf("Expected %s to be %d, got %v", name, want, val)
+		}
+	}
+}
+
+// TestIntegration_ListConcat verifies that ++ on lists, used directly and
+// inside std/list, concatenates lists at runtime
+func TestIntegration_ListConcat(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+	t.Setenv("AILANG_STDLIB_PATH", filepath.Join(testPath, "stdlib"))
+
+	rt := NewModuleRuntime(testPath)
+
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/list_map")
+	if err != nil {
+		t.Fatalf("Failed to load module using std/list: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"incremented": "[2, 3, 4]",
+		"evens":       "[2, 4]",
+		"joined":      "[1, 2, 3]",
+	} {
+		val, err := inst.GetExport(name)
+		if err != nil {
+			t.Fatalf("Failed to get %s export: %v", name, err)
+		}
+		if got := val.String(); got != want {
+			t.Errorf("Expected %s to be %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestIntegration_ForwardConstructors(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+
+	rt := NewModuleRuntime(testPath)
+
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/forward_ctor")
+	if err != nil {
+		t.Fatalf("Failed to load module with forward constructor references: %v", err)
+	}
+
+	// The leading None arm must not catch Some(7)
+	for name, want := range map[string]int{"some": 7, "none": 0} {
+		val, err := inst.GetExport(name)
+		if err != nil {
+			t.Fatalf("Failed to get %s export: %v", name, err)
+		}
+		if n, ok := val.(*eval.IntValue); !ok || n.Value != want {
+			t.Errorf("Expected %s to be %d, got %v", name, want, val)
+		}
+	}
+}
+
+// TestIntegration_ListPatterns verifies recursive list processing through
+// list patterns: empty, fixed-length, rest and ignored-rest patterns
+func TestIntegration_ListPatterns(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+
+	rt := NewModuleRuntime(testPath)
+
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/list_match")
+	if err != nil {
+		t.Fatalf("Failed to load module with list patterns: %v", err)
+	}
+
+	for name, want := range map[string]int{"total": 10, "shapes": 123, "nested": 5} {
+		val, err := inst.GetExport(name)
+		if err != nil {
+			t.Fatalf("Failed to get %s export: %v", name, err)
+		}
+		if n, ok := val.(*eval.IntValue); !ok || n.Value != want {
+			t.Errorf("Expected %s to be %d, got %v", name, want, val)
+		}
+	}
+}
+
+// TestIntegration_InlineTests verifies that each inline test assertion is a
+// private module value that evaluates to whether it holds
+func TestIntegration_InlineTests(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+
+	rt := NewModuleRuntime(testPath)
+
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/inline_tests")
+	if err != nil {
+		t.Fatalf("Failed to load module with inline tests: %v", err)
+	}
+
+	for i, want := range []bool{true, false} {
+		name := elaborate.TestBindingName("double", i)
+		if inst.HasExport(name) {
+			t.Errorf("test binding %s is exported", name)
+		}
+		val, err := inst.GetBinding(name)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %v", name, err)
+		}
+		if b, ok := val.(*eval.BoolValue); !ok || b.Value != want {
+			t.Errorf("Expected %s to be %v, got %v", name, want, val)
+		}
+	}
+
+	name := elaborate.PropertyBindingName("double", 0)
+	val, err := inst.GetBinding(name)
+	if err != nil {
+		t.Fatalf("Failed to get %s: %v", name, err)
+	}
+	fn, ok := val.(*eval.FunctionValue)
+	if !ok {
+		t.Fatalf("Expected %s to be a function, got %T", name, val)
+	}
+	result, err := rt.GetEvaluator().CallFunction(fn, []eval.Value{&eval.IntValue{Value: 21}})
+	if err != nil {
+		t.Fatalf("Failed to call %s: %v", name, err)
+	}
+	if b, ok := result.(*eval.BoolValue); !ok || !b.Value {
+		t.Errorf("Expected %s(21) to hold, got %v", name, result)
+	}
+}
+
+// TestIntegration_HandleIO verifies that handle expressions intercept the
+// IO print operations performed by their body
+func TestIntegration_HandleIO(t *testing.T) {
+	testPath, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("Failed to get absolute path: %v", err)
+	}
+	t.Setenv("AILANG_STDLIB_PATH", filepath.Join(testPath, "stdlib"))
+
+	rt := NewModuleRuntime(testPath)
+	ctx := effects.NewEffContext()
+	ctx.Grant(effects.NewCapability("IO"))
+	out := ctx.CaptureOutput()
+	rt.GetEvaluator().SetEffContext(ctx)
+
+	inst, err := rt.LoadAndEvaluate("tests/runtime_integration/io_handle")
+	if err != nil {
+		t.Fatalf("Failed to load module with handle expressions: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		result eval.Value
+		output string
+	}{
+		{"bracketed", &eval.IntValue{Value: 2}, "[hello a]<b>!\n"},
+		{"silenced", &eval.UnitValue{}, ""},
+		{"nested", &eval.UnitValue{}, "p\n(l)\n"},
+	}
+	for _, tt := range tests {
+		out.Reset()
+		val, err := inst.GetExport(tt.name)
+		if err != nil {
+			t.Fatalf("Failed to get %s export: %v", tt.name, err)
+		}
+		result, err := rt.GetEvaluator().CallFunction(val.(*eval.FunctionValue), nil)
+		if err != nil {
+			t.Fatalf("%s() failed: %v", tt.name, err)
+		}
+		if result.String() != tt.result.String() {
+			t.Errorf("Expected %s() to return %s, got %s", tt.name, tt.result, result)
+		}
+		if got := out.String(); got != tt.output {
+			t.Errorf("Expected %s() to print %q, got %q", tt.name, tt.output, got)
+		}
+	}
+}
@@ -63,7 +63,8 @@ func NewModuleInstance(loaded *loader.LoadedModule) *ModuleInstance {
 // GetExport retrieves an exported value by name
 //
 // This method is used for cross-module access to ensure encapsulation:
-// only exported bindings are accessible from other modules.
+// only exported bindings are accessible from other modules. The binding
+// is evaluated on first access.
 //
 // Parameters:
 //   - name: The name of the exported binding
@@ -94,7 +95,7 @@ func (mi *ModuleInstance) GetExport(name string) (eval.Value, error) {
 		return nil, fmt.Errorf("export %s not found in module %s (available: %v)", name, mi.Path, available)
 	}
 
-	return val, nil
+	return forceBinding(val)
 }
 
 // HasExport checks if a module exports a given name
@@ -126,6 +127,17 @@ func (mi *ModuleInstance) GetBinding(name string) (eval.Value, error) {
 		return nil, fmt.Errorf("undefined binding '%s' in module %s", name, mi.Path)
 	}
 
+	return forceBinding(val)
+}
+
+// forceBinding evaluates a lazy top-level binding on first access
+//
+// Bindings are stored as eval.IndirectValue cells until forced; values
+// placed in the maps directly are returned unchanged.
+func forceBinding(val eval.Value) (eval.Value, error) {
+	if iv, ok := val.(*eval.IndirectValue); ok {
+		return iv.Force()
+	}
 	return val, nil
 }
 
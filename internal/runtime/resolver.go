@@ -85,7 +85,7 @@ func (r *moduleGlobalResolver) ResolveValue(ref core.GlobalRef) (eval.Value, err
 			return nil, fmt.Errorf("undefined binding '%s' in module %s (available: %v)",
 				ref.Name, r.current.Path, available)
 		}
-		return val, nil
+		return forceBinding(val)
 	}
 
 	// Case 2: Reference to imported module (exports only)
@@ -143,13 +143,15 @@ func newModuleGlobalResolver(inst *ModuleInstance, rt *ModuleRuntime) eval.Globa
 //  2. Searches all imported modules for a matching constructor
 //  3. Returns an error if the constructor is ambiguous (found in multiple modules)
 //  4. For nullary constructors, returns a cached singleton TaggedValue
-//  5. For constructors with fields, returns a factory function that creates TaggedValues
+//  5. For newtype constructors, returns a factory that passes its field through unboxed
+//  6. For other constructors with fields, returns a factory function that creates TaggedValues
 //
 // Parameters:
 //   - ref: GlobalRef with Module="$adt" and Name="make_TypeName_CtorName"
 //
 // Returns:
 //   - For nullary (arity 0): A cached TaggedValue singleton
+//   - For newtypes: A BuiltinFunction that returns its argument
 //   - For non-nullary: A BuiltinFunction that creates TaggedValues
 //   - Error if constructor not found or ambiguous
 func (r *moduleGlobalResolver) resolveAdtFactory(ref core.GlobalRef) (eval.Value, error) {
@@ -198,14 +200,22 @@ func (r *moduleGlobalResolver) resolveAdtFactory(ref core.GlobalRef) (eval.Value
 			TypeName:   typeName,
 			CtorName:   ctorName,
 			Fields:     nil,
+			CtorIndex:  match.Index,
 		}
 		r.runtime.nullaryCache.Store(key, singleton)
 		return singleton, nil
 	}
 
+	// Newtypes: the value is the unboxed field
+	if match.Newtype {
+		return eval.NewtypeConstructor(ref.Name), nil
+	}
+
 	// Non-nullary: return factory function
 	modPath := match.ModulePath  // Capture for closure
 	expectedArity := match.Arity // Capture arity for closure
+	fieldNames := match.FieldNames
+	ctorIndex := match.Index
 	return &eval.BuiltinFunction{
 		Name: ref.Name,
 		Fn: func(args []eval.Value) (eval.Value, error) {
@@ -218,6 +228,8 @@ func (r *moduleGlobalResolver) resolveAdtFactory(ref core.GlobalRef) (eval.Value
 				TypeName:   typeName,
 				CtorName:   ctorName,
 				Fields:     args,
+				FieldNames: fieldNames,
+				CtorIndex:  ctorIndex,
 			}, nil
 		},
 	}, nil
@@ -227,6 +239,9 @@ func (r *moduleGlobalResolver) resolveAdtFactory(ref core.GlobalRef) (eval.Value
 type constructorMatch struct {
 	ModulePath string
 	Arity      int
+	FieldNames []string
+	Newtype    bool
+	Index      int
 }
 
 // findConstructorMatches searches for constructors matching the given type and constructor name
@@ -254,6 +269,9 @@ func (r *moduleGlobalResolver) findConstructorMatches(typeName, ctorName string)
 				matches = append(matches, constructorMatch{
 					ModulePath: modulePath,
 					Arity:      ctor.Arity,
+					FieldNames: ctor.FieldNames,
+					Newtype:    ctor.Newtype,
+					Index:      ctor.Index,
 				})
 			}
 		}
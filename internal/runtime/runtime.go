@@ -3,11 +3,14 @@ package runtime
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/elaborate"
+	"github.com/sunholo/ailang/internal/errors"
 	"github.com/sunholo/ailang/internal/eval"
 	"github.com/sunholo/ailang/internal/iface"
 	"github.com/sunholo/ailang/internal/loader"
@@ -199,7 +202,7 @@ func (rt *ModuleRuntime) LoadAndEvaluate(modulePath string) (*ModuleInstance, er
 
 	// 6. Evaluate this module (thread-safe via sync.Once)
 	inst.initOnce.Do(func() {
-		inst.initErr = rt.evaluateModule(inst)
+		inst.initErr = rt.evaluateModule(inst, loaded.File)
 	})
 
 	return inst, inst.initErr
@@ -211,12 +214,13 @@ func (rt *ModuleRuntime) LoadAndEvaluate(modulePath string) (*ModuleInstance, er
 // It performs the following steps:
 //  1. Set up a GlobalResolver for cross-module references
 //  2. Iterate over top-level declarations in the Core AST
-//  3. Evaluate each declaration (currently only LetRec is supported)
+//  3. Bind each declaration lazily (evaluated on first use)
 //  4. Populate the Bindings map
 //  5. Filter Exports based on the module interface
 //
 // Parameters:
 //   - inst: The ModuleInstance to evaluate
+//   - file: The module's AST, for its selective imports (may be nil)
 //
 // Returns:
 //   - nil if evaluation succeeds
@@ -224,7 +228,7 @@ func (rt *ModuleRuntime) LoadAndEvaluate(modulePath string) (*ModuleInstance, er
 //
 // Note: This method is not exported because it should only be called
 // internally by LoadAndEvaluate.
-func (rt *ModuleRuntime) evaluateModule(inst *ModuleInstance) error {
+func (rt *ModuleRuntime) evaluateModule(inst *ModuleInstance, file *ast.File) error {
 	// 1. Set up global resolver for cross-module references
 	resolver := newModuleGlobalResolver(inst, rt)
 	rt.evaluator.SetGlobalResolver(resolver)
@@ -235,15 +239,36 @@ func (rt *ModuleRuntime) evaluateModule(inst *ModuleInstance) error {
 	}
 	if len(inst.Core.Decls) == 0 {
 		// Empty module is valid only if there are no exports
-		if len(inst.Iface.Exports) > 0 {
+		// (or if dead-code elimination removed all of them)
+		if len(inst.Iface.Exports) > 0 && !inst.Core.Flags.Pruned {
 			return fmt.Errorf("module %s has %d exports but no Core declarations", inst.Path, len(inst.Iface.Exports))
 		}
 		return nil
 	}
 
-	// Process declarations - recursively extract nested Let bindings
+	// Bind declarations lazily in a module-private environment: each one is
+	// evaluated on first use, so mutually referential functions work in any
+	// order while an eager value that needs itself fails with RT009
+	scope := &moduleScope{
+		env:      rt.evaluator.Env().NewChildEnvironment(),
+		resolver: resolver,
+	}
+	if file != nil {
+		// Selectively imported names are visible to the module's own code
+		for _, imp := range file.Imports {
+			dep, ok := inst.Imports[imp.Path]
+			if !ok {
+				continue
+			}
+			for _, sym := range imp.Symbols {
+				if val, ok := dep.Exports[sym]; ok {
+					scope.env.Set(sym, val)
+				}
+			}
+		}
+	}
 	for _, decl := range inst.Core.Decls {
-		err := rt.extractBindings(inst, decl)
+		err := rt.extractBindings(inst, scope, decl)
 		if err != nil {
 			return err
 		}
@@ -254,6 +279,9 @@ func (rt *ModuleRuntime) evaluateModule(inst *ModuleInstance) error {
 		for exportName := range inst.Iface.Exports {
 			// Check if the binding exists
 			val, ok := inst.Bindings[exportName]
+			if !ok && inst.Core.Flags.Pruned {
+				continue // Unreachable export dropped by dead-code elimination
+			}
 			if !ok {
 				return fmt.Errorf("exported binding '%s' not found in module %s bindings", exportName, inst.Path)
 			}
@@ -266,56 +294,49 @@ func (rt *ModuleRuntime) evaluateModule(inst *ModuleInstance) error {
 	return nil
 }
 
+// moduleScope is the evaluation context of one module's lazy bindings
+type moduleScope struct {
+	env      *eval.Environment   // Module-private environment (child of the builtins)
+	resolver eval.GlobalResolver // Resolver for the module's global references
+	forcing  []string            // Bindings being initialized, outermost first
+}
+
 // extractBindings recursively extracts Let and LetRec bindings from nested expressions
 //
 // Module elaboration produces nested Let expressions like:
 //
 //	let f1 = ... in (let f2 = ... in Var(...))
 //
-// This function recursively traverses the structure to extract all bindings.
+// This function recursively traverses the structure and binds every name
+// lazily (see bindLazy). Nothing is evaluated here.
 //
 // Parameters:
 //   - inst: The module instance to populate
+//   - scope: The module's evaluation context
 //   - expr: The expression to extract bindings from
 //
 // Returns:
-//   - An error if binding evaluation fails
-func (rt *ModuleRuntime) extractBindings(inst *ModuleInstance, expr core.CoreExpr) error {
+//   - An error if the expression is not a module-level declaration
+func (rt *ModuleRuntime) extractBindings(inst *ModuleInstance, scope *moduleScope, expr core.CoreExpr) error {
 	switch e := expr.(type) {
 	case *core.LetRec:
-		// Evaluate let rec bindings
-		bindings, err := rt.evaluator.EvalLetRecBindings(e)
-		if err != nil {
-			return fmt.Errorf("failed to evaluate let rec in module %s: %w", inst.Path, err)
-		}
-
-		// Store bindings and add to evaluator environment
-		for name, val := range bindings {
-			inst.Bindings[name] = val
-			// Add to environment so subsequent bindings can reference these
-			rt.evaluator.Env().Set(name, val)
+		// Recursive groups need no special treatment: every binding is
+		// already visible to every other one through the lazy cells
+		for _, binding := range e.Bindings {
+			rt.bindLazy(inst, scope, binding.Name, binding.Value)
 		}
 
 		// Recursively process body if it exists
 		if e.Body != nil {
-			return rt.extractBindings(inst, e.Body)
+			return rt.extractBindings(inst, scope, e.Body)
 		}
 
 	case *core.Let:
-		// Evaluate let binding
-		val, err := rt.evaluator.Eval(e.Value)
-		if err != nil {
-			return fmt.Errorf("failed to evaluate let %s in module %s: %w", e.Name, inst.Path, err)
-		}
-		inst.Bindings[e.Name] = val
-
-		// CRITICAL: Add binding to evaluator's environment so subsequent bindings can reference it
-		// This allows functions within the same module to call each other
-		rt.evaluator.Env().Set(e.Name, val)
+		rt.bindLazy(inst, scope, e.Name, e.Value)
 
 		// Recursively process body if it exists
 		if e.Body != nil {
-			return rt.extractBindings(inst, e.Body)
+			return rt.extractBindings(inst, scope, e.Body)
 		}
 
 	case *core.Var:
@@ -337,6 +358,49 @@ func (rt *ModuleRuntime) extractBindings(inst *ModuleInstance, expr core.CoreExp
 	return nil
 }
 
+// bindLazy binds a top-level name to a cell that evaluates expr on first use
+//
+// The cell is shared by the module environment (for references from the
+// module's own code) and inst.Bindings (for the resolver and GetExport), so
+// the value is computed at most once. Forcing a binding while it is still
+// being initialized is an eager cycle and fails with RT009, naming every
+// binding on the cycle.
+func (rt *ModuleRuntime) bindLazy(inst *ModuleInstance, scope *moduleScope, name string, expr core.CoreExpr) {
+	cell := &eval.RefCell{}
+	cell.Thunk = func() (eval.Value, error) {
+		if cell.Visiting {
+			cycle := scope.forcing[slices.Index(scope.forcing, name):]
+			return nil, eval.NewRuntimeError(errors.RT009,
+				fmt.Sprintf("value initialization cycle in module %s: %s → %s",
+					inst.Path, strings.Join(cycle, " → "), name), nil)
+		}
+
+		cell.Visiting = true
+		scope.forcing = append(scope.forcing, name)
+		val, err := rt.evaluator.EvalIn(scope.env, scope.resolver, expr)
+		scope.forcing = scope.forcing[:len(scope.forcing)-1]
+		cell.Visiting = false
+		if err != nil {
+			if len(scope.forcing) > 0 {
+				return nil, err // Reported once, by the outermost binding
+			}
+			return nil, fmt.Errorf("failed to evaluate %s in module %s: %w", name, inst.Path, err)
+		}
+
+		// Name top-level functions so runtime error traces can refer to them
+		if fn, ok := val.(*eval.FunctionValue); ok {
+			if _, isLam := expr.(*core.Lambda); isLam {
+				fn.Name = name
+			}
+		}
+		return val, nil
+	}
+
+	lazy := &eval.IndirectValue{Cell: cell}
+	inst.Bindings[name] = lazy
+	scope.env.Set(name, lazy)
+}
+
 // GetInstance retrieves a module instance from the cache
 //
 // This is useful for debugging and testing.
@@ -394,7 +458,14 @@ func (rt *ModuleRuntime) ListInstances() []string {
 func (rt *ModuleRuntime) buildMinimalInterface(loaded *loader.LoadedModule) *iface.Iface {
 	exports := make(map[string]*iface.IfaceItem)
 
+	names := make([]string, 0, len(loaded.Exports)+len(loaded.Values))
 	for name := range loaded.Exports {
+		names = append(names, name)
+	}
+	for name := range loaded.Values {
+		names = append(names, name)
+	}
+	for _, name := range names {
 		exports[name] = &iface.IfaceItem{
 			Name:   name,
 			Type:   nil, // No type info available without type checking
@@ -12,11 +12,17 @@ import (
 
 // Schema version constants
 const (
-	ErrorV1     = "ailang.error/v1"
-	TestV1      = "ailang.test/v1"
-	DecisionsV1 = "ailang.decisions/v1"
-	PlanV1      = "ailang.plan/v1"
-	EffectsV1   = "ailang.effects/v1"
+	ErrorV1      = "ailang.error/v1"
+	TestV1       = "ailang.test/v1"
+	DecisionsV1  = "ailang.decisions/v1"
+	PlanV1       = "ailang.plan/v1"
+	EffectsV1    = "ailang.effects/v1"
+	DefaultingV1 = "ailang.defaulting/v1"
+	InstancesV1  = "ailang.instances/v1"
+	CheckV1      = "ailang.check/v1"
+	EvalV1       = "ailang.eval/v1"
+	CheckStateV1 = "ailang.checkstate/v1"
+	AuditV1      = "ailang.audit/v1"
 )
 
 // Accepts checks if a schema version is compatible with the expected version.
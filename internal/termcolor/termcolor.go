@@ -0,0 +1,70 @@
+// Package termcolor decides in one place whether AILANG colors its terminal
+// output. The CLI and the REPL build their color functions with Func, and
+// the CLI sets the mode once from the environment and --format-errors.
+package termcolor
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+)
+
+// Modes accepted by SetMode
+const (
+	Auto  = ""      // Follow NO_COLOR, CLICOLOR_FORCE, CLICOLOR and the terminal
+	Plain = "plain" // Never color
+	Rich  = "rich"  // Always color
+)
+
+// terminal is fatih/color's own answer, taken before any SetMode: stdout is
+// a terminal, TERM is not dumb and NO_COLOR is unset
+var terminal = !color.NoColor
+
+// SetMode decides whether the functions made by Func color their output.
+// Plain and Rich override everything else. Auto follows the environment: a
+// non-empty NO_COLOR turns color off, CLICOLOR_FORCE (other than 0) turns it
+// on, CLICOLOR=0 turns it off, and otherwise color is used when stdout is a
+// terminal whose TERM is not dumb.
+func SetMode(mode string) error {
+	switch mode {
+	case Plain:
+		color.NoColor = true
+	case Rich:
+		color.NoColor = false
+	case Auto:
+		color.NoColor = !envColor(os.Getenv, terminal)
+	default:
+		return fmt.Errorf("must be plain or rich, got %q", mode)
+	}
+	return nil
+}
+
+// Func returns a function that formats its arguments like fmt.Sprint,
+// wrapped in the given attributes while color is enabled. Unlike
+// color.SprintFunc, it looks at the mode on every call, so a function made
+// at package initialization follows a later SetMode.
+func Func(attrs ...color.Attribute) func(a ...interface{}) string {
+	c := color.New(attrs...)
+	c.EnableColor() // Whether to color is decided per call, not by NO_COLOR at creation
+	return func(a ...interface{}) string {
+		if color.NoColor {
+			return fmt.Sprint(a...)
+		}
+		return c.Sprint(a...)
+	}
+}
+
+// envColor applies the color environment variables on top of terminal,
+// the terminal detection's answer
+func envColor(getenv func(string) string, terminal bool) bool {
+	switch {
+	case getenv("NO_COLOR") != "":
+		return false
+	case getenv("CLICOLOR_FORCE") != "" && getenv("CLICOLOR_FORCE") != "0":
+		return true
+	case getenv("CLICOLOR") == "0":
+		return false
+	}
+	return terminal
+}
@@ -0,0 +1,55 @@
+package termcolor
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestSetMode(t *testing.T) {
+	saved := color.NoColor
+	defer func() { color.NoColor = saved }()
+	red := Func(color.FgRed)
+
+	if err := SetMode(Plain); err != nil {
+		t.Fatal(err)
+	}
+	if got := red("Error"); got != "Error" {
+		t.Errorf("plain: got %q, want no ANSI codes", got)
+	}
+
+	if err := SetMode(Rich); err != nil {
+		t.Fatal(err)
+	}
+	if got := red("Error"); got != "\x1b[31mError\x1b[0m" {
+		t.Errorf("rich: got %q, want red", got)
+	}
+
+	if err := SetMode("fancy"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestEnvColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		terminal bool
+		want     bool
+	}{
+		{"terminal", nil, true, true},
+		{"pipe", nil, false, false},
+		{"NO_COLOR", map[string]string{"NO_COLOR": "1"}, true, false},
+		{"NO_COLOR beats CLICOLOR_FORCE", map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, true, false},
+		{"CLICOLOR_FORCE on a pipe", map[string]string{"CLICOLOR_FORCE": "1"}, false, true},
+		{"CLICOLOR_FORCE=0", map[string]string{"CLICOLOR_FORCE": "0"}, false, false},
+		{"CLICOLOR=0", map[string]string{"CLICOLOR": "0"}, true, false},
+		{"CLICOLOR=1", map[string]string{"CLICOLOR": "1"}, false, false},
+	}
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		if got := envColor(getenv, tt.terminal); got != tt.want {
+			t.Errorf("%s: envColor = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package test
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+// Gen produces and shrinks random values of one AILANG type
+type Gen struct {
+	Type string
+	// Generate returns a value whose magnitude grows with size
+	Generate func(r *rand.Rand, size int) eval.Value
+	// Shrink returns simpler candidates for v, simplest first
+	Shrink func(v eval.Value) []eval.Value
+}
+
+// maxSize bounds the magnitude of generated values
+const maxSize = 100
+
+// maxShrinks bounds how many times a counterexample is simplified
+const maxShrinks = 1000
+
+const stringAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 "
+
+// GenFor returns the generator for a type name. Only int, bool and string
+// (in either case) have generators.
+func GenFor(typeName string) (*Gen, bool) {
+	switch strings.ToLower(typeName) {
+	case "int":
+		return &Gen{Type: "int", Generate: genInt, Shrink: shrinkInt}, true
+	case "bool":
+		return &Gen{Type: "bool", Generate: genBool, Shrink: shrinkBool}, true
+	case "string":
+		return &Gen{Type: "string", Generate: genString, Shrink: shrinkString}, true
+	}
+	return nil, false
+}
+
+func genInt(r *rand.Rand, size int) eval.Value {
+	return &eval.IntValue{Value: r.Intn(2*size+1) - size}
+}
+
+func shrinkInt(v eval.Value) []eval.Value {
+	n := v.(*eval.IntValue).Value
+	if n == 0 {
+		return nil
+	}
+	candidates := []int{0, n / 2}
+	if n < 0 {
+		candidates = append(candidates, -n, n+1)
+	} else {
+		candidates = append(candidates, n-1)
+	}
+	var out []eval.Value
+	seen := map[int]bool{n: true}
+	for _, c := range candidates {
+		if !seen[c] {
+			seen[c] = true
+			out = append(out, &eval.IntValue{Value: c})
+		}
+	}
+	return out
+}
+
+func genBool(r *rand.Rand, _ int) eval.Value {
+	return &eval.BoolValue{Value: r.Intn(2) == 1}
+}
+
+func shrinkBool(v eval.Value) []eval.Value {
+	if v.(*eval.BoolValue).Value {
+		return []eval.Value{&eval.BoolValue{Value: false}}
+	}
+	return nil
+}
+
+func genString(r *rand.Rand, size int) eval.Value {
+	n := r.Intn(min(size, 32) + 1)
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = stringAlphabet[r.Intn(len(stringAlphabet))]
+	}
+	return &eval.StringValue{Value: string(b)}
+}
+
+func shrinkString(v eval.Value) []eval.Value {
+	s := []rune(v.(*eval.StringValue).Value)
+	if len(s) == 0 {
+		return nil
+	}
+	candidates := []string{
+		"",
+		string(s[:len(s)/2]),
+		string(s[len(s)/2:]),
+		string(s[1:]),
+		string(s[:len(s)-1]),
+		strings.Repeat("a", len(s)),
+	}
+	var out []eval.Value
+	seen := map[string]bool{string(s): true}
+	for _, c := range candidates {
+		if !seen[c] {
+			seen[c] = true
+			out = append(out, &eval.StringValue{Value: c})
+		}
+	}
+	return out
+}
+
+// Counterexample is the simplest argument list found for which a property
+// does not hold
+type Counterexample struct {
+	Args    []eval.Value
+	Err     error // Set if the property failed with an error rather than false
+	Trial   int   // 1-based trial that first failed
+	Shrinks int   // How many times the failing arguments were simplified
+}
+
+// CheckProperty tries holds on trials random argument lists drawn from gens.
+// It returns nil if the property held every time, and otherwise the first
+// failing arguments shrunk as far as they still fail. An error from holds
+// counts as a failure.
+func CheckProperty(gens []*Gen, trials int, r *rand.Rand, holds func(args []eval.Value) (bool, error)) *Counterexample {
+	fails := func(args []eval.Value) (bool, error) {
+		ok, err := holds(args)
+		return err != nil || !ok, err
+	}
+
+	for trial := 1; trial <= trials; trial++ {
+		size := min(trial, maxSize)
+		args := make([]eval.Value, len(gens))
+		for i, g := range gens {
+			args[i] = g.Generate(r, size)
+		}
+		failed, err := fails(args)
+		if !failed {
+			continue
+		}
+
+		cex := &Counterexample{Args: args, Err: err, Trial: trial}
+		for cex.Shrinks < maxShrinks && shrinkOnce(gens, cex, fails) {
+			cex.Shrinks++
+		}
+		return cex
+	}
+	return nil
+}
+
+// shrinkOnce replaces one argument of cex by a simpler one that still fails,
+// and reports whether it found one
+func shrinkOnce(gens []*Gen, cex *Counterexample, fails func([]eval.Value) (bool, error)) bool {
+	for i, g := range gens {
+		for _, candidate := range g.Shrink(cex.Args[i]) {
+			args := append([]eval.Value{}, cex.Args...)
+			args[i] = candidate
+			if failed, err := fails(args); failed {
+				cex.Args, cex.Err = args, err
+				return true
+			}
+		}
+	}
+	return false
+}
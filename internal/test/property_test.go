@@ -0,0 +1,105 @@
+package test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/sunholo/ailang/internal/eval"
+)
+
+func mustGen(t *testing.T, typeName string) *Gen {
+	t.Helper()
+	g, ok := GenFor(typeName)
+	if !ok {
+		t.Fatalf("no generator for %s", typeName)
+	}
+	return g
+}
+
+func TestGenFor(t *testing.T) {
+	for _, name := range []string{"int", "Int", "bool", "Bool", "string", "String"} {
+		if _, ok := GenFor(name); !ok {
+			t.Errorf("GenFor(%q) found no generator", name)
+		}
+	}
+	if _, ok := GenFor("float"); ok {
+		t.Error("GenFor(\"float\") should have no generator")
+	}
+}
+
+func TestCheckPropertyHolds(t *testing.T) {
+	calls := 0
+	cex := CheckProperty([]*Gen{mustGen(t, "int")}, 50, rand.New(rand.NewSource(1)), func(args []eval.Value) (bool, error) {
+		calls++
+		n := args[0].(*eval.IntValue).Value
+		return n*n >= 0, nil
+	})
+	if cex != nil {
+		t.Fatalf("expected property to hold, got counterexample %v", cex.Args)
+	}
+	if calls != 50 {
+		t.Errorf("expected 50 trials, got %d", calls)
+	}
+}
+
+func TestCheckPropertyShrinksInt(t *testing.T) {
+	cex := CheckProperty([]*Gen{mustGen(t, "int")}, 100, rand.New(rand.NewSource(1)), func(args []eval.Value) (bool, error) {
+		return args[0].(*eval.IntValue).Value <= 10, nil
+	})
+	if cex == nil {
+		t.Fatal("expected a counterexample")
+	}
+	if got := cex.Args[0].(*eval.IntValue).Value; got != 11 {
+		t.Errorf("expected counterexample shrunk to 11, got %d", got)
+	}
+}
+
+func TestCheckPropertyShrinksString(t *testing.T) {
+	cex := CheckProperty([]*Gen{mustGen(t, "string")}, 100, rand.New(rand.NewSource(1)), func(args []eval.Value) (bool, error) {
+		return len(args[0].(*eval.StringValue).Value) < 3, nil
+	})
+	if cex == nil {
+		t.Fatal("expected a counterexample")
+	}
+	if got := cex.Args[0].(*eval.StringValue).Value; got != "aaa" {
+		t.Errorf("expected counterexample shrunk to \"aaa\", got %q", got)
+	}
+}
+
+func TestCheckPropertyError(t *testing.T) {
+	boom := errors.New("boom")
+	cex := CheckProperty([]*Gen{mustGen(t, "int"), mustGen(t, "bool")}, 100, rand.New(rand.NewSource(1)), func(args []eval.Value) (bool, error) {
+		if args[0].(*eval.IntValue).Value < 0 {
+			return false, boom
+		}
+		return true, nil
+	})
+	if cex == nil {
+		t.Fatal("expected a counterexample")
+	}
+	if !errors.Is(cex.Err, boom) {
+		t.Errorf("expected error to be kept, got %v", cex.Err)
+	}
+	if got := cex.Args[0].(*eval.IntValue).Value; got != -1 {
+		t.Errorf("expected counterexample shrunk to -1, got %d", got)
+	}
+	if got := cex.Args[1].(*eval.BoolValue).Value; got {
+		t.Error("expected bool argument shrunk to false")
+	}
+}
+
+func TestCheckPropertyDeterministic(t *testing.T) {
+	run := func() *Counterexample {
+		return CheckProperty([]*Gen{mustGen(t, "int")}, 100, rand.New(rand.NewSource(42)), func(args []eval.Value) (bool, error) {
+			return args[0].(*eval.IntValue).Value%7 != 3, nil
+		})
+	}
+	a, b := run(), run()
+	if a == nil || b == nil {
+		t.Fatal("expected counterexamples")
+	}
+	if a.Trial != b.Trial || a.Args[0].String() != b.Args[0].String() {
+		t.Errorf("same seed gave different results: trial %d %s vs trial %d %s", a.Trial, a.Args[0], b.Trial, b.Args[0])
+	}
+}
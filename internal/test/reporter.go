@@ -1,4 +1,5 @@
-// Package test provides structured test reporting for AI consumption.
+// Package test provides structured test reporting for AI consumption and the
+// random value generation behind property-based tests.
 package test
 
 import (
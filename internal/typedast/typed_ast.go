@@ -240,6 +240,30 @@ func (p TypedConstructorPattern) String() string {
 	return fmt.Sprintf("%s(%v)", p.Name, p.Args)
 }
 
+type TypedAsPattern struct {
+	Name    string
+	Type    interface{} // types.Type
+	Pattern TypedPattern
+}
+
+func (p TypedAsPattern) patternNode() {}
+func (p TypedAsPattern) String() string {
+	return fmt.Sprintf("%s @ %s", p.Name, p.Pattern)
+}
+
+type TypedOrPattern struct {
+	Alternatives []TypedPattern
+}
+
+func (p TypedOrPattern) patternNode() {}
+func (p TypedOrPattern) String() string {
+	parts := make([]string, len(p.Alternatives))
+	for i, alt := range p.Alternatives {
+		parts[i] = alt.String()
+	}
+	return strings.Join(parts, " | ")
+}
+
 type TypedWildcardPattern struct{}
 
 func (p TypedWildcardPattern) patternNode()   {}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"github.com/sunholo/ailang/internal/ast"
+)
+
+// ResolveTypeAliases converts the transparent aliases of a module
+// (type Celsius = float) to the types they stand for. Aliases used inside
+// other aliases are expanded, and imported holds already resolved aliases of
+// other modules. Builtin types may be capitalised as in annotations.
+//
+// Only aliases of types the checker compares structurally are resolved:
+// an alias of a record or one with type variables is left out, and its
+// name stays unknown as before. Alias cycles must already have been
+// rejected (TYPE_ALIAS_CYCLE, see the elaborator).
+func ResolveTypeAliases(decls map[string]ast.Type, imported map[string]Type) map[string]Type {
+	resolved := make(map[string]Type, len(imported)+len(decls))
+	for name, t := range imported {
+		resolved[name] = t
+	}
+	var resolve func(name string) Type
+	resolve = func(name string) Type {
+		if t, ok := resolved[name]; ok {
+			return t
+		}
+		decl, ok := decls[name]
+		if !ok {
+			return nil
+		}
+		t, ok := expandAliasNames(TypeFromAST(decl), resolve)
+		if !ok {
+			return nil
+		}
+		resolved[name] = t
+		return t
+	}
+	for name := range decls {
+		resolve(name)
+	}
+	for name, t := range resolved {
+		if t == nil {
+			delete(resolved, name)
+		}
+	}
+	return resolved
+}
+
+// ExpandAliases replaces every alias name in t by the type it stands for
+func ExpandAliases(t Type, aliases map[string]Type) Type {
+	if len(aliases) == 0 {
+		return t
+	}
+	expanded, _ := expandAliasNames(t, func(name string) Type { return aliases[name] })
+	return expanded
+}
+
+// expandAliasNames rewrites capitalised builtins and alias names in t. It
+// reports false if t contains a type variable, or a shape TypeFromAST does
+// not model, which cannot be part of an alias.
+func expandAliasNames(t Type, alias func(name string) Type) (Type, bool) {
+	switch typ := t.(type) {
+	case *TCon:
+		if prim, ok := annotationPrims[typ.Name]; ok {
+			return prim, true
+		}
+		if target := alias(typ.Name); target != nil {
+			return target, true
+		}
+		return typ, true
+	case *TVar2:
+		return typ, false
+	case *TFunc2:
+		ok := true
+		params := make([]Type, len(typ.Params))
+		for i, p := range typ.Params {
+			var pok bool
+			params[i], pok = expandAliasNames(p, alias)
+			ok = ok && pok
+		}
+		ret, rok := expandAliasNames(typ.Return, alias)
+		return &TFunc2{Params: params, EffectRow: typ.EffectRow, Return: ret}, ok && rok
+	case *TList:
+		elem, ok := expandAliasNames(typ.Element, alias)
+		return &TList{Element: elem}, ok
+	case *TApp:
+		ok := true
+		args := make([]Type, len(typ.Args))
+		for i, arg := range typ.Args {
+			var aok bool
+			args[i], aok = expandAliasNames(arg, alias)
+			ok = ok && aok
+		}
+		return &TApp{Constructor: typ.Constructor, Args: args}, ok
+	case *TTuple:
+		ok := true
+		elements := make([]Type, len(typ.Elements))
+		for i, e := range typ.Elements {
+			var eok bool
+			elements[i], eok = expandAliasNames(e, alias)
+			ok = ok && eok
+		}
+		return &TTuple{Elements: elements}, ok
+	default:
+		return t, true
+	}
+}
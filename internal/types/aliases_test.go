@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+)
+
+// TestResolveTypeAliases checks that aliases resolve through other aliases
+// and imports, and that aliases the checker cannot compare are left out
+func TestResolveTypeAliases(t *testing.T) {
+	decls := map[string]ast.Type{
+		"Celsius":  &ast.SimpleType{Name: "Float"},
+		"Readings": &ast.ListType{Element: &ast.SimpleType{Name: "Celsius"}},
+		"Pair":     &ast.TupleType{Elements: []ast.Type{&ast.SimpleType{Name: "Id"}, &ast.SimpleType{Name: "a"}}},
+		"Point":    &ast.RecordType{Fields: []*ast.RecordField{{Name: "x", Type: &ast.SimpleType{Name: "int"}}}},
+	}
+	imported := map[string]Type{"Id": TInt}
+
+	aliases := ResolveTypeAliases(decls, imported)
+	for name, want := range map[string]string{"Celsius": "float", "Readings": "[float]", "Id": "int"} {
+		if got, ok := aliases[name]; !ok || got.String() != want {
+			t.Errorf("%s: expected %s, got %v", name, want, got)
+		}
+	}
+	for _, skipped := range []string{"Pair", "Point"} {
+		if got, ok := aliases[skipped]; ok {
+			t.Errorf("%s should not resolve, got %s", skipped, got)
+		}
+	}
+
+	if got := ExpandAliases(&TFunc2{Params: []Type{&TCon{Name: "Celsius"}}, Return: &TCon{Name: "Box"}}, aliases); got.String() != "float -> Box" {
+		t.Errorf("ExpandAliases: got %s", got)
+	}
+}
+
+// TestUnifyTypeAliases checks that an alias unifies with the type it stands
+// for and still rejects other types
+func TestUnifyTypeAliases(t *testing.T) {
+	u := NewUnifier()
+	u.SetTypeAliases(map[string]Type{"Celsius": TFloat})
+
+	if _, err := u.Unify(&TCon{Name: "Celsius"}, TFloat, make(Substitution)); err != nil {
+		t.Errorf("Celsius vs float: %v", err)
+	}
+	sub, err := u.Unify(&TVar2{Name: "t", Kind: Star}, &TCon{Name: "Celsius"}, make(Substitution))
+	if err != nil {
+		t.Fatalf("t vs Celsius: %v", err)
+	}
+	if got := sub["t"]; got != TFloat {
+		t.Errorf("expected t to be bound to float, got %v", got)
+	}
+	if _, err := u.Unify(&TCon{Name: "Celsius"}, TInt, make(Substitution)); err == nil {
+		t.Error("expected Celsius vs int to fail")
+	}
+}
@@ -45,6 +45,16 @@ func (b *Builder) Float() Type {
 	return &TCon{Name: "float"}
 }
 
+// Bytes returns the opaque byte sequence type
+func (b *Builder) Bytes() Type {
+	return &TCon{Name: "bytes"}
+}
+
+// BigInt returns the arbitrary-precision integer type
+func (b *Builder) BigInt() Type {
+	return &TCon{Name: "BigInt"}
+}
+
 // Unit returns the unit type ()
 func (b *Builder) Unit() Type {
 	return &TCon{Name: "()"}
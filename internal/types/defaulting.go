@@ -4,14 +4,29 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
 )
 
 // DefaultingTrace records when numeric defaulting occurs
 type DefaultingTrace struct {
-	TypeVar   string // The type variable being defaulted
-	ClassName string // The class constraint (Num, Fractional, etc.)
-	Default   Type   // The chosen default type
-	Location  string // Source location
+	TypeVar   string  // The type variable being defaulted
+	ClassName string  // The class constraint (Num, Fractional, etc.)
+	Default   Type    // The chosen default type
+	Location  string  // Where defaulting happened ("top-level", "generalization boundary")
+	NodeID    uint64  // Core node whose constraint was defaulted (0 if unknown)
+	Span      ast.Pos // Source position of that node
+}
+
+// defaultingOrigin finds the first constraint on varName that points back to
+// a source node, so a trace can say which literal or operator was defaulted
+func defaultingOrigin(varName string, constraints []ClassConstraint) (uint64, ast.Pos) {
+	for _, c := range constraints {
+		if extractVarName(c.Type) == varName && c.NodeID != 0 {
+			return c.NodeID, c.Span
+		}
+	}
+	return 0, ast.Pos{}
 }
 
 // DefaultingConfig controls numeric literal defaulting
@@ -55,16 +70,15 @@ func FormatDefaultingTraces(traces []DefaultingTrace) string {
 	lines = append(lines, "Numeric defaulting applied:")
 
 	// Sort for deterministic output
-	sort.Slice(traces, func(i, j int) bool {
-		if traces[i].Location != traces[j].Location {
-			return traces[i].Location < traces[j].Location
-		}
-		return traces[i].TypeVar < traces[j].TypeVar
-	})
+	SortDefaultingTraces(traces)
 
 	for _, trace := range traces {
+		where := trace.Location
+		if trace.Span.Line > 0 {
+			where = trace.Span.String()
+		}
 		lines = append(lines, fmt.Sprintf("  • %s: %s[%s] defaulted to %s",
-			trace.Location,
+			where,
 			trace.ClassName,
 			trace.TypeVar,
 			trace.Default.String(),
@@ -74,6 +88,26 @@ func FormatDefaultingTraces(traces []DefaultingTrace) string {
 	return strings.Join(lines, "\n")
 }
 
+// SortDefaultingTraces orders traces by source position, then type variable
+func SortDefaultingTraces(traces []DefaultingTrace) {
+	sort.SliceStable(traces, func(i, j int) bool {
+		a, b := traces[i].Span, traces[j].Span
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		if traces[i].Location != traces[j].Location {
+			return traces[i].Location < traces[j].Location
+		}
+		return traces[i].TypeVar < traces[j].TypeVar
+	})
+}
+
 // DisableDefaulting creates a config with defaulting disabled
 func DisableDefaulting() *DefaultingConfig {
 	return &DefaultingConfig{
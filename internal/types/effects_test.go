@@ -423,3 +423,25 @@ func TestFormatEffectRow(t *testing.T) {
 		})
 	}
 }
+
+func TestJoinEffects_OpenRows(t *testing.T) {
+	ctx := NewInferenceContext()
+	callee1 := ctx.freshEffectRow()
+	callee2 := ctx.freshEffectRow()
+	ioRow, _ := ElaborateEffectRow([]string{"IO"})
+	fsRow, _ := ElaborateEffectRow([]string{"FS"})
+	traceRow, _ := ElaborateEffectRow([]string{"Trace"})
+
+	joined := ctx.joinEffects(callee1, traceRow, callee2)
+	ctx.addConstraint(RowEq{Left: callee1, Right: ioRow})
+	ctx.addConstraint(RowEq{Left: callee2, Right: fsRow})
+
+	sub, _, err := ctx.SolveConstraints()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	solved := ApplySubstitution(sub, joined).(*Row)
+	if got := FormatEffectRow(solved); got != "! {FS, IO, Trace}" {
+		t.Errorf("expected the union of all three rows, got %q", got)
+	}
+}
@@ -70,6 +70,21 @@ func (env *TypeEnv) Lookup(name string) (interface{}, error) {
 	return nil, fmt.Errorf("unbound variable: %s", name)
 }
 
+// Names returns every name bound in the environment and its parents
+func (env *TypeEnv) Names() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for e := env; e != nil; e = e.parent {
+		for name := range e.bindings {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
 // BindScheme adds a scheme binding to the environment (for REPL persistence)
 // This mutates the environment in-place, unlike Extend which creates a child.
 // Use this only when you need top-level bindings to persist (e.g., REPL).
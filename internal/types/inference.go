@@ -2,8 +2,10 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sunholo/ailang/internal/ast"
+	"github.com/sunholo/ailang/internal/core"
 )
 
 // InferenceContext maintains state during type inference
@@ -14,6 +16,7 @@ type InferenceContext struct {
 	freshCounter         int
 	path                 []string          // For error reporting
 	qualifiedConstraints []ClassConstraint // Non-ground constraints for qualified types
+	moduleValue          *core.Let         // Top-level module value, kept monomorphic
 }
 
 // TypeConstraint represents a constraint to be solved
@@ -42,12 +45,30 @@ type RowEq struct {
 func (c RowEq) constraint()    {}
 func (c RowEq) String() string { return fmt.Sprintf("%s ~ %s", c.Left, c.Right) }
 
+// EffectJoin binds Tail to the union of Rows once their row variables are
+// solved (see joinEffects)
+type EffectJoin struct {
+	Tail *RowVar
+	Rows []*Row
+	Path []string
+}
+
+func (c EffectJoin) constraint() {}
+func (c EffectJoin) String() string {
+	rows := make([]string, len(c.Rows))
+	for i, r := range c.Rows {
+		rows[i] = r.String()
+	}
+	return fmt.Sprintf("%s ~ %s", c.Tail.Name, strings.Join(rows, " ∪ "))
+}
+
 // ClassConstraint represents a type class constraint
 type ClassConstraint struct {
 	Class  string
 	Type   Type
 	Path   []string
-	NodeID uint64 // NodeID of the Core expression that generated this constraint
+	NodeID uint64  // NodeID of the Core expression that generated this constraint
+	Span   ast.Pos // Source position of that expression, if known
 }
 
 func (c ClassConstraint) constraint()    {}
@@ -590,6 +611,27 @@ func (ctx *InferenceContext) SolveConstraints() (Substitution, []ClassConstraint
 		}
 	}
 
+	// Phase 1b: Bind each effect join to the union of its solved rows. Joins
+	// are recorded in creation order, so a join only refers to earlier ones.
+	for _, c := range ctx.constraints {
+		join, ok := c.(EffectJoin)
+		if !ok {
+			continue
+		}
+		solvedRows := make([]*Row, len(join.Rows))
+		for i, r := range join.Rows {
+			solvedRows[i] = ApplySubstitution(sub, r).(*Row)
+		}
+		tail := &Row{Kind: EffectRow, Labels: map[string]Type{}, Tail: join.Tail}
+		var err error
+		sub, err = ctx.unifier.rowUnifier.UnifyRows(tail, UnionEffects(solvedRows...), sub)
+		if err != nil {
+			return nil, nil, fmt.Errorf("effect unification failed at %v: %w", join.Path, err)
+		}
+	}
+
+	sub = closeSubstitution(sub)
+
 	// Phase 2: Apply final substitution to all class constraints
 	unsolvedClass := []ClassConstraint{}
 	for _, c := range ctx.constraints {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
 )
 
 // Dict represents method implementations for a type class instance
@@ -42,6 +44,19 @@ func (env *InstanceEnv) Add(inst *ClassInstance) error {
 	return nil
 }
 
+// Clone returns a copy of the environment that can be extended independently
+// (e.g., with the instances visible to a single module)
+func (env *InstanceEnv) Clone() *InstanceEnv {
+	clone := NewInstanceEnv()
+	for key, inst := range env.instances {
+		clone.instances[key] = inst
+	}
+	for class, typ := range env.defaults {
+		clone.defaults[class] = typ
+	}
+	return clone
+}
+
 // Lookup finds an instance, including superclass derivation
 func (env *InstanceEnv) Lookup(class string, typ Type) (*ClassInstance, error) {
 	// Direct lookup
@@ -58,11 +73,56 @@ func (env *InstanceEnv) Lookup(class string, typ Type) (*ClassInstance, error) {
 		}
 	}
 
+	// Structural provision: tuples compare element-wise
+	if tuple, ok := typ.(*TTuple); ok && (class == "Eq" || class == "Ord") {
+		return env.deriveTupleInstance(class, tuple)
+	}
+
+	available := env.InstancesOf(class)
 	return nil, &MissingInstanceError{
-		Class: class,
-		Type:  typ,
-		Hint:  "Import std/prelude or define instance",
+		Class:     class,
+		Type:      typ,
+		Hint:      missingInstanceHint(class, typ, available),
+		Available: available,
+	}
+}
+
+// InstancesOf returns the normalized names of the types that have an
+// instance of class, sorted. Eq includes the types Ord provides it for.
+func (env *InstanceEnv) InstancesOf(class string) []string {
+	seen := make(map[string]bool)
+	for _, inst := range env.instances {
+		if inst.ClassName == class || (class == "Eq" && inst.ClassName == "Ord") {
+			seen[NormalizeTypeName(inst.TypeHead)] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// missingInstanceHint suggests a fix for a missing class[typ] instance,
+// preferring the operation the user most likely meant
+func missingInstanceHint(class string, typ Type, available []string) string {
+	if len(available) == 0 {
+		return "Import std/prelude or define instance"
+	}
+	name := NormalizeTypeName(typ)
+	switch {
+	case (class == "Num" || class == "Fractional") && name == "String":
+		return "Did you mean to use a String-specific operation like ++?"
+	case (class == "Num" || class == "Fractional") && name == "Bool":
+		return "Did you mean a boolean operation like && or ||?"
+	case class == "Fractional" && name == "Int":
+		return "Convert with intToFloat, or write a float literal such as 1.0"
+	}
+	if _, isFunc := typ.(*TFunc2); isFunc {
+		return fmt.Sprintf("Functions have no %s instance; apply the function first", class)
 	}
+	return "Convert the value to one of the available types"
 }
 
 // DefaultFor returns the default type for a class (for numeric literal defaulting)
@@ -104,19 +164,85 @@ func deriveEqFromOrd(ord *ClassInstance) *ClassInstance {
 	}
 }
 
+// deriveTupleInstance creates the Eq or Ord instance for a tuple whose
+// elements all have one. Ord compares lexicographically, left to right.
+func (env *InstanceEnv) deriveTupleInstance(class string, tuple *TTuple) (*ClassInstance, error) {
+	for _, elem := range tuple.Elements {
+		if _, err := env.Lookup(class, elem); err != nil {
+			return nil, &MissingInstanceError{
+				Class:     class,
+				Type:      tuple,
+				Hint:      fmt.Sprintf("Tuple element %s has no %s instance", elem, class),
+				Available: env.InstancesOf(class),
+			}
+		}
+	}
+
+	inst := &ClassInstance{
+		ClassName: class,
+		TypeHead:  tuple,
+		Dict:      Dict{"eq": "builtin_eq_tuple_eq", "neq": "builtin_eq_tuple_neq"},
+	}
+	if class == "Ord" {
+		inst.Super = []string{"Eq"}
+		inst.Dict = Dict{
+			"lt":  "builtin_ord_tuple_lt",
+			"lte": "builtin_ord_tuple_lte",
+			"gt":  "builtin_ord_tuple_gt",
+			"gte": "builtin_ord_tuple_gte",
+		}
+	}
+	return inst, nil
+}
+
+// DerivedInstance returns the instance of class that a derive clause
+// generates for the type head. Derived instances compare and show values
+// structurally; ok is false when the class cannot be derived.
+func DerivedInstance(class string, head Type) (*ClassInstance, bool) {
+	inst := &ClassInstance{ClassName: class, TypeHead: head}
+	switch class {
+	case "Eq":
+		inst.Dict = Dict{"eq": "builtin_eq_adt_eq", "neq": "builtin_eq_adt_neq"}
+	case "Ord":
+		inst.Super = []string{"Eq"}
+		inst.Dict = Dict{
+			"lt":  "builtin_ord_adt_lt",
+			"lte": "builtin_ord_adt_lte",
+			"gt":  "builtin_ord_adt_gt",
+			"gte": "builtin_ord_adt_gte",
+		}
+	case "Show":
+		inst.Dict = Dict{"show": "builtin_show_adt"}
+	default:
+		return nil, false
+	}
+	return inst, true
+}
+
 // MissingInstanceError represents a missing type class instance
 type MissingInstanceError struct {
-	Class string
-	Type  Type
-	Hint  string
+	Class     string
+	Type      Type
+	Hint      string   // Suggested fix
+	Available []string // Types that do have an instance of Class
+	Span      ast.Pos  // Source of the expression that needed the instance, if known
 }
 
 func (e *MissingInstanceError) Error() string {
-	msg := fmt.Sprintf("No instance for %s[%s] in scope", e.Class, e.Type)
+	msg := fmt.Sprintf("No instance for %s[%s] in scope.", e.Class, e.Type)
+	if e.Span.Line > 0 {
+		msg = fmt.Sprintf("No instance for %s[%s] at %s.", e.Class, e.Type, e.Span)
+	}
 	if e.Hint != "" {
-		msg += ". " + e.Hint
+		msg += " " + e.Hint
+		if !strings.HasSuffix(e.Hint, "?") && !strings.HasSuffix(e.Hint, ".") {
+			msg += "."
+		}
+	}
+	if len(e.Available) > 0 {
+		msg += fmt.Sprintf(" Available %s instances: %s", e.Class, strings.Join(e.Available, ", "))
 	}
-	return msg
+	return strings.TrimSuffix(msg, ".")
 }
 
 // LoadBuiltinInstances creates the standard set of built-in instances
@@ -165,6 +291,18 @@ func builtinInstances() []*ClassInstance {
 			},
 		},
 
+		// Num[BigInt] - arbitrary precision, never overflows
+		{
+			ClassName: "Num",
+			TypeHead:  TBigInt,
+			Dict: Dict{
+				"add": "builtin_num_bigint_add",
+				"sub": "builtin_num_bigint_sub",
+				"mul": "builtin_num_bigint_mul",
+				"div": "builtin_num_bigint_div",
+			},
+		},
+
 		// Eq[Int]
 		{
 			ClassName: "Eq",
@@ -205,6 +343,16 @@ func builtinInstances() []*ClassInstance {
 			},
 		},
 
+		// Eq[Unit] - there is only one value, so it is always equal
+		{
+			ClassName: "Eq",
+			TypeHead:  TUnit,
+			Dict: Dict{
+				"eq":  "builtin_eq_unit_eq",
+				"neq": "builtin_eq_unit_neq",
+			},
+		},
+
 		// Ord[Int]
 		{
 			ClassName: "Ord",
@@ -218,6 +366,19 @@ func builtinInstances() []*ClassInstance {
 			},
 		},
 
+		// Ord[BigInt] (also provides Eq[BigInt])
+		{
+			ClassName: "Ord",
+			TypeHead:  TBigInt,
+			Super:     []string{"Eq"},
+			Dict: Dict{
+				"lt":  "builtin_ord_bigint_lt",
+				"lte": "builtin_ord_bigint_lte",
+				"gt":  "builtin_ord_bigint_gt",
+				"gte": "builtin_ord_bigint_gte",
+			},
+		},
+
 		// Ord[Float] - Total order with NaN greatest
 		{
 			ClassName: "Ord",
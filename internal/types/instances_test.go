@@ -1,7 +1,11 @@
 package types
 
 import (
+	"errors"
+	"strings"
 	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
 )
 
 func TestInstanceEnvCoherence(t *testing.T) {
@@ -55,6 +59,11 @@ func TestInstanceLookup(t *testing.T) {
 		{"Ord[Int]", "Ord", TInt, true},
 		{"Show[Bool]", "Show", TBool, true},
 		{"Num[String]", "Num", TString, false}, // No instance
+		{"Eq[Unit]", "Eq", TUnit, true},
+		{"Eq[(Int, String)]", "Eq", &TTuple{Elements: []Type{TInt, TString}}, true},
+		{"Ord[(Int, (String, Float))]", "Ord", &TTuple{Elements: []Type{TInt, &TTuple{Elements: []Type{TString, TFloat}}}}, true},
+		{"Ord[(Int, Bool)]", "Ord", &TTuple{Elements: []Type{TInt, TBool}}, false}, // Bool is not ordered
+		{"Num[(Int, Int)]", "Num", &TTuple{Elements: []Type{TInt, TInt}}, false},
 	}
 
 	for _, tt := range tests {
@@ -224,3 +233,62 @@ func TestNoAmbientInstances(t *testing.T) {
 		}
 	}
 }
+
+func TestMissingInstanceSuggestions(t *testing.T) {
+	env := LoadBuiltinInstances()
+
+	tests := []struct {
+		class string
+		typ   Type
+		want  string
+	}{
+		{"Num", TString, "No instance for Num[string] in scope. Did you mean to use a String-specific operation like ++? Available Num instances: BigInt, Float, Int"},
+		{"Fractional", TInt, "No instance for Fractional[int] in scope. Convert with intToFloat, or write a float literal such as 1.0. Available Fractional instances: Float"},
+		{"Num", &TCon{Name: "Color"}, "No instance for Num[Color] in scope. Convert the value to one of the available types. Available Num instances: BigInt, Float, Int"},
+		{"Ord", &TTuple{Elements: []Type{TInt, TBool}}, "No instance for Ord[(int, bool)] in scope. Tuple element bool has no Ord instance. Available Ord instances: BigInt, Float, Int, String"},
+	}
+	for _, tt := range tests {
+		_, err := env.Lookup(tt.class, tt.typ)
+		missingErr, ok := err.(*MissingInstanceError)
+		if !ok {
+			t.Fatalf("%s[%s]: expected MissingInstanceError, got %v", tt.class, tt.typ, err)
+		}
+		if got := missingErr.Error(); got != tt.want {
+			t.Errorf("got:  %s\nwant: %s", got, tt.want)
+		}
+	}
+
+	// Eq is also provided by every Ord instance
+	ordOnly := NewInstanceEnv()
+	_ = ordOnly.Add(&ClassInstance{ClassName: "Ord", TypeHead: TString})
+	if got := ordOnly.InstancesOf("Eq"); len(got) != 1 || got[0] != "String" {
+		t.Errorf("expected Eq instances [String], got %v", got)
+	}
+}
+
+func TestMissingInstanceSpan(t *testing.T) {
+	tc := NewCoreTypeCheckerWithInstances(LoadBuiltinInstances())
+	span := ast.Pos{File: "foo.ail", Line: 8, Column: 12}
+	err := tc.resolveGroundConstraints([]ClassConstraint{
+		{Class: "Num", Type: TString, Path: []string{"binop"}, Span: span},
+	}, nil)
+
+	var missingErr *MissingInstanceError
+	if !errors.As(err, &missingErr) {
+		t.Fatalf("expected MissingInstanceError, got %v", err)
+	}
+	if missingErr.Span != span {
+		t.Errorf("expected span %s, got %s", span, missingErr.Span)
+	}
+	if !strings.HasPrefix(err.Error(), "No instance for Num[string] at foo.ail:8:12.") {
+		t.Errorf("expected the span in the message, got: %s", err)
+	}
+
+	// Without a span the constraint's path still says where it came from
+	err = tc.resolveGroundConstraints([]ClassConstraint{
+		{Class: "Num", Type: TString, Path: []string{"binop"}},
+	}, nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "at binop: No instance for Num[string] in scope.") {
+		t.Errorf("expected the path in the message, got: %v", err)
+	}
+}
@@ -271,3 +271,94 @@ func TestOpenClosedInteractions(t *testing.T) {
 		})
 	}
 }
+
+// TestADTFieldAccessUnification tests field access on record-style ADT values
+func TestADTFieldAccessUnification(t *testing.T) {
+	u := NewUnifier()
+	u.SetADTFields(map[string]*ADTFields{
+		"Point": {Fields: map[string]Type{"x": TInt, "y": TInt}},
+		"Box": {
+			Params: []string{"a"},
+			Fields: map[string]Type{"val": &TVar2{Name: "a", Kind: Star}, "tag": TString},
+		},
+	})
+
+	typed := func(field string, typ Type) *TRecordOpen {
+		return &TRecordOpen{
+			Fields: map[string]Type{field: typ},
+			Row:    &RowVar{Name: "r", Kind: RecordRow},
+		}
+	}
+	open := func(field string) *TRecordOpen {
+		return typed(field, &TVar2{Name: "f", Kind: Star})
+	}
+	box := func(arg Type) *TApp {
+		return &TApp{Constructor: &TCon{Name: "Box"}, Args: []Type{arg}}
+	}
+
+	tests := []struct {
+		name    string
+		t1      Type
+		t2      Type
+		wantErr bool
+	}{
+		{"Point ~ {x | ρ} succeeds", &TCon{Name: "Point"}, open("x"), false},
+		{"{y | ρ} ~ Point succeeds", open("y"), &TCon{Name: "Point"}, false},
+		{"Point ~ {z | ρ} fails (unknown field)", &TCon{Name: "Point"}, open("z"), true},
+		{"Color ~ {x | ρ} fails (no named fields)", &TCon{Name: "Color"}, open("x"), true},
+		{"Point ~ {x: int | ρ} succeeds", &TCon{Name: "Point"}, typed("x", TInt), false},
+		{"Point ~ {x: string | ρ} fails (field type)", &TCon{Name: "Point"}, typed("x", TString), true},
+		{"Box ~ {tag: int | ρ} fails (field type)", &TCon{Name: "Box"}, typed("tag", TInt), true},
+		{"Box ~ {val: string | ρ} succeeds (parameter not applied)", &TCon{Name: "Box"}, typed("val", TString), false},
+		{"Box[int] ~ {val: int | ρ} succeeds", box(TInt), typed("val", TInt), false},
+		{"{val: string | ρ} ~ Box[int] fails (field type)", typed("val", TString), box(TInt), true},
+		{"Box[int] ~ {nope | ρ} fails (unknown field)", box(TInt), open("nope"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.Unify(tt.t1, tt.t2, make(Substitution))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestMissingFieldErrorListsFields tests that field access on a record
+// without the field names the record's fields
+func TestMissingFieldErrorListsFields(t *testing.T) {
+	u := NewUnifier()
+	u.SetADTFields(map[string]*ADTFields{
+		"Point": {Fields: map[string]Type{"x": TInt, "y": TInt}},
+	})
+	open := &TRecordOpen{
+		Fields: map[string]Type{"nam": &TVar2{Name: "f", Kind: Star}},
+		Row:    &RowVar{Name: "r", Kind: RecordRow},
+	}
+	fields := map[string]Type{"name": TString, "age": TInt}
+
+	tests := []struct {
+		name string
+		t2   Type
+		want string
+	}{
+		{"closed record", &TRecord{Fields: fields},
+			"record of type {age, name} has no field 'nam' (did you mean 'name'?); available fields: age, name"},
+		{"TRecord2", &TRecord2{Row: &Row{Kind: RecordRow, Labels: fields}},
+			"record of type {age, name} has no field 'nam' (did you mean 'name'?); available fields: age, name"},
+		{"empty record", &TRecord{Fields: map[string]Type{}},
+			"record of type {} has no field 'nam'; available fields: none"},
+		{"record-style ADT", &TCon{Name: "Point"},
+			"type Point has no field 'nam'; available fields: x, y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.Unify(open, tt.t2, make(Substitution))
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Unify() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortResolvedConstraints orders resolved constraints by source position,
+// then node ID
+func SortResolvedConstraints(rcs []*ResolvedConstraint) {
+	sort.SliceStable(rcs, func(i, j int) bool {
+		a, b := rcs[i].Span, rcs[j].Span
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return rcs[i].NodeID < rcs[j].NodeID
+	})
+}
+
+// FormatResolvedConstraints renders resolved constraints as a table with
+// one row per node: where it is, the class, the instance type and the
+// method its operator uses ("-" for literals)
+func FormatResolvedConstraints(rcs []*ResolvedConstraint) string {
+	if len(rcs) == 0 {
+		return "No resolved instances"
+	}
+
+	rows := [][]string{{"NODE", "POSITION", "CLASS", "TYPE", "METHOD"}}
+	for _, rc := range rcs {
+		where := "-"
+		if rc.Span.Line > 0 {
+			where = rc.Span.String()
+		}
+		method := rc.Method
+		if method == "" {
+			method = "-"
+		}
+		rows = append(rows, []string{fmt.Sprint(rc.NodeID), where, rc.ClassName, rc.Type.String(), method})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	lines := make([]string, len(rows))
+	for r, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = fmt.Sprintf("%-*s", widths[i], cell)
+		}
+		lines[r] = strings.TrimRight(strings.Join(cells, "  "), " ")
+	}
+	return strings.Join(lines, "\n")
+}
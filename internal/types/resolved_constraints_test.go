@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/sunholo/ailang/internal/ast"
+)
+
+// TestFormatResolvedConstraints checks the --dump-instances table is sorted
+// by position, aligned, and marks literals without a method
+func TestFormatResolvedConstraints(t *testing.T) {
+	rcs := []*ResolvedConstraint{
+		{NodeID: 12, ClassName: "Ord", Type: &TCon{Name: "Float"}, Method: "lt", Span: ast.Pos{File: "a.ail", Line: 2, Column: 5}},
+		{NodeID: 3, ClassName: "Num", Type: &TCon{Name: "Int"}, Span: ast.Pos{File: "a.ail", Line: 1, Column: 9}},
+	}
+	SortResolvedConstraints(rcs)
+
+	want := "NODE  POSITION   CLASS  TYPE   METHOD\n" +
+		"3     a.ail:1:9  Num    Int    -\n" +
+		"12    a.ail:2:5  Ord    Float  lt"
+	if got := FormatResolvedConstraints(rcs); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if got := FormatResolvedConstraints(nil); got != "No resolved instances" {
+		t.Errorf("empty table: got %q", got)
+	}
+}
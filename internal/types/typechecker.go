@@ -186,6 +186,12 @@ func (tc *TypeChecker) checkExpression(expr ast.Expr, env *TypeEnv) (*TypedExpre
 	}, nil
 }
 
+// TypeFromAST converts a surface type annotation to an internal type
+// (used outside the type checker, e.g. for instance heads in module interfaces)
+func TypeFromAST(t ast.Type) Type {
+	return (&TypeChecker{}).astTypeToType(t)
+}
+
 // astTypeToType converts an AST type to an internal type
 func (tc *TypeChecker) astTypeToType(t ast.Type) Type {
 	switch typ := t.(type) {
@@ -211,6 +217,16 @@ func (tc *TypeChecker) astTypeToType(t ast.Type) Type {
 			return &TCon{Name: typ.Name}
 		}
 
+	case *ast.TypeApp:
+		args := make([]Type, len(typ.Args))
+		for i, arg := range typ.Args {
+			args[i] = tc.astTypeToType(arg)
+		}
+		if typ.Name == "List" && len(args) == 1 {
+			return &TList{Element: args[0]}
+		}
+		return &TApp{Constructor: &TCon{Name: typ.Name}, Args: args}
+
 	case *ast.FuncType:
 		paramTypes := make([]Type, len(typ.Params))
 		for i, p := range typ.Params {
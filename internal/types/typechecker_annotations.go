@@ -0,0 +1,122 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/sunholo/ailang/internal/ast"
+)
+
+// annotationType converts a let, lambda parameter or return annotation to
+// the type it is checked against. Builtin types may be capitalised (Float as
+// well as float), and the annotation's type variables are replaced by fresh
+// ones, so `let id: (a) -> a = ...` fixes the shape of the value without
+// making a rigid type. Type aliases are expanded, and List[a] is the list
+// type [a]. An ADT in scope stays a named type: Option[int] rejects an int,
+// though its argument is only compared with values whose type carries one,
+// since constructed values are typed by the bare ADT. Other names (records,
+// types not in scope) stay unknown.
+func (tc *CoreTypeChecker) annotationType(ctx *InferenceContext, t ast.Type) Type {
+	a := &annotation{ctx: ctx, adts: tc.adtNames(), vars: make(map[string]Type)}
+	return a.freshen(ExpandAliases(TypeFromAST(t), tc.typeAliases))
+}
+
+// adtNames returns the ADTs whose constructors are in scope, read off the
+// result types of their $adt factories
+func (tc *CoreTypeChecker) adtNames() map[string]bool {
+	names := make(map[string]bool)
+	for key, scheme := range tc.globalTypes {
+		if !strings.HasPrefix(key, "$adt.") || scheme == nil {
+			continue
+		}
+		result := scheme.Type
+		if fn, ok := result.(*TFunc2); ok {
+			result = fn.Return
+		}
+		if head, _ := decomposeApp(result); head != nil {
+			if con, ok := head.(*TCon); ok {
+				names[con.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// annotationPrims maps both spellings of the builtin types
+var annotationPrims = map[string]Type{
+	"int": TInt, "Int": TInt,
+	"float": TFloat, "Float": TFloat,
+	"string": TString, "String": TString,
+	"bool": TBool, "Bool": TBool,
+	"()": TUnit, "Unit": TUnit,
+	"bytes": TBytes, "Bytes": TBytes,
+}
+
+// annotation converts one annotation, sharing fresh variables between
+// repeated type variable names
+type annotation struct {
+	ctx  *InferenceContext
+	adts map[string]bool
+	vars map[string]Type
+}
+
+func (a *annotation) freshen(t Type) Type {
+	switch typ := t.(type) {
+	case *TCon:
+		if prim, ok := annotationPrims[typ.Name]; ok {
+			return prim
+		}
+		if a.adts[typ.Name] {
+			return typ
+		}
+		return a.ctx.freshTypeVar()
+	case *TApp:
+		head, args := decomposeApp(typ)
+		con, ok := head.(*TCon)
+		if !ok || !a.adts[con.Name] {
+			return a.ctx.freshTypeVar()
+		}
+		freshArgs := make([]Type, len(args))
+		for i, arg := range args {
+			freshArgs[i] = a.freshen(arg)
+		}
+		return &TApp{Constructor: con, Args: freshArgs}
+	case *TVar2:
+		if v, ok := a.vars[typ.Name]; ok {
+			return v
+		}
+		v := a.ctx.freshTypeVar()
+		if typ.Name != "unknown" {
+			a.vars[typ.Name] = v
+		}
+		return v
+	case *TFunc2:
+		params := make([]Type, len(typ.Params))
+		for i, p := range typ.Params {
+			params[i] = a.freshen(p)
+		}
+		// An annotated function type keeps its declared effects but leaves
+		// the row open, so `f: (a) -> b` also accepts an effectful function
+		// and higher-order functions stay effect-polymorphic
+		row := a.ctx.freshEffectRow()
+		if typ.EffectRow != nil {
+			for label, t := range typ.EffectRow.Labels {
+				row.Labels[label] = t
+			}
+		}
+		return &TFunc2{
+			Params:    params,
+			EffectRow: row,
+			Return:    a.freshen(typ.Return),
+		}
+	case *TList:
+		return &TList{Element: a.freshen(typ.Element)}
+	case *TTuple:
+		elements := make([]Type, len(typ.Elements))
+		for i, e := range typ.Elements {
+			elements[i] = a.freshen(e)
+		}
+		return &TTuple{Elements: elements}
+	default:
+		return t
+	}
+}
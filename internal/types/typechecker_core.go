@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/sunholo/ailang/internal/ast"
 	"github.com/sunholo/ailang/internal/core"
 	"github.com/sunholo/ailang/internal/typedast"
 )
@@ -22,6 +23,22 @@ type CoreTypeChecker struct {
 	trackInstantiations bool                           // Whether to track instantiations
 	varCounter          int                            // Counter for generating fresh variable names
 	effectAnnots        map[uint64][]string            // Effect annotations from elaboration (NodeID → effects)
+	listConcats         map[uint64]bool                // ++ nodes typed as list concatenation (NodeID)
+	polymorphicNodes    map[uint64]bool                // Operators and literals at a let-generalized type variable (NodeID)
+	typeAnnots          map[uint64][]ast.Type          // Let and parameter type annotations from elaboration
+	returnAnnots        map[uint64]ast.Type            // Declared return types from elaboration (lambda NodeID → type)
+	typeAliases         map[string]Type                // Transparent type aliases (name → resolved type)
+	adtFields           map[string]*ADTFields          // ADT name → named constructor fields (record-style ADTs)
+	newtypes            map[string]*Newtype            // Constructor name → newtype it builds
+	showApps            []showApp                      // show applications awaiting their argument's final type
+}
+
+// Newtype describes a single-field wrapper type such as `type UserId = UserId(int)`.
+// A newtype never unifies with its field type, even though its values are
+// represented by the unboxed field at runtime.
+type Newtype struct {
+	TypeName string // The newtype (e.g., "UserId")
+	Field    Type   // The wrapped field type (e.g., int)
 }
 
 // Instantiation records a polymorphic type instantiation for debugging
@@ -63,10 +80,11 @@ func (tc *CoreTypeChecker) EnableInstantiationTracking() {
 // ResolvedConstraint records a resolved class constraint at a specific node
 // This is used by the elaborator to insert dictionary passing
 type ResolvedConstraint struct {
-	NodeID    uint64 // Core node ID where constraint was resolved
-	ClassName string // "Num", "Eq", "Ord", etc.
-	Type      Type   // Normalized ground type (Int, Float, etc.)
-	Method    string // Method name for operators: "add", "eq", "lt", etc.
+	NodeID    uint64  // Core node ID where constraint was resolved
+	ClassName string  // "Num", "Eq", "Ord", etc.
+	Type      Type    // Normalized ground type (Int, Float, etc.)
+	Method    string  // Method name for operators: "add", "eq", "lt", etc.
+	Span      ast.Pos // Source position of the node
 }
 
 // NewCoreTypeChecker creates a new Core type checker
@@ -99,6 +117,8 @@ func NewCoreTypeChecker() *CoreTypeChecker {
 		resolvedConstraints: make(map[uint64]*ResolvedConstraint),
 		globalTypes:         make(map[string]*Scheme),
 		effectAnnots:        make(map[uint64][]string),
+		listConcats:         make(map[uint64]bool),
+		polymorphicNodes:    make(map[uint64]bool),
 	}
 }
 
@@ -116,6 +136,8 @@ func NewCoreTypeCheckerWithInstances(instances *InstanceEnv) *CoreTypeChecker {
 		resolvedConstraints: make(map[uint64]*ResolvedConstraint),
 		globalTypes:         make(map[string]*Scheme),
 		effectAnnots:        make(map[uint64][]string),
+		listConcats:         make(map[uint64]bool),
+		polymorphicNodes:    make(map[uint64]bool),
 	}
 }
 
@@ -124,6 +146,18 @@ func (tc *CoreTypeChecker) SetGlobalTypes(types map[string]*Scheme) {
 	tc.globalTypes = types
 }
 
+// SetADTFields sets the named constructor fields of record-style ADTs,
+// enabling `p.x` field access on values of those types
+func (tc *CoreTypeChecker) SetADTFields(fields map[string]*ADTFields) {
+	tc.adtFields = fields
+}
+
+// SetNewtypes sets the newtype constructors in scope, keyed by constructor
+// name, so that their patterns fix the scrutinee and field types
+func (tc *CoreTypeChecker) SetNewtypes(newtypes map[string]*Newtype) {
+	tc.newtypes = newtypes
+}
+
 // SetGlobalType sets a single global type scheme
 func (tc *CoreTypeChecker) SetGlobalType(key string, scheme *Scheme) {
 	if tc.globalTypes == nil {
@@ -147,11 +181,34 @@ func (tc *CoreTypeChecker) SetDefaultingConfig(config *DefaultingConfig) {
 	tc.defaultingConfig = config
 }
 
+// DefaultingTraces returns the defaulting decisions recorded so far
+func (tc *CoreTypeChecker) DefaultingTraces() []DefaultingTrace {
+	return tc.defaultingConfig.Traces
+}
+
 // SetEffectAnnotations sets effect annotations from elaboration
 func (tc *CoreTypeChecker) SetEffectAnnotations(annots map[uint64][]string) {
 	tc.effectAnnots = annots
 }
 
+// SetTypeAnnotations sets let and lambda parameter type annotations from
+// elaboration (see elaborate.Elaborator.GetTypeAnnotations)
+func (tc *CoreTypeChecker) SetTypeAnnotations(annots map[uint64][]ast.Type) {
+	tc.typeAnnots = annots
+}
+
+// SetTypeAliases sets the transparent type aliases in scope (see
+// ResolveTypeAliases). Unification and annotations expand them.
+func (tc *CoreTypeChecker) SetTypeAliases(aliases map[string]Type) {
+	tc.typeAliases = aliases
+}
+
+// SetReturnAnnotations sets declared function return types from elaboration
+// (see elaborate.Elaborator.GetReturnAnnotations)
+func (tc *CoreTypeChecker) SetReturnAnnotations(annots map[uint64]ast.Type) {
+	tc.returnAnnots = annots
+}
+
 // InferWithConstraints infers type with constraints for a Core expression
 // Returns: typed expression, updated env, qualified type, constraints, error
 func (tc *CoreTypeChecker) InferWithConstraints(expr core.CoreExpr, env *TypeEnv) (typedast.TypedNode, *TypeEnv, Type, []Constraint, error) {
@@ -164,6 +221,9 @@ func (tc *CoreTypeChecker) InferWithConstraints(expr core.CoreExpr, env *TypeEnv
 		path:                 []string{},
 		qualifiedConstraints: []ClassConstraint{},
 	}
+	ctx.unifier.SetADTFields(tc.adtFields)
+	ctx.unifier.SetTypeAliases(tc.typeAliases)
+	ctx.moduleValue = moduleValueLet(expr)
 
 	// Infer type (returns updated env)
 	typedNode, updatedEnv, err := tc.inferCore(ctx, expr)
@@ -196,11 +256,22 @@ func (tc *CoreTypeChecker) InferWithConstraints(expr core.CoreExpr, env *TypeEnv
 
 	// Compose substitutions if defaulting was applied
 	if len(defaultingSub) > 0 {
-		sub = composeSubstitutions(defaultingSub, sub)
+		sub = composeSubstitutions(sub, defaultingSub)
 		finalType = defaultedType
 		unsolved = defaultedConstraints
 	}
 
+	// A monomorphic top-level binding (e.g., the module value `let pi = 3.14159`)
+	// keeps the type it was solved and defaulted to, so later declarations see
+	// float instead of re-solving its type variable on their own
+	if let, ok := expr.(*core.Let); ok {
+		if binding, lookupErr := updatedEnv.Lookup(let.Name); lookupErr == nil {
+			if typ, isMono := binding.(Type); isMono {
+				updatedEnv = updatedEnv.Extend(let.Name, ApplySubstitution(sub, typ))
+			}
+		}
+	}
+
 	// Apply final substitution to typed node
 	typedNode = tc.applySubstitutionToTyped(sub, typedNode)
 
@@ -212,6 +283,7 @@ func (tc *CoreTypeChecker) InferWithConstraints(expr core.CoreExpr, env *TypeEnv
 
 	// Fill in operator methods
 	tc.FillOperatorMethods(expr)
+	tc.fillNewtypeShows(sub)
 
 	// Convert ClassConstraints to Constraints for return value
 	constraints := make([]Constraint, len(nonGround))
@@ -223,7 +295,23 @@ func (tc *CoreTypeChecker) InferWithConstraints(expr core.CoreExpr, env *TypeEnv
 	}
 
 	// Return with updated env, constraints and defaulted type
-	return typedNode, updatedEnv, finalType, constraints, nil
+	return typedNode, updatedEnv, ApplySubstitution(sub, finalType), constraints, nil
+}
+
+// moduleValueLet returns expr if it is an elaborated module value, a
+// non-function `let name = value in name`, and nil otherwise
+func moduleValueLet(expr core.CoreExpr) *core.Let {
+	let, ok := expr.(*core.Let)
+	if !ok {
+		return nil
+	}
+	if _, isLambda := let.Value.(*core.Lambda); isLambda {
+		return nil
+	}
+	if body, ok := let.Body.(*core.Var); !ok || body.Name != let.Name {
+		return nil
+	}
+	return let
 }
 
 // GetResolvedConstraints returns the map of resolved constraints
@@ -238,6 +326,12 @@ func (tc *CoreTypeChecker) GetResolvedConstraints() map[uint64]*ResolvedConstrai
 	return tc.resolvedConstraints
 }
 
+// ListConcats returns the ++ nodes that concatenate lists rather than strings
+// Used by operator lowering to pick concat_List over concat_String
+func (tc *CoreTypeChecker) ListConcats() map[uint64]bool {
+	return tc.listConcats
+}
+
 // CheckCoreProgram type checks a Core program and produces TypedAST
 func (tc *CoreTypeChecker) CheckCoreProgram(prog *core.Program) (*typedast.TypedProgram, error) {
 	typed := &typedast.TypedProgram{
@@ -269,6 +363,8 @@ func (tc *CoreTypeChecker) CheckCoreProgram(prog *core.Program) (*typedast.Typed
 func (tc *CoreTypeChecker) CheckCoreExpr(expr core.CoreExpr, env *TypeEnv) (typedast.TypedNode, *TypeEnv, error) {
 	ctx := NewInferenceContext()
 	ctx.env = env
+	ctx.unifier.SetADTFields(tc.adtFields)
+	ctx.unifier.SetTypeAliases(tc.typeAliases)
 
 	// Infer type and effects
 	typedNode, newEnv, err := tc.inferCore(ctx, expr)
@@ -356,6 +452,7 @@ func (tc *CoreTypeChecker) CheckCoreExpr(expr core.CoreExpr, env *TypeEnv) (type
 
 	// Fill in operator methods for resolved constraints
 	tc.FillOperatorMethods(expr)
+	tc.fillNewtypeShows(sub)
 
 	return typedNode, newEnv, nil
 }
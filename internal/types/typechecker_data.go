@@ -49,7 +49,7 @@ func (tc *CoreTypeChecker) inferRecord(ctx *InferenceContext, rec *core.Record)
 			NodeID:    rec.ID(),
 			Span:      rec.Span(),
 			Type:      recordType,
-			EffectRow: combineEffectList(allEffects),
+			EffectRow: ctx.joinEffects(allEffects...),
 			Core:      rec,
 		},
 		Fields: fields,
@@ -211,7 +211,7 @@ func (tc *CoreTypeChecker) inferList(ctx *InferenceContext, list *core.List) (*t
 			NodeID:    list.ID(),
 			Span:      list.Span(),
 			Type:      &TList{Element: elemType},
-			EffectRow: combineEffectList(allEffects),
+			EffectRow: ctx.joinEffects(allEffects...),
 			Core:      list,
 		},
 		Elements: elements,
@@ -240,7 +240,7 @@ func (tc *CoreTypeChecker) inferTuple(ctx *InferenceContext, tuple *core.Tuple)
 			NodeID:    tuple.ID(),
 			Span:      tuple.Span(),
 			Type:      &TTuple{Elements: elemTypes},
-			EffectRow: combineEffectList(allEffects),
+			EffectRow: ctx.joinEffects(allEffects...),
 			Core:      tuple,
 		},
 		Elements: elements,
@@ -278,40 +278,50 @@ func isCoreValue(expr core.CoreExpr) bool {
 	}
 }
 
-// combineEffects combines two effect rows
-func combineEffects(e1, e2 *Row) *Row {
-	if e1 == nil || (len(e1.Labels) == 0 && e1.Tail == nil) {
-		return e2
-	}
-	if e2 == nil || (len(e2.Labels) == 0 && e2.Tail == nil) {
-		return e1
+// joinEffects is the union of the effect rows of an expression's parts.
+// Labels are merged directly. When more than one part has an open row (e.g.
+// a body that calls a function parameter and then another function), the
+// union gets a fresh tail that SolveConstraints binds to the union of their
+// solved rows, so the callees' effects still reach the caller.
+func (ctx *InferenceContext) joinEffects(rows ...*Row) *Row {
+	labels := make(map[string]Type)
+	var tails []*RowVar
+	var open []*Row
+	for _, r := range rows {
+		if r == nil {
+			continue
+		}
+		for k, v := range r.Labels {
+			labels[k] = v
+		}
+		if r.Tail != nil && !containsRowVar(tails, r.Tail) {
+			tails = append(tails, r.Tail)
+			open = append(open, r)
+		}
 	}
 
-	// Combine labels
-	combined := make(map[string]Type)
-	for k, v := range e1.Labels {
-		combined[k] = v
-	}
-	for k, v := range e2.Labels {
-		combined[k] = v
+	if len(tails) <= 1 {
+		joined := &Row{Kind: EffectRow, Labels: labels}
+		if len(tails) == 1 {
+			joined.Tail = tails[0]
+		}
+		return joined
 	}
 
-	// For now, ignore tail variables in combination
-	// Full implementation would handle row unification
-	return &Row{
-		Kind:   EffectRow,
-		Labels: combined,
-		Tail:   nil,
-	}
+	// The join carries the labels, so the fresh tail alone stands for the union
+	closed := &Row{Kind: EffectRow, Labels: labels}
+	joined := ctx.freshEffectRow()
+	ctx.addConstraint(EffectJoin{Tail: joined.Tail, Rows: append(open, closed), Path: ctx.path})
+	return joined
 }
 
-// combineEffectList combines multiple effect rows
-func combineEffectList(effects []*Row) *Row {
-	result := EmptyEffectRow()
-	for _, e := range effects {
-		result = combineEffects(result, e)
+func containsRowVar(vars []*RowVar, v *RowVar) bool {
+	for _, existing := range vars {
+		if existing.Name == v.Name {
+			return true
+		}
 	}
-	return result
+	return false
 }
 
 // toInterfaceSlice converts []Type to []interface{}
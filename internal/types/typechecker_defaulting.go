@@ -73,14 +73,17 @@ func (tc *CoreTypeChecker) defaultAmbiguities(
 			sub[varName] = defaultType
 
 			// Record trace for deterministic output
+			nodeID, span := defaultingOrigin(varName, constraints)
 			trace := DefaultingTrace{
 				TypeVar:   varName,
 				ClassName: getFirstClassName(classes), // Representative class
 				Default:   defaultType,
 				Location:  "generalization boundary",
+				NodeID:    nodeID,
+				Span:      span,
 			}
 			// traces = append(traces, trace) // Not used after this
-			tc.defaultingConfig.Traces = append(tc.defaultingConfig.Traces, trace)
+			tc.recordDefaulting(trace)
 
 			if tc.debugMode {
 				tc.logDefaulting(trace)
@@ -104,6 +107,18 @@ func (tc *CoreTypeChecker) defaultAmbiguities(
 	return sub, monotype, constraints, nil
 }
 
+// recordDefaulting appends a trace unless the same variable of the same node
+// was already defaulted the same way (let boundaries and the top level can
+// both see a literal's constraint)
+func (tc *CoreTypeChecker) recordDefaulting(trace DefaultingTrace) {
+	for _, seen := range tc.defaultingConfig.Traces {
+		if seen.TypeVar == trace.TypeVar && seen.NodeID == trace.NodeID && seen.Default.String() == trace.Default.String() {
+			return
+		}
+	}
+	tc.defaultingConfig.Traces = append(tc.defaultingConfig.Traces, trace)
+}
+
 // defaultAmbiguitiesTopLevel applies defaulting at top-level, including non-ambiguous numeric literals
 func (tc *CoreTypeChecker) defaultAmbiguitiesTopLevel(
 	monotype Type,
@@ -149,14 +164,17 @@ func (tc *CoreTypeChecker) defaultAmbiguitiesTopLevel(
 		if defaultType != nil {
 			sub[varName] = defaultType
 
+			nodeID, span := defaultingOrigin(varName, constraints)
 			trace := DefaultingTrace{
 				TypeVar:   varName,
 				ClassName: getFirstClassName(classes),
 				Default:   defaultType,
 				Location:  "top-level",
+				NodeID:    nodeID,
+				Span:      span,
 			}
 			// traces = append(traces, trace) // Not used after this
-			tc.defaultingConfig.Traces = append(tc.defaultingConfig.Traces, trace)
+			tc.recordDefaulting(trace)
 
 			if tc.debugMode {
 				tc.logDefaulting(trace)
@@ -306,6 +324,18 @@ func collectFreeVars(t Type, vars map[string]bool) {
 		for _, fieldType := range typ.Fields {
 			collectFreeVars(fieldType, vars)
 		}
+	case *TList:
+		collectFreeVars(typ.Element, vars)
+	case *TTuple:
+		for _, elem := range typ.Elements {
+			collectFreeVars(elem, vars)
+		}
+	case *TRecord2:
+		if typ.Row != nil {
+			for _, fieldType := range typ.Row.Labels {
+				collectFreeVars(fieldType, vars)
+			}
+		}
 	}
 }
 
@@ -13,8 +13,12 @@ func (tc *CoreTypeChecker) inferLambda(ctx *InferenceContext, lam *core.Lambda)
 	paramTypes := make([]Type, len(lam.Params))
 	newEnv := ctx.env
 
+	annots := tc.typeAnnots[lam.ID()]
 	for i, param := range lam.Params {
 		paramType := ctx.freshTypeVar()
+		if i < len(annots) && annots[i] != nil {
+			paramType = tc.annotationType(ctx, annots[i])
+		}
 		paramTypes[i] = paramType
 		newEnv = newEnv.Extend(param, paramType)
 	}
@@ -29,6 +33,16 @@ func (tc *CoreTypeChecker) inferLambda(ctx *InferenceContext, lam *core.Lambda)
 		return nil, oldEnv, err
 	}
 
+	// A declared return type constrains the body, so it also decides the
+	// type of a literal result (func f() -> float { 3 })
+	if annot, ok := tc.returnAnnots[lam.ID()]; ok {
+		ctx.addConstraint(TypeEq{
+			Left:  tc.annotationType(ctx, annot),
+			Right: getType(bodyNode),
+			Path:  []string{fmt.Sprintf("declared return type at %s", lam.Span())},
+		})
+	}
+
 	// Check for linear capture violations
 	captured := tc.findCapturedVars(lam, oldEnv)
 	for _, cap := range captured {
@@ -92,11 +106,22 @@ func (tc *CoreTypeChecker) inferLet(ctx *InferenceContext, let *core.Let) (*type
 	valueType := getType(valueNode)
 	valueEffects := getEffectRow(valueNode)
 
+	// A declared type must agree with the value; unifying before solving
+	// lets it decide the type of a literal (let x: float = 3)
+	if annots := tc.typeAnnots[let.ID()]; len(annots) == 1 {
+		ctx.addConstraint(TypeEq{
+			Left:  tc.annotationType(ctx, annots[0]),
+			Right: valueType,
+			Path:  []string{fmt.Sprintf("type annotation of %s at %s", let.Name, let.Span())},
+		})
+	}
+
 	// Get unsolved constraints from current context
-	_, unsolvedConstraints, err := ctx.SolveConstraints()
+	solved, unsolvedConstraints, err := ctx.SolveConstraints()
 	if err != nil {
 		return nil, ctx.env, err
 	}
+	valueType = ApplySubstitution(solved, valueType)
 
 	// Apply defaulting at this generalization boundary
 	defaultingSub, defaultedType, defaultedConstraints, err := tc.defaultAmbiguities(valueType, unsolvedConstraints)
@@ -120,7 +145,22 @@ func (tc *CoreTypeChecker) inferLet(ctx *InferenceContext, let *core.Let) (*type
 				nonGroundConstraints = append(nonGroundConstraints, c)
 			}
 		}
-		binding = tc.generalizeWithConstraints(defaultedType, valueEffects, nonGroundConstraints)
+		_, isLambda := let.Value.(*core.Lambda)
+		if let == ctx.moduleValue && len(nonGroundConstraints) > 0 {
+			// A constrained module value such as `export pi = 3.14159` is
+			// evaluated once, so it stays monomorphic and is defaulted with
+			// its declaration (pi : float) rather than at every use
+			binding = defaultedType
+		} else if !isLambda && len(nonGroundConstraints) > 0 {
+			// Monomorphism restriction: a constrained non-function binding such
+			// as `let x = 3` is evaluated once, so its uses decide the type of
+			// the literal (x + 0.5 makes 3 a Float) instead of defaulting it
+			binding = defaultedType
+		} else {
+			scheme := tc.generalizeWithConstraints(defaultedType, valueEffects, nonGroundConstraints)
+			tc.keepPolymorphic(scheme, nonGroundConstraints)
+			binding = scheme
+		}
 	} else {
 		binding = defaultedType
 	}
@@ -151,7 +191,7 @@ func (tc *CoreTypeChecker) inferLet(ctx *InferenceContext, let *core.Let) (*type
 			NodeID:    let.ID(),
 			Span:      let.Span(),
 			Type:      bodyNode.GetType(),
-			EffectRow: combineEffects(valueEffects, getEffectRow(bodyNode)),
+			EffectRow: ctx.joinEffects(valueEffects, getEffectRow(bodyNode)),
 			Core:      let,
 		},
 		Name:   let.Name,
@@ -200,14 +240,15 @@ func (tc *CoreTypeChecker) inferLetRec(ctx *InferenceContext, letrec *core.LetRe
 	}
 
 	// CRITICAL: Apply defaulting ONCE for the entire SCC after solving mutual block
-	_, unsolvedConstraints, err := ctx.SolveConstraints()
+	solved, unsolvedConstraints, err := ctx.SolveConstraints()
 	if err != nil {
 		return nil, oldEnv, err
 	}
 
 	// Apply defaulting to the entire mutual block (once per SCC)
 	for i, binding := range letrec.Bindings {
-		valueType := allValueTypes[i]
+		valueType := ApplySubstitution(solved, allValueTypes[i])
+		allValueTypes[i] = valueType
 		valueNode := allValueNodes[i]
 
 		// Apply defaulting at this generalization boundary
@@ -248,6 +289,7 @@ func (tc *CoreTypeChecker) inferLetRec(ctx *InferenceContext, letrec *core.LetRe
 
 		// Generalize for recursion
 		scheme := tc.generalizeWithConstraints(valueType, getEffectRow(valueNode), nonGroundConstraints)
+		tc.keepPolymorphic(scheme, nonGroundConstraints)
 
 		typedBindings[i] = typedast.TypedRecBinding{
 			Name:   binding.Name,
@@ -283,7 +325,7 @@ func (tc *CoreTypeChecker) inferLetRec(ctx *InferenceContext, letrec *core.LetRe
 			NodeID:    letrec.ID(),
 			Span:      letrec.Span(),
 			Type:      bodyNode.GetType(),
-			EffectRow: combineEffectList(allEffects),
+			EffectRow: ctx.joinEffects(allEffects...),
 			Core:      letrec,
 		},
 		Bindings: typedBindings,
@@ -313,14 +355,66 @@ func (tc *CoreTypeChecker) generalizeWithConstraints(typ Type, effects *Row, con
 		})
 	}
 
+	// Effect row variables are quantified too, so that each use of a
+	// higher-order function gets the effects of the function passed to it
+	rowFreeVars := make(map[string]bool)
+	collectEffectRowVars(typ, rowFreeVars)
+
 	return &Scheme{
 		TypeVars:    generalizedTypeVars,
-		RowVars:     []string{}, // Simplified for now
+		RowVars:     sortedNames(rowFreeVars),
 		Constraints: schemeConstraints,
 		Type:        typ,
 	}
 }
 
+// keepPolymorphic records the nodes of the class constraints a generalized
+// binding quantifies over. Each use instantiates them at its own type, so
+// they are not resolved to the instance their leftover type variable
+// defaults to; lowering dispatches them at runtime instead.
+func (tc *CoreTypeChecker) keepPolymorphic(scheme *Scheme, constraints []ClassConstraint) {
+	quantified := make(map[string]bool, len(scheme.TypeVars))
+	for _, v := range scheme.TypeVars {
+		quantified[v] = true
+	}
+	for _, c := range constraints {
+		vars := make(map[string]bool)
+		collectFreeVars(c.Type, vars)
+		bound := len(vars) > 0
+		for v := range vars {
+			bound = bound && quantified[v]
+		}
+		if bound && c.NodeID != 0 {
+			tc.polymorphicNodes[c.NodeID] = true
+		}
+	}
+}
+
+// collectEffectRowVars collects the tails of the effect rows in t, including
+// those of function parameters and of functions nested in other types
+func collectEffectRowVars(t Type, vars map[string]bool) {
+	switch typ := t.(type) {
+	case *TFunc2:
+		if typ.EffectRow != nil && typ.EffectRow.Tail != nil {
+			vars[typ.EffectRow.Tail.Name] = true
+		}
+		for _, p := range typ.Params {
+			collectEffectRowVars(p, vars)
+		}
+		collectEffectRowVars(typ.Return, vars)
+	case *TList:
+		collectEffectRowVars(typ.Element, vars)
+	case *TTuple:
+		for _, elem := range typ.Elements {
+			collectEffectRowVars(elem, vars)
+		}
+	case *TApp:
+		for _, arg := range typ.Args {
+			collectEffectRowVars(arg, vars)
+		}
+	}
+}
+
 // inferApp infers type of function application
 func (tc *CoreTypeChecker) inferApp(ctx *InferenceContext, app *core.App) (*typedast.TypedApp, *TypeEnv, error) {
 	// Infer function type
@@ -343,11 +437,30 @@ func (tc *CoreTypeChecker) inferApp(ctx *InferenceContext, app *core.App) (*type
 		argTypes = append(argTypes, getType(argNode))
 		argEffects = append(argEffects, getEffectRow(argNode))
 	}
+	tc.recordShowApp(app, argTypes)
 
 	// Create result type variable and fresh effect row for the function's effects
 	resultType := ctx.freshTypeVar()
 	effectRow := ctx.freshEffectRow()
 
+	// Functions are curried: applying a known function to fewer arguments
+	// than it has parameters yields a function of the rest, and applying it
+	// to more applies its result to the remaining arguments
+	if fnType, ok := getType(funcNode).(*TFunc2); ok && len(fnType.Params) != len(argTypes) {
+		appType, appEffects := tc.curriedApp(ctx, app, fnType, argTypes, effectRow, resultType)
+		return &typedast.TypedApp{
+			TypedExpr: typedast.TypedExpr{
+				NodeID:    app.ID(),
+				Span:      app.Span(),
+				Type:      appType,
+				EffectRow: ctx.joinEffects(append(argEffects, appEffects...)...),
+				Core:      app,
+			},
+			Func: funcNode,
+			Args: argNodes,
+		}, ctx.env, nil
+	}
+
 	// Unify function type with expected type
 	// The effectRow variable will be unified with the function's actual effect row
 	expectedFuncType := &TFunc2{
@@ -374,7 +487,7 @@ func (tc *CoreTypeChecker) inferApp(ctx *InferenceContext, app *core.App) (*type
 			NodeID:    app.ID(),
 			Span:      app.Span(),
 			Type:      resultType,
-			EffectRow: combineEffectList(appEffects),
+			EffectRow: ctx.joinEffects(appEffects...),
 			Core:      app,
 		},
 		Func: funcNode,
@@ -382,6 +495,42 @@ func (tc *CoreTypeChecker) inferApp(ctx *InferenceContext, app *core.App) (*type
 	}, ctx.env, nil
 }
 
+// curriedApp constrains an application whose argument count differs from the
+// arity of the known function type fnType, returning the application's type
+// and the effects of performing it.
+//
+// Under-application is pure and returns a function of the remaining
+// parameters that carries fnType's effects. Over-application calls fnType
+// with its own parameters, then applies the result (which must be a function)
+// to the remaining arguments, performing both calls' effects.
+func (tc *CoreTypeChecker) curriedApp(ctx *InferenceContext, app *core.App, fnType *TFunc2, argTypes []Type, effectRow *Row, resultType Type) (Type, []*Row) {
+	path := []string{"function application at " + app.Span().String()}
+	n := len(fnType.Params)
+
+	if len(argTypes) < n {
+		ctx.addConstraint(TypeEq{
+			Left:  fnType,
+			Right: &TFunc2{Params: append(append([]Type{}, argTypes...), fnType.Params[len(argTypes):]...), EffectRow: effectRow, Return: resultType},
+			Path:  path,
+		})
+		return &TFunc2{Params: fnType.Params[len(argTypes):], EffectRow: effectRow, Return: resultType}, nil
+	}
+
+	partial := ctx.freshTypeVar()
+	restEffects := ctx.freshEffectRow()
+	ctx.addConstraint(TypeEq{
+		Left:  fnType,
+		Right: &TFunc2{Params: argTypes[:n], EffectRow: effectRow, Return: partial},
+		Path:  path,
+	})
+	ctx.addConstraint(TypeEq{
+		Left:  partial,
+		Right: &TFunc2{Params: argTypes[n:], EffectRow: restEffects, Return: resultType},
+		Path:  path,
+	})
+	return resultType, []*Row{effectRow, restEffects}
+}
+
 // inferIf infers type of conditional
 func (tc *CoreTypeChecker) inferIf(ctx *InferenceContext, ifExpr *core.If) (*typedast.TypedIf, *TypeEnv, error) {
 	// Infer condition type
@@ -416,11 +565,11 @@ func (tc *CoreTypeChecker) inferIf(ctx *InferenceContext, ifExpr *core.If) (*typ
 	})
 
 	// Combine effects from all parts
-	effects := combineEffectList([]*Row{
+	effects := ctx.joinEffects(
 		getEffectRow(condNode),
 		getEffectRow(thenNode),
 		getEffectRow(elseNode),
-	})
+	)
 
 	return &typedast.TypedIf{
 		TypedExpr: typedast.TypedExpr{
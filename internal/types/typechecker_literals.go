@@ -2,8 +2,10 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sunholo/ailang/internal/core"
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
 	"github.com/sunholo/ailang/internal/typedast"
 )
 
@@ -20,6 +22,7 @@ func (tc *CoreTypeChecker) inferLit(ctx *InferenceContext, lit *core.Lit) (*type
 			Type:   tv,
 			Path:   []string{fmt.Sprintf("literal at %v", lit.Span())},
 			NodeID: lit.ID(),
+			Span:   lit.Span(),
 		})
 		typ = tv
 	case core.FloatLit:
@@ -30,6 +33,7 @@ func (tc *CoreTypeChecker) inferLit(ctx *InferenceContext, lit *core.Lit) (*type
 			Type:   tv,
 			Path:   []string{fmt.Sprintf("literal at %v", lit.Span())},
 			NodeID: lit.ID(),
+			Span:   lit.Span(),
 		})
 		typ = tv
 	case core.StringLit:
@@ -55,11 +59,24 @@ func (tc *CoreTypeChecker) inferLit(ctx *InferenceContext, lit *core.Lit) (*type
 	}, ctx.env, nil
 }
 
+// namesInScope lists the variables visible at this point of inference: the
+// local environment and the imported globals (by their unqualified name)
+func (tc *CoreTypeChecker) namesInScope(ctx *InferenceContext) []string {
+	names := ctx.env.Names()
+	for key := range tc.globalTypes {
+		if !strings.HasPrefix(key, "$") {
+			names = append(names, key[strings.LastIndex(key, ".")+1:])
+		}
+	}
+	return names
+}
+
 // inferVar infers type of variable
 func (tc *CoreTypeChecker) inferVar(ctx *InferenceContext, v *core.Var) (*typedast.TypedVar, *TypeEnv, error) {
 	typ, err := ctx.env.Lookup(v.Name)
 	if err != nil {
-		return nil, ctx.env, fmt.Errorf("undefined variable: %s at %s", v.Name, v.Span())
+		return nil, ctx.env, fmt.Errorf("undefined variable: %s at %s%s", v.Name, v.Span(),
+			ailangErrors.DidYouMean(v.Name, tc.namesInScope(ctx)))
 	}
 
 	// Instantiate if it's a scheme
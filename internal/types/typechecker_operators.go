@@ -89,6 +89,7 @@ func (tc *CoreTypeChecker) inferBinOp(ctx *InferenceContext, binop *core.BinOp)
 			Type:   resultType,
 			Path:   []string{binop.Span().String()},
 			NodeID: binop.ID(), // keep this for operator→method linking
+			Span:   binop.Span(),
 		})
 
 	case "++":
@@ -143,6 +144,7 @@ func (tc *CoreTypeChecker) inferBinOp(ctx *InferenceContext, binop *core.BinOp)
 			})
 
 			resultType = &TList{Element: elemType}
+			tc.listConcats[binop.ID()] = true
 		} else if leftIsString || rightIsString {
 			// At least one is a concrete string → string concat
 			// The type variable (if any) will be unified with String
@@ -173,6 +175,7 @@ func (tc *CoreTypeChecker) inferBinOp(ctx *InferenceContext, binop *core.BinOp)
 			})
 
 			resultType = &TList{Element: elemType}
+			tc.listConcats[binop.ID()] = true
 		} else {
 			// Fallback: assume string concat
 			ctx.addConstraint(TypeEq{
@@ -195,12 +198,14 @@ func (tc *CoreTypeChecker) inferBinOp(ctx *InferenceContext, binop *core.BinOp)
 			Type:   getType(leftNode),
 			Path:   []string{binop.Span().String()},
 			NodeID: binop.ID(),
+			Span:   binop.Span(),
 		})
 		ctx.addConstraint(ClassConstraint{
 			Class:  "Ord",
 			Type:   getType(rightNode),
 			Path:   []string{binop.Span().String()},
 			NodeID: binop.ID(),
+			Span:   binop.Span(),
 		})
 		ctx.addConstraint(TypeEq{
 			Left:  getType(leftNode),
@@ -216,12 +221,14 @@ func (tc *CoreTypeChecker) inferBinOp(ctx *InferenceContext, binop *core.BinOp)
 			Type:   getType(leftNode),
 			Path:   []string{binop.Span().String()},
 			NodeID: binop.ID(),
+			Span:   binop.Span(),
 		})
 		ctx.addConstraint(ClassConstraint{
 			Class:  "Eq",
 			Type:   getType(rightNode),
 			Path:   []string{binop.Span().String()},
 			NodeID: binop.ID(),
+			Span:   binop.Span(),
 		})
 		ctx.addConstraint(TypeEq{
 			Left:  getType(leftNode),
@@ -249,7 +256,7 @@ func (tc *CoreTypeChecker) inferBinOp(ctx *InferenceContext, binop *core.BinOp)
 	}
 
 	// Combine effects
-	effects := combineEffects(getEffectRow(leftNode), getEffectRow(rightNode))
+	effects := ctx.joinEffects(getEffectRow(leftNode), getEffectRow(rightNode))
 
 	return &typedast.TypedBinOp{
 		TypedExpr: typedast.TypedExpr{
@@ -283,6 +290,7 @@ func (tc *CoreTypeChecker) inferUnOp(ctx *InferenceContext, unop *core.UnOp) (*t
 			Type:   getType(operandNode),
 			Path:   []string{unop.Span().String()},
 			NodeID: unop.ID(),
+			Span:   unop.Span(),
 		})
 		resultType = getType(operandNode)
 
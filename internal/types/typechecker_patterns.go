@@ -93,7 +93,7 @@ func (tc *CoreTypeChecker) inferMatch(ctx *InferenceContext, match *core.Match)
 			NodeID:    match.ID(),
 			Span:      match.Span(),
 			Type:      resultType,
-			EffectRow: combineEffectList(allEffects),
+			EffectRow: ctx.joinEffects(allEffects...),
 			Core:      match,
 		},
 		Scrutinee:  scrutineeNode,
@@ -138,6 +138,66 @@ func (tc *CoreTypeChecker) checkPattern(pat core.CorePattern, scrutType Type, ct
 		// Wildcard matches anything, binds nothing
 		return nil, typedast.TypedWildcardPattern{}, nil
 
+	case *core.AsPattern:
+		// As-pattern binds the whole scrutinee alongside the inner bindings
+		innerBindings, typedInner, err := tc.checkPattern(p.Pattern, scrutType, ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		bindings := make(map[string]Type, len(innerBindings)+1)
+		for name, typ := range innerBindings {
+			bindings[name] = typ
+		}
+		if existing, ok := bindings[p.Name]; ok {
+			// Variable bound multiple times - must unify
+			ctx.addConstraint(TypeEq{
+				Left:  existing,
+				Right: scrutType,
+				Path:  []string{fmt.Sprintf("pattern variable %s", p.Name)},
+			})
+		} else {
+			bindings[p.Name] = scrutType
+		}
+
+		return bindings, typedast.TypedAsPattern{
+			Name:    p.Name,
+			Type:    scrutType,
+			Pattern: typedInner,
+		}, nil
+
+	case *core.OrPattern:
+		// Or-pattern - every alternative must bind the same variables with
+		// the same types, since the arm body sees whichever alternative matched
+		var bindings map[string]Type
+		typedAlts := make([]typedast.TypedPattern, len(p.Alternatives))
+
+		for i, alt := range p.Alternatives {
+			altBindings, typedAlt, err := tc.checkPattern(alt, scrutType, ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			typedAlts[i] = typedAlt
+
+			if i == 0 {
+				bindings = altBindings
+				continue
+			}
+			if err := sameBoundNames(bindings, altBindings); err != nil {
+				return nil, nil, fmt.Errorf("or-pattern alternative %d (%s) %v", i+1, alt, err)
+			}
+			for name, typ := range altBindings {
+				ctx.addConstraint(TypeEq{
+					Left:  bindings[name],
+					Right: typ,
+					Path:  []string{fmt.Sprintf("or-pattern variable %s", name)},
+				})
+			}
+		}
+
+		return bindings, typedast.TypedOrPattern{
+			Alternatives: typedAlts,
+		}, nil
+
 	case *core.ConstructorPattern:
 		// Constructor pattern - need to lookup constructor scheme
 		// TODO: This needs access to the module interface to get constructor schemes
@@ -149,9 +209,23 @@ func (tc *CoreTypeChecker) checkPattern(pat core.CorePattern, scrutType Type, ct
 		bindings := make(map[string]Type)
 		typedArgs := make([]typedast.TypedPattern, len(p.Args))
 
+		// Newtype constructors are known exactly: the scrutinee is the newtype
+		// and the single argument has the wrapped field type
+		nt := tc.newtypes[p.Name]
+		if nt != nil && len(p.Args) == 1 {
+			ctx.addConstraint(TypeEq{
+				Left:  scrutType,
+				Right: &TCon{Name: nt.TypeName},
+				Path:  []string{fmt.Sprintf("constructor pattern %s", p.Name)},
+			})
+		}
+
 		for i, argPat := range p.Args {
 			// Create fresh type variable for each argument
-			argType := ctx.freshTypeVar()
+			var argType Type = ctx.freshTypeVar()
+			if nt != nil && len(p.Args) == 1 {
+				argType = nt.Field
+			}
 			argBindings, typedArg, err := tc.checkPattern(argPat, argType, ctx)
 			if err != nil {
 				return nil, nil, err
@@ -279,8 +353,8 @@ func (tc *CoreTypeChecker) checkPattern(pat core.CorePattern, scrutType Type, ct
 		// Type check tail pattern if present
 		var typedTail *typedast.TypedPattern
 		if p.Tail != nil {
-			// Tail must have list type (same as scrutinee)
-			tailBindings, tail, err := tc.checkPattern(*p.Tail, scrutType, ctx)
+			// The tail is the rest of the list: List[elem]
+			tailBindings, tail, err := tc.checkPattern(*p.Tail, &TList{Element: elemType}, ctx)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -309,3 +383,18 @@ func (tc *CoreTypeChecker) checkPattern(pat core.CorePattern, scrutType Type, ct
 		return nil, nil, fmt.Errorf("pattern type checking not implemented for %T", pat)
 	}
 }
+
+// sameBoundNames reports an error unless both binding sets name the same variables
+func sameBoundNames(want, got map[string]Type) error {
+	for name := range want {
+		if _, ok := got[name]; !ok {
+			return fmt.Errorf("does not bind %s, but the first alternative does", name)
+		}
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			return fmt.Errorf("binds %s, but the first alternative does not", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package types
+
+import "github.com/sunholo/ailang/internal/core"
+
+// Newtype values are represented by their unboxed field, so the show builtin
+// cannot tell UserId(42) from 42. The type checker knows the difference: a
+// call show(x) whose argument turns out to be a newtype is rewritten to
+// show_newtype("UserId", x) once the final substitution is known, the same
+// point at which operators are resolved to their instances.
+
+// showApp is a show application and the (not yet solved) type of its argument
+type showApp struct {
+	app     *core.App
+	argType Type
+}
+
+// recordShowApp remembers app if it applies the show builtin
+func (tc *CoreTypeChecker) recordShowApp(app *core.App, argTypes []Type) {
+	fn, ok := app.Func.(*core.VarGlobal)
+	if !ok || fn.Ref.Module != "$builtin" || fn.Ref.Name != "show" || len(argTypes) != 1 {
+		return
+	}
+	tc.showApps = append(tc.showApps, showApp{app: app, argType: argTypes[0]})
+}
+
+// fillNewtypeShows rewrites the recorded show applications whose argument
+// is a newtype under sub
+func (tc *CoreTypeChecker) fillNewtypeShows(sub Substitution) {
+	for _, s := range tc.showApps {
+		con, ok := ApplySubstitution(sub, s.argType).(*TCon)
+		if !ok {
+			continue
+		}
+		ctor := tc.newtypeConstructor(con.Name)
+		if ctor == "" {
+			continue
+		}
+		fn := s.app.Func.(*core.VarGlobal)
+		s.app.Func = &core.VarGlobal{
+			CoreNode: fn.CoreNode,
+			Ref:      core.GlobalRef{Module: "$builtin", Name: "show_newtype"},
+		}
+		name := &core.Lit{CoreNode: fn.CoreNode, Kind: core.StringLit, Value: ctor}
+		s.app.Args = append([]core.CoreExpr{name}, s.app.Args...)
+	}
+	tc.showApps = nil
+}
+
+// newtypeConstructor returns the constructor of the named newtype, or "" if
+// typeName is not a newtype
+func (tc *CoreTypeChecker) newtypeConstructor(typeName string) string {
+	for ctor, nt := range tc.newtypes {
+		if nt.TypeName == typeName {
+			return ctor
+		}
+	}
+	return ""
+}
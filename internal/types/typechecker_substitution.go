@@ -66,6 +66,7 @@ func (tc *CoreTypeChecker) applySubstitutionToConstraints(sub Substitution, cons
 			Type:   c.Type.Substitute(sub),
 			Path:   c.Path,
 			NodeID: c.NodeID,
+			Span:   c.Span,
 		}
 	}
 	return result
@@ -175,6 +176,13 @@ func isGround(t Type) bool {
 			}
 		}
 		return isGround(typ.Return)
+	case *TTuple:
+		for _, elem := range typ.Elements {
+			if !isGround(elem) {
+				return false
+			}
+		}
+		return true
 	case *TRecord:
 		for _, fieldType := range typ.Fields {
 			if !isGround(fieldType) {
@@ -215,13 +223,18 @@ func (tc *CoreTypeChecker) resolveGroundConstraints(constraints []ClassConstrain
 		if err != nil {
 			// No instance found - return error with hint
 			if missingErr, ok := err.(*MissingInstanceError); ok {
-				return fmt.Errorf("at %s: %v", c.Path[0], missingErr)
+				if c.Span.Line > 0 {
+					missingErr.Span = c.Span
+					return missingErr
+				}
+				return fmt.Errorf("at %s: %w", c.Path[0], missingErr)
 			}
 			return err
 		}
 
-		// Instance found - record the resolved constraint if it has a NodeID
-		if c.NodeID != 0 {
+		// Instance found - record the resolved constraint if it has a NodeID,
+		// unless the node is polymorphic and was only defaulted here
+		if c.NodeID != 0 && !tc.polymorphicNodes[c.NodeID] {
 			// CRITICAL: Double-check that the type we're recording is ground
 			if !isGround(c.Type) {
 				return fmt.Errorf("INTERNAL ERROR: storing non-ground type %s in ResolvedConstraints for node %d", c.Type, c.NodeID)
@@ -238,6 +251,7 @@ func (tc *CoreTypeChecker) resolveGroundConstraints(constraints []ClassConstrain
 				ClassName: c.Class,
 				Type:      normalizedType, // Normalized type (float→Float, int→Int)
 				Method:    "",             // Will be filled in during Core traversal
+				Span:      c.Span,
 			}
 		}
 	}
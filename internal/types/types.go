@@ -445,6 +445,7 @@ var (
 	TBool   = &TCon{Name: "bool"}
 	TUnit   = &TCon{Name: "()"}
 	TBytes  = &TCon{Name: "bytes"}
+	TBigInt = &TCon{Name: "BigInt"}
 )
 
 // Common effects
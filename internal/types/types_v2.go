@@ -347,6 +347,25 @@ func (s *Scheme) Instantiate(fresh func(Kind) Type) Type {
 	return s.Type.Substitute(subs)
 }
 
+// ClosedScheme quantifies every type variable and effect row variable free
+// in t. Builtin signatures are closed, so each use instantiates them afresh.
+func ClosedScheme(t Type) *Scheme {
+	typeVars := make(map[string]bool)
+	collectFreeVars(t, typeVars)
+	rowVars := make(map[string]bool)
+	collectEffectRowVars(t, rowVars)
+	return &Scheme{TypeVars: sortedNames(typeVars), RowVars: sortedNames(rowVars), Type: t}
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Helper functions
 
 // EmptyEffectRow creates an empty effect row
@@ -2,6 +2,10 @@ package types
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
+	ailangErrors "github.com/sunholo/ailang/internal/errors"
 )
 
 // Substitution maps variable names to types
@@ -10,6 +14,14 @@ type Substitution map[string]Type
 // Unifier handles type unification with occurs check
 type Unifier struct {
 	rowUnifier *RowUnifier
+	adtFields  map[string]*ADTFields // ADT name -> named constructor fields
+	aliases    map[string]Type       // Type alias name -> resolved type
+}
+
+// ADTFields describes the named fields of a record-style ADT's constructors
+type ADTFields struct {
+	Params []string        // The ADT's type parameters, in declaration order
+	Fields map[string]Type // Field name -> declared type
 }
 
 // NewUnifier creates a new unifier
@@ -19,12 +31,92 @@ func NewUnifier() *Unifier {
 	}
 }
 
+// SetADTFields registers the named fields of record-style ADT constructors,
+// allowing field access (an open record) on values of those ADTs
+func (u *Unifier) SetADTFields(fields map[string]*ADTFields) {
+	u.adtFields = fields
+}
+
+// SetTypeAliases registers transparent type aliases, which unify as the
+// types they stand for
+func (u *Unifier) SetTypeAliases(aliases map[string]Type) {
+	u.aliases = aliases
+}
+
+// unifyADTFieldAccess unifies an ADT type with an open record produced by field
+// access, e.g. Point ~ {x: α | ρ}: each accessed field must exist and unify with
+// its declared type. args are the type arguments of an applied ADT (Box[int]).
+// ADT values are usually typed by the bare constructor, leaving its parameters
+// open, so a field whose type mentions an unapplied parameter is not unified.
+func (u *Unifier) unifyADTFieldAccess(adt string, args []Type, rec *TRecordOpen, sub Substitution) (Substitution, error) {
+	info, ok := u.adtFields[adt]
+	if !ok {
+		return nil, fmt.Errorf("cannot unify type constructor %s with %T", adt, rec)
+	}
+	applied := make(map[string]Type)
+	open := make(map[string]Type)
+	for i, param := range info.Params {
+		if i < len(args) {
+			applied[param] = args[i]
+		} else {
+			open[param] = &TCon{Name: "?" + param}
+		}
+	}
+	for fieldName, fieldType := range rec.Fields {
+		declared, ok := info.Fields[fieldName]
+		if !ok {
+			names := make([]string, 0, len(info.Fields))
+			for name := range info.Fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("type %s has no field '%s'%s; available fields: %s",
+				adt, fieldName, ailangErrors.DidYouMean(fieldName, names), strings.Join(names, ", "))
+		}
+		if declared == nil || !declared.Substitute(open).Equals(declared) {
+			continue
+		}
+		var err error
+		sub, err = u.Unify(fieldType, declared.Substitute(applied), sub)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s' of type %s: %w", fieldName, adt, err)
+		}
+	}
+	return sub, nil
+}
+
+// missingFieldError reports field access on a record without the field,
+// e.g. record of type {x, y} has no field 'z'; available fields: x, y.
+// Field types are left out: at this point they are often still unsolved.
+func missingFieldError(field string, fields map[string]Type) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := strings.Join(names, ", ")
+	available := list
+	if available == "" {
+		available = "none"
+	}
+	return fmt.Errorf("record of type {%s} has no field '%s'%s; available fields: %s",
+		list, field, ailangErrors.DidYouMean(field, names), available)
+}
+
 // Unify attempts to unify two types, returning an updated substitution
 func (u *Unifier) Unify(t1, t2 Type, sub Substitution) (Substitution, error) {
 	// Apply current substitution
 	t1 = ApplySubstitution(sub, t1)
 	t2 = ApplySubstitution(sub, t2)
 
+	// Aliases are interchangeable with the types they stand for
+	if con, ok := t1.(*TCon); ok && u.aliases[con.Name] != nil {
+		t1 = u.aliases[con.Name]
+	}
+	if con, ok := t2.(*TCon); ok && u.aliases[con.Name] != nil {
+		t2 = u.aliases[con.Name]
+	}
+
 	// Check if already equal
 	if t1.Equals(t2) {
 		return sub, nil
@@ -74,16 +166,36 @@ func (u *Unifier) Unify(t1, t2 Type, sub Substitution) (Substitution, error) {
 			}
 			return nil, fmt.Errorf("cannot unify type constructors: %s vs %s", t1.Name, t2Con.Name)
 		}
+		if t2App, ok := t2.(*TApp); ok {
+			// ADT values are typed by their bare constructor (Option), which
+			// stands for any application of it (Option[int])
+			if head, _ := decomposeApp(t2App); equalHead(t1, head) {
+				return sub, nil
+			}
+			return nil, fmt.Errorf("type constructor mismatch: %s vs %s", t1.Name, t2App)
+		}
 		if t2Var, ok := t2.(*TVar2); ok {
 			// Swap and retry
 			return u.Unify(t2Var, t1, sub)
 		}
+		if t2Open, ok := t2.(*TRecordOpen); ok {
+			// Field access on a record-style ADT value: p.x where p : Point
+			return u.unifyADTFieldAccess(t1.Name, nil, t2Open, sub)
+		}
 		return nil, fmt.Errorf("cannot unify type constructor %s with %T", t1.Name, t2)
 
 	case *TFunc2:
 		// Function type unification
 		if t2Func, ok := t2.(*TFunc2); ok {
 			if len(t1.Params) != len(t2Func.Params) {
+				// Functions are curried (see curriedApp), so \a. \b. e can be
+				// passed where a two-parameter function is expected
+				if n := len(t2Func.Params); n > 0 && len(t1.Params) > n {
+					return u.Unify(curryFunc(t1, n), t2Func, sub)
+				}
+				if n := len(t1.Params); n > 0 && len(t2Func.Params) > n {
+					return u.Unify(t1, curryFunc(t2Func, n), sub)
+				}
 				return nil, fmt.Errorf("function arity mismatch: %d vs %d", len(t1.Params), len(t2Func.Params))
 			}
 
@@ -127,6 +239,13 @@ func (u *Unifier) Unify(t1, t2 Type, sub Substitution) (Substitution, error) {
 		if t2List, ok := t2.(*TList); ok {
 			return u.Unify(t1.Element, t2List.Element, sub)
 		}
+		if t2App, ok := t2.(*TApp); ok {
+			// Builtin signatures spell lists as List[a] (see Builder.List)
+			if head, args := decomposeApp(t2App); equalHead(head, &TCon{Name: "List"}) && len(args) == 1 {
+				return u.Unify(t1.Element, args[0], sub)
+			}
+			return nil, fmt.Errorf("cannot unify list type with %s", t2App)
+		}
 		if t2Var, ok := t2.(*TVar2); ok {
 			// Swap and retry
 			return u.Unify(t2Var, t1, sub)
@@ -271,7 +390,7 @@ func (u *Unifier) Unify(t1, t2 Type, sub Substitution) (Substitution, error) {
 			for fieldName, openFieldType := range t1.Fields {
 				closedFieldType, exists := t2.Fields[fieldName]
 				if !exists {
-					return nil, fmt.Errorf("record field '%s' not found in concrete record", fieldName)
+					return nil, missingFieldError(fieldName, t2.Fields)
 				}
 				// Unify the field types
 				var err error
@@ -341,7 +460,7 @@ func (u *Unifier) Unify(t1, t2 Type, sub Substitution) (Substitution, error) {
 			for fieldName, openFieldType := range t1.Fields {
 				newFieldType, exists := t2.Row.Labels[fieldName]
 				if !exists {
-					return nil, fmt.Errorf("field '%s' not found in TRecord2", fieldName)
+					return nil, missingFieldError(fieldName, t2.Row.Labels)
 				}
 				// Unify the field types
 				var err error
@@ -357,6 +476,18 @@ func (u *Unifier) Unify(t1, t2 Type, sub Substitution) (Substitution, error) {
 			// Swap and retry
 			return u.Unify(t2, t1, sub)
 
+		case *TCon:
+			// Field access on a record-style ADT value
+			return u.unifyADTFieldAccess(t2.Name, nil, t1, sub)
+
+		case *TApp:
+			// Field access on an applied record-style ADT: b.val where b : Box[int]
+			head, args := decomposeApp(t2)
+			if con, ok := head.(*TCon); ok {
+				return u.unifyADTFieldAccess(con.Name, args, t1, sub)
+			}
+			return nil, fmt.Errorf("cannot unify open record with %s", t2)
+
 		default:
 			return nil, fmt.Errorf("cannot unify open record with %T", t2)
 		}
@@ -392,6 +523,17 @@ func (u *Unifier) Unify(t1, t2 Type, sub Substitution) (Substitution, error) {
 			// Swap and retry
 			return u.Unify(t2Var, t1, sub)
 		}
+		if t2Open, ok := t2.(*TRecordOpen); ok {
+			// Swap: field access on an applied record-style ADT
+			return u.Unify(t2Open, t1, sub)
+		}
+		if t2Con, ok := t2.(*TCon); ok {
+			// Swap: a bare ADT constructor against its application
+			return u.Unify(t2Con, t1, sub)
+		}
+		if t2List, ok := t2.(*TList); ok {
+			return u.Unify(t2List, t1, sub)
+		}
 		return nil, fmt.Errorf("cannot unify type application with %T", t2)
 
 	default:
@@ -605,6 +747,16 @@ func (u *Unifier) kindsCompatible(k1, k2 Kind) bool {
 	return k1.Equals(k2)
 }
 
+// curryFunc splits fn after its first n parameters: taking the leading
+// arguments is pure and returns a function of the rest with fn's effects
+func curryFunc(fn *TFunc2, n int) *TFunc2 {
+	return &TFunc2{
+		Params:    fn.Params[:n],
+		EffectRow: EmptyEffectRow(),
+		Return:    &TFunc2{Params: fn.Params[n:], EffectRow: fn.EffectRow, Return: fn.Return},
+	}
+}
+
 // ApplySubstitution applies a substitution to a type
 func ApplySubstitution(sub Substitution, t Type) Type {
 	if len(sub) == 0 {
@@ -613,6 +765,25 @@ func ApplySubstitution(sub Substitution, t Type) Type {
 	return t.Substitute(sub)
 }
 
+// closeSubstitution resolves bindings that mention variables bound later
+// (α1 := [α2], α2 := int) so that one application of the result is final.
+// The occurs check rules out cycles; the bound only guards against a
+// malformed substitution.
+func closeSubstitution(sub Substitution) Substitution {
+	closed := make(Substitution, len(sub))
+	for name, t := range sub {
+		for i := 0; i <= len(sub); i++ {
+			next := t.Substitute(sub)
+			if next.Equals(t) {
+				break
+			}
+			t = next
+		}
+		closed[name] = t
+	}
+	return closed
+}
+
 // ComposeSubstitutions composes two substitutions
 func ComposeSubstitutions(s1, s2 Substitution) Substitution {
 	result := make(Substitution)